@@ -0,0 +1,253 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	purposeEmailVerification = "email_verification"
+	purposePasswordReset     = "password_reset"
+	defaultPasswordMinLength = 8
+)
+
+// TokenPage is the template data of the pages rendered by EmailVerification
+// and PasswordReset once the token was checked.
+type TokenPage struct {
+	// Subject is the subject of the token, e.g. the user ID.
+	Subject string
+	// Error describes why the token was rejected, when it was.
+	Error string
+}
+
+// EmailVerification handles email verification links carrying a signed token.
+//
+// Example:
+//
+//	verification := &okapi.EmailVerification{
+//		Tokens:   &okapi.Tokens{Secret: secret, TTL: 48 * time.Hour},
+//		Template: "email_verified.html",
+//		Verified: func(c *okapi.Context, userID string) error {
+//			return users.MarkVerified(c.Context(), userID)
+//		},
+//	}
+//	o.Get("/verify-email", verification.Handler)
+//
+//	// On sign-up
+//	link, err := verification.Link("https://example.com/verify-email", user.ID)
+//	mailer.Send(user.Email, "Confirm your email address", link)
+type EmailVerification struct {
+	// Tokens signs the verification tokens. Required.
+	Tokens *Tokens
+	// Verified marks the account identified by subject as verified. Required.
+	Verified func(c *Context, subject string) error
+	// Template is the page rendered with a *TokenPage, with status 200 or 400.
+	// Without a template, the handler answers with JSON.
+	Template string
+}
+
+// Token returns a verification token for subject.
+func (v *EmailVerification) Token(subject string) (string, error) {
+	return v.Tokens.Sign(purposeEmailVerification, subject)
+}
+
+// Link returns base with a verification token for subject in its "token" query
+// parameter, to send by email.
+func (v *EmailVerification) Link(base, subject string) (string, error) {
+	token, err := v.Token(subject)
+	if err != nil {
+		return "", err
+	}
+	return tokenLink(base, token)
+}
+
+// Handler verifies the token in the "token" query parameter and calls Verified.
+// Invalid and expired tokens are answered with a 400 Bad Request.
+func (v *EmailVerification) Handler(c *Context) error {
+	subject, err := v.Tokens.Verify(purposeEmailVerification, c.Query("token"))
+	if err != nil {
+		if v.Template != "" {
+			return c.Render(http.StatusBadRequest, v.Template, &TokenPage{Error: tokenErrorMessage(err)})
+		}
+		return c.AbortBadRequest(tokenErrorMessage(err), err)
+	}
+	if err := v.Verified(c, subject); err != nil {
+		return err
+	}
+	if v.Template != "" {
+		return c.Render(http.StatusOK, v.Template, &TokenPage{Subject: subject})
+	}
+	return c.OK(M{"verified": true})
+}
+
+// PasswordResetForm is the submission handled by PasswordReset.Handler, as a
+// form or JSON.
+type PasswordResetForm struct {
+	Token    string `form:"token" json:"token" required:"true"`
+	Password string `form:"password" json:"password" required:"true"`
+}
+
+// PasswordReset handles password reset links carrying a signed token: it
+// renders the form asking for the new password and handles its submission.
+//
+// Example:
+//
+//	reset := &okapi.PasswordReset{
+//		Tokens:       &okapi.Tokens{Secret: secret, TTL: time.Hour},
+//		FormTemplate: "reset_password.html",
+//		DoneTemplate: "password_changed.html",
+//		Fingerprint:  func(userID string) string { return users.PasswordHash(userID) },
+//		Reset: func(c *okapi.Context, userID, password string) error {
+//			return users.SetPassword(c.Context(), userID, password)
+//		},
+//	}
+//	o.Get("/reset-password", reset.FormHandler)
+//	o.Post("/reset-password", reset.Handler)
+type PasswordReset struct {
+	// Tokens signs the reset tokens. Required.
+	Tokens *Tokens
+	// Reset sets the new password of the account identified by subject. Required.
+	Reset func(c *Context, subject, password string) error
+	// Fingerprint returns a value that changes with the password, such as a
+	// hash of the stored password hash. Tokens are bound to it, so they stop
+	// working once the password was reset. Optional, but recommended.
+	Fingerprint func(subject string) string
+	// MinLength is the minimum length of the new password. Defaults to 8.
+	MinLength int
+	// FormTemplate is the form asking for the new password, rendered with a
+	// *FormView whose Form is a *PasswordResetForm. Failed submissions re-render
+	// it with RenderWithErrors. Without a template, the handlers answer with JSON.
+	FormTemplate string
+	// DoneTemplate is the page rendered with a *TokenPage once the password was
+	// reset. Without a template, Handler answers with JSON.
+	DoneTemplate string
+}
+
+// Token returns a reset token for subject.
+func (p *PasswordReset) Token(subject string) (string, error) {
+	return p.Tokens.sign(purposePasswordReset, subject, p.Fingerprint)
+}
+
+// Link returns base with a reset token for subject in its "token" query
+// parameter, to send by email.
+func (p *PasswordReset) Link(base, subject string) (string, error) {
+	token, err := p.Token(subject)
+	if err != nil {
+		return "", err
+	}
+	return tokenLink(base, token)
+}
+
+// FormHandler checks the token in the "token" query parameter and renders the
+// form asking for the new password.
+func (p *PasswordReset) FormHandler(c *Context) error {
+	form := &PasswordResetForm{Token: c.Query("token")}
+	if _, err := p.verify(form.Token); err != nil {
+		if p.FormTemplate != "" {
+			return c.Render(http.StatusBadRequest, p.FormTemplate, &FormView{
+				Form:   form,
+				Errors: []ValidationError{{Field: "token", Message: tokenErrorMessage(err)}},
+			})
+		}
+		return c.AbortBadRequest(tokenErrorMessage(err), err)
+	}
+	if p.FormTemplate != "" {
+		return c.Render(http.StatusOK, p.FormTemplate, &FormView{Form: form})
+	}
+	return c.OK(M{"valid": true})
+}
+
+// Handler checks the submitted PasswordResetForm and calls Reset. Invalid
+// tokens and passwords are answered with a 422 Unprocessable Entity.
+func (p *PasswordReset) Handler(c *Context) error {
+	var form PasswordResetForm
+	if err := c.Bind(&form); err != nil {
+		if p.FormTemplate != "" {
+			return c.RenderWithErrors(p.FormTemplate, &form, err)
+		}
+		return c.abortBindError(&form, err)
+	}
+	subject, err := p.verify(form.Token)
+	var verrs ValidationErrors
+	if err != nil {
+		verrs = append(verrs, ValidationError{Field: "token", Message: tokenErrorMessage(err)})
+	}
+	if minLength := p.minLength(); len([]rune(form.Password)) < minLength {
+		verrs = append(verrs, ValidationError{
+			Field:   "password",
+			Message: fmt.Sprintf("must be at least %d characters long", minLength),
+		})
+	}
+	if len(verrs) > 0 {
+		if p.FormTemplate != "" {
+			return c.RenderWithErrors(p.FormTemplate, &form, verrs)
+		}
+		return c.AbortValidationError(msgValidationFailed, verrs)
+	}
+	if err := p.Reset(c, subject, form.Password); err != nil {
+		return err
+	}
+	if p.DoneTemplate != "" {
+		return c.Render(http.StatusOK, p.DoneTemplate, &TokenPage{Subject: subject})
+	}
+	return c.OK(M{"reset": true})
+}
+
+// verify returns the subject of a reset token, checking that the password
+// fingerprint did not change since it was signed.
+func (p *PasswordReset) verify(token string) (string, error) {
+	return p.Tokens.verify(purposePasswordReset, token, p.Fingerprint)
+}
+
+func (p *PasswordReset) minLength() int {
+	if p.MinLength > 0 {
+		return p.MinLength
+	}
+	return defaultPasswordMinLength
+}
+
+// tokenLink adds token to the query of base.
+func tokenLink(base, token string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// tokenErrorMessage describes a rejected token to the user.
+func tokenErrorMessage(err error) string {
+	if errors.Is(err, ErrTokenExpired) {
+		return "The link has expired"
+	}
+	return "The link is invalid"
+}
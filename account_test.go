@@ -0,0 +1,175 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailVerification(t *testing.T) {
+	verified := map[string]bool{}
+	verification := &EmailVerification{
+		Tokens: &Tokens{Secret: []byte("secret")},
+		Verified: func(c *Context, subject string) error {
+			verified[subject] = true
+			return nil
+		},
+	}
+	ts := NewTestServer(t)
+	ts.Get("/verify-email", verification.Handler)
+
+	link, err := verification.Link(ts.BaseURL+"/verify-email?lang=en", "user-1")
+	require.NoError(t, err)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "en", u.Query().Get("lang"))
+
+	okapitest.GET(t, link).ExpectStatusOK().ExpectJSONPath("verified", true)
+	assert.True(t, verified["user-1"])
+
+	reset := &PasswordReset{Tokens: verification.Tokens}
+	token, err := reset.Token("user-2")
+	require.NoError(t, err)
+	okapitest.GET(t, ts.BaseURL+"/verify-email").QueryParam("token", token).
+		ExpectStatusBadRequest().
+		ExpectBodyContains("The link is invalid")
+	assert.False(t, verified["user-2"])
+}
+
+func TestPasswordReset(t *testing.T) {
+	passwords := map[string]string{"user-1": "old password"}
+	reset := &PasswordReset{
+		Tokens:      &Tokens{Secret: []byte("secret"), TTL: time.Hour},
+		Fingerprint: func(subject string) string { return passwords[subject] },
+		Reset: func(c *Context, subject, password string) error {
+			passwords[subject] = password
+			return nil
+		},
+	}
+	ts := NewTestServer(t)
+	ts.Get("/reset-password", reset.FormHandler)
+	ts.Post("/reset-password", reset.Handler)
+
+	token, err := reset.Token("user-1")
+	require.NoError(t, err)
+	okapitest.GET(t, ts.BaseURL+"/reset-password").QueryParam("token", token).ExpectStatusOK()
+
+	okapitest.POST(t, ts.BaseURL+"/reset-password").
+		JSONBody(M{"token": token, "password": "short"}).
+		ExpectStatus(http.StatusUnprocessableEntity).
+		ExpectBodyContains("at least 8 characters")
+	assert.Equal(t, "old password", passwords["user-1"])
+
+	okapitest.POST(t, ts.BaseURL+"/reset-password").
+		JSONBody(M{"token": token, "password": "new password"}).
+		ExpectStatusOK()
+	assert.Equal(t, "new password", passwords["user-1"])
+
+	// The password changed, so the token no longer works.
+	okapitest.POST(t, ts.BaseURL+"/reset-password").
+		JSONBody(M{"token": token, "password": "another password"}).
+		ExpectStatus(http.StatusUnprocessableEntity).
+		ExpectBodyContains("The link is invalid")
+	assert.Equal(t, "new password", passwords["user-1"])
+}
+
+func TestPasswordResetChecksSignatureBeforeFingerprint(t *testing.T) {
+	var fingerprinted []string
+	reset := &PasswordReset{
+		Tokens: &Tokens{Secret: []byte("secret"), TTL: time.Hour},
+		Fingerprint: func(subject string) string {
+			fingerprinted = append(fingerprinted, subject)
+			return "hash"
+		},
+	}
+	forged, err := (&Tokens{Secret: []byte("other"), TTL: time.Hour}).sign(purposePasswordReset, "user-1", reset.Fingerprint)
+	require.NoError(t, err)
+	fingerprinted = nil
+
+	_, err = reset.verify(forged)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	assert.Empty(t, fingerprinted)
+
+	token, err := reset.Token("user-1")
+	require.NoError(t, err)
+	subject, err := reset.verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", subject)
+
+	// Reset tokens are not accepted for the bare purpose, nor the reverse.
+	_, err = reset.Tokens.Verify(purposePasswordReset, token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	plain, err := reset.Tokens.Sign(purposePasswordReset, "user-1")
+	require.NoError(t, err)
+	_, err = reset.verify(plain)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPasswordResetTemplates(t *testing.T) {
+	tmpl, err := NewTemplate(fstest.MapFS{
+		"reset.html": {Data: []byte(`<input name="token" value="{{ .Form.Token }}">` +
+			`{{ if has_error . "token" }}<p>{{ field_error . "token" }}</p>{{ end }}` +
+			`{{ if has_error . "password" }}<p>{{ field_error . "password" }}</p>{{ end }}`)},
+		"done.html": {Data: []byte(`Password changed for {{ .Subject }}`)},
+	}, "*.html")
+	require.NoError(t, err)
+	reset := &PasswordReset{
+		Tokens:       &Tokens{Secret: []byte("secret")},
+		FormTemplate: "reset.html",
+		DoneTemplate: "done.html",
+		Reset:        func(c *Context, subject, password string) error { return nil },
+	}
+	ts := NewTestServerWithOkapi(t, New().WithRenderer(tmpl))
+	ts.Get("/reset-password", reset.FormHandler)
+	ts.Post("/reset-password", reset.Handler)
+
+	okapitest.GET(t, ts.BaseURL+"/reset-password").QueryParam("token", "forged").
+		ExpectStatusBadRequest().
+		ExpectBodyContains("The link is invalid")
+
+	token, err := reset.Token("user-1")
+	require.NoError(t, err)
+	_, body := okapitest.GET(t, ts.BaseURL+"/reset-password").QueryParam("token", token).ExpectStatusOK().Execute()
+	assert.Regexp(t, regexp.MustCompile(`value="`+regexp.QuoteMeta(token)+`"`), string(body))
+
+	okapitest.POST(t, ts.BaseURL+"/reset-password").
+		FormBody(map[string]string{"token": token, "password": "short"}).
+		ExpectStatus(http.StatusUnprocessableEntity).
+		ExpectBodyContains("at least 8 characters")
+
+	okapitest.POST(t, ts.BaseURL+"/reset-password").
+		FormBody(map[string]string{"token": token, "password": "long enough"}).
+		ExpectStatusOK().
+		ExpectBody("Password changed for user-1")
+}
@@ -0,0 +1,329 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrAsyncJobNotFound is returned by a JobStore for unknown job IDs.
+var ErrAsyncJobNotFound = errors.New("okapi: async job not found")
+
+// AsyncJobStatus is the state of an asynchronous job.
+type AsyncJobStatus string
+
+const (
+	AsyncJobPending   AsyncJobStatus = "pending"
+	AsyncJobRunning   AsyncJobStatus = "running"
+	AsyncJobSucceeded AsyncJobStatus = "succeeded"
+	AsyncJobFailed    AsyncJobStatus = "failed"
+)
+
+type (
+	// AsyncJob is the state of a job submitted to endpoints registered with AsyncJobs.
+	AsyncJob struct {
+		ID     string         `json:"id" description:"Job ID"`
+		Status AsyncJobStatus `json:"status" enum:"pending,running,succeeded,failed" description:"Job status"`
+		// Progress is the completion percentage reported by the job.
+		Progress int `json:"progress" min:"0" max:"100" description:"Completion percentage"`
+		// Error is a generic failure message; the job's error is logged only.
+		Error string `json:"error,omitempty" description:"Failure reason"`
+		// Result is the JSON encoded result of a succeeded job.
+		Result    json.RawMessage `json:"-"`
+		CreatedAt time.Time       `json:"created_at"`
+		UpdatedAt time.Time       `json:"updated_at"`
+		// StatusURL and ResultURL are set in responses only.
+		StatusURL string `json:"status_url,omitempty" description:"Job status URL"`
+		ResultURL string `json:"result_url,omitempty" description:"Job result URL, once succeeded"`
+	}
+
+	// JobStore persists asynchronous jobs, so that their status can be served
+	// by any instance. NewMemoryJobStore is a single instance implementation;
+	// the okapi/redis package provides a shared one.
+	JobStore interface {
+		// SaveJob creates or replaces a job.
+		SaveJob(ctx context.Context, job *AsyncJob) error
+		// GetJob returns a job, or ErrAsyncJobNotFound.
+		GetJob(ctx context.Context, id string) (*AsyncJob, error)
+	}
+
+	// AsyncJobFunc runs an asynchronous job on its input, reporting its
+	// completion percentage with progress.
+	AsyncJobFunc[I, O any] func(ctx context.Context, in *I, progress func(percent int)) (*O, error)
+
+	// AsyncJobsConfig configures AsyncJobs.
+	AsyncJobsConfig struct {
+		// Store persists jobs. Defaults to NewMemoryJobStore(time.Hour).
+		Store JobStore
+		// Concurrency is the number of jobs run in parallel. Defaults to 1.
+		Concurrency int
+		// QueueSize is the number of submitted jobs waiting to run. Defaults to 100;
+		// submissions get 503 Service Unavailable while the queue is full.
+		QueueSize int
+		// RetryAfter is the polling interval suggested to clients. Defaults to 1s.
+		RetryAfter time.Duration
+	}
+
+	// MemoryJobStore is an in-memory JobStore.
+	MemoryJobStore struct {
+		mu   sync.RWMutex
+		jobs map[string]*AsyncJob
+		ttl  time.Duration
+	}
+
+	// asyncJobTask is the queue payload of a submitted job.
+	asyncJobTask struct {
+		ID    string          `json:"id"`
+		Input json.RawMessage `json:"input"`
+	}
+)
+
+// NewMemoryJobStore returns an in-memory JobStore forgetting finished jobs
+// ttl after their last update. A zero ttl keeps them forever.
+func NewMemoryJobStore(ttl time.Duration) *MemoryJobStore {
+	return &MemoryJobStore{jobs: make(map[string]*AsyncJob), ttl: ttl}
+}
+
+// SaveJob stores a copy of job.
+func (s *MemoryJobStore) SaveJob(_ context.Context, job *AsyncJob) error {
+	cp := *job
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = &cp
+	if s.ttl > 0 {
+		for id, j := range s.jobs {
+			if j.finished() && time.Since(j.UpdatedAt) > s.ttl {
+				delete(s.jobs, id)
+			}
+		}
+	}
+	return nil
+}
+
+// GetJob returns a copy of the job with the given ID.
+func (s *MemoryJobStore) GetJob(_ context.Context, id string) (*AsyncJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[id]
+	if !ok || (s.ttl > 0 && job.finished() && time.Since(job.UpdatedAt) > s.ttl) {
+		return nil, ErrAsyncJobNotFound
+	}
+	cp := *job
+	return &cp, nil
+}
+
+// finished reports whether the job succeeded or failed.
+func (j *AsyncJob) finished() bool {
+	return j.Status == AsyncJobSucceeded || j.Status == AsyncJobFailed
+}
+
+// AsyncJobs registers the 202 + polling pattern for long-running work on g:
+//
+//   - POST {prefix} binds I, queues the job and answers 202 Accepted with the
+//     job, a Location header pointing to its status and a Retry-After hint;
+//   - GET {prefix}/{id} reports the job status and progress;
+//   - GET {prefix}/{id}/result returns O once the job succeeded, 409 Conflict before.
+//
+// Jobs run on the instance's Workers, started and stopped with the server. The
+// routes are documented in the OpenAPI specification under the group's tags.
+//
+// Example:
+//
+//	okapi.AsyncJobs(app.Group("/reports"), func(ctx context.Context, in *ReportRequest, progress func(int)) (*Report, error) {
+//		report := &Report{}
+//		for i, section := range in.Sections {
+//			report.Add(buildSection(ctx, section))
+//			progress((i + 1) * 100 / len(in.Sections))
+//		}
+//		return report, nil
+//	})
+func AsyncJobs[I, O any](g *Group, run AsyncJobFunc[I, O], config ...AsyncJobsConfig) *Route {
+	var cfg AsyncJobsConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.Store == nil {
+		cfg.Store = NewMemoryJobStore(time.Hour)
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 100
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = time.Second
+	}
+	store := cfg.Store
+	retryAfter := fmt.Sprint(int(cfg.RetryAfter.Seconds() + 0.999))
+	logger := g.okapi.logger.With(slog.String("async_jobs", g.Prefix))
+	queue := NewMemoryQueue(cfg.QueueSize)
+	statusName := "async-job-status:" + g.Prefix
+	resultName := "async-job-result:" + g.Prefix
+
+	g.okapi.Workers().Register("async-jobs:"+g.Prefix, queue, func(ctx context.Context, task *Job) error {
+		var t asyncJobTask
+		if err := task.Bind(&t); err != nil {
+			return err
+		}
+		return runAsyncJob(ctx, logger, store, t, run)
+	}, Concurrency(max(cfg.Concurrency, 1)))
+
+	// respond adds the status and result URLs to job.
+	respond := func(c *Context, job *AsyncJob) *AsyncJob {
+		job.StatusURL, _ = c.URL(statusName, "id", job.ID)
+		if job.Status == AsyncJobSucceeded {
+			job.ResultURL, _ = c.URL(resultName, "id", job.ID)
+		}
+		return job
+	}
+	load := func(c *Context) (*AsyncJob, error) {
+		job, err := store.GetJob(c.Context(), c.Param("id"))
+		if errors.Is(err, ErrAsyncJobNotFound) {
+			return nil, c.AbortNotFound("Job not found")
+		}
+		if err != nil {
+			return nil, c.AbortInternalServerError("Failed to load job", err)
+		}
+		return job, nil
+	}
+
+	submit := g.Post("", func(c *Context) error {
+		in := new(I)
+		if err := c.bindInput(in); err != nil {
+			return c.abortBindError(in, err)
+		}
+		input, err := json.Marshal(in)
+		if err != nil {
+			return c.AbortInternalServerError("Failed to encode job input", err)
+		}
+		now := time.Now()
+		job := &AsyncJob{ID: uuid.NewString(), Status: AsyncJobPending, CreatedAt: now, UpdatedAt: now}
+		if err := store.SaveJob(c.Context(), job); err != nil {
+			return c.AbortInternalServerError("Failed to save job", err)
+		}
+		payload, err := json.Marshal(asyncJobTask{ID: job.ID, Input: input})
+		if err != nil {
+			return c.AbortInternalServerError("Failed to encode job", err)
+		}
+		select {
+		case queue.jobs <- &Job{ID: job.ID, Payload: payload}:
+		default:
+			job.Status, job.Error, job.UpdatedAt = AsyncJobFailed, "queue full", time.Now()
+			_ = store.SaveJob(c.Context(), job)
+			c.SetHeader("Retry-After", retryAfter)
+			return c.AbortServiceUnavailable("Too many pending jobs")
+		}
+		return c.Accepted(respond(c, job), cfg.RetryAfter, statusName, "id", job.ID)
+	}, Request(new(I)), DocResponse(http.StatusAccepted, AsyncJob{}),
+		DocResponseHeader("Location", "string", "Job status URL"),
+		DocResponseHeader("Retry-After", "integer", "Suggested polling interval, in seconds"),
+		DocResponse(http.StatusServiceUnavailable, ErrorResponse{}))
+
+	g.Get("/{id}", func(c *Context) error {
+		job, err := load(c)
+		if job == nil {
+			return err
+		}
+		if !job.finished() {
+			c.SetHeader("Retry-After", retryAfter)
+		}
+		return c.OK(respond(c, job))
+	}, RouteName(statusName), Summary("Get job status"), DocPathParam("id", "string", "Job ID"),
+		DocResponse(AsyncJob{}), DocResponse(http.StatusNotFound, ErrorResponse{}))
+
+	g.Get("/{id}/result", func(c *Context) error {
+		job, err := load(c)
+		if job == nil {
+			return err
+		}
+		switch job.Status {
+		case AsyncJobSucceeded:
+			return c.writeResponse(http.StatusOK, constJSON, func() error {
+				_, err := c.response.Write(job.Result)
+				return err
+			})
+		case AsyncJobFailed:
+			return c.AbortConflict("Job failed")
+		default:
+			return c.AbortConflict("Job is " + string(job.Status))
+		}
+	}, RouteName(resultName), Summary("Get job result"), DocPathParam("id", "string", "Job ID"),
+		DocResponse(new(O)), DocResponse(http.StatusNotFound, ErrorResponse{}),
+		DocResponse(http.StatusConflict, ErrorResponse{}))
+
+	return submit
+}
+
+// runAsyncJob runs a queued job, recording its progress and outcome in store.
+// The error of a failed job is logged, not stored, as job statuses are public.
+func runAsyncJob[I, O any](ctx context.Context, logger *slog.Logger, store JobStore, t asyncJobTask, run AsyncJobFunc[I, O]) error {
+	job, err := store.GetJob(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	// mu serializes updates, as jobs may report progress from several goroutines.
+	var mu sync.Mutex
+	update := func(apply func(*AsyncJob)) {
+		mu.Lock()
+		defer mu.Unlock()
+		apply(job)
+		job.UpdatedAt = time.Now()
+		_ = store.SaveJob(ctx, job)
+	}
+	update(func(j *AsyncJob) { j.Status = AsyncJobRunning })
+
+	in := new(I)
+	out, err := func() (out *O, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		if err := json.Unmarshal(t.Input, in); err != nil {
+			return nil, fmt.Errorf("decode job input: %w", err)
+		}
+		return run(ctx, in, func(percent int) {
+			update(func(j *AsyncJob) { j.Progress = min(max(percent, 0), 100) })
+		})
+	}()
+	var result []byte
+	if err == nil {
+		result, err = json.Marshal(out)
+	}
+	if err != nil {
+		logger.Error("async job failed", slog.String("job", t.ID), slog.String("error", err.Error()))
+		update(func(j *AsyncJob) { j.Status, j.Error = AsyncJobFailed, "job failed" })
+		return nil
+	}
+	update(func(j *AsyncJob) { j.Status, j.Progress, j.Result = AsyncJobSucceeded, 100, result })
+	return nil
+}
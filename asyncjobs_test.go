@@ -0,0 +1,229 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type reportRequest struct {
+	Pages int `json:"pages" required:"true"`
+}
+
+type reportResult struct {
+	Pages int `json:"pages"`
+}
+
+func TestAsyncJobs(t *testing.T) {
+	ts := NewTestServer(t)
+	release := make(chan struct{})
+	AsyncJobs(ts.Group("/reports"), func(ctx context.Context, in *reportRequest, progress func(int)) (*reportResult, error) {
+		progress(50)
+		<-release
+		if in.Pages < 0 {
+			return nil, errors.New("negative pages")
+		}
+		return &reportResult{Pages: in.Pages}, nil
+	}, AsyncJobsConfig{RetryAfter: 2 * time.Second})
+	ts.Workers().Start()
+	t.Cleanup(func() { _ = ts.Workers().Stop(context.Background()) })
+
+	getJob := func(url string) (int, AsyncJob) {
+		t.Helper()
+		resp, err := http.Get(ts.BaseURL + url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var job AsyncJob
+		_ = json.NewDecoder(resp.Body).Decode(&job)
+		return resp.StatusCode, job
+	}
+
+	resp, err := http.Post(ts.BaseURL+"/reports", "application/json", strings.NewReader(`{"pages":3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var job AsyncJob
+	_ = json.NewDecoder(resp.Body).Decode(&job)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || job.ID == "" {
+		t.Fatalf("expected 202 with a job ID, got %d %+v", resp.StatusCode, job)
+	}
+	statusURL := "/reports/" + job.ID
+	if got := resp.Header.Get("Location"); got != statusURL {
+		t.Errorf("expected Location %q, got %q", statusURL, got)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "2" {
+		t.Errorf("expected Retry-After 2, got %q", got)
+	}
+
+	waitFor := func(want AsyncJobStatus) AsyncJob {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			code, job := getJob(statusURL)
+			if code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", code)
+			}
+			if job.Status == want {
+				return job
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("job still %s, expected %s", job.Status, want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	running := waitFor(AsyncJobRunning)
+	for running.Progress != 50 {
+		time.Sleep(5 * time.Millisecond)
+		running = waitFor(AsyncJobRunning)
+	}
+	if code, _ := getJob(statusURL + "/result"); code != http.StatusConflict {
+		t.Errorf("expected 409 before completion, got %d", code)
+	}
+
+	close(release)
+	done := waitFor(AsyncJobSucceeded)
+	if done.Progress != 100 || done.ResultURL != statusURL+"/result" {
+		t.Errorf("unexpected finished job: %+v", done)
+	}
+	r, err := http.Get(ts.BaseURL + done.ResultURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Body.Close()
+	var result reportResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil || result.Pages != 3 {
+		t.Errorf("expected result with 3 pages, got %+v (%v)", result, err)
+	}
+
+	if code, _ := getJob("/reports/unknown"); code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown job, got %d", code)
+	}
+	resp, err = http.Post(ts.BaseURL+"/reports", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid input, got %d", resp.StatusCode)
+	}
+}
+
+func TestAsyncJobsFailure(t *testing.T) {
+	store := NewMemoryJobStore(0)
+	err := runAsyncJob(context.Background(), slog.Default(), store, asyncJobTask{ID: "j1", Input: json.RawMessage(`{}`)},
+		func(ctx context.Context, in *reportRequest, progress func(int)) (*reportResult, error) {
+			return nil, nil
+		})
+	if !errors.Is(err, ErrAsyncJobNotFound) {
+		t.Fatalf("expected ErrAsyncJobNotFound, got %v", err)
+	}
+
+	_ = store.SaveJob(context.Background(), &AsyncJob{ID: "j1", Status: AsyncJobPending})
+	_ = runAsyncJob(context.Background(), slog.Default(), store, asyncJobTask{ID: "j1", Input: json.RawMessage(`{}`)},
+		func(ctx context.Context, in *reportRequest, progress func(int)) (*reportResult, error) {
+			panic("boom")
+		})
+	job, _ := store.GetJob(context.Background(), "j1")
+	if job.Status != AsyncJobFailed || job.Error != "job failed" {
+		t.Errorf("expected failed job, got %+v", job)
+	}
+}
+
+func TestAsyncJobsQueueFull(t *testing.T) {
+	ts := NewTestServer(t)
+	AsyncJobs(ts.Group("/reports"), func(ctx context.Context, in *reportRequest, progress func(int)) (*reportResult, error) {
+		return &reportResult{}, nil
+	}, AsyncJobsConfig{QueueSize: 1, RetryAfter: 3 * time.Second})
+
+	for i, want := range []int{http.StatusAccepted, http.StatusServiceUnavailable} {
+		resp, err := http.Post(ts.BaseURL+"/reports", "application/json", strings.NewReader(`{"pages":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Fatalf("submission %d: expected %d, got %d", i, want, resp.StatusCode)
+		}
+		if got := resp.Header.Get("Retry-After"); got != "3" {
+			t.Errorf("submission %d: expected Retry-After 3, got %q", i, got)
+		}
+	}
+}
+
+func TestAsyncJobsConcurrentProgress(t *testing.T) {
+	store := NewMemoryJobStore(0)
+	_ = store.SaveJob(context.Background(), &AsyncJob{ID: "j1", Status: AsyncJobPending})
+	_ = runAsyncJob(context.Background(), slog.Default(), store, asyncJobTask{ID: "j1", Input: json.RawMessage(`{}`)},
+		func(ctx context.Context, in *reportRequest, progress func(int)) (*reportResult, error) {
+			var wg sync.WaitGroup
+			for i := range 10 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					progress(i * 10)
+				}()
+			}
+			wg.Wait()
+			return &reportResult{}, nil
+		})
+	job, _ := store.GetJob(context.Background(), "j1")
+	if job.Status != AsyncJobSucceeded || job.Progress != 100 {
+		t.Errorf("expected succeeded job, got %+v", job)
+	}
+}
+
+func TestAsyncJobsOpenAPI(t *testing.T) {
+	o := New()
+	AsyncJobs(o.Group("/exports").WithTags([]string{"Exports"}), func(ctx context.Context, in *reportRequest, progress func(int)) (*reportResult, error) {
+		return &reportResult{}, nil
+	})
+	o.buildOpenAPISpec()
+
+	submit := o.openapiSpec.Paths.Find("/exports")
+	if submit == nil || submit.Post == nil || submit.Post.Responses.Status(http.StatusAccepted) == nil {
+		t.Fatal("expected documented 202 submit route")
+	}
+	status := o.openapiSpec.Paths.Find("/exports/{id}")
+	if status == nil || status.Get == nil || status.Get.Responses.Status(http.StatusNotFound) == nil {
+		t.Fatal("expected documented status route")
+	}
+	result := o.openapiSpec.Paths.Find("/exports/{id}/result")
+	if result == nil || result.Get == nil || result.Get.Responses.Status(http.StatusConflict) == nil {
+		t.Fatal("expected documented result route")
+	}
+}
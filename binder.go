@@ -31,6 +31,7 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
@@ -98,11 +99,72 @@ func (c *Context) B(v any) error {
 //	  }
 //	  return c.Respond(book)
 //	})
+//
+// Once tag validation passes, a struct implementing Validatable is checked
+// with its Validate method.
 func (c *Context) Bind(out any) error {
+	var err error
 	if hasBodyField(out) {
-		return c.bindStruct(out)
+		err = c.bindStruct(out)
+	} else {
+		err = c.bindRequest(out)
+	}
+	if err != nil {
+		return c.localizeError(err)
+	}
+	if v, ok := out.(Validatable); ok {
+		return v.Validate(c)
 	}
-	return c.bindRequest(out)
+	return nil
+}
+
+// bindInput binds the input of a typed handler (Handle, HandleIO, ...). A panic
+// raised while binding, e.g. for a field type the binder does not support, is
+// returned as an error instead of crashing the handler.
+func (c *Context) bindInput(in any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &bindPanicError{target: in, value: r}
+		}
+	}()
+	return c.Bind(in)
+}
+
+// bindPanicError reports a panic recovered while binding a typed handler input.
+type bindPanicError struct {
+	target any
+	value  any
+}
+
+func (e *bindPanicError) Error() string {
+	return fmt.Sprintf("okapi: binding %T panicked: %v", e.target, e.value)
+}
+
+// abortBindError answers a failed bind of a typed handler input through the
+// error handler, so the response matches the application's error format:
+//   - ValidationErrors returned by a Validatable get a 422;
+//   - tag validation failures get a 400, with the failing field reported as
+//     ValidationErrors too;
+//   - a panic recovered by bindInput gets a 500;
+//   - other errors, such as a malformed parameter, get a 400.
+//
+// The default error handler renders ValidationErrors as a ValidationErrorResponse.
+func (c *Context) abortBindError(in any, err error) error {
+	var (
+		panicked *bindPanicError
+		verrs    ValidationErrors
+		required *RequiredFieldError
+		field    *FieldError
+	)
+	switch {
+	case errors.As(err, &panicked):
+		return c.AbortInternalServerError("Internal Server Error", err)
+	case errors.As(err, &verrs):
+		return c.abortWithError(http.StatusUnprocessableEntity, c.T(msgValidationFailed), verrs)
+	case errors.As(err, &required), errors.As(err, &field):
+		return c.abortWithError(http.StatusBadRequest, c.T(msgValidationFailed), ValidationErrors(c.formErrors(in, err)))
+	}
+	return c.AbortBadRequest("Bad Request", err)
 }
 
 // Bind binds the request data to the provided struct based on the content type and tags.
@@ -337,7 +399,7 @@ func (c *Context) bindHeaderFieldWithStatus(tag string, v reflect.Value, fld ref
 
 func (c *Context) bindFormFieldWithStatus(tag string, valField reflect.Value, field reflect.StructField) (bool, error) {
 	// Handle slice types (arrays)
-	if valField.Kind() == reflect.Slice && valField.Type().Elem().Kind() == reflect.String {
+	if valField.Kind() == reflect.Slice && valField.Type().Elem().Kind() == reflect.String && !hasBindFunc(field) {
 		values := c.request.MultipartForm.Value[tag]
 		if len(values) == 0 {
 			// No form values found - return false to indicate no value was set
@@ -387,28 +449,14 @@ func (c *Context) bindQueryFieldWithStatus(tag string, vf reflect.Value, fld ref
 	}
 
 	// Handle slice types (arrays)
-	if vf.Kind() == reflect.Slice && vf.Type().Elem().Kind() == reflect.String {
-		values := c.request.Form[tag]
-		if len(values) == 0 {
+	if vf.Kind() == reflect.Slice && vf.Type().Elem().Kind() == reflect.String && !hasBindFunc(fld) {
+		// Repeated, comma-separated or bracketed values, as configured
+		allValues := c.arrayValues(c.request.Form, tag)
+		if len(allValues) == 0 {
 			// No query values found - return false to indicate no value was set
 			return false, nil
 		}
 
-		// Handle comma-separated values
-		var allValues []string
-		for _, value := range values {
-			if strings.Contains(value, ",") {
-				allValues = append(allValues, strings.Split(value, ",")...)
-			} else {
-				allValues = append(allValues, value)
-			}
-		}
-
-		// Trim whitespace
-		for i, val := range allValues {
-			allValues[i] = strings.TrimSpace(val)
-		}
-
 		slice := reflect.MakeSlice(vf.Type(), len(allValues), len(allValues))
 		for i, val := range allValues {
 			slice.Index(i).SetString(val)
@@ -449,7 +497,7 @@ func (c *Context) applyDefaultAndValidate(valField reflect.Value, field reflect.
 
 	// Only check required if no value was set and field is still zero after potential default application
 	if !wasSet && field.Tag.Get(tagRequired) == constTRUE && isEmptyValue(valField) {
-		return fmt.Errorf("field %s is required", field.Name)
+		return &RequiredFieldError{Field: field.Name}
 	}
 
 	return nil
@@ -484,6 +532,16 @@ func (c *Context) bindFromFields(out any) error {
 			continue
 		}
 
+		// Array query parameters, read with the accepted array syntaxes
+		if key := field.Tag.Get(tagQuery); key != "" && valField.Kind() == reflect.Slice && !hasBindFunc(field) {
+			if values := c.QueryArray(key); len(values) > 0 {
+				if err := setSliceWithType(valField, values); err != nil {
+					return fmt.Errorf("bind error for field %s: %w", field.Name, err)
+				}
+				continue
+			}
+		}
+
 		wasSet := false
 
 		// Map of tag type → function returning value
@@ -535,7 +593,7 @@ func (c *Context) bindFromFields(out any) error {
 
 		// Required check
 		if !wasSet && field.Tag.Get(tagRequired) == constTRUE && isEmptyValue(valField) {
-			return fmt.Errorf("field %s is required", field.Name)
+			return &RequiredFieldError{Field: field.Name}
 		}
 	}
 
@@ -545,6 +603,9 @@ func (c *Context) bindFromFields(out any) error {
 func setValueWithValidation(field reflect.Value, value string, sf reflect.StructField) error {
 	if field.CanSet() {
 		if value != "" {
+			if hasBindFunc(sf) {
+				return setWithBindFunc(field, value, sf)
+			}
 			if err := setWithType(field, value); err != nil {
 				return fmt.Errorf("cannot set field %s: %w", sf.Name, err)
 			}
@@ -624,16 +685,22 @@ func validateStruct(v any) error {
 			continue
 		}
 
-		if err := validateNestedStruct(field); err != nil {
-			return err
+		value, ok := unwrapNull(field)
+		if ok {
+			if err := validateNestedStruct(value); err != nil {
+				return err
+			}
 		}
 
 		if sf.Tag.Get(tagRequired) == constTRUE && isEmptyValue(field) {
-			return fmt.Errorf("field %s is required", sf.Name)
+			return &RequiredFieldError{Field: sf.Name}
+		}
+		if !ok {
+			continue
 		}
 		for _, check := range fieldConstraintCheckers {
-			if err := check(field, sf); err != nil {
-				return fmt.Errorf("field %s: %w", sf.Name, err)
+			if err := check(value, sf); err != nil {
+				return &FieldError{Field: sf.Name, Err: err}
 			}
 		}
 	}
@@ -28,6 +28,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -691,3 +692,75 @@ func TestBindQuery_DecodesValues(t *testing.T) {
 		t.Errorf("Name = %v, want [Jane]", got.Name)
 	}
 }
+
+type pricedInput struct {
+	Limit int `query:"limit" max:"10"`
+	Body  struct {
+		Name string `json:"name" required:"true"`
+	}
+}
+
+type panickyInput struct {
+	Name string `json:"name"`
+	rule func() bool
+}
+
+func (p *panickyInput) Validate(c *Context) error {
+	if !p.rule() {
+		return ValidationErrors{{Field: "name", Message: "rejected"}}
+	}
+	return nil
+}
+
+func TestTypedHandlerBindErrors(t *testing.T) {
+	create := H(func(c *Context, in *pricedInput) error {
+		return c.Created(in.Body)
+	})
+
+	t.Run("default error handler", func(t *testing.T) {
+		ts := NewTestServer(t)
+		ts.Post("/books", create)
+		ts.Post("/panics", HandleIO(func(c *Context, in *panickyInput) (*panickyInput, error) {
+			return in, nil
+		}))
+
+		okapitest.POST(t, ts.BaseURL+"/books").JSONBody(M{}).
+			ExpectStatusBadRequest().
+			ExpectBodyContains(`"message":"Validation failed"`).
+			ExpectBodyContains(`"errors":[{"field":"name","message":"field Name is required"}]`)
+		okapitest.POST(t, ts.BaseURL+"/books?limit=50").JSONBody(M{"name": "Go"}).
+			ExpectStatusBadRequest().
+			ExpectBodyContains(`"field":"limit"`)
+		okapitest.POST(t, ts.BaseURL+"/books?limit=ten").JSONBody(M{"name": "Go"}).
+			ExpectStatusBadRequest().
+			ExpectBodyNotContains(`"errors"`)
+		okapitest.POST(t, ts.BaseURL+"/panics").JSONBody(M{"name": "Go"}).
+			ExpectStatusInternalServerError().
+			ExpectBodyContains("panickyInput panicked")
+	})
+
+	t.Run("problem details", func(t *testing.T) {
+		ts := NewTestServerWithOkapi(t, New(WithSimpleProblemDetailErrorHandler()))
+		ts.Post("/books", create)
+
+		okapitest.POST(t, ts.BaseURL+"/books").JSONBody(M{}).
+			ExpectStatusBadRequest().
+			ExpectContentType(constJSONProblem).
+			ExpectBodyContains(`"errors":[{"field":"name","message":"field Name is required"}]`)
+	})
+
+	t.Run("custom error handler", func(t *testing.T) {
+		ts := NewTestServerWithOkapi(t, New(WithErrorHandler(func(c *Context, code int, message string, err error) error {
+			var verrs ValidationErrors
+			if errors.As(err, &verrs) {
+				return c.JSON(code, M{"invalid": len(verrs)})
+			}
+			return DefaultErrorHandler(c, code, message, err)
+		})))
+		ts.Post("/books", create)
+
+		okapitest.POST(t, ts.BaseURL+"/books").JSONBody(M{}).
+			ExpectStatusBadRequest().
+			ExpectJSON(M{"invalid": 1})
+	})
+}
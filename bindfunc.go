@@ -0,0 +1,113 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// bindFunc is a registered per-field parser, erased to reflect values.
+type bindFunc struct {
+	out   reflect.Type
+	parse func(value string) (reflect.Value, error)
+}
+
+var bindFuncs = struct {
+	mu    sync.RWMutex
+	funcs map[string]bindFunc
+}{funcs: make(map[string]bindFunc)}
+
+// RegisterBindFunc registers fn under name so struct fields tagged
+// `bindWith:"name"` are parsed by it during Bind, instead of the built-in
+// conversions. It suits formats the binder does not know, such as
+// comma-separated integers, base64 blobs or money values like "12.30 USD".
+//
+// The field must be of type T or *T. An error returned by fn is reported as a
+// *FieldError, so typed handlers answer 400 with the failing field. Registering
+// the same name twice replaces the previous function.
+//
+// Example:
+//
+//	okapi.RegisterBindFunc("csvInts", func(s string) ([]int, error) { ... })
+//
+//	type Filter struct {
+//	    IDs []int `query:"ids" bindWith:"csvInts"`
+//	}
+func RegisterBindFunc[T any](name string, fn func(value string) (T, error)) {
+	bf := bindFunc{
+		out: reflect.TypeFor[T](),
+		parse: func(value string) (reflect.Value, error) {
+			v, err := fn(value)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(&v).Elem(), nil
+		},
+	}
+	bindFuncs.mu.Lock()
+	defer bindFuncs.mu.Unlock()
+	bindFuncs.funcs[name] = bf
+}
+
+// lookupBindFunc returns the function registered under name.
+func lookupBindFunc(name string) (bindFunc, bool) {
+	bindFuncs.mu.RLock()
+	defer bindFuncs.mu.RUnlock()
+	bf, ok := bindFuncs.funcs[name]
+	return bf, ok
+}
+
+// hasBindFunc reports whether sf is parsed by a registered bind function, in
+// which case the raw value is passed whole, without slice splitting.
+func hasBindFunc(sf reflect.StructField) bool {
+	return sf.Tag.Get(tagBindWith) != ""
+}
+
+// setWithBindFunc parses value with the bind function named by the field's
+// bindWith tag and stores the result in field.
+func setWithBindFunc(field reflect.Value, value string, sf reflect.StructField) error {
+	name := sf.Tag.Get(tagBindWith)
+	bf, ok := lookupBindFunc(name)
+	if !ok {
+		return fmt.Errorf("okapi: no bind function registered as %q", name)
+	}
+	ft := field.Type()
+	if !bf.out.AssignableTo(ft) && !(ft.Kind() == reflect.Ptr && bf.out.AssignableTo(ft.Elem())) {
+		return fmt.Errorf("okapi: bind function %q returns %s, not assignable to field %s of type %s", name, bf.out, sf.Name, ft)
+	}
+	v, err := bf.parse(value)
+	if err != nil {
+		return &FieldError{Field: sf.Name, Err: err}
+	}
+	if ft.Kind() == reflect.Ptr && !bf.out.AssignableTo(ft) {
+		ptr := reflect.New(ft.Elem())
+		ptr.Elem().Set(v)
+		v = ptr
+	}
+	field.Set(v)
+	return nil
+}
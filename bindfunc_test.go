@@ -0,0 +1,133 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+type testMoney struct {
+	Cents    int64
+	Currency string
+}
+
+func init() {
+	RegisterBindFunc("csvInts", func(s string) ([]int, error) {
+		var out []int
+		for _, part := range strings.Split(s, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an integer", part)
+			}
+			out = append(out, n)
+		}
+		return out, nil
+	})
+	RegisterBindFunc("base64", func(s string) ([]byte, error) {
+		return base64.StdEncoding.DecodeString(s)
+	})
+	RegisterBindFunc("money", func(s string) (testMoney, error) {
+		amount, currency, ok := strings.Cut(s, " ")
+		if !ok || len(currency) != 3 {
+			return testMoney{}, errors.New("must look like 12.30 USD")
+		}
+		f, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			return testMoney{}, errors.New("must look like 12.30 USD")
+		}
+		return testMoney{Cents: int64(f*100 + 0.5), Currency: currency}, nil
+	})
+}
+
+type bindFuncInput struct {
+	IDs   []int      `query:"ids" bindWith:"csvInts"`
+	Blob  []byte     `header:"X-Blob" bindWith:"base64"`
+	Price *testMoney `query:"price" bindWith:"money"`
+}
+
+func TestBindFunc(t *testing.T) {
+	c, _ := NewTestContext(http.MethodGet, "/?ids=1,2,3&price=12.30+USD", nil)
+	c.Request().Header.Set("X-Blob", base64.StdEncoding.EncodeToString([]byte("hello")))
+
+	var in bindFuncInput
+	if err := c.Bind(&in); err != nil {
+		t.Fatalf("bind: %v", err)
+	}
+	if fmt.Sprint(in.IDs) != "[1 2 3]" {
+		t.Errorf("IDs = %v, want [1 2 3]", in.IDs)
+	}
+	if string(in.Blob) != "hello" {
+		t.Errorf("Blob = %q, want %q", in.Blob, "hello")
+	}
+	if in.Price == nil || *in.Price != (testMoney{Cents: 1230, Currency: "USD"}) {
+		t.Errorf("Price = %+v, want 1230 USD", in.Price)
+	}
+
+	var field *FieldError
+	c, _ = NewTestContext(http.MethodGet, "/?ids=1,x", nil)
+	if err := c.Bind(&bindFuncInput{}); !errors.As(err, &field) || field.Field != "IDs" {
+		t.Errorf("invalid value: got %v, want a FieldError for IDs", err)
+	}
+
+	c, _ = NewTestContext(http.MethodGet, "/?ids=1", nil)
+	var unknown struct {
+		IDs []int `query:"ids" bindWith:"missing"`
+	}
+	if err := c.Bind(&unknown); err == nil || !strings.Contains(err.Error(), `"missing"`) {
+		t.Errorf("unregistered function: got %v", err)
+	}
+
+	var mismatched struct {
+		IDs string `query:"ids" bindWith:"csvInts"`
+	}
+	if err := c.Bind(&mismatched); err == nil || !strings.Contains(err.Error(), "not assignable") {
+		t.Errorf("mismatched type: got %v", err)
+	}
+}
+
+func TestBindFunc_TypedHandler(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/orders", Handle(func(c *Context, in *bindFuncInput) error {
+		return c.OK(M{"ids": in.IDs, "cents": in.Price.Cents})
+	}))
+
+	okapitest.GET(t, ts.BaseURL+"/orders").
+		QueryParam("ids", "4,5").QueryParam("price", "0.99 EUR").
+		ExpectStatusOK().
+		ExpectJSONPath("cents", float64(99))
+
+	okapitest.GET(t, ts.BaseURL+"/orders").
+		QueryParam("ids", "4").QueryParam("price", "cheap").
+		ExpectStatus(http.StatusBadRequest).
+		ExpectBodyContains("must look like 12.30 USD")
+}
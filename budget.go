@@ -0,0 +1,323 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrResponseTooLarge is returned by response writes that exceed the byte
+// budget set with Route.WithMaxResponseBytes.
+var ErrResponseTooLarge = errors.New("okapi: response exceeds the route byte budget")
+
+type (
+	// routeBudget holds the guardrails set with Route.WithMaxDuration and
+	// Route.WithMaxResponseBytes.
+	routeBudget struct {
+		maxDuration      time.Duration
+		maxResponseBytes int64
+	}
+
+	// budgetWriter enforces a routeBudget on a handler's response. Writes are
+	// serialized so the deadline timer can answer on behalf of a stalled handler.
+	budgetWriter struct {
+		mu       sync.Mutex
+		w        http.ResponseWriter
+		header   http.Header
+		maxBytes int64
+		// logger and the request attributes are captured when the handler
+		// starts, as the deadline timer must not read the Context.
+		logger      *slog.Logger
+		method      string
+		route       string
+		path        string
+		maxDuration time.Duration
+		start       time.Time
+		deadline    time.Time // zero without a time budget
+		status      int
+		written     int64
+		buf         bytes.Buffer
+		sent        bool  // status line forwarded to w
+		err         error // set once the response was aborted
+		done        bool
+	}
+)
+
+// WithMaxDuration limits the time the route's handler may take. The request
+// context is canceled at the deadline; if the handler has not responded by then,
+// the client receives a 500 Internal Server Error, further writes are discarded
+// and the overrun is logged with the route and elapsed time.
+//
+// It is a guardrail to spot runaway endpoints, not a replacement for
+// cooperative cancellation: the handler keeps running until it returns.
+//
+// Example:
+//
+//	o.Get("/reports", buildReport).WithMaxDuration(5 * time.Second)
+func (r *Route) WithMaxDuration(d time.Duration) *Route {
+	r.ensureBudget().maxDuration = max(d, 0)
+	return r
+}
+
+// MaxDuration is the RouteOption form of Route.WithMaxDuration.
+func MaxDuration(d time.Duration) RouteOption {
+	return func(r *Route) {
+		r.WithMaxDuration(d)
+	}
+}
+
+// WithMaxResponseBytes limits the size of the route's response body. The body
+// is buffered up to n bytes, so a response exceeding the budget is replaced by a
+// 507 Insufficient Storage and logged. Once the handler flushes, for instance
+// while streaming, the body is sent as written and an overrun stops the stream.
+// Writes beyond the budget return ErrResponseTooLarge.
+//
+// Example:
+//
+//	o.Get("/users", listUsers).WithMaxResponseBytes(1 << 20)
+func (r *Route) WithMaxResponseBytes(n int64) *Route {
+	r.ensureBudget().maxResponseBytes = max(n, 0)
+	return r
+}
+
+// MaxResponseBytes is the RouteOption form of Route.WithMaxResponseBytes.
+func MaxResponseBytes(n int64) RouteOption {
+	return func(r *Route) {
+		r.WithMaxResponseBytes(n)
+	}
+}
+
+// ensureBudget returns the route's guardrails, creating them on first use.
+func (r *Route) ensureBudget() *routeBudget {
+	if r.budget == nil {
+		r.budget = &routeBudget{}
+	}
+	return r.budget
+}
+
+// active reports whether any guardrail is set.
+func (b *routeBudget) active() bool {
+	return b != nil && (b.maxDuration > 0 || b.maxResponseBytes > 0)
+}
+
+// wrap returns h enforcing the route's budget.
+func (b *routeBudget) wrap(r *Route, h HandlerFunc) HandlerFunc {
+	return func(c *Context) error {
+		bw := &budgetWriter{
+			w:           c.response,
+			header:      c.response.Header().Clone(),
+			maxBytes:    b.maxResponseBytes,
+			logger:      c.Logger(),
+			method:      r.Method,
+			route:       r.Path,
+			path:        c.request.URL.Path,
+			maxDuration: b.maxDuration,
+		}
+		original, request := c.response, c.request
+		c.response = newResponseWriter(bw).withDiagnostics(c.okapi)
+		defer func() {
+			c.response, c.request = original, request
+		}()
+
+		if b.maxDuration > 0 {
+			bw.start = time.Now()
+			bw.deadline = bw.start.Add(b.maxDuration)
+			ctx, cancel := context.WithDeadline(request.Context(), bw.deadline)
+			defer cancel()
+			c.request = request.WithContext(ctx)
+			// Answers for a stalled handler. A handler returning as soon as it
+			// sees ctx.Done() may run first, its writes then check the deadline.
+			stop := context.AfterFunc(ctx, func() {
+				bw.mu.Lock()
+				defer bw.mu.Unlock()
+				bw.expire()
+			})
+			defer stop()
+		}
+
+		err := h(c)
+		bw.finish()
+		if bw.aborted() {
+			return nil
+		}
+		return err
+	}
+}
+
+// Header returns the handler's header map, copied to the response when sent.
+func (w *budgetWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *budgetWriter) WriteHeader(status int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	if w.err != nil || w.status != 0 {
+		return
+	}
+	if status >= 100 && status < 200 && status != http.StatusSwitchingProtocols {
+		w.w.WriteHeader(status)
+		return
+	}
+	w.status = status
+	if w.maxBytes == 0 {
+		w.send()
+	}
+}
+
+func (w *budgetWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	if w.err != nil {
+		return 0, w.err
+	}
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.maxBytes > 0 && w.written+int64(len(b)) > w.maxBytes {
+		w.logger.Error("[okapi] route exceeded its response budget",
+			"method", w.method, "route", w.route, "path", w.path,
+			"max_response_bytes", w.maxBytes, "attempted_bytes", w.written+int64(len(b)))
+		w.abort(http.StatusInsufficientStorage, ErrResponseTooLarge)
+		return 0, w.err
+	}
+	w.written += int64(len(b))
+	if w.maxBytes > 0 && !w.sent {
+		return w.buf.Write(b)
+	}
+	w.send()
+	return w.w.Write(b)
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (w *budgetWriter) Unwrap() http.ResponseWriter {
+	return w.w
+}
+
+// Flush sends the buffered response, the rest of the body is then streamed.
+func (w *budgetWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	if w.err != nil {
+		return
+	}
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.send()
+	if fl, ok := w.w.(http.Flusher); ok {
+		fl.Flush()
+	}
+}
+
+// expire answers with a 500 once the time budget is over, unless the handler
+// already returned or the response was aborted. The caller holds w.mu.
+func (w *budgetWriter) expire() {
+	if w.done || w.err != nil || w.deadline.IsZero() || time.Now().Before(w.deadline) {
+		return
+	}
+	w.logger.Error("[okapi] route exceeded its time budget",
+		"method", w.method, "route", w.route, "path", w.path,
+		"max_duration", w.maxDuration.String(), "elapsed", time.Since(w.start).String())
+	w.abort(http.StatusInternalServerError, http.ErrHandlerTimeout)
+}
+
+// abort replaces the response with an error status when nothing was sent yet,
+// and discards any further write. The caller holds w.mu.
+func (w *budgetWriter) abort(status int, err error) {
+	w.err = err
+	w.buf.Reset()
+	if w.sent {
+		return
+	}
+	w.sent = true
+	body := http.StatusText(status) + "\n"
+	h := w.w.Header()
+	h.Set("Content-Type", "text/plain; charset=utf-8")
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.w.WriteHeader(status)
+	_, _ = w.w.Write([]byte(body))
+	if fl, ok := w.w.(http.Flusher); ok {
+		fl.Flush()
+	}
+}
+
+// send forwards the headers and any buffered body. The caller holds w.mu.
+func (w *budgetWriter) send() {
+	if w.sent {
+		return
+	}
+	w.sent = true
+	w.copyHeader()
+	w.w.WriteHeader(w.status)
+	if w.buf.Len() > 0 {
+		_, _ = w.w.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+}
+
+// finish sends a buffered response once the handler returned and disarms the
+// deadline.
+func (w *budgetWriter) finish() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.expire()
+	w.done = true
+	switch {
+	case w.err != nil:
+	case w.status != 0:
+		w.send()
+	default:
+		// Nothing written, keep the handler's headers for the error response.
+		w.copyHeader()
+	}
+}
+
+// copyHeader replaces the response headers with the handler's.
+func (w *budgetWriter) copyHeader() {
+	h := w.w.Header()
+	clear(h)
+	for name, values := range w.header {
+		h[name] = values
+	}
+}
+
+// aborted reports whether the response was replaced by a budget error.
+func (w *budgetWriter) aborted() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err != nil
+}
@@ -0,0 +1,99 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestRouteBudget(t *testing.T) {
+	ts := NewTestServer(t)
+	writeErr := make(chan error, 1)
+
+	ts.Get("/fast", func(c *Context) error {
+		c.SetHeader("X-Route", "fast")
+		return c.OK(M{"ok": true})
+	}).WithMaxDuration(time.Second)
+
+	ts.Get("/slow", func(c *Context) error {
+		select {
+		case <-c.Request().Context().Done():
+		case <-time.After(time.Second):
+		}
+		// The deadline already answered, this write is discarded.
+		return c.String(200, "late")
+	}, MaxDuration(50*time.Millisecond))
+
+	ts.Get("/small", func(c *Context) error {
+		return c.String(200, "tiny")
+	}).WithMaxResponseBytes(16)
+
+	ts.Get("/large", func(c *Context) error {
+		err := c.String(200, strings.Repeat("x", 64))
+		writeErr <- err
+		return err
+	}, MaxResponseBytes(16))
+
+	ts.Get("/stream", func(c *Context) error {
+		_, _ = c.response.Write([]byte("first chunk\n"))
+		c.response.(interface{ Flush() }).Flush()
+		_, err := c.response.Write([]byte(strings.Repeat("x", 64)))
+		writeErr <- err
+		return nil
+	}).WithMaxResponseBytes(16)
+
+	t.Run("within budget", func(t *testing.T) {
+		okapitest.GET(t, ts.BaseURL+"/fast").ExpectStatusOK().ExpectHeader("X-Route", "fast").
+			ExpectBodyContains(`"ok":true`)
+		okapitest.GET(t, ts.BaseURL+"/small").ExpectStatusOK().ExpectBody("tiny")
+	})
+
+	t.Run("time budget exceeded", func(t *testing.T) {
+		start := time.Now()
+		okapitest.GET(t, ts.BaseURL+"/slow").ExpectStatus(500).ExpectBodyContains("Internal Server Error")
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("response took %s, want it at the deadline", elapsed)
+		}
+	})
+
+	t.Run("response budget exceeded", func(t *testing.T) {
+		okapitest.GET(t, ts.BaseURL+"/large").ExpectStatus(507).ExpectBodyContains("Insufficient Storage")
+		if err := <-writeErr; !errors.Is(err, ErrResponseTooLarge) {
+			t.Errorf("write error = %v, want ErrResponseTooLarge", err)
+		}
+	})
+
+	t.Run("streamed response is cut", func(t *testing.T) {
+		okapitest.GET(t, ts.BaseURL+"/stream").ExpectStatusOK().ExpectBody("first chunk\n")
+		if err := <-writeErr; !errors.Is(err, ErrResponseTooLarge) {
+			t.Errorf("write error = %v, want ErrResponseTooLarge", err)
+		}
+	})
+}
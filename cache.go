@@ -0,0 +1,384 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// CacheStore stores the responses cached by Route.CacheFor. The default store
+	// keeps them in memory; use a shared backend such as the okapi/redis package
+	// so that every instance serves the same cached responses, see WithCacheStore.
+	CacheStore interface {
+		// Get returns the value stored under key, reporting false when it is
+		// missing or expired.
+		Get(ctx context.Context, key string) ([]byte, bool, error)
+		// Set stores value under key for ttl.
+		Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	}
+
+	// routeCache memoizes the responses of a single route, see Route.CacheFor.
+	routeCache struct {
+		ttl    time.Duration
+		memory *memoryCacheStore
+		mu     sync.Mutex
+		calls  map[string]*cacheCall
+		// identify computes id, the route's part of the keys, once the route
+		// is fully configured.
+		identify sync.Once
+		id       string
+		hostKey  bool
+	}
+
+	// cacheEntry is a recorded response. An entry without status lists the
+	// request headers named by the Vary header of the responses stored under
+	// the key, which are keyed by the values of these headers as well.
+	cacheEntry struct {
+		Status int         `json:"status,omitempty"`
+		Header http.Header `json:"header,omitempty"`
+		Body   []byte      `json:"body,omitempty"`
+		Vary   []string    `json:"vary,omitempty"`
+	}
+
+	// cacheCall tracks an in-flight handler call shared by concurrent misses.
+	cacheCall struct {
+		done  chan struct{}
+		entry *cacheEntry
+	}
+
+	// cacheRecorder captures a handler's response so it can be stored and replayed.
+	cacheRecorder struct {
+		header http.Header
+		status int
+		body   bytes.Buffer
+	}
+
+	// memoryCacheStore is the default, per instance CacheStore.
+	memoryCacheStore struct {
+		mu      sync.Mutex
+		entries map[string]memoryCacheItem
+	}
+
+	memoryCacheItem struct {
+		value   []byte
+		expires time.Time
+	}
+)
+
+// CacheFor memoizes the route's responses for ttl, keyed by route, method, path
+// and query string, and by host for routes matching on it. Concurrent requests for an uncached key share a single handler call.
+//
+// Only successful (2xx) responses to GET and HEAD requests are cached, and
+// middlewares still run on every request, so authentication is not bypassed.
+// Requests carrying an Authorization or Cookie header are never served from
+// the cache, and responses are cached per value of the request headers listed
+// in their Vary header; responses varying on "*" are not cached.
+// It is meant for expensive but small endpoints such as reference data.
+// Responses are kept in memory unless a shared store is set with WithCacheStore.
+//
+// Example:
+//
+//	o.Get("/countries", listCountries).CacheFor(10 * time.Minute)
+func (r *Route) CacheFor(ttl time.Duration) *Route {
+	if ttl <= 0 {
+		r.cache = nil
+		return r
+	}
+	r.cache = &routeCache{
+		ttl:    ttl,
+		memory: &memoryCacheStore{entries: make(map[string]memoryCacheItem)},
+		calls:  make(map[string]*cacheCall),
+	}
+	return r
+}
+
+// CacheFor is the RouteOption form of Route.CacheFor.
+func CacheFor(ttl time.Duration) RouteOption {
+	return func(r *Route) {
+		r.CacheFor(ttl)
+	}
+}
+
+// WithCacheStore sets the store used by the routes cached with CacheFor.
+// Store errors are logged and the handler is called as on a cache miss.
+func WithCacheStore(store CacheStore) OptionFunc {
+	return func(o *Okapi) {
+		o.cacheStore = store
+	}
+}
+
+// WithCacheStore sets the store used by the routes cached with CacheFor.
+func (o *Okapi) WithCacheStore(store CacheStore) *Okapi {
+	return o.apply(WithCacheStore(store))
+}
+
+// wrap returns h, the handler of r, serving from the cache when possible.
+func (rc *routeCache) wrap(r *Route, h HandlerFunc) HandlerFunc {
+	return func(c *Context) error {
+		req := c.request
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			return h(c)
+		}
+		// Responses to credentialed requests are likely personalized.
+		if req.Header.Get("Authorization") != "" || req.Header.Get("Cookie") != "" {
+			return h(c)
+		}
+		base := rc.key(r, req)
+		store := rc.store(c)
+
+		key, entry := base, rc.lookup(c, store, base)
+		if entry != nil && entry.Status == 0 {
+			key = varyKey(base, entry.Vary, req)
+			entry = rc.lookup(c, store, key)
+		}
+		if entry != nil {
+			entry.replay(c.response)
+			return nil
+		}
+		rc.mu.Lock()
+		call, inflight := rc.calls[key]
+		if !inflight {
+			call = &cacheCall{done: make(chan struct{})}
+			rc.calls[key] = call
+			rc.mu.Unlock()
+			return rc.populate(c, h, store, base, key, call)
+		}
+		rc.mu.Unlock()
+
+		<-call.done
+		if call.entry == nil {
+			// The shared call was not cacheable, serve this request on its own.
+			return h(c)
+		}
+		call.entry.replay(c.response)
+		return nil
+	}
+}
+
+// key returns the cache key of req. It identifies the route as well as the
+// request, as routes sharing a path with different MatchHeader, MatchQuery or
+// host predicates must not share entries in a shared store.
+func (rc *routeCache) key(r *Route, req *http.Request) string {
+	rc.identify.Do(func() {
+		id := []string{r.Method, r.Path}
+		id = append(id, r.matchHeaders...)
+		id = append(id, r.matchQueries...)
+		if r.muxRoute != nil {
+			if host, err := r.muxRoute.GetHostTemplate(); err == nil {
+				id = append(id, "host="+host)
+				rc.hostKey = true
+			}
+		}
+		rc.id = strings.Join(id, "\x00")
+	})
+	key := "okapi:cache:" + rc.id + " " + req.Method + " " + req.URL.Path + "?" + req.URL.Query().Encode()
+	if rc.hostKey {
+		key += " " + req.Host
+	}
+	return key
+}
+
+// store returns the instance's shared store, or the route's memory store.
+func (rc *routeCache) store(c *Context) CacheStore {
+	if c.okapi != nil && c.okapi.cacheStore != nil {
+		return c.okapi.cacheStore
+	}
+	return rc.memory
+}
+
+// lookup returns the cached response for key, or nil on a miss.
+func (rc *routeCache) lookup(c *Context, store CacheStore, key string) *cacheEntry {
+	value, ok, err := store.Get(c.request.Context(), key)
+	if err != nil {
+		c.Logger().Warn("cache lookup failed", slog.String("key", key), slog.String("error", err.Error()))
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(value, entry); err != nil {
+		c.Logger().Warn("invalid cached response", slog.String("key", key), slog.String("error", err.Error()))
+		return nil
+	}
+	return entry
+}
+
+// populate runs the handler on behalf of every request waiting on key and
+// stores a successful response. When the response varies on request headers,
+// it is stored under the variant key of base, and key only serves waiters
+// when it already was that variant key.
+func (rc *routeCache) populate(c *Context, h HandlerFunc, store CacheStore, base, key string, call *cacheCall) error {
+	defer func() {
+		rc.mu.Lock()
+		delete(rc.calls, key)
+		rc.mu.Unlock()
+		close(call.done)
+	}()
+
+	rec := &cacheRecorder{header: make(http.Header)}
+	original := c.response
+	c.response = newResponseWriter(rec).withDiagnostics(c.okapi)
+	// Restore the writer even if the handler panics, so recovery can respond.
+	defer func() { c.response = original }()
+	err := h(c)
+	c.response = original
+
+	entry := &cacheEntry{Status: rec.status, Header: rec.header, Body: rec.body.Bytes()}
+	if entry.Status == 0 {
+		entry.Status = http.StatusOK
+	}
+	entry.replay(c.response)
+	if err != nil || entry.Status < 200 || entry.Status >= 300 {
+		return err
+	}
+	vary, cacheable := varyHeaders(entry.Header)
+	if !cacheable {
+		return nil
+	}
+	target := base
+	if len(vary) > 0 {
+		target = varyKey(base, vary, c.request)
+		rc.save(c, store, base, &cacheEntry{Vary: vary})
+	}
+	if target == key {
+		call.entry = entry
+	}
+	rc.save(c, store, target, entry)
+	return nil
+}
+
+// save stores entry under key, logging failures.
+func (rc *routeCache) save(c *Context, store CacheStore, key string, entry *cacheEntry) {
+	value, err := json.Marshal(entry)
+	if err == nil {
+		err = store.Set(c.request.Context(), key, value, rc.ttl)
+	}
+	if err != nil {
+		c.Logger().Warn("cache store failed", slog.String("key", key), slog.String("error", err.Error()))
+	}
+}
+
+// varyHeaders returns the canonical, sorted names listed by the Vary header,
+// reporting false for "Vary: *", which makes the response uncacheable.
+func varyHeaders(h http.Header) ([]string, bool) {
+	var names []string
+	for _, value := range h.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			switch {
+			case name == "":
+			case name == "*":
+				return nil, false
+			default:
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names), true
+}
+
+// varyKey returns the key of the variant of base selected by the values of the
+// vary request headers of req.
+func varyKey(base string, vary []string, req *http.Request) string {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, name := range vary {
+		b.WriteString("\x00")
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strings.Join(req.Header.Values(name), ","))
+	}
+	return b.String()
+}
+
+// replay writes the recorded response to w.
+func (e *cacheEntry) replay(w http.ResponseWriter) {
+	header := w.Header()
+	for name, values := range e.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	w.WriteHeader(e.Status)
+	if len(e.Body) > 0 {
+		_, _ = w.Write(e.Body)
+	}
+}
+
+// Get implements CacheStore.
+func (s *memoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !time.Now().Before(item.expires) {
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	return item.value, true, nil
+}
+
+// Set implements CacheStore, dropping expired entries on the way.
+func (s *memoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for k, item := range s.entries {
+		if !now.Before(item.expires) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = memoryCacheItem{value: value, expires: now.Add(ttl)}
+	return nil
+}
+
+func (r *cacheRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *cacheRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.body.Write(b)
+}
+
+// WriteHeader records the final status, informational responses are not replayed.
+func (r *cacheRecorder) WriteHeader(status int) {
+	if r.status == 0 && status >= 200 {
+		r.status = status
+	}
+}
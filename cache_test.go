@@ -0,0 +1,240 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestRouteCacheFor(t *testing.T) {
+	ts := NewTestServer(t)
+	var calls, slowCalls, failCalls, middlewareCalls atomic.Int32
+
+	ts.Get("/countries", func(c *Context) error {
+		n := calls.Add(1)
+		c.SetHeader("X-Call", strconv.Itoa(int(n)))
+		return c.OK(M{"call": n, "q": c.Query("q")})
+	}, UseMiddleware(func(c *Context) error {
+		middlewareCalls.Add(1)
+		return c.Next()
+	})).CacheFor(time.Minute)
+
+	ts.Get("/slow", func(c *Context) error {
+		slowCalls.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return c.OK(M{"ok": true})
+	}, CacheFor(time.Minute))
+
+	ts.Get("/flaky", func(c *Context) error {
+		failCalls.Add(1)
+		return c.AbortServiceUnavailable("try again")
+	}).CacheFor(time.Minute)
+
+	ts.Get("/short", func(c *Context) error {
+		return c.OK(M{"call": calls.Add(1)})
+	}).CacheFor(50 * time.Millisecond)
+
+	t.Run("memoizes per path and query", func(t *testing.T) {
+		okapitest.GET(t, ts.BaseURL+"/countries").ExpectStatusOK().ExpectHeader("X-Call", "1")
+		okapitest.GET(t, ts.BaseURL+"/countries").ExpectStatusOK().ExpectHeader("X-Call", "1").
+			ExpectBodyContains(`"call":1`)
+		okapitest.GET(t, ts.BaseURL+"/countries?q=fr").ExpectStatusOK().ExpectBodyContains(`"call":2`)
+		okapitest.GET(t, ts.BaseURL+"/countries?q=fr").ExpectStatusOK().ExpectBodyContains(`"call":2`)
+		if got := middlewareCalls.Load(); got != 4 {
+			t.Errorf("middleware calls = %d, want 4", got)
+		}
+	})
+
+	t.Run("single flight", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				okapitest.GET(t, ts.BaseURL+"/slow").ExpectStatusOK().ExpectBodyContains(`"ok":true`)
+			}()
+		}
+		wg.Wait()
+		if got := slowCalls.Load(); got != 1 {
+			t.Errorf("handler calls = %d, want 1", got)
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		okapitest.GET(t, ts.BaseURL+"/flaky").ExpectStatus(http.StatusServiceUnavailable)
+		okapitest.GET(t, ts.BaseURL+"/flaky").ExpectStatus(http.StatusServiceUnavailable)
+		if got := failCalls.Load(); got != 2 {
+			t.Errorf("handler calls = %d, want 2", got)
+		}
+	})
+
+	t.Run("entries expire", func(t *testing.T) {
+		_, first := okapitest.GET(t, ts.BaseURL+"/short").Execute()
+		_, cached := okapitest.GET(t, ts.BaseURL+"/short").Execute()
+		if string(cached) != string(first) {
+			t.Errorf("cached body = %s, want %s", cached, first)
+		}
+		time.Sleep(80 * time.Millisecond)
+		if _, fresh := okapitest.GET(t, ts.BaseURL+"/short").Execute(); string(fresh) == string(first) {
+			t.Errorf("expected a fresh response after the ttl, got %s", fresh)
+		}
+	})
+}
+
+// sharedCacheStore is a CacheStore shared by several instances, standing in for Redis.
+type sharedCacheStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (s *sharedCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.entries[key]
+	return v, ok, nil
+}
+
+func (s *sharedCacheStore) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = value
+	return nil
+}
+
+func TestRouteCacheSharedStore(t *testing.T) {
+	store := &sharedCacheStore{entries: make(map[string][]byte)}
+	var calls atomic.Int32
+	handler := func(c *Context) error {
+		c.SetHeader("X-Call", strconv.Itoa(int(calls.Add(1))))
+		return c.OK(M{"rates": "EUR"})
+	}
+
+	first := NewTestServer(t)
+	first.WithCacheStore(store)
+	first.Get("/rates", handler).CacheFor(time.Minute)
+	second := NewTestServer(t)
+	second.WithCacheStore(store)
+	second.Get("/rates", handler, CacheFor(time.Minute))
+
+	okapitest.GET(t, first.BaseURL+"/rates").ExpectStatusOK().ExpectHeader("X-Call", "1")
+	okapitest.GET(t, second.BaseURL+"/rates").ExpectStatusOK().ExpectHeader("X-Call", "1").
+		ExpectBodyContains(`"rates":"EUR"`)
+	if got := calls.Load(); got != 1 {
+		t.Errorf("handler calls = %d, want 1", got)
+	}
+}
+
+func TestRouteCacheSharedStoreKeysByRoute(t *testing.T) {
+	store := &sharedCacheStore{entries: make(map[string][]byte)}
+	o := New(WithCacheStore(store))
+	o.Get("/books", func(c *Context) error {
+		return c.OK(M{"version": 2})
+	}, MatchHeader("X-API-Version", "2"), CacheFor(time.Minute))
+	o.Get("/books", func(c *Context) error {
+		return c.OK(M{"version": 1})
+	}, CacheFor(time.Minute))
+	for _, host := range []string{"a.example.com", "b.example.com"} {
+		o.Get("/home", func(c *Context) error {
+			return c.OK(M{"host": host})
+		}, CacheFor(time.Minute), WithMuxRoute(func(r *mux.Route) { r.Host(host) }))
+	}
+
+	serve := func(target string, header ...string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d", target, rec.Code)
+		}
+		return rec.Body.String()
+	}
+	if body := serve("/books"); !strings.Contains(body, `"version":1`) {
+		t.Errorf("unexpected body %s", body)
+	}
+	if body := serve("/books", "X-API-Version", "2"); !strings.Contains(body, `"version":2`) {
+		t.Errorf("MatchHeader route served another route's entry: %s", body)
+	}
+	if body := serve("http://a.example.com/home"); !strings.Contains(body, "a.example.com") {
+		t.Errorf("unexpected body %s", body)
+	}
+	if body := serve("http://b.example.com/home"); !strings.Contains(body, "b.example.com") {
+		t.Errorf("host route served another route's entry: %s", body)
+	}
+}
+
+func TestRouteCacheVaryAndCredentials(t *testing.T) {
+	ts := NewTestServer(t)
+	var calls, anyCalls atomic.Int32
+	ts.Get("/greeting", func(c *Context) error {
+		calls.Add(1)
+		c.Vary("Accept-Language")
+		if strings.HasPrefix(c.Header("Accept-Language"), "fr") {
+			return c.OK(M{"greeting": "bonjour"})
+		}
+		return c.OK(M{"greeting": "hello"})
+	}, CacheFor(time.Minute))
+	ts.Get("/any", func(c *Context) error {
+		anyCalls.Add(1)
+		c.SetHeader("Vary", "*")
+		return c.OK(M{})
+	}, CacheFor(time.Minute))
+
+	okapitest.GET(t, ts.BaseURL+"/greeting").Header("Accept-Language", "fr").ExpectBodyContains("bonjour")
+	okapitest.GET(t, ts.BaseURL+"/greeting").Header("Accept-Language", "en").ExpectBodyContains("hello")
+	okapitest.GET(t, ts.BaseURL+"/greeting").Header("Accept-Language", "fr").ExpectBodyContains("bonjour")
+	okapitest.GET(t, ts.BaseURL+"/greeting").Header("Accept-Language", "en").ExpectBodyContains("hello")
+	if got := calls.Load(); got != 2 {
+		t.Errorf("handler calls = %d, want one per language", got)
+	}
+
+	okapitest.GET(t, ts.BaseURL+"/greeting").Header("Accept-Language", "fr").
+		Header("Authorization", "Bearer token").ExpectBodyContains("bonjour")
+	okapitest.GET(t, ts.BaseURL+"/greeting").Header("Accept-Language", "fr").
+		Header("Cookie", "session=1").ExpectBodyContains("bonjour")
+	if got := calls.Load(); got != 4 {
+		t.Errorf("handler calls = %d, want credentialed requests to bypass the cache", got)
+	}
+
+	okapitest.GET(t, ts.BaseURL+"/any").ExpectStatusOK()
+	okapitest.GET(t, ts.BaseURL+"/any").ExpectStatusOK()
+	if got := anyCalls.Load(); got != 2 {
+		t.Errorf("handler calls = %d, want Vary: * responses not cached", got)
+	}
+}
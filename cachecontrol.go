@@ -0,0 +1,122 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheDirectives describes a Cache-Control response header, see Context.CacheControl.
+// Durations are rounded down to whole seconds, and zero durations are omitted.
+type CacheDirectives struct {
+	// MaxAge is how long the response stays fresh (max-age).
+	MaxAge time.Duration
+	// SMaxAge overrides MaxAge for shared caches such as CDNs (s-maxage).
+	// It is ignored for private responses.
+	SMaxAge time.Duration
+	// SWR lets caches serve a stale response while revalidating it in the
+	// background (stale-while-revalidate).
+	SWR time.Duration
+	// StaleIfError lets caches serve a stale response when the origin fails (stale-if-error).
+	StaleIfError time.Duration
+	// Public allows shared caches to store the response, even when it is authenticated.
+	Public bool
+	// Private restricts storage to the client's own cache; it takes precedence over Public.
+	Private bool
+	// Immutable tells clients the response never changes while fresh, e.g. for fingerprinted assets.
+	Immutable bool
+	// MustRevalidate forbids serving the response once stale without revalidation.
+	MustRevalidate bool
+	// NoTransform forbids intermediaries from altering the body.
+	NoTransform bool
+}
+
+// String returns the Cache-Control header value.
+func (d CacheDirectives) String() string {
+	directives := make([]string, 0, 8)
+	switch {
+	case d.Private:
+		directives = append(directives, "private")
+	case d.Public:
+		directives = append(directives, "public")
+	}
+	seconds := func(name string, v time.Duration) {
+		if s := int64(v / time.Second); s > 0 {
+			directives = append(directives, name+"="+strconv.FormatInt(s, 10))
+		}
+	}
+	seconds("max-age", d.MaxAge)
+	if !d.Private {
+		seconds("s-maxage", d.SMaxAge)
+	}
+	if d.MustRevalidate {
+		directives = append(directives, "must-revalidate")
+	}
+	seconds("stale-while-revalidate", d.SWR)
+	seconds("stale-if-error", d.StaleIfError)
+	if d.Immutable {
+		directives = append(directives, "immutable")
+	}
+	if d.NoTransform {
+		directives = append(directives, "no-transform")
+	}
+	return strings.Join(directives, ", ")
+}
+
+// CacheControl sets the Cache-Control header from d.
+//
+//	c.CacheControl(okapi.CacheDirectives{Public: true, MaxAge: time.Hour, SWR: time.Minute})
+//	// Cache-Control: public, max-age=3600, stale-while-revalidate=60
+func (c *Context) CacheControl(d CacheDirectives) {
+	value := d.String()
+	if value == "" {
+		c.response.Header().Del("Cache-Control")
+		return
+	}
+	c.response.Header().Set("Cache-Control", value)
+}
+
+// NoCache prevents clients and intermediaries from storing the response,
+// e.g. for pages showing personal data. It also sets the legacy Pragma and
+// Expires headers understood by HTTP/1.0 caches.
+func (c *Context) NoCache() {
+	h := c.response.Header()
+	h.Set("Cache-Control", "no-store, no-cache, must-revalidate")
+	h.Set("Pragma", "no-cache")
+	h.Set("Expires", "0")
+}
+
+// Vary adds the request headers the response depends on to the Vary header,
+// skipping those already listed, so caches store one variant per value.
+//
+//	c.Vary("Accept-Language", "Accept-Encoding")
+func (c *Context) Vary(headers ...string) {
+	h := c.response.Header()
+	for _, name := range headers {
+		addVary(h, name)
+	}
+}
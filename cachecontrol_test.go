@@ -0,0 +1,84 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheDirectives(t *testing.T) {
+	tests := []struct {
+		name string
+		d    CacheDirectives
+		want string
+	}{
+		{"empty", CacheDirectives{}, ""},
+		{"public with swr", CacheDirectives{Public: true, MaxAge: time.Hour, SWR: time.Minute},
+			"public, max-age=3600, stale-while-revalidate=60"},
+		{"private drops s-maxage", CacheDirectives{Private: true, Public: true, MaxAge: 90 * time.Second, SMaxAge: time.Hour},
+			"private, max-age=90"},
+		{"immutable asset", CacheDirectives{Public: true, MaxAge: 365 * 24 * time.Hour, Immutable: true},
+			"public, max-age=31536000, immutable"},
+		{"all directives", CacheDirectives{MaxAge: 1500 * time.Millisecond, SMaxAge: time.Minute, MustRevalidate: true,
+			StaleIfError: time.Hour, NoTransform: true},
+			"max-age=1, s-maxage=60, must-revalidate, stale-if-error=3600, no-transform"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.d.String())
+		})
+	}
+}
+
+func TestContext_CachingHeaders(t *testing.T) {
+	t.Run("cache control", func(t *testing.T) {
+		ctx, rec := NewTestContext("GET", "/", nil)
+		ctx.SetHeader("Cache-Control", "no-store")
+		ctx.CacheControl(CacheDirectives{Private: true, MaxAge: time.Minute})
+		assert.Equal(t, "private, max-age=60", rec.Header().Get("Cache-Control"))
+
+		ctx.CacheControl(CacheDirectives{})
+		assert.Empty(t, rec.Header().Values("Cache-Control"))
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		ctx, rec := NewTestContext("GET", "/", nil)
+		ctx.NoCache()
+		assert.Equal(t, "no-store, no-cache, must-revalidate", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, "no-cache", rec.Header().Get("Pragma"))
+		assert.Equal(t, "0", rec.Header().Get("Expires"))
+	})
+
+	t.Run("vary", func(t *testing.T) {
+		ctx, rec := NewTestContext("GET", "/", nil)
+		rec.Header().Set("Vary", "Origin, Accept-Encoding")
+		ctx.Vary("Accept-Language", "origin", "accept-encoding")
+		ctx.Vary("accept-language")
+		assert.Equal(t, []string{"Origin, Accept-Encoding", "Accept-Language"}, rec.Header().Values("Vary"))
+	})
+}
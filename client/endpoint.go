@@ -0,0 +1,312 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package client
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Endpoint is a typed operation of an API whose inputs and outputs are
+// described by okapi-style structs. It lets internal services share the input
+// and output types of an Okapi API instead of hand-writing request code:
+//
+//	type GetBookInput struct {
+//		ID     int    `path:"id"`
+//		Fields string `query:"fields"`
+//	}
+//
+//	var getBook = client.Endpoint[GetBookInput, Book]{Method: http.MethodGet, Path: "/books/{id}"}
+//
+//	func (b *BooksClient) GetBook(ctx context.Context, id int) (*Book, error) {
+//		return getBook.Call(ctx, b.client, &GetBookInput{ID: id})
+//	}
+type Endpoint[I, O any] struct {
+	// Method is the HTTP method of the operation.
+	Method string
+	// Path is the operation path, with {name} or :name path parameters.
+	Path string
+}
+
+// Call sends in, encoded with RequestBuilder.Input, and decodes a 2xx response
+// into a new O. When O has a Body field, the body is decoded into it and the
+// fields tagged `header` or `status:"true"` are set from the response.
+// A non-2xx response is returned as an *HTTPError.
+func (e Endpoint[I, O]) Call(ctx context.Context, c *Client, in *I) (*O, error) {
+	rb := c.Request(e.Method, e.Path).WithContext(ctx)
+	if in != nil {
+		rb.Input(in)
+	}
+	resp, err := rb.Do()
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Error(); err != nil {
+		return nil, err
+	}
+	out := new(O)
+	if err := decodeOutput(resp, out); err != nil {
+		return nil, fmt.Errorf("decode %s %s response: %w", e.Method, e.Path, err)
+	}
+	return out, nil
+}
+
+// Input encodes v, a struct or a pointer to one, the way the okapi binder
+// decodes requests: fields tagged `path` (or `param`) fill the path parameters,
+// `query`, `header` and `cookie` fields are sent as such, and a field named Body
+// (or tagged `json:"body"`) is sent as the JSON body. Without a Body field,
+// `form` fields are sent form-encoded, and any untagged field makes the whole
+// struct the JSON body, except for GET and HEAD requests. Nil pointers and
+// unset values, whose IsZero method reports true like okapi.Null, are omitted;
+// other zero values such as 0 and false are sent, as the server may default
+// them to something else.
+func (rb *RequestBuilder) Input(v any) *RequestBuilder {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		rb.buildErr = fmt.Errorf("input must be a struct, got %T", v)
+		return rb
+	}
+	var (
+		form      url.Values
+		body      reflect.Value
+		hasBody   bool
+		flatBody  bool
+		cookies   []string
+		rt        = rv.Type()
+		bindTags  = []string{"path", "param", "query", "header", "cookie", "form"}
+		noBodyFor = rb.method == http.MethodGet || rb.method == http.MethodHead
+	)
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := rv.Field(i)
+		if field.Name == "Body" || field.Tag.Get("json") == "body" {
+			body, hasBody = fv, true
+			continue
+		}
+		tagged := false
+		for _, tag := range bindTags {
+			name := field.Tag.Get(tag)
+			if name == "" {
+				continue
+			}
+			tagged = true
+			values := inputValues(fv)
+			switch tag {
+			case "path", "param":
+				if len(values) == 0 {
+					rb.buildErr = fmt.Errorf("missing path parameter %q", name)
+					return rb
+				}
+				rb.url = replacePathParam(rb.url, name, url.PathEscape(values[0]))
+			case "query":
+				for _, val := range values {
+					rb.QueryParam(name, val)
+				}
+			case "header":
+				if len(values) > 0 {
+					rb.Header(name, strings.Join(values, ","))
+				}
+			case "cookie":
+				if len(values) > 0 {
+					cookies = append(cookies, (&http.Cookie{Name: name, Value: values[0]}).String())
+				}
+			case "form":
+				if len(values) > 0 {
+					if form == nil {
+						form = url.Values{}
+					}
+					form[name] = values
+				}
+			}
+			break
+		}
+		if !tagged && field.Tag.Get("json") != "-" {
+			flatBody = true
+		}
+	}
+	if len(cookies) > 0 {
+		rb.Header("Cookie", strings.Join(cookies, "; "))
+	}
+	switch {
+	case hasBody:
+		if !isNil(body) {
+			rb.JSONBody(body.Interface())
+		}
+	case form != nil:
+		rb.contentType = "application/x-www-form-urlencoded"
+		rb.body = strings.NewReader(form.Encode())
+	case flatBody && !noBodyFor:
+		rb.JSONBody(rv.Interface())
+	}
+	return rb
+}
+
+// inputValues formats a field value as parameter values, none when it is
+// unset.
+func inputValues(v reflect.Value) []string {
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if isNil(v) {
+		return nil
+	}
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok && z.IsZero() {
+		return nil
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		values := make([]string, 0, v.Len())
+		for i := range v.Len() {
+			values = append(values, inputValues(v.Index(i))...)
+		}
+		return values
+	}
+	switch x := v.Interface().(type) {
+	case time.Time:
+		return []string{x.Format(time.RFC3339)}
+	case encoding.TextMarshaler:
+		if text, err := x.MarshalText(); err == nil {
+			return []string{string(text)}
+		}
+	}
+	return []string{fmt.Sprint(v.Interface())}
+}
+
+// replacePathParam substitutes the {name}, {name:pattern} and :name
+// placeholders of the request URL.
+func replacePathParam(rawURL, name, value string) string {
+	segments := strings.Split(rawURL, "/")
+	for i, s := range segments {
+		if s == ":"+name || s == "{"+name+"}" || (strings.HasPrefix(s, "{"+name+":") && strings.HasSuffix(s, "}")) {
+			segments[i] = value
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// decodeOutput decodes resp into out, see Endpoint.Call.
+func decodeOutput(resp *Response, out any) error {
+	rv := reflect.ValueOf(out).Elem()
+	if rv.Kind() != reflect.Struct {
+		if len(resp.Body) == 0 {
+			return nil
+		}
+		return resp.Decode(out)
+	}
+	rt := rv.Type()
+	body := -1
+	for i := range rt.NumField() {
+		if f := rt.Field(i); f.IsExported() && (f.Name == "Body" || f.Tag.Get("json") == "body") {
+			body = i
+		}
+	}
+	if body < 0 {
+		if len(resp.Body) == 0 {
+			return nil
+		}
+		return resp.Decode(out)
+	}
+	if len(resp.Body) > 0 {
+		if err := resp.Decode(rv.Field(body).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		var value string
+		switch {
+		case field.Tag.Get("status") == "true":
+			value = strconv.Itoa(resp.StatusCode)
+		case field.Tag.Get("header") != "":
+			value = resp.Header.Get(field.Tag.Get("header"))
+		default:
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		if err := setOutputValue(rv.Field(i), value); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// setOutputValue sets a string, boolean or numeric field from its text form.
+func setOutputValue(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
+
+// isNil reports whether v is a nil pointer, interface, map or slice.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
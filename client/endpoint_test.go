@@ -0,0 +1,134 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jkaninda/okapi/client"
+)
+
+func TestInputPathAndQuery(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/users/0/posts/a%20b" {
+			t.Errorf("path = %s", r.URL.EscapedPath())
+		}
+		if got := r.URL.Query()["tag"]; len(got) != 2 || got[0] != "x" || got[1] != "y" {
+			t.Errorf("tag = %v", got)
+		}
+		if got := r.URL.Query()["page"]; len(got) != 1 || got[0] != "0" {
+			t.Errorf("page = %v, zero values must be sent", got)
+		}
+		if got := r.URL.Query().Get("archived"); got != "false" {
+			t.Errorf("archived = %q", got)
+		}
+		if _, ok := r.URL.Query()["limit"]; ok {
+			t.Error("nil query values must be omitted")
+		}
+		if body, _ := io.ReadAll(r.Body); len(body) != 0 {
+			t.Errorf("GET body = %q", body)
+		}
+		_, _ = w.Write([]byte(`{"id":7}`))
+	})
+	type input struct {
+		UserID   int      `path:"user_id"`
+		Slug     string   `param:"slug"`
+		Tags     []string `query:"tag"`
+		Page     int      `query:"page"`
+		Archived bool     `query:"archived"`
+		Limit    *int     `query:"limit"`
+		Filter   string
+	}
+	type user struct {
+		ID int `json:"id"`
+	}
+	endpoint := client.Endpoint[input, user]{Method: http.MethodGet, Path: "/users/{user_id:[0-9]+}/posts/:slug"}
+	out, err := endpoint.Call(context.Background(), client.New(srv.URL), &input{UserID: 0, Slug: "a b", Tags: []string{"x", "y"}, Filter: "f"})
+	if err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if out.ID != 7 {
+		t.Errorf("id = %d", out.ID)
+	}
+}
+
+func TestInputFlatBody(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var got map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if got["name"] != testName {
+			t.Errorf("body = %v", got)
+		}
+		if r.Header.Get("X-Trace") != "abc" {
+			t.Errorf("header = %q", r.Header.Get("X-Trace"))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	type input struct {
+		Trace string `header:"X-Trace" json:"-"`
+		Name  string `json:"name"`
+	}
+	endpoint := client.Endpoint[input, struct{}]{Method: http.MethodPost, Path: "/users"}
+	if _, err := endpoint.Call(context.Background(), client.New(srv.URL), &input{Trace: "abc", Name: testName}); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+}
+
+func TestInputForm(t *testing.T) {
+	srv := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("ParseForm: %v", err)
+		}
+		if r.PostForm.Get("name") != testName {
+			t.Errorf("form = %v", r.PostForm)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	type input struct {
+		Name string `form:"name"`
+	}
+	if _, err := client.New(srv.URL).Post("/users").Input(input{Name: testName}).Do(); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+}
+
+func TestInputErrors(t *testing.T) {
+	c := client.New("http://example.invalid")
+	if _, err := c.Get("/users").Input("not a struct").Do(); err == nil {
+		t.Error("expected an error for a non-struct input")
+	}
+	type input struct {
+		ID *int `path:"id"`
+	}
+	if _, err := c.Get("/users/{id}").Input(input{}).Do(); err == nil {
+		t.Error("expected an error for a missing path parameter")
+	}
+}
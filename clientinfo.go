@@ -0,0 +1,266 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"strings"
+)
+
+// DeviceClass is the kind of device a request comes from.
+type DeviceClass string
+
+const (
+	DeviceDesktop DeviceClass = "desktop"
+	DeviceMobile  DeviceClass = "mobile"
+	DeviceTablet  DeviceClass = "tablet"
+	DeviceBot     DeviceClass = "bot"
+	DeviceUnknown DeviceClass = "unknown"
+)
+
+// ClientInfo describes the client of a request, see Context.ClientInfo.
+type ClientInfo struct {
+	// IP is the client IP address, see Context.RealIP.
+	IP string
+	// UserAgent is the raw User-Agent header.
+	UserAgent string
+	// Browser is the browser or HTTP client name, e.g. "Chrome" or "curl".
+	Browser string
+	// BrowserVersion is the version of the browser, e.g. "126.0.6478.126".
+	BrowserVersion string
+	// OS is the operating system, e.g. "Windows", "macOS", "iOS" or "Android".
+	OS string
+	// Device is the device class.
+	Device DeviceClass
+	// Bot reports whether the client is a crawler.
+	Bot bool
+	// Geo is the location of the client IP, set by ClientInfoMiddleware with a
+	// GeoResolver.
+	Geo *GeoLocation
+	// Extra holds the values added by custom ClientEnrichers.
+	Extra map[string]any
+
+	// enriched is set once ClientInfoMiddleware ran.
+	enriched bool
+}
+
+// GeoLocation is the location of an IP address.
+type GeoLocation struct {
+	// CountryCode is the ISO 3166-1 alpha-2 country code, e.g. "CD".
+	CountryCode string  `json:"country_code,omitempty"`
+	Country     string  `json:"country,omitempty"`
+	Region      string  `json:"region,omitempty"`
+	City        string  `json:"city,omitempty"`
+	Latitude    float64 `json:"latitude,omitempty"`
+	Longitude   float64 `json:"longitude,omitempty"`
+}
+
+// GeoResolver locates IP addresses, e.g. with a MaxMind GeoIP database.
+// Resolve returns nil for unknown addresses. Resolvers querying a remote
+// service should cache their answers, as Resolve runs for every request.
+type GeoResolver interface {
+	Resolve(ctx context.Context, ip string) (*GeoLocation, error)
+}
+
+// ClientEnricher adds information to the ClientInfo of a request, e.g. from a
+// device detection service or a customer database.
+type ClientEnricher interface {
+	Enrich(c *Context, info *ClientInfo) error
+}
+
+// ClientEnricherFunc is a function implementing ClientEnricher.
+type ClientEnricherFunc func(c *Context, info *ClientInfo) error
+
+// Enrich calls f(c, info).
+func (f ClientEnricherFunc) Enrich(c *Context, info *ClientInfo) error {
+	return f(c, info)
+}
+
+// ClientInfoMiddleware populates the ClientInfo of each request once, before
+// the handlers, with the location from Geo and the information added by
+// Enrichers. Failing lookups are logged and leave the information empty.
+//
+// Once it ran, the access log includes the device, browser, os and country of
+// the client.
+//
+// Example:
+//
+//	clientInfo := &okapi.ClientInfoMiddleware{Geo: geoip}
+//	o.Use(clientInfo.Middleware)
+//
+//	limiter := &okapi.RateLimit{Limit: 100, Window: time.Minute, KeyFunc: func(c *okapi.Context) string {
+//		return c.RealIP() + ":" + string(c.ClientInfo().Device)
+//	}}
+type ClientInfoMiddleware struct {
+	// Geo locates the client IP. Optional.
+	Geo GeoResolver
+	// Enrichers run in order, after the location was resolved.
+	Enrichers []ClientEnricher
+}
+
+// Middleware populates the ClientInfo of the request.
+func (m *ClientInfoMiddleware) Middleware(c *Context) error {
+	info := c.ClientInfo()
+	if !info.enriched {
+		info.enriched = true
+		if m.Geo != nil && info.IP != "" {
+			geo, err := m.Geo.Resolve(c.request.Context(), info.IP)
+			if err != nil {
+				c.Logger().Warn("[okapi] geolocation failed", "ip", info.IP, "error", err)
+			}
+			info.Geo = geo
+		}
+		for _, enricher := range m.Enrichers {
+			if err := enricher.Enrich(c, info); err != nil {
+				c.Logger().Warn("[okapi] client enrichment failed", "error", err)
+			}
+		}
+	}
+	return c.Next()
+}
+
+// ClientInfo returns the client of the request, parsed from its IP address and
+// User-Agent header on the first call. Use ClientInfoMiddleware to add the
+// location and custom information.
+func (c *Context) ClientInfo() *ClientInfo {
+	if c.clientInfo == nil {
+		info := parseUserAgent(c.request.UserAgent())
+		info.IP = c.RealIP()
+		c.clientInfo = info
+	}
+	return c.clientInfo
+}
+
+// clientLogFields returns the access log fields of the client, once
+// ClientInfoMiddleware populated it.
+func clientLogFields(c *Context) []any {
+	info := c.clientInfo
+	if info == nil || !info.enriched {
+		return nil
+	}
+	fields := []any{"device", string(info.Device), "browser", info.Browser, "os", info.OS}
+	if info.Geo != nil {
+		fields = append(fields, "country", info.Geo.CountryCode)
+	}
+	return fields
+}
+
+// botMarkers identify crawlers in lower-cased User-Agent headers.
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "facebookexternalhit", "headless"}
+
+// uaClients are the browsers and HTTP clients recognized in User-Agent
+// headers, in order of precedence: Chromium-based browsers also claim to be
+// Chrome and Safari.
+var uaClients = []struct{ name, token string }{
+	{"Edge", "Edg/"},
+	{"Edge", "EdgA/"},
+	{"Edge", "EdgiOS/"},
+	{"Opera", "OPR/"},
+	{"Samsung Internet", "SamsungBrowser/"},
+	{"Firefox", "Firefox/"},
+	{"Firefox", "FxiOS/"},
+	{"Chrome", "CriOS/"},
+	{"Chrome", "Chrome/"},
+	{"Safari", "Version/"},
+	{"Internet Explorer", "MSIE "},
+	{"Internet Explorer", "rv:"},
+	{"curl", "curl/"},
+	{"Wget", "Wget/"},
+	{"Go", "Go-http-client/"},
+	{"Python", "python-requests/"},
+	{"Postman", "PostmanRuntime/"},
+}
+
+// uaSystems are the operating systems recognized in User-Agent headers, in
+// order of precedence: Android and ChromeOS also claim to be Linux, iOS to be
+// like macOS.
+var uaSystems = []struct{ name, token string }{
+	{"Windows", "Windows"},
+	{"iOS", "iPhone"},
+	{"iOS", "iPad"},
+	{"iOS", "iPod"},
+	{"Android", "Android"},
+	{"ChromeOS", "CrOS"},
+	{"macOS", "Macintosh"},
+	{"Linux", "Linux"},
+}
+
+// parseUserAgent extracts the browser, operating system and device class from
+// a User-Agent header.
+func parseUserAgent(ua string) *ClientInfo {
+	info := &ClientInfo{UserAgent: ua, Device: DeviceUnknown}
+	if ua == "" {
+		return info
+	}
+	lower := strings.ToLower(ua)
+	for _, marker := range botMarkers {
+		if strings.Contains(lower, marker) {
+			info.Bot = true
+			info.Device = DeviceBot
+			break
+		}
+	}
+	for _, client := range uaClients {
+		if client.name == "Safari" && !strings.Contains(ua, "Safari/") {
+			continue
+		}
+		if client.token == "rv:" && !strings.Contains(ua, "Trident/") {
+			continue
+		}
+		if i := strings.Index(ua, client.token); i >= 0 {
+			info.Browser = client.name
+			info.BrowserVersion = uaVersion(ua[i+len(client.token):])
+			break
+		}
+	}
+	for _, system := range uaSystems {
+		if strings.Contains(ua, system.token) {
+			info.OS = system.name
+			break
+		}
+	}
+	if info.Bot {
+		return info
+	}
+	switch {
+	case strings.Contains(ua, "iPad") || strings.Contains(ua, "Tablet") ||
+		(info.OS == "Android" && !strings.Contains(ua, "Mobile")):
+		info.Device = DeviceTablet
+	case strings.Contains(ua, "Mobi") || strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPod"):
+		info.Device = DeviceMobile
+	case info.OS == "Windows" || info.OS == "macOS" || info.OS == "Linux" || info.OS == "ChromeOS":
+		info.Device = DeviceDesktop
+	}
+	return info
+}
+
+// uaVersion returns the version at the start of s, up to the next separator.
+func uaVersion(s string) string {
+	end := strings.IndexAny(s, " ;)")
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
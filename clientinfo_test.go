@@ -0,0 +1,119 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		ua, browser, version, os string
+		device                   DeviceClass
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+			"Chrome", "126.0.0.0", "Windows", DeviceDesktop},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.2592.87",
+			"Edge", "126.0.2592.87", "Windows", DeviceDesktop},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Safari/605.1.15",
+			"Safari", "17.5", "macOS", DeviceDesktop},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+			"Safari", "17.5", "iOS", DeviceMobile},
+		{"Mozilla/5.0 (iPad; CPU OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/126.0.6478.153 Mobile/15E148 Safari/604.1",
+			"Chrome", "126.0.6478.153", "iOS", DeviceTablet},
+		{"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.6478.122 Mobile Safari/537.36",
+			"Chrome", "126.0.6478.122", "Android", DeviceMobile},
+		{"Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/25.0 Chrome/121.0.0.0 Safari/537.36",
+			"Samsung Internet", "25.0", "Android", DeviceTablet},
+		{"Mozilla/5.0 (X11; Ubuntu; Linux x86_64; rv:127.0) Gecko/20100101 Firefox/127.0",
+			"Firefox", "127.0", "Linux", DeviceDesktop},
+		{"Mozilla/5.0 (Windows NT 6.1; Trident/7.0; rv:11.0) like Gecko",
+			"Internet Explorer", "11.0", "Windows", DeviceDesktop},
+		{"curl/8.7.1", "curl", "8.7.1", "", DeviceUnknown},
+		{"", "", "", "", DeviceUnknown},
+	}
+	for _, tt := range tests {
+		info := parseUserAgent(tt.ua)
+		assert.Equal(t, tt.browser, info.Browser, tt.ua)
+		assert.Equal(t, tt.version, info.BrowserVersion, tt.ua)
+		assert.Equal(t, tt.os, info.OS, tt.ua)
+		assert.Equal(t, tt.device, info.Device, tt.ua)
+		assert.False(t, info.Bot, tt.ua)
+	}
+
+	bot := parseUserAgent("Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)")
+	assert.True(t, bot.Bot)
+	assert.Equal(t, DeviceBot, bot.Device)
+}
+
+type staticGeoResolver map[string]*GeoLocation
+
+func (r staticGeoResolver) Resolve(_ context.Context, ip string) (*GeoLocation, error) {
+	if geo, ok := r[ip]; ok {
+		return geo, nil
+	}
+	return nil, errors.New("unknown address")
+}
+
+func TestClientInfoMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	o := New(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	clientInfo := &ClientInfoMiddleware{
+		Geo: staticGeoResolver{"203.0.113.7": {CountryCode: "CD", City: "Kinshasa"}},
+		Enrichers: []ClientEnricher{ClientEnricherFunc(func(c *Context, info *ClientInfo) error {
+			info.Extra = map[string]any{"tenant": c.Header("X-Tenant")}
+			return nil
+		})},
+	}
+	o.Use(clientInfo.Middleware)
+	o.Get("/whoami", func(c *Context) error {
+		info := c.ClientInfo()
+		return c.OK(M{"device": info.Device, "geo": info.Geo, "tenant": info.Extra["tenant"]})
+	})
+	ts := NewTestServerWithOkapi(t, o)
+
+	okapitest.GET(t, ts.BaseURL+"/whoami").
+		Header("X-Forwarded-For", "203.0.113.7").
+		Header("X-Tenant", "acme").
+		Header("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) Mobile/15E148").
+		ExpectStatusOK().
+		ExpectJSONPath("device", "mobile").
+		ExpectJSONPath("geo.city", "Kinshasa").
+		ExpectJSONPath("tenant", "acme")
+	assert.Contains(t, logs.String(), "device=mobile")
+	assert.Contains(t, logs.String(), "country=CD")
+
+	okapitest.GET(t, ts.BaseURL+"/whoami").
+		ExpectStatusOK().
+		ExpectJSONPath("geo", nil)
+	assert.Contains(t, logs.String(), "geolocation failed")
+}
@@ -0,0 +1,197 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// ConnStats is a snapshot of the client connections of the HTTP and HTTPS servers,
+// see Okapi.Connections.
+type ConnStats struct {
+	// Open is the number of connections currently open.
+	Open int64 `json:"open"`
+	// Active is the number of connections reading or serving a request.
+	Active int64 `json:"active"`
+	// Idle is the number of keep-alive connections waiting for their next request.
+	Idle int64 `json:"idle"`
+	// Accepted is the number of connections accepted since start.
+	Accepted uint64 `json:"accepted"`
+	// Limit is the maximum set with WithMaxConnections, 0 when unlimited.
+	Limit int `json:"limit"`
+}
+
+// connTracker follows the state of the server connections.
+type connTracker struct {
+	states   sync.Map // net.Conn -> http.ConnState
+	open     atomic.Int64
+	active   atomic.Int64
+	idle     atomic.Int64
+	accepted atomic.Uint64
+}
+
+// WithMaxConnections limits the client connections open at once across the HTTP
+// and HTTPS servers. Once the limit is reached, new connections wait in the
+// listen backlog until one closes. Zero or less means no limit.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithMaxConnections(10000))
+func WithMaxConnections(n int) OptionFunc {
+	return func(o *Okapi) {
+		o.connSlots = nil
+		if n > 0 {
+			o.connSlots = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithMaxConnections limits the client connections open at once, see WithMaxConnections.
+func (o *Okapi) WithMaxConnections(n int) *Okapi {
+	return o.apply(WithMaxConnections(n))
+}
+
+// WithKeepAlivesDisabled closes every connection after its response, trading
+// connection reuse for fewer idle connections holding resources.
+func WithKeepAlivesDisabled() OptionFunc {
+	return func(o *Okapi) {
+		o.noKeepAlives = true
+		o.server.SetKeepAlivesEnabled(false)
+	}
+}
+
+// WithKeepAlivesDisabled closes every connection after its response, see WithKeepAlivesDisabled.
+func (o *Okapi) WithKeepAlivesDisabled() *Okapi {
+	return o.With(WithKeepAlivesDisabled())
+}
+
+// Connections returns the state of the client connections, e.g. to export the
+// number of idle keep-alive connections as a metric.
+func (o *Okapi) Connections() ConnStats {
+	return ConnStats{
+		Open:     o.conns.open.Load(),
+		Active:   o.conns.active.Load(),
+		Idle:     o.conns.idle.Load(),
+		Accepted: o.conns.accepted.Load(),
+		Limit:    cap(o.connSlots),
+	}
+}
+
+// trackConnections records the connection states of server, keeping its own
+// ConnState hook.
+func (o *Okapi) trackConnections(server *http.Server) {
+	hook := server.ConnState
+	server.ConnState = func(conn net.Conn, state http.ConnState) {
+		o.conns.track(conn, state)
+		if hook != nil {
+			hook(conn, state)
+		}
+	}
+}
+
+// track moves conn to state. Repeated notifications of the same state are ignored.
+func (t *connTracker) track(conn net.Conn, state http.ConnState) {
+	prev, known := t.states.Load(conn)
+	if known && prev == state {
+		return
+	}
+	if known {
+		t.counter(prev.(http.ConnState)).Add(-1)
+	} else if state == http.StateNew {
+		t.open.Add(1)
+		t.accepted.Add(1)
+	}
+	switch state {
+	case http.StateClosed, http.StateHijacked:
+		if known {
+			t.states.Delete(conn)
+			t.open.Add(-1)
+		}
+	default:
+		t.states.Store(conn, state)
+		t.counter(state).Add(1)
+	}
+}
+
+// counter returns the counter of connections in state.
+func (t *connTracker) counter(state http.ConnState) *atomic.Int64 {
+	switch state {
+	case http.StateIdle:
+		return &t.idle
+	default:
+		return &t.active
+	}
+}
+
+// limitListener accepts connections while a slot is free, see WithMaxConnections.
+type limitListener struct {
+	net.Listener
+	slots     chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// limitListener bounds the connections accepted by ln when WithMaxConnections is set.
+func (o *Okapi) limitListener(ln net.Listener) net.Listener {
+	if o.connSlots == nil {
+		return ln
+	}
+	return &limitListener{Listener: ln, slots: o.connSlots, done: make(chan struct{})}
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	select {
+	case l.slots <- struct{}{}:
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		<-l.slots
+		return nil, err
+	}
+	return &limitConn{Conn: conn, release: func() { <-l.slots }}, nil
+}
+
+func (l *limitListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return l.Listener.Close()
+}
+
+// limitConn frees its slot when closed.
+type limitConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
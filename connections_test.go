@@ -0,0 +1,110 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveConnections serves o on a loopback listener opened like Start does.
+func serveConnections(t *testing.T, o *Okapi) string {
+	t.Helper()
+	o.Get("/", func(c *Context) error { return c.Text(http.StatusOK, "ok") })
+	server := &http.Server{Addr: "127.0.0.1:0", Handler: o}
+	o.applyServerConfig(server)
+	ln, err := o.listen(server, false)
+	require.NoError(t, err)
+	go func() { _ = server.Serve(ln) }()
+	t.Cleanup(func() { _ = server.Close() })
+	return ln.Addr().String()
+}
+
+// getOnConn sends a request on conn and reads the response.
+func getOnConn(t *testing.T, conn net.Conn, timeout time.Duration) (*http.Response, error) {
+	t.Helper()
+	_, err := conn.Write([]byte("GET / HTTP/1.1\r\nHost: okapi\r\n\r\n"))
+	require.NoError(t, err)
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	return resp, err
+}
+
+func TestWithMaxConnections(t *testing.T) {
+	o := New(WithMaxConnections(1))
+	addr := serveConnections(t, o)
+
+	first, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = getOnConn(t, first, time.Second)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		s := o.Connections()
+		return s.Open == 1 && s.Idle == 1 && s.Active == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// The second connection waits until the first one closes.
+	second, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer second.Close()
+	_, err = getOnConn(t, second, 200*time.Millisecond)
+	require.True(t, errors.Is(err, os.ErrDeadlineExceeded), "expected timeout, got %v", err)
+
+	require.NoError(t, first.Close())
+	_ = second.SetReadDeadline(time.Now().Add(time.Second))
+	resp, err := http.ReadResponse(bufio.NewReader(second), nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	s := o.Connections()
+	assert.Equal(t, uint64(2), s.Accepted)
+	assert.Equal(t, 1, s.Limit)
+}
+
+func TestWithKeepAlivesDisabled(t *testing.T) {
+	o := New(WithKeepAlivesDisabled())
+	addr := serveConnections(t, o)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	resp, err := getOnConn(t, conn, time.Second)
+	require.NoError(t, err)
+	assert.True(t, resp.Close)
+	assert.Eventually(t, func() bool { return o.Connections().Open == 0 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, o.Connections().Limit)
+}
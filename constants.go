@@ -35,24 +35,26 @@ const (
 	constTRUE              = "true"
 	constIndex             = "index.html"
 
-	openApiVersion                     = "3.0.3"
-	openApiVersion31                   = "3.1.0"
-	openApiDocPrefix                   = "/docs"
-	openApiDocPath                     = "/openapi.json"
-	openApiYamlPath                    = "/openapi.yaml"
-	openApiDocPath30                   = "/openapi-3.0.json"
-	openApiYamlPath30                  = "/openapi-3.0.yaml"
-	jsonSchemaDialect                  = "https://spec.openapis.org/oas/3.1/dialect/base"
-	docSwaggerPath                     = "/swagger"
-	docRedocPath                       = "/redoc"
-	docScalarPath                      = "/scalar"
-	docFaviconPath                     = "/docs/favicon.png"
-	constAccessControlAllowOrigin      = "Access-Control-Allow-Origin"
-	constAccessControlAllowHeaders     = "Access-Control-Allow-Headers"
-	constAccessControlExposeHeaders    = "Access-Control-Expose-Headers"
-	constAccessControlAllowMethods     = "Access-Control-Allow-Methods"
-	constAccessControlMaxAge           = "Access-Control-Max-Age"
-	constAccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	openApiVersion                          = "3.0.3"
+	openApiVersion31                        = "3.1.0"
+	openApiDocPrefix                        = "/docs"
+	openApiDocPath                          = "/openapi.json"
+	openApiYamlPath                         = "/openapi.yaml"
+	openApiDocPath30                        = "/openapi-3.0.json"
+	openApiYamlPath30                       = "/openapi-3.0.yaml"
+	jsonSchemaDialect                       = "https://spec.openapis.org/oas/3.1/dialect/base"
+	docSwaggerPath                          = "/swagger"
+	docRedocPath                            = "/redoc"
+	docScalarPath                           = "/scalar"
+	docFaviconPath                          = "/docs/favicon.png"
+	constAccessControlAllowOrigin           = "Access-Control-Allow-Origin"
+	constAccessControlAllowHeaders          = "Access-Control-Allow-Headers"
+	constAccessControlExposeHeaders         = "Access-Control-Expose-Headers"
+	constAccessControlAllowMethods          = "Access-Control-Allow-Methods"
+	constAccessControlMaxAge                = "Access-Control-Max-Age"
+	constAccessControlAllowCredentials      = "Access-Control-Allow-Credentials"
+	constAccessControlAllowPrivateNetwork   = "Access-Control-Allow-Private-Network"
+	constAccessControlRequestPrivateNetwork = "Access-Control-Request-Private-Network"
 )
 
 // HTTP methods
@@ -74,6 +76,7 @@ const (
 	tagForm          = "form"
 	tagQuery         = "query"
 	tagCookie        = "cookie"
+	tagTrailer       = "trailer"
 	tagPath          = "path"
 	tagParam         = "param"
 	tagJSON          = "json"
@@ -97,6 +100,8 @@ const (
 	tagExclusiveMax  = "exclusiveMax"
 	tagMinProperties = "minProperties"
 	tagMaxProperties = "maxProperties"
+	tagScope         = "scope"
+	tagBindWith      = "bindWith"
 
 	// extOkapiConst is an internal marker extension used to carry an OpenAPI 3.1
 	// `const` value on the version-agnostic base schema. It is promoted to a real
@@ -152,5 +157,6 @@ const (
 	constLocalhost   = "localhost"
 	constDevelopment = "development"
 
-	requestIDHeader = "X-Request-ID"
+	requestIDHeader      = "X-Request-ID"
+	requestTimeoutHeader = "X-Request-Timeout"
 )
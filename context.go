@@ -25,23 +25,30 @@
 package okapi
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"log/slog"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"path"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v3"
 )
 
@@ -59,10 +66,39 @@ type (
 		handlers []HandlerFunc
 		// index tracks the current position in the handler chain
 		index int
+		// route is the matched route, nil for requests not served by a route
+		route *Route
+		// events buffers the events published through Events
+		events *requestEvents
+		// abortErr is the error given to the last Abort call, reported to OnError hooks
+		abortErr error
+		// flushInterval controls the flushing of streamed bodies, see SetFlushInterval
+		flushInterval time.Duration
+		// clientCtx is the request's original context, canceled when the client disconnects
+		clientCtx context.Context
+		// disconnects holds the callbacks registered with OnDisconnect
+		disconnects *disconnectHooks
+		// authExpiry is when the request's credentials expire, in Unix nanoseconds, see SetAuthExpiry
+		authExpiry atomic.Int64
+		// principal is the authenticated caller, see SetPrincipal
+		principal Principal
+		// clientInfo is the client of the request, see ClientInfo
+		clientInfo *ClientInfo
 	}
 	Store struct {
 		mu   sync.RWMutex
 		data map[string]any
+		// lazy tracks the values being computed by GetOrCompute
+		lazy map[string]*lazyValue
+		// mirrored lists the keys set with SetBoth, seen by the request's
+		// context.Context
+		mirrored map[string]struct{}
+	}
+	// lazyValue is the single computation of a GetOrCompute key.
+	lazyValue struct {
+		once sync.Once
+		val  any
+		err  error
 	}
 	// C is a shortcut of *Context
 	C   = *Context
@@ -71,21 +107,26 @@ type (
 
 // Mime types
 const (
-	constJSON        = "application/json"
-	constJSONProblem = "application/problem+json"
-	constXML         = "application/xml"
-	constXMLProblem  = "application/problem+xml"
-	constHTML        = "text/html"
-	constFormData    = "multipart/form-data"
-	constPLAINTEXT   = "text/plain"
-	constYAML        = "application/yaml"
-	constYamlX       = "application/x-yaml"
-	constYamlText    = "text/yaml"
-	constPROTOBUF    = "application/protobuf"
+	constJSON           = "application/json"
+	constJSONProblem    = "application/problem+json"
+	constXML            = "application/xml"
+	constXMLProblem     = "application/problem+xml"
+	constHTML           = "text/html"
+	constFormData       = "multipart/form-data"
+	constFormURLEncoded = "application/x-www-form-urlencoded"
+	constPLAINTEXT      = "text/plain"
+	constYAML           = "application/yaml"
+	constYamlX          = "application/x-yaml"
+	constYamlText       = "text/yaml"
+	constPROTOBUF       = "application/protobuf"
+	constXProtoBuf      = "application/x-protobuf"
 )
 
 // ************** Accessors *************
 
+// ErrKeyNotFound is returned by GetAs when the key is not in the context's data store.
+var ErrKeyNotFound = errors.New("okapi: key not found in context")
+
 // newStoreData creates a new instance of Store
 func newStoreData() *Store {
 	return &Store{
@@ -107,8 +148,49 @@ func (c *Context) Request() *http.Request {
 }
 
 // Context returns the context.Context associated with the current request.
+// It carries the Context itself, see FromRequest.
 func (c *Context) Context() context.Context {
-	return c.request.Context()
+	if c.request == nil {
+		return context.Background()
+	}
+	return c.requestWithContext().Context()
+}
+
+// SetContext replaces the context.Context of the request, e.g. to add values or
+// a deadline seen by the next handlers of the chain and by Context.
+func (c *Context) SetContext(ctx context.Context) {
+	c.request = c.request.WithContext(ctx)
+}
+
+// WithTimeout returns a copy of the request context canceled after d, or when
+// the request context is, to bound a database call or an outgoing request.
+// Call cancel once the operation completes.
+//
+// Example:
+//
+//	ctx, cancel := c.WithTimeout(2 * time.Second)
+//	defer cancel()
+//	row := db.QueryRowContext(ctx, "SELECT title FROM books WHERE id = $1", c.Param("id"))
+func (c *Context) WithTimeout(d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Context(), d)
+}
+
+// Deadline returns when the request context is canceled, set by a route
+// budget, RequestDeadline or SetContext, and false when it has no deadline.
+func (c *Context) Deadline() (time.Time, bool) {
+	return c.request.Context().Deadline()
+}
+
+// Done returns a channel closed when the request context is canceled: the
+// client disconnected or the deadline passed.
+func (c *Context) Done() <-chan struct{} {
+	return c.request.Context().Done()
+}
+
+// Err returns why the request context was canceled, context.Canceled or
+// context.DeadlineExceeded, or nil while it is not.
+func (c *Context) Err() error {
+	return c.request.Context().Err()
 }
 
 // Response returns the http.ResponseWriter for writing responses.
@@ -124,7 +206,7 @@ func (c *Context) ResponseWriter() http.ResponseWriter {
 
 // Logger returns the logger instance associated with the Okapi context.
 func (c *Context) Logger() *slog.Logger {
-	if c.okapi.logger == nil {
+	if c.okapi == nil || c.okapi.logger == nil {
 		return slog.Default()
 	}
 	return c.okapi.logger
@@ -141,18 +223,187 @@ func (c *Context) Next() error {
 	return nil
 }
 
-// Get retrieves a value from the context's data store with thread-safe access.
-// Returns the value and a boolean indicating if the key exists.
-func (c *Context) Get(key string) (any, bool) {
+// okapiContextKey is the request context key holding the current *Context.
+type okapiContextKey struct{}
+
+// FromRequest returns the Okapi Context attached to a request passed to a
+// standard-library handler or middleware registered through UseMiddleware,
+// WrapMiddleware, HandleHTTP or HandleStd.
+func FromRequest(r *http.Request) (*Context, bool) {
+	if r == nil {
+		return nil, false
+	}
+	c, ok := r.Context().Value(okapiContextKey{}).(*Context)
+	return c, ok
+}
+
+// requestContext is the context.Context of a request served by okapi. It
+// carries the Context, for FromRequest, and resolves the values mirrored by Set
+// and SetBoth from the data store when they are looked up, so storing a value
+// never replaces the request.
+type requestContext struct {
+	context.Context
+	c *Context
+}
+
+func (rc requestContext) Value(key any) any {
+	switch k := key.(type) {
+	case okapiContextKey:
+		return rc.c
+	case ContextKey:
+		if val, ok := rc.c.mirroredValue(string(k)); ok {
+			return val
+		}
+	}
+	return rc.Context.Value(key)
+}
+
+// attach gives the request a context carrying c. It is called once, when the
+// server creates the Context, so that Context and requestWithContext only read.
+func (c *Context) attach() *Context {
+	if c.request != nil {
+		c.request = c.request.WithContext(requestContext{Context: c.request.Context(), c: c})
+	}
+	return c
+}
+
+// requestWithContext returns the request with the Context attached, so that
+// standard-library code can retrieve it with FromRequest. When a handler has
+// replaced the request context, it returns a copy carrying c, leaving the
+// Context unchanged.
+func (c *Context) requestWithContext() *http.Request {
+	if c.request == nil {
+		return nil
+	}
+	if existing, ok := FromRequest(c.request); ok && existing == c {
+		return c.request
+	}
+	return c.request.WithContext(requestContext{Context: c.request.Context(), c: c})
+}
+
+// mirroredValue returns the stored value of key when it is mirrored into the
+// request's context.Context: every key with WithContextPropagation, else the
+// keys set with SetBoth.
+func (c *Context) mirroredValue(key string) (any, bool) {
 	if c.store == nil {
 		return nil, false
 	}
+	propagated := c.okapi != nil && c.okapi.contextPropagation
 	c.store.mu.RLock()
 	defer c.store.mu.RUnlock()
+	if !propagated {
+		if _, ok := c.store.mirrored[key]; !ok {
+			return nil, false
+		}
+	}
 	val, ok := c.store.data[key]
 	return val, ok
 }
 
+// ContextKey is the type of the keys under which values are mirrored into the
+// request's context.Context by SetBoth or WithContextPropagation.
+type ContextKey string
+
+// Get retrieves a value from the context's data store with thread-safe access.
+// Returns the value and a boolean indicating if the key exists.
+// Values missing from the store are looked up in the request's context.Context
+// under ContextKey(key), where standard-library middlewares may have set them.
+func (c *Context) Get(key string) (any, bool) {
+	if c.store != nil {
+		c.store.mu.RLock()
+		val, ok := c.store.data[key]
+		c.store.mu.RUnlock()
+		if ok {
+			return val, true
+		}
+	}
+	if c.request != nil {
+		if val := c.request.Context().Value(ContextKey(key)); val != nil {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+// MustGet retrieves a value from the context's data store, panicking when the
+// key is missing. Use it for values a middleware is guaranteed to have set,
+// such as the authenticated user; the panic message names the key.
+func (c *Context) MustGet(key string) any {
+	val, ok := c.Get(key)
+	if !ok {
+		panic(fmt.Sprintf("okapi: key %q does not exist in the context", key))
+	}
+	return val
+}
+
+// GetAs retrieves the value stored under key as a T. It returns an error
+// wrapping ErrKeyNotFound when the key is missing, or describing the stored
+// type when it is not a T.
+//
+// Example:
+//
+//	user, err := okapi.GetAs[*User](c, "user")
+//	if err != nil {
+//		return c.AbortUnauthorized("Not authenticated", err)
+//	}
+func GetAs[T any](c *Context, key string) (T, error) {
+	var zero T
+	raw, ok := c.Get(key)
+	if !ok {
+		return zero, fmt.Errorf("%w: %q", ErrKeyNotFound, key)
+	}
+	v, ok := raw.(T)
+	if !ok {
+		return zero, fmt.Errorf("okapi: key %q holds a %T, not a %s", key, raw, reflect.TypeFor[T]())
+	}
+	return v, nil
+}
+
+// GetOrCompute returns the value stored under key, computing it on first use.
+// Concurrent and later calls for the same key share a single call to compute
+// and its result, error included, for the rest of the request, so middlewares
+// and handlers needing the same expensive value (e.g. the user behind a JWT
+// subject) load it once. A successfully computed value is also stored under
+// key, where Get and GetAs find it.
+//
+// Example:
+//
+//	user, err := c.GetOrCompute("user", func() (any, error) {
+//		return users.Find(c.Context(), c.GetString("sub"))
+//	})
+func (c *Context) GetOrCompute(key string, compute func() (any, error)) (any, error) {
+	if val, ok := c.Get(key); ok {
+		return val, nil
+	}
+	if c.store == nil {
+		c.store = newStoreData()
+	}
+	c.store.mu.Lock()
+	if c.store.lazy == nil {
+		c.store.lazy = make(map[string]*lazyValue)
+	}
+	lv, ok := c.store.lazy[key]
+	if !ok {
+		lv = &lazyValue{}
+		c.store.lazy[key] = lv
+	}
+	c.store.mu.Unlock()
+
+	lv.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				lv.err = fmt.Errorf("okapi: computing %q panicked: %v", key, r)
+				panic(r)
+			}
+		}()
+		lv.val, lv.err = compute()
+		if lv.err == nil {
+			c.Set(key, lv.val)
+		}
+	})
+	return lv.val, lv.err
+}
+
 // GetTime retrieves a time.Time value from the context's data store.
 func (c *Context) GetTime(key string) (time.Time, bool) {
 	if val, ok := getAs[time.Time](c, key); ok {
@@ -163,6 +414,9 @@ func (c *Context) GetTime(key string) (time.Time, bool) {
 
 // Set stores a value in the context's data store with thread-safe access.
 // Initializes the data map if it doesn't exist.
+//
+// When context propagation is enabled (see WithContextPropagation), the value is
+// also visible in the request's context.Context, like SetBoth.
 func (c *Context) Set(key string, value any) {
 	if c.store == nil {
 		c.store = newStoreData()
@@ -172,6 +426,26 @@ func (c *Context) Set(key string, value any) {
 	c.store.mu.Unlock()
 }
 
+// SetBoth stores a value in the context's data store and mirrors it into the
+// request's context.Context under ContextKey(key), so standard-library handlers
+// and middlewares registered through HandleHTTP or UseMiddleware can read it:
+//
+//	claims := r.Context().Value(okapi.ContextKey("claims"))
+//
+// Like Set, it is safe for concurrent use.
+func (c *Context) SetBoth(key string, value any) {
+	if c.store == nil {
+		c.store = newStoreData()
+	}
+	c.store.mu.Lock()
+	c.store.data[key] = value
+	if c.store.mirrored == nil {
+		c.store.mirrored = make(map[string]struct{})
+	}
+	c.store.mirrored[key] = struct{}{}
+	c.store.mu.Unlock()
+}
+
 // GetString retrieves a string value from the context.
 // Returns empty string if key doesn't exist or value isn't a string.
 func (c *Context) GetString(key string) string {
@@ -289,19 +563,13 @@ func (c *Context) Query(key string) string {
 }
 
 // QueryArray retrieves all values for a query parameter.
-// Supports both repeated params (?tags=a&tags=b) and comma-separated (?tags=a,b).
+// Supports both repeated params (?tags=a&tags=b) and comma-separated (?tags=a,b)
+// by default; WithQueryLimits selects the accepted syntaxes, including ?tags[]=a.
 func (c *Context) QueryArray(key string) []string {
-	values, ok := c.request.URL.Query()[key]
-	if !ok {
-		return nil
-	}
 	var result []string
-	for _, v := range values {
-		parts := strings.Split(v, ",")
-		for _, p := range parts {
-			if trimmed := strings.TrimSpace(p); trimmed != "" {
-				result = append(result, trimmed)
-			}
+	for _, v := range c.arrayValues(c.request.URL.Query(), key) {
+		if v != "" {
+			result = append(result, v)
 		}
 	}
 	return result
@@ -426,6 +694,48 @@ func (c *Context) Header(key string) string {
 	return c.request.Header.Get(key)
 }
 
+// ExpectsContinue reports whether the client sent "Expect: 100-continue" and
+// waits for approval before sending the body. The 100 Continue response is
+// only sent once the body is first read, so middlewares and handlers can reject
+// the request (authentication, size, ...) before the body is transferred.
+func (c *Context) ExpectsContinue() bool {
+	return c.request.ProtoAtLeast(1, 1) && strings.EqualFold(c.request.Header.Get("Expect"), "100-continue")
+}
+
+// DeclareTrailer announces trailers in the Trailer response header. It must be
+// called before the body is written; SetTrailer declares its trailer itself when
+// it is called early enough.
+func (c *Context) DeclareTrailer(names ...string) {
+	if c.committed() {
+		return
+	}
+	for _, name := range names {
+		if !slices.Contains(c.response.Header().Values("Trailer"), name) {
+			c.response.Header().Add("Trailer", name)
+		}
+	}
+}
+
+// SetTrailer sets an HTTP trailer, sent after a streamed body. It can be
+// called at any time before the handler returns, typically once the body is
+// written:
+//
+//	return c.Stream(http.StatusOK, "text/csv", func(w io.Writer) error {
+//		h := sha256.New()
+//		if err := export(io.MultiWriter(w, h)); err != nil {
+//			return err
+//		}
+//		c.SetTrailer("X-Checksum", hex.EncodeToString(h.Sum(nil)))
+//		return nil
+//	})
+//
+// Trailers require a chunked HTTP/1.1 or an HTTP/2 response; they are dropped
+// when the body has a Content-Length.
+func (c *Context) SetTrailer(name, value string) {
+	c.DeclareTrailer(name)
+	c.response.Header().Set(http.TrailerPrefix+name, value)
+}
+
 // Headers returns all request headers as a map.
 func (c *Context) Headers() map[string][]string {
 	return c.request.Header
@@ -436,6 +746,27 @@ func (c *Context) WriteStatus(code int) {
 	c.response.WriteHeader(code)
 }
 
+// Status sends the given status code without a response body.
+// Like the other response helpers, it is a no-op once the response is committed.
+func (c *Context) Status(code int) error {
+	if c.committed() {
+		c.logDiscardedWrite(code)
+		return nil
+	}
+	c.response.WriteHeader(code)
+	return nil
+}
+
+// EarlyHints sends a 103 Early Hints informational response advertising the given
+// Link header values, e.g. `</app.css>; rel=preload; as=style`.
+// The final response can still be written afterward.
+func (c *Context) EarlyHints(links ...string) {
+	for _, link := range links {
+		c.response.Header().Add("Link", link)
+	}
+	c.response.WriteHeader(http.StatusEarlyHints)
+}
+
 // committed reports whether the response has already been written.
 // Once a response is committed (typically by an Abort* or a successful body write),
 // subsequent attempts to write a full response are skipped to prevent appending
@@ -451,8 +782,20 @@ func (c *Context) writeResponse(code int, contentType string, writeFunc func() e
 		c.logDiscardedWrite(code)
 		return nil
 	}
+	if !statusAllowsBody(code) {
+		// 1xx, 204 and 304 responses must not carry a body or content headers.
+		c.response.WriteHeader(code)
+		return nil
+	}
+	if !c.acceptable(code, contentType) {
+		c.writeNotAcceptable()
+		return nil
+	}
 	c.response.Header().Set(constContentTypeHeader, contentType)
 	c.response.WriteHeader(code)
+	if c.request != nil && c.request.Method == http.MethodHead {
+		return nil
+	}
 	if err := writeFunc(); err != nil {
 		http.Error(c.response, err.Error(), http.StatusInternalServerError)
 		return err
@@ -473,10 +816,11 @@ func (c *Context) logDiscardedWrite(attemptedCode int) {
 	)
 }
 
-// JSON writes a JSON response with the given status code.
+// JSON writes a JSON response with the given status code. Struct fields
+// tagged with `scope:"role"` are left out unless c.Principal() has the role.
 func (c *Context) JSON(code int, v any) error {
 	return c.writeResponse(code, constJSON, func() error {
-		return json.NewEncoder(c.response).Encode(v)
+		return json.NewEncoder(c.response).Encode(c.redact(c.localizeTimes(v)))
 	})
 }
 
@@ -498,23 +842,102 @@ func (c *Context) OK(v any) error {
 
 // NoContent returns an empty response body with status code 204
 func (c *Context) NoContent() error {
-	return c.String(http.StatusNoContent, "")
+	return c.Status(http.StatusNoContent)
+}
+
+// NotModified returns an empty response body with status code 304
+func (c *Context) NotModified() error {
+	return c.Status(http.StatusNotModified)
 }
 
 // Created writes a JSON response with 201 status code.
-func (c *Context) Created(v any) error {
+// The optional location, a route name followed by its path params as key/value
+// pairs (see Okapi.URL) or a URL, is sent in the Location header:
+//
+//	return c.Created(book, "book", "id", book.ID)
+//
+// In strict mode, it returns ErrMissingLocation unless a Location header is set.
+func (c *Context) Created(v any, location ...string) error {
+	if len(location) > 0 {
+		loc, err := c.location(location)
+		if err != nil {
+			return err
+		}
+		c.response.Header().Set("Location", loc)
+	}
+	if c.missingLocation() {
+		return ErrMissingLocation
+	}
 	return c.JSON(http.StatusCreated, v)
 }
 
-// XML writes an XML response with the given status code.
+// Accepted writes a 202 Accepted response for asynchronous processing, with v
+// as JSON body unless nil. The optional status location, a route name followed
+// by its path params (see Okapi.URL) or a URL, points to a status monitor in the
+// Content-Location and Location headers; a positive retryAfter tells clients
+// when to poll it:
+//
+//	return c.Accepted(job, 5*time.Second, "job-status", "id", job.ID)
+func (c *Context) Accepted(v any, retryAfter time.Duration, statusLocation ...string) error {
+	h := c.response.Header()
+	if len(statusLocation) > 0 {
+		loc, err := c.location(statusLocation)
+		if err != nil {
+			return err
+		}
+		h.Set("Content-Location", loc)
+		h.Set("Location", loc)
+	}
+	if retryAfter > 0 {
+		h.Set("Retry-After", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+	}
+	if v == nil {
+		return c.Status(http.StatusAccepted)
+	}
+	return c.JSON(http.StatusAccepted, v)
+}
+
+// XML writes an XML response with the given status code. Fields tagged with
+// `scope:"role"` are zeroed unless c.Principal() has the role.
 func (c *Context) XML(code int, v any) error {
+	v = c.redactFields(v)
+	if c.okapi == nil || c.okapi.xmlOptions == nil {
+		return c.writeResponse(code, constXML, func() error {
+			return xml.NewEncoder(c.response).Encode(v)
+		})
+	}
+	data, err := c.okapi.xmlOptions.marshal(v)
+	if err != nil {
+		return c.AbortInternalServerError("Internal Server Error", fmt.Errorf("encode xml: %w", err))
+	}
 	return c.writeResponse(code, constXML, func() error {
-		return xml.NewEncoder(c.response).Encode(v)
+		_, err := c.response.Write(data)
+		return err
 	})
 }
 
-// YAML writes a YAML response with the given status code.
+// ProtoBuf writes a protobuf-encoded response with the given status code.
+func (c *Context) ProtoBuf(code int, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return c.AbortInternalServerError("Internal Server Error", fmt.Errorf("encode protobuf: %w", err))
+	}
+	return c.writeResponse(code, constPROTOBUF, func() error {
+		_, err := c.response.Write(data)
+		return err
+	})
+}
+
+// acceptsProtoBuf reports whether the client asked for a protobuf response.
+func (c *Context) acceptsProtoBuf() bool {
+	accept := c.request.Header.Get("Accept")
+	return strings.Contains(accept, constPROTOBUF) || strings.Contains(accept, constXProtoBuf)
+}
+
+// YAML writes a YAML response with the given status code. Fields tagged with
+// `scope:"role"` are zeroed unless c.Principal() has the role.
 func (c *Context) YAML(code int, data any) error {
+	data = c.redactFields(data)
 	return c.writeResponse(code, constYAML, func() error {
 		return yaml.NewEncoder(c.response).Encode(data)
 	})
@@ -537,6 +960,7 @@ func (c *Context) sendSSE(id, eventType string, message any) error {
 		Event: eventType,
 		Data:  message,
 	}
+	c.extendWriteDeadline()
 	_, err := msg.Send(c.response)
 	return err
 }
@@ -569,6 +993,7 @@ func (c *Context) SSEStream(ctx context.Context, messageChan <-chan Message) err
 		case <-ctx.Done():
 			return ctx.Err()
 		case msg := <-messageChan:
+			c.extendWriteDeadline()
 			if _, err := msg.Send(c.response); err != nil {
 				return err
 			}
@@ -576,6 +1001,11 @@ func (c *Context) SSEStream(ctx context.Context, messageChan <-chan Message) err
 	}
 }
 
+// LastEventID returns the Last-Event-ID header sent by a reconnecting SSE client.
+func (c *Context) LastEventID() string {
+	return c.request.Header.Get("Last-Event-ID")
+}
+
 // SSESendData sends structured data as JSON
 func (c *Context) SSESendData(data any) error {
 	msg := Message{
@@ -637,6 +1067,36 @@ func (c *Context) SSEStreamWithOptions(ctx context.Context, messageChan <-chan M
 		flusher.Flush()
 	}
 
+	// Resume a reconnecting client from its last received message
+	if opts.Store != nil {
+		if lastID := c.LastEventID(); lastID != "" {
+			missed, err := opts.Store.Since(lastID)
+			if err != nil {
+				if opts.OnError != nil {
+					opts.OnError(err)
+				}
+				return err
+			}
+			for _, msg := range missed {
+				if msg.Serializer == nil && opts.Serializer != nil {
+					msg.Serializer = opts.Serializer
+				}
+				if _, err := msg.Send(c.response); err != nil {
+					if opts.OnError != nil {
+						opts.OnError(err)
+					}
+					return err
+				}
+			}
+		}
+	}
+
+	if opts.Auth != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = c.AuthContext(ctx, *opts.Auth)
+		defer cancel()
+	}
+
 	var ticker *time.Ticker
 	var pingChan <-chan time.Time
 
@@ -649,10 +1109,21 @@ func (c *Context) SSEStreamWithOptions(ctx context.Context, messageChan <-chan M
 	for {
 		select {
 		case <-ctx.Done():
+			if cause := context.Cause(ctx); errors.Is(cause, ErrAuthExpired) {
+				// Tell the client to reconnect with fresh credentials
+				msg := Message{
+					Event: cmp.Or(opts.Auth.ExpiredEvent, "auth_expired"),
+					Data:  M{"message": "Authentication expired"},
+				}
+				c.extendWriteDeadline()
+				_, _ = msg.Send(c.response)
+				return cause
+			}
 			return ctx.Err()
 
 		case <-pingChan:
 			// Send comment line to keep connection alive
+			c.extendWriteDeadline()
 			if _, err := fmt.Fprint(c.response, ": ping\n\n"); err != nil {
 				if opts.OnError != nil {
 					opts.OnError(err)
@@ -672,6 +1143,7 @@ func (c *Context) SSEStreamWithOptions(ctx context.Context, messageChan <-chan M
 				msg.Serializer = opts.Serializer
 			}
 
+			c.extendWriteDeadline()
 			if _, err := msg.Send(c.response); err != nil {
 				if opts.OnError != nil {
 					opts.OnError(err)
@@ -737,9 +1209,11 @@ func (c *Context) renderHTML(code int, tmpl *template.Template, data any) error
 
 // Redirect sends a redirect response to the specified location.
 func (c *Context) Redirect(code int, location string) {
-	c.SetHeader(constLocationHeader, location)                    // Set Location header
-	c.WriteStatus(code)                                           // Write status code
-	_, _ = fmt.Fprintf(c.response, "Redirecting to %s", location) // Optional message
+	c.SetHeader(constLocationHeader, location) // Set Location header
+	c.WriteStatus(code)                        // Write status code
+	if (c.request == nil || c.request.Method != http.MethodHead) && statusAllowsBody(code) {
+		_, _ = fmt.Fprintf(c.response, "Redirecting to %s", location) // Optional message
+	}
 }
 
 // *********** File Serving **************
@@ -770,13 +1244,13 @@ func (c *Context) ServeFileFromFS(filepath string, fs http.FileSystem) {
 
 // ServeFileAttachment serves a file as an attachment (download).
 func (c *Context) ServeFileAttachment(path, filename string) {
-	c.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Attachment(filename)
 	http.ServeFile(c.response, c.request, path)
 }
 
 // ServeFileInline serves a file to be displayed inline in the browser.
 func (c *Context) ServeFileInline(path, filename string) {
-	c.SetHeader("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	c.Inline(filename)
 	http.ServeFile(c.response, c.request, path)
 }
 
@@ -802,8 +1276,10 @@ func (c *Context) Return(output any) error {
 }
 
 // Respond serializes the output struct into the HTTP response.
-// It inspects struct tags to automatically set headers, cookies, and status code,
+// It inspects struct tags to automatically set headers, cookies, trailers and status code,
 // and encodes the response body in the format requested by the `Accept` header.
+// A cookie field may be an http.Cookie to control its attributes, and a Body
+// implementing io.Reader is streamed as is.
 //
 // Supported formats: JSON, XML, YAML, plain text, HTML.
 //
@@ -813,6 +1289,7 @@ func (c *Context) Return(output any) error {
 //	  Status  int                           // HTTP status code
 //	  version string `header:"version"`     // Response header
 //	  Session string `cookie:"SessionID"`   // Response cookie
+//	  Sum     string `trailer:"X-Checksum"` // HTTP trailer, sent after the body
 //	  Body    struct {
 //	    ID    int    `json:"id"`
 //	    Name  string `json:"name"`
@@ -852,8 +1329,13 @@ func (c *Context) Respond(output any) error {
 	if t.Kind() != reflect.Struct {
 		return c.AbortInternalServerError("Internal Server Error", fmt.Errorf("output must be a struct"))
 	}
+	// A protobuf message is the body itself, it carries no status or headers
+	if _, ok := output.(proto.Message); ok {
+		return c.writeOutputBody(http.StatusOK, output)
+	}
 
 	status := getResponseStatus(v)
+	var trailers []outputTrailer
 
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
@@ -866,11 +1348,13 @@ func (c *Context) Respond(output any) error {
 		}
 		// Cookie tag
 		if cookie := field.Tag.Get(tagCookie); cookie != "" {
-			http.SetCookie(c.Response(), &http.Cookie{
-				Name:  cookie,
-				Value: fmt.Sprint(val),
-				Path:  "/",
-			})
+			http.SetCookie(c.Response(), outputCookie(cookie, val))
+			continue
+		}
+		// Trailer tag, declared now and written once the body is sent
+		if trailer := field.Tag.Get(tagTrailer); trailer != "" {
+			c.DeclareTrailer(trailer)
+			trailers = append(trailers, outputTrailer{name: trailer, value: val})
 			continue
 		}
 		// Fallback: expose non-status, non-body fields as headers
@@ -888,6 +1372,34 @@ func (c *Context) Respond(output any) error {
 		body = f.Interface()
 	}
 
+	err := c.writeOutputBody(status, body)
+	for _, tr := range trailers {
+		c.SetTrailer(tr.name, tr.resolve())
+	}
+	return err
+}
+
+// writeOutputBody writes the body of an output struct. An io.Reader body is
+// streamed as is, a protobuf message is sent as protobuf when the client accepts
+// it, a route declaring Renders negotiates among its representations, anything
+// else is encoded in the format requested by the Accept header.
+func (c *Context) writeOutputBody(status int, body any) error {
+	if r, ok := body.(io.Reader); ok {
+		contentType := c.Response().Header().Get(constContentTypeHeader)
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		return c.writeResponse(status, contentType, func() error {
+			_, err := io.Copy(c.response, r)
+			return err
+		})
+	}
+	if msg, ok := body.(proto.Message); ok && c.acceptsProtoBuf() {
+		return c.ProtoBuf(status, msg)
+	}
+	if c.route != nil && len(c.route.renders) > 0 {
+		return c.Negotiate(status, body)
+	}
 	accept := c.request.Header.Get("Accept")
 	switch {
 	case strings.Contains(accept, constXML):
@@ -897,12 +1409,52 @@ func (c *Context) Respond(output any) error {
 	case strings.Contains(accept, constJSON):
 		return c.JSON(status, body)
 	case strings.Contains(accept, constPLAINTEXT), strings.Contains(accept, constHTML):
-		return c.String(status, body)
+		return c.String(status, c.redactFields(body))
 	default:
 		return c.JSON(status, body)
 	}
 }
 
+// outputTrailer is a trailer-tagged field of an output struct.
+type outputTrailer struct {
+	name  string
+	value any
+}
+
+// resolve returns the trailer value. A func() string field is called after
+// the body is written, so it can report values computed while streaming,
+// such as a checksum.
+func (t outputTrailer) resolve() string {
+	if fn, ok := t.value.(func() string); ok {
+		if fn == nil {
+			return ""
+		}
+		return fn()
+	}
+	return fmt.Sprint(t.value)
+}
+
+// outputCookie builds the cookie for a cookie-tagged field. An http.Cookie
+// field keeps its attributes and takes the tag as its default name, any other
+// value becomes a cookie scoped to "/".
+func outputCookie(name string, val any) *http.Cookie {
+	var cookie http.Cookie
+	switch v := val.(type) {
+	case http.Cookie:
+		cookie = v
+	case *http.Cookie:
+		if v != nil {
+			cookie = *v
+		}
+	default:
+		return &http.Cookie{Name: name, Value: fmt.Sprint(val), Path: "/"}
+	}
+	if cookie.Name == "" {
+		cookie.Name = name
+	}
+	return &cookie
+}
+
 // NewContext creates a new Okapi Context
 func NewContext(o *Okapi, w http.ResponseWriter, r *http.Request) *Context {
 	if o == nil {
@@ -911,7 +1463,7 @@ func NewContext(o *Okapi, w http.ResponseWriter, r *http.Request) *Context {
 	return &Context{
 		request:  r,
 		okapi:    o,
-		response: newResponseWriter(w),
+		response: newResponseWriter(w).withDiagnostics(o),
 		store:    newStoreData(),
 	}
 }
@@ -25,14 +25,27 @@
 package okapi
 
 import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/jkaninda/okapi/okapitest"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // HelloHandler is referenced by helper_test.go (handleName name extraction).
@@ -161,6 +174,84 @@ func TestContext_GetTypedAccessors(t *testing.T) {
 	})
 }
 
+func TestContext_MustGetAndGetAs(t *testing.T) {
+	t.Parallel()
+
+	type user struct{ Name string }
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	ctx.Set("user", &user{Name: "jonas"})
+
+	if got := ctx.MustGet("user").(*user); got.Name != "jonas" {
+		t.Errorf("MustGet(user) = %v", got)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), `"missing"`) {
+				t.Errorf("MustGet(missing) panic = %v, want a message naming the key", r)
+			}
+		}()
+		ctx.MustGet("missing")
+	}()
+
+	u, err := GetAs[*user](ctx, "user")
+	if err != nil || u.Name != "jonas" {
+		t.Errorf("GetAs[*user] = (%v, %v)", u, err)
+	}
+	if _, err := GetAs[string](ctx, "missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("GetAs(missing) error = %v, want ErrKeyNotFound", err)
+	}
+	if _, err := GetAs[string](ctx, "user"); err == nil || !strings.Contains(err.Error(), "*okapi.user") {
+		t.Errorf("GetAs[string](user) error = %v, want a type mismatch", err)
+	}
+}
+
+func TestContext_GetOrCompute(t *testing.T) {
+	t.Parallel()
+
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	var calls atomic.Int32
+	compute := func() (any, error) {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return "jonas", nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := ctx.GetOrCompute("user", compute); err != nil || v != "jonas" {
+				t.Errorf("GetOrCompute = (%v, %v)", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("compute called %d times, want 1", n)
+	}
+	if got := ctx.GetString("user"); got != "jonas" {
+		t.Errorf("GetString(user) = %q, want the computed value", got)
+	}
+
+	boom := errors.New("boom")
+	failing := func() (any, error) {
+		calls.Add(1)
+		return nil, boom
+	}
+	for range 2 {
+		if _, err := ctx.GetOrCompute("account", failing); !errors.Is(err, boom) {
+			t.Errorf("GetOrCompute error = %v, want boom", err)
+		}
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("compute called %d times, want the error to be shared", n)
+	}
+	if _, ok := ctx.Get("account"); ok {
+		t.Error("failed computation stored in the context")
+	}
+}
+
 func TestContext_Set_IsConcurrencySafe(t *testing.T) {
 	t.Parallel()
 
@@ -183,6 +274,70 @@ func TestContext_Set_IsConcurrencySafe(t *testing.T) {
 	}
 }
 
+func TestContext_SetBoth(t *testing.T) {
+	t.Parallel()
+
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	ctx.Set("only-store", 1)
+	ctx.SetBoth("user", "alice")
+
+	if v, _ := ctx.Get("user"); v != "alice" {
+		t.Errorf("store value = %v, want alice", v)
+	}
+	if v := ctx.Request().Context().Value(ContextKey("user")); v != "alice" {
+		t.Errorf("request context value = %v, want alice", v)
+	}
+	if v := ctx.Request().Context().Value(ContextKey("only-store")); v != nil {
+		t.Errorf("Set should not propagate by default, got %v", v)
+	}
+}
+
+func TestContext_WithContextPropagation(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.WithContextPropagation()
+	ts.Use(func(c *Context) error {
+		c.Set("request_id", "req-1")
+		return c.Next()
+	})
+	ts.HandleHTTP(http.MethodGet, "/std", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ := r.Context().Value(ContextKey("request_id")).(string)
+		_, _ = w.Write([]byte(id))
+	}))
+
+	okapitest.GET(t, ts.BaseURL+"/std").ExpectStatusOK().ExpectBody("req-1")
+}
+
+func TestContext_ConcurrentContextAndSet(t *testing.T) {
+	o := New(WithContextPropagation())
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	c := NewContext(o, httptest.NewRecorder(), req).attach()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Set(fmt.Sprintf("k%d", i), i)
+			c.SetBoth("shared", i)
+			if got, _ := FromRequest(c.Request()); got != c {
+				t.Error("FromRequest did not return the Context")
+			}
+			_ = c.Context().Value(ContextKey("shared"))
+		}(i)
+	}
+	wg.Wait()
+
+	if got := c.Context().Value(ContextKey("k3")); got != 3 {
+		t.Fatalf("propagated value = %v, want 3", got)
+	}
+	r := c.Request()
+	_ = c.Context()
+	c.Set("late", true)
+	if c.Request() != r {
+		t.Fatal("Context and Set must not replace the request")
+	}
+}
+
 func TestContext_Copy(t *testing.T) {
 	t.Parallel()
 
@@ -430,6 +585,73 @@ func TestContext_Redirect(t *testing.T) {
 	}
 }
 
+// TestContext_RedirectWithoutRequest checks that Redirect works on a Context
+// built without a request, as in unit tests of response helpers.
+func TestContext_RedirectWithoutRequest(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	ctx := NewContext(nil, rec, nil)
+
+	ctx.Redirect(http.StatusSeeOther, "/elsewhere")
+
+	if rec.Code != http.StatusSeeOther {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusSeeOther)
+	}
+	if loc := rec.Header().Get("Location"); loc != "/elsewhere" {
+		t.Errorf("Location = %q, want %q", loc, "/elsewhere")
+	}
+}
+
+// TestContext_BodylessStatuses checks that statuses forbidding a body, and
+// HEAD requests, never write one even when a response helper is given content.
+func TestContext_BodylessStatuses(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		method string
+		write  func(c *Context) error
+		status int
+	}{
+		{"NoContent", http.MethodGet, func(c *Context) error { return c.NoContent() }, http.StatusNoContent},
+		{"NotModified", http.MethodGet, func(c *Context) error { return c.NotModified() }, http.StatusNotModified},
+		{"Status", http.MethodGet, func(c *Context) error { return c.Status(http.StatusResetContent) }, http.StatusResetContent},
+		{"JSON with 204", http.MethodGet, func(c *Context) error { return c.JSON(http.StatusNoContent, M{"a": 1}) }, http.StatusNoContent},
+		{"JSON on HEAD", http.MethodHead, func(c *Context) error { return c.OK(M{"a": 1}) }, http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, rec := NewTestContext(tc.method, "/", nil)
+			if err := tc.write(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rec.Code != tc.status {
+				t.Errorf("status = %d, want %d", rec.Code, tc.status)
+			}
+			if rec.Body.Len() != 0 {
+				t.Errorf("body = %q, want empty", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestContext_EarlyHints(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/hints", func(c *Context) error {
+		c.EarlyHints("</app.css>; rel=preload; as=style")
+		if c.Response().Written() {
+			return c.AbortInternalServerError("103 Early Hints committed the response")
+		}
+		return c.Text(http.StatusOK, "ok")
+	})
+
+	okapitest.GET(t, ts.BaseURL+"/hints").
+		ExpectStatusOK().
+		ExpectBody("ok").
+		ExpectHeader("Link", "</app.css>; rel=preload; as=style")
+}
+
 // File serving
 
 func TestContext_ServeFileAttachment(t *testing.T) {
@@ -466,3 +688,237 @@ func TestContext_PathParam(t *testing.T) {
 		ExpectBodyContains(`"id":"42"`).
 		ExpectBodyContains(`"path":"/books/42"`)
 }
+
+// TestContext_SetTrailer checks trailers set while streaming a body.
+func TestContext_SetTrailer(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/export", func(c *Context) error {
+		c.DeclareTrailer("X-Rows")
+		return c.Stream(http.StatusOK, "text/csv", func(w io.Writer) error {
+			h := sha256.New()
+			if _, err := io.WriteString(io.MultiWriter(w, h), "id\n1\n"); err != nil {
+				return err
+			}
+			c.SetTrailer("X-Rows", "1")
+			c.SetTrailer("X-Checksum", hex.EncodeToString(h.Sum(nil)))
+			return nil
+		})
+	})
+
+	resp, err := http.Get(ts.BaseURL + "/export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("id\n1\n"))
+	if got := resp.Trailer.Get("X-Checksum"); got != hex.EncodeToString(sum[:]) {
+		t.Errorf("X-Checksum trailer = %q", got)
+	}
+	if got := resp.Trailer.Get("X-Rows"); got != "1" {
+		t.Errorf("X-Rows trailer = %q", got)
+	}
+}
+
+// TestContext_ExpectsContinue checks that 100 Continue is only sent once the
+// body is read, so a middleware can reject the upload before it is transferred.
+func TestContext_ExpectsContinue(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Use(func(c *Context) error {
+		if c.ExpectsContinue() && c.Header("Authorization") == "" {
+			return c.AbortUnauthorized("Missing token")
+		}
+		return c.Next()
+	})
+	ts.Put("/upload", func(c *Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, strconv.Itoa(len(body)))
+	})
+
+	statusLine := func(headers string) string {
+		t.Helper()
+		conn, err := net.Dial("tcp", strings.TrimPrefix(ts.BaseURL, "http://"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+		req := "PUT /upload HTTP/1.1\r\nHost: test\r\nContent-Length: 5\r\nExpect: 100-continue\r\n" + headers + "\r\n"
+		if _, err := io.WriteString(conn, req); err != nil {
+			t.Fatal(err)
+		}
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(line)
+	}
+
+	if got := statusLine(""); got != "HTTP/1.1 401 Unauthorized" {
+		t.Errorf("rejected upload: got %q before the body was sent", got)
+	}
+	if got := statusLine("Authorization: Bearer token\r\n"); got != "HTTP/1.1 100 Continue" {
+		t.Errorf("accepted upload: got %q, want 100 Continue", got)
+	}
+}
+
+// TestContext_RespondCookiesAndTrailers checks output struct cookie and trailer fields.
+func TestContext_RespondCookiesAndTrailers(t *testing.T) {
+	type output struct {
+		Session  string        `cookie:"session_id"`
+		Theme    http.Cookie   `cookie:"theme"`
+		Version  string        `trailer:"X-Version"`
+		Checksum func() string `trailer:"X-Checksum"`
+		Body     io.Reader
+	}
+	ts := NewTestServer(t)
+	ts.Get("/stream", func(c *Context) error {
+		h := sha256.New()
+		return c.Respond(output{
+			Session:  "abc",
+			Theme:    http.Cookie{Value: "dark", HttpOnly: true, MaxAge: 60},
+			Version:  "v1",
+			Checksum: func() string { return hex.EncodeToString(h.Sum(nil)) },
+			Body:     io.TeeReader(strings.NewReader("streamed payload"), h),
+		})
+	})
+
+	resp, err := http.Get(ts.BaseURL + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "streamed payload" {
+		t.Errorf("body = %q", body)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/octet-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	sum := sha256.Sum256([]byte("streamed payload"))
+	if got := resp.Trailer.Get("X-Checksum"); got != hex.EncodeToString(sum[:]) {
+		t.Errorf("X-Checksum trailer = %q", got)
+	}
+	if got := resp.Trailer.Get("X-Version"); got != "v1" {
+		t.Errorf("X-Version trailer = %q", got)
+	}
+
+	cookies := map[string]*http.Cookie{}
+	for _, ck := range resp.Cookies() {
+		cookies[ck.Name] = ck
+	}
+	if ck := cookies["session_id"]; ck == nil || ck.Value != "abc" || ck.Path != "/" {
+		t.Errorf("session_id cookie = %+v", ck)
+	}
+	if ck := cookies["theme"]; ck == nil || ck.Value != "dark" || !ck.HttpOnly || ck.MaxAge != 60 {
+		t.Errorf("theme cookie = %+v", ck)
+	}
+}
+
+// TestContext_SSEResume checks that a reconnecting client receives the messages it missed.
+func TestContext_SSEResume(t *testing.T) {
+	t.Parallel()
+
+	store := NewRingEventStore(3)
+	for _, data := range []string{"a", "b", "c", "d"} {
+		if _, err := store.Append(Message{Event: "note", Data: data}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, rec := NewTestContext(http.MethodGet, "/notifications", nil)
+	ctx.Request().Header.Set("Last-Event-ID", "2")
+	live := make(chan Message, 1)
+	live <- Message{ID: "5", Data: "e"}
+	close(live)
+
+	if err := ctx.SSEStreamWithOptions(context.Background(), live, &StreamOptions{Store: store}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"id: 3\nevent: note\ndata: c", "id: 4\nevent: note\ndata: d", "id: 5\ndata: e"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("stream is missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "data: b") {
+		t.Errorf("stream replayed an already received message:\n%s", body)
+	}
+
+	// An ID evicted from the ring replays everything still stored.
+	missed, _ := store.Since("1")
+	if len(missed) != 3 || missed[0].ID != "2" {
+		t.Errorf("Since(evicted) = %+v", missed)
+	}
+}
+
+// TestContext_RespondProtoBuf checks protobuf content negotiation for typed handlers.
+func TestContext_RespondProtoBuf(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/greeting", HandleO(func(c *Context) (*wrapperspb.StringValue, error) {
+		return wrapperspb.String("hello"), nil
+	}))
+
+	okapitest.GET(t, ts.BaseURL+"/greeting").
+		ExpectStatusOK().
+		ExpectHeader("Content-Type", constJSON).
+		ExpectBodyContains(`"value":"hello"`)
+
+	for _, accept := range []string{constPROTOBUF, constXProtoBuf} {
+		resp, body := okapitest.GET(t, ts.BaseURL+"/greeting").Header("Accept", accept).Execute()
+		if ct := resp.Header.Get("Content-Type"); ct != constPROTOBUF {
+			t.Errorf("Accept %s: Content-Type = %q", accept, ct)
+		}
+		msg := &wrapperspb.StringValue{}
+		if err := proto.Unmarshal(body, msg); err != nil || msg.GetValue() != "hello" {
+			t.Errorf("Accept %s: decoded %q, err %v", accept, msg.GetValue(), err)
+		}
+	}
+}
+
+type requestIDKey struct{}
+
+func TestContext_CancellationHelpers(t *testing.T) {
+	c, _ := NewTestContext(http.MethodGet, "/books", nil)
+	if _, ok := c.Deadline(); ok {
+		t.Fatal("expected no deadline")
+	}
+	if c.Err() != nil {
+		t.Fatalf("expected a live context, got %v", c.Err())
+	}
+
+	ctx, cancel := c.WithTimeout(time.Minute)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Fatalf("unexpected timeout deadline %v", deadline)
+	}
+	if _, ok := c.Deadline(); ok {
+		t.Fatal("WithTimeout must not change the request context")
+	}
+	if got, _ := ctx.Value(okapiContextKey{}).(*Context); got != c {
+		t.Fatal("expected the derived context to carry the Context")
+	}
+
+	parent, cancelParent := context.WithCancel(context.WithValue(c.Context(), requestIDKey{}, "req-1"))
+	c.SetContext(parent)
+	if c.Context().Value(requestIDKey{}) != "req-1" {
+		t.Fatal("expected SetContext to replace the request context")
+	}
+	cancelParent()
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected Done to be closed")
+	}
+	if !errors.Is(c.Err(), context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", c.Err())
+	}
+}
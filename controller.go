@@ -0,0 +1,245 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// routesProvider is implemented by controllers listing their routes explicitly.
+type routesProvider interface {
+	Routes() []RouteDefinition
+}
+
+// controllerVerbs are the method name prefixes mapped to HTTP methods.
+var controllerVerbs = []string{"Get", "Post", "Put", "Patch", "Delete", "Head", "Options"}
+
+// RegisterController registers the handler methods of controller, a pointer to a struct.
+// Handler methods have the HandlerFunc signature, func(*okapi.Context) error. Routes come from,
+// in order of precedence:
+//
+//   - a Routes() []RouteDefinition method, which then lists every route of the controller;
+//   - blank fields tagged with `route:"METHOD /path"` and `handler:"MethodName"`, optionally
+//     with `summary`, `description`, `operationId` and comma-separated `tags`;
+//   - the remaining handler methods named after an HTTP method, whose path is derived from
+//     the rest of the name: GetBooks is GET /books, GetBookByID is GET /book/{id},
+//     and PostBookReviews is POST /book-reviews.
+//
+// A blank field tagged with `group:"/prefix"` registers the routes in a group with that
+// prefix, documented under its comma-separated `tags`.
+//
+// RegisterController panics on an invalid controller or tag, as RegisterRoutes does.
+//
+// Example:
+//
+//	type BookController struct {
+//		_ struct{} `group:"/books" tags:"Books"`
+//		_ struct{} `route:"POST /{id}/borrow" handler:"Borrow" summary:"Borrow a book"`
+//		store *BookStore
+//	}
+//
+//	func (b *BookController) Get(c *okapi.Context) error      { ... } // GET /books
+//	func (b *BookController) GetByID(c *okapi.Context) error  { ... } // GET /books/{id}
+//	func (b *BookController) Post(c *okapi.Context) error     { ... } // POST /books
+//	func (b *BookController) Borrow(c *okapi.Context) error   { ... } // POST /books/{id}/borrow
+//
+//	okapi.RegisterController(app, &BookController{store: store})
+func RegisterController(o *Okapi, controller any) {
+	registerController(o, nil, controller)
+}
+
+// RegisterController registers the handler methods of controller, see RegisterController.
+func (o *Okapi) RegisterController(controller any) {
+	registerController(o, nil, controller)
+}
+
+// RegisterController registers the handler methods of controller within the group,
+// see RegisterController.
+func (g *Group) RegisterController(controller any) {
+	registerController(g.okapi, g, controller)
+}
+
+func registerController(o *Okapi, parent *Group, controller any) {
+	v := reflect.ValueOf(controller)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("okapi: controller must be a non-nil pointer to a struct, got %T", controller))
+	}
+	name := v.Elem().Type().Name()
+	group := parent
+	var defs []RouteDefinition
+	bound := make(map[string]bool)
+
+	st := v.Elem().Type()
+	for i := range st.NumField() {
+		field := st.Field(i)
+		if prefix, ok := field.Tag.Lookup("group"); ok {
+			if parent != nil {
+				group = parent.Group(prefix)
+			} else {
+				group = o.Group(prefix)
+			}
+			if tags := splitTagList(field.Tag.Get("tags")); len(tags) > 0 {
+				group.WithTags(tags)
+			}
+			continue
+		}
+		route, ok := field.Tag.Lookup("route")
+		if !ok {
+			continue
+		}
+		method, path, found := strings.Cut(strings.TrimSpace(route), " ")
+		handlerName := field.Tag.Get("handler")
+		if !found || handlerName == "" {
+			panic(fmt.Sprintf("okapi: controller %s: route tag %q needs the form \"METHOD /path\" and a handler tag", name, route))
+		}
+		h, ok := controllerHandler(v, handlerName)
+		if !ok {
+			panic(fmt.Sprintf("okapi: controller %s: %s is not a method of type func(*okapi.Context) error", name, handlerName))
+		}
+		bound[handlerName] = true
+		defs = append(defs, RouteDefinition{
+			Method:      method,
+			Path:        strings.TrimSpace(path),
+			Handler:     h,
+			OperationId: field.Tag.Get("operationId"),
+			Summary:     field.Tag.Get("summary"),
+			Description: field.Tag.Get("description"),
+			Tags:        splitTagList(field.Tag.Get("tags")),
+		})
+	}
+
+	if rp, ok := controller.(routesProvider); ok {
+		defs = rp.Routes()
+	} else {
+		t := v.Type()
+		for i := range t.NumMethod() {
+			m := t.Method(i)
+			if bound[m.Name] {
+				continue
+			}
+			h, ok := controllerHandler(v, m.Name)
+			if !ok {
+				continue
+			}
+			if method, path, ok := routeFromMethodName(m.Name); ok {
+				defs = append(defs, RouteDefinition{Method: method, Path: path, Handler: h})
+			}
+		}
+	}
+
+	for i := range defs {
+		if defs[i].Group == nil {
+			defs[i].Group = group
+		}
+	}
+	RegisterRoutes(o, defs)
+}
+
+// controllerHandler returns the method of v called name when it is a HandlerFunc.
+func controllerHandler(v reflect.Value, name string) (HandlerFunc, bool) {
+	m := v.MethodByName(name)
+	if !m.IsValid() {
+		return nil, false
+	}
+	h, ok := m.Interface().(func(*Context) error)
+	return h, ok
+}
+
+// routeFromMethodName derives the HTTP method and path of a controller method
+// named after an HTTP method, such as GetBookByID for GET /book/{id}.
+func routeFromMethodName(name string) (method, path string, ok bool) {
+	var verb string
+	for _, v := range controllerVerbs {
+		if strings.HasPrefix(name, v) {
+			rest := name[len(v):]
+			if rest == "" || unicode.IsUpper(rune(rest[0])) {
+				verb = v
+				break
+			}
+		}
+	}
+	if verb == "" {
+		return "", "", false
+	}
+	var segments, param, words []string
+	inParam := false
+	flush := func() {
+		switch {
+		case inParam && len(param) > 0:
+			segments = append(segments, "{"+strings.ToLower(strings.Join(param, "_"))+"}")
+		case !inParam && len(words) > 0:
+			segments = append(segments, strings.ToLower(strings.Join(words, "-")))
+		}
+		param, words = nil, nil
+	}
+	for _, w := range splitCamelCase(name[len(verb):]) {
+		if w == "By" {
+			flush()
+			inParam = true
+			continue
+		}
+		if inParam {
+			param = append(param, w)
+		} else {
+			words = append(words, w)
+		}
+	}
+	flush()
+	return strings.ToUpper(verb), "/" + strings.Join(segments, "/"), true
+}
+
+// splitCamelCase splits an identifier into words, keeping acronyms together:
+// "BookHTTPStatusID" gives Book, HTTP, Status, ID.
+func splitCamelCase(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		next := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && next)) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// splitTagList splits a comma-separated struct tag value.
+func splitTagList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
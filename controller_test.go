@@ -0,0 +1,118 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBookController struct {
+	_      struct{} `group:"/books" tags:"Books"`
+	_      struct{} `route:"POST /{id}/borrow" handler:"Borrow" summary:"Borrow a book"`
+	prefix string
+}
+
+func (b *testBookController) Get(c *Context) error { return c.String(http.StatusOK, b.prefix+"list") }
+func (b *testBookController) GetByID(c *Context) error {
+	return c.String(http.StatusOK, b.prefix+"book "+c.Param("id"))
+}
+func (b *testBookController) PostReviewsByBookID(c *Context) error {
+	return c.String(http.StatusCreated, "review "+c.Param("book_id"))
+}
+func (b *testBookController) Borrow(c *Context) error  { return c.String(http.StatusOK, "borrowed") }
+func (b *testBookController) Getaway(c *Context) error { return nil } // not a verb prefix
+func (b *testBookController) GetStore() string         { return "" }  // not a handler
+
+type testRoutesController struct{}
+
+func (testRoutesController) GetIgnored(c *Context) error { return nil }
+func (testRoutesController) Routes() []RouteDefinition {
+	return []RouteDefinition{{Method: http.MethodGet, Path: "/explicit", Handler: anyHandler}}
+}
+
+func TestRegisterController(t *testing.T) {
+	o := New()
+	o.RegisterController(&testBookController{prefix: "> "})
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+	assert.Equal(t, "> list", serve(http.MethodGet, "/books").Body.String())
+	assert.Equal(t, "> book 7", serve(http.MethodGet, "/books/7").Body.String())
+	rec := serve(http.MethodPost, "/books/reviews/7")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "review 7", rec.Body.String())
+	assert.Equal(t, "borrowed", serve(http.MethodPost, "/books/7/borrow").Body.String())
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/books/7/away").Code)
+
+	o.buildOpenAPISpec()
+	item := o.openapiSpec.Paths.Find("/books/{id}/borrow")
+	require.NotNil(t, item)
+	assert.Equal(t, "Borrow a book", item.Post.Summary)
+	assert.Equal(t, []string{"Books"}, item.Post.Tags)
+}
+
+func TestRegisterControllerRoutes(t *testing.T) {
+	o := New()
+	api := o.Group("/api")
+	api.RegisterController(&testRoutesController{})
+
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/explicit", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/ignored", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	assert.Panics(t, func() { o.RegisterController(testRoutesController{}) })
+}
+
+func TestRouteFromMethodName(t *testing.T) {
+	tests := []struct{ name, method, path string }{
+		{"Get", http.MethodGet, "/"},
+		{"GetBooks", http.MethodGet, "/books"},
+		{"GetBookByID", http.MethodGet, "/book/{id}"},
+		{"PostBookReviews", http.MethodPost, "/book-reviews"},
+		{"DeleteReviewsByBookID", http.MethodDelete, "/reviews/{book_id}"},
+		{"GetByAuthorByYear", http.MethodGet, "/{author}/{year}"},
+		{"PatchHTTPStatus", http.MethodPatch, "/http-status"},
+	}
+	for _, tt := range tests {
+		method, path, ok := routeFromMethodName(tt.name)
+		require.True(t, ok, tt.name)
+		assert.Equal(t, tt.method, method, tt.name)
+		assert.Equal(t, tt.path, path, tt.name)
+	}
+	_, _, ok := routeFromMethodName("Getaway")
+	assert.False(t, ok)
+}
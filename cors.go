@@ -64,6 +64,16 @@ type Cors struct {
 	// When set, AllowedOrigins should not rely on the bare "*" wildcard —
 	// the origin is always echoed verbatim so credentialed requests work.
 	AllowCredentials bool
+
+	// AllowOriginFunc, when set, is consulted for origins not listed in
+	// AllowedOrigins, e.g. to look up tenant domains in a database.
+	AllowOriginFunc func(origin string) bool
+
+	// AllowPrivateNetwork answers preflight requests carrying
+	// Access-Control-Request-Private-Network: true with
+	// Access-Control-Allow-Private-Network: true, letting public websites
+	// reach a server on a private network or localhost (Private Network Access).
+	AllowPrivateNetwork bool
 }
 
 // CORSHandler applies CORS headers and short-circuits real preflight
@@ -74,7 +84,7 @@ func (cors Cors) CORSHandler(c *Context) error {
 	isPreflight := c.request.Method == http.MethodOptions &&
 		c.request.Header.Get("Access-Control-Request-Method") != ""
 
-	if origin == "" || !originAllowed(cors.AllowedOrigins, origin) {
+	if !cors.allowsOrigin(origin) {
 		if isPreflight {
 			c.response.WriteHeader(http.StatusNoContent)
 			return nil
@@ -123,6 +133,11 @@ func (cors Cors) writeHeaders(h http.Header, r *http.Request, isPreflight bool)
 		if cors.MaxAge > 0 {
 			h.Set(constAccessControlMaxAge, strconv.Itoa(cors.MaxAge))
 		}
+
+		if cors.AllowPrivateNetwork && r.Header.Get(constAccessControlRequestPrivateNetwork) == constTRUE {
+			h.Set(constAccessControlAllowPrivateNetwork, constTRUE)
+			addVary(h, constAccessControlRequestPrivateNetwork)
+		}
 	}
 
 	for k, v := range cors.Headers {
@@ -130,6 +145,17 @@ func (cors Cors) writeHeaders(h http.Header, r *http.Request, isPreflight bool)
 	}
 }
 
+// allowsOrigin reports whether origin is listed in AllowedOrigins or accepted by AllowOriginFunc.
+func (cors Cors) allowsOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	if originAllowed(cors.AllowedOrigins, origin) {
+		return true
+	}
+	return cors.AllowOriginFunc != nil && cors.AllowOriginFunc(origin)
+}
+
 func originAllowed(allowed []string, origin string) bool {
 	if origin == "" {
 		return false
@@ -166,10 +192,11 @@ func matchWildcardOrigin(pattern, origin string) bool {
 
 // addVary appends value to the Vary header if not already present.
 func addVary(h http.Header, value string) {
-	existing := h.Values("Vary")
-	for _, v := range existing {
-		if strings.EqualFold(v, value) {
-			return
+	for _, v := range h.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(name), value) {
+				return
+			}
 		}
 	}
 	h.Add("Vary", value)
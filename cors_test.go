@@ -235,3 +235,50 @@ func TestCORSHandler_WildcardSubdomainMatches(t *testing.T) {
 	assert.Equal(t, "https://tenant-1.example.com",
 		rec.Header().Get(constAccessControlAllowOrigin))
 }
+
+func TestCORSHandler_AllowOriginFunc(t *testing.T) {
+	cors := Cors{
+		AllowedOrigins: []string{"https://app.example"},
+		AllowOriginFunc: func(origin string) bool {
+			return origin == "https://tenant.example"
+		},
+	}
+	for origin, allowed := range map[string]bool{
+		"https://app.example":    true,
+		"https://tenant.example": true,
+		"https://other.example":  false,
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/x", nil)
+		r.Header.Set("Origin", origin)
+
+		rec := invokeCORS(cors, r)
+
+		if allowed {
+			assert.Equal(t, origin, rec.Header().Get(constAccessControlAllowOrigin))
+			assert.Contains(t, rec.Header().Values("Vary"), "Origin")
+		} else {
+			assert.Empty(t, rec.Header().Get(constAccessControlAllowOrigin))
+		}
+	}
+}
+
+func TestCORSHandler_PrivateNetwork(t *testing.T) {
+	preflight := func(cors Cors, private bool) http.Header {
+		r := httptest.NewRequest(http.MethodOptions, "/x", nil)
+		r.Header.Set("Origin", "https://app.example")
+		r.Header.Set("Access-Control-Request-Method", "GET")
+		if private {
+			r.Header.Set(constAccessControlRequestPrivateNetwork, "true")
+		}
+		return invokeCORS(cors, r).Header()
+	}
+
+	cors := Cors{AllowedOrigins: []string{"https://app.example"}, AllowPrivateNetwork: true}
+	h := preflight(cors, true)
+	assert.Equal(t, "true", h.Get(constAccessControlAllowPrivateNetwork))
+	assert.Contains(t, h.Values("Vary"), constAccessControlRequestPrivateNetwork)
+
+	assert.Empty(t, preflight(cors, false).Get(constAccessControlAllowPrivateNetwork))
+	cors.AllowPrivateNetwork = false
+	assert.Empty(t, preflight(cors, true).Get(constAccessControlAllowPrivateNetwork))
+}
@@ -0,0 +1,324 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const (
+	constCSV = "text/csv"
+	tagCSV   = "csv"
+)
+
+// CSVOptions configures how BindCSV reads an upload.
+type CSVOptions struct {
+	// Comma is the field delimiter. Defaults to ','.
+	Comma rune
+	// Comment, when set, marks lines starting with it as comments.
+	Comment rune
+	// NoHeader maps columns by struct field order instead of reading a header row.
+	NoHeader bool
+	// MaxRows caps the number of data rows read; 0 means no limit.
+	MaxRows int
+	// MaxErrors stops reading once this many rows are invalid; 0 collects them all.
+	MaxErrors int
+}
+
+// CSVRowError describes why a single CSV row could not be bound.
+type CSVRowError struct {
+	// Line is the line number of the row in the upload, starting at 1.
+	Line int
+	// Column is the header of the offending column, when known.
+	Column string
+	Err    error
+}
+
+func (e CSVRowError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("line %d, column %s: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e CSVRowError) Unwrap() error { return e.Err }
+
+// CSVError is returned by BindCSV when one or more rows are invalid.
+// Valid rows are still appended to the target slice.
+type CSVError struct {
+	Rows []CSVRowError
+}
+
+func (e *CSVError) Error() string {
+	if len(e.Rows) == 1 {
+		return "invalid CSV row: " + e.Rows[0].Error()
+	}
+	return fmt.Sprintf("%d invalid CSV rows, first: %s", len(e.Rows), e.Rows[0].Error())
+}
+
+// tableColumn maps a CSV or spreadsheet column to a struct field.
+type tableColumn struct {
+	name  string
+	index []int
+}
+
+// BindCSV streams a CSV request body into out, which must be a pointer to a slice
+// of structs (or struct pointers). Columns are matched to fields by their `csv` tag,
+// falling back to the field name; `csv:"-"` skips a field.
+//
+// Each row is validated like any other bound struct. Invalid rows are skipped and
+// reported together in a *CSVError, so callers can decide whether to keep the valid ones.
+//
+//	type Contact struct {
+//	  Name  string `csv:"name" required:"true"`
+//	  Email string `csv:"email" format:"email"`
+//	}
+//
+//	var contacts []Contact
+//	if err := c.BindCSV(&contacts, okapi.CSVOptions{MaxRows: 10000}); err != nil {
+//	  return c.AbortBadRequest("Invalid CSV", err)
+//	}
+func (c *Context) BindCSV(out any, opts ...CSVOptions) error {
+	var opt CSVOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errors.New("bind target must be a non-nil pointer to a slice")
+	}
+	slice := v.Elem()
+	elemType, isPtr := tableElemType(slice.Type())
+	if elemType.Kind() != reflect.Struct {
+		return errors.New("bind target must be a slice of structs")
+	}
+	fields := tableFields(elemType, tagCSV)
+
+	r := csv.NewReader(c.request.Body)
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+	if opt.Comma != 0 {
+		r.Comma = opt.Comma
+	}
+	r.Comment = opt.Comment
+
+	columns := fields
+	if !opt.NoHeader {
+		header, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid CSV header: %w", err)
+		}
+		columns = csvHeaderColumns(header, fields)
+	}
+
+	var rowErrs []CSVRowError
+	for rows := 0; opt.MaxRows == 0 || rows < opt.MaxRows; rows++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+
+		elem := reflect.New(elemType)
+		if rowErr := bindCSVRecord(elem.Elem(), columns, record); rowErr != nil {
+			rowErr.Line = line
+			rowErrs = append(rowErrs, *rowErr)
+		} else if err := validateStruct(elem.Interface()); err != nil {
+			rowErrs = append(rowErrs, CSVRowError{Line: line, Err: c.localizeError(err)})
+		} else if isPtr {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+		if opt.MaxErrors > 0 && len(rowErrs) >= opt.MaxErrors {
+			break
+		}
+	}
+	if len(rowErrs) > 0 {
+		return &CSVError{Rows: rowErrs}
+	}
+	return nil
+}
+
+// CSV writes rows as a CSV response with the given status code. rows must be a
+// slice of structs (or struct pointers), whose `csv` tags name the header columns,
+// or a [][]string written as is.
+func (c *Context) CSV(code int, rows any) error {
+	records, ok := rows.([][]string)
+	if !ok {
+		var err error
+		if records, err = csvRecords(rows); err != nil {
+			return c.AbortInternalServerError("Internal Server Error", err)
+		}
+	}
+	return c.writeResponse(code, constCSV+"; charset=utf-8", func() error {
+		w := csv.NewWriter(c.response)
+		return w.WriteAll(records)
+	})
+}
+
+// csvRecords converts a slice of structs into a header row followed by one record per element.
+func csvRecords(rows any) ([][]string, error) {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("csv: unsupported type %T", rows)
+	}
+	elemType, _ := tableElemType(v.Type())
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csv: unsupported element type %s", elemType)
+	}
+	fields := tableFields(elemType, tagCSV)
+	records := make([][]string, 0, v.Len()+1)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+	records = append(records, header)
+	for i := 0; i < v.Len(); i++ {
+		elem := reflect.Indirect(v.Index(i))
+		record := make([]string, len(fields))
+		if elem.IsValid() {
+			for j, f := range fields {
+				record[j] = formatCSVValue(elem.FieldByIndex(f.index))
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// tableElemType returns the struct type held by a slice, and whether elements are pointers.
+func tableElemType(t reflect.Type) (reflect.Type, bool) {
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		return elem.Elem(), true
+	}
+	return elem, false
+}
+
+// tableFields lists the exported fields of t in declaration order. Column names
+// come from the first of tags set on the field, falling back to the field name.
+func tableFields(t reflect.Type, tags ...string) []tableColumn {
+	var fields []tableColumn
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		var name string
+		for _, tag := range tags {
+			if name = sf.Tag.Get(tag); name != "" {
+				break
+			}
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, tableColumn{name: name, index: sf.Index})
+	}
+	return fields
+}
+
+// csvHeaderColumns maps each header cell to its field; unknown columns are ignored.
+func csvHeaderColumns(header []string, fields []tableColumn) []tableColumn {
+	columns := make([]tableColumn, len(header))
+	for i, h := range header {
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))
+		for _, f := range fields {
+			if strings.EqualFold(f.name, h) {
+				columns[i] = f
+				break
+			}
+		}
+		columns[i].name = h
+	}
+	return columns
+}
+
+func bindCSVRecord(elem reflect.Value, columns []tableColumn, record []string) *CSVRowError {
+	for i, col := range columns {
+		if col.index == nil || i >= len(record) || record[i] == "" {
+			continue
+		}
+		if err := setCSVValue(elem.FieldByIndex(col.index), record[i]); err != nil {
+			return &CSVRowError{Column: col.name, Err: err}
+		}
+	}
+	return nil
+}
+
+// setCSVValue sets field from a CSV cell, preferring encoding.TextUnmarshaler (e.g. time.Time).
+func setCSVValue(field reflect.Value, raw string) error {
+	if field.Kind() == reflect.Ptr && field.IsNil() {
+		field.Set(reflect.New(field.Type().Elem()))
+	}
+	if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+	if field.Kind() == reflect.Ptr {
+		return setCSVValue(field.Elem(), raw)
+	}
+	return setWithType(field, raw)
+}
+
+// formatCSVValue renders a field as a CSV cell, the inverse of setCSVValue.
+func formatCSVValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		b, err := m.MarshalText()
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = formatCSVValue(v.Index(i))
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,121 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type csvContact struct {
+	Name     string    `csv:"name" required:"true"`
+	Age      int       `csv:"age" min:"18"`
+	Tags     []string  `csv:"tags"`
+	JoinedAt time.Time `csv:"joined_at"`
+	Internal string    `csv:"-"`
+}
+
+func TestContext_BindCSV(t *testing.T) {
+	body := "name,age,tags,joined_at,ignored\n" +
+		"Alice,30,\"a,b\",2024-01-02T00:00:00Z,x\n" +
+		",40,,,\n" +
+		"Bob,abc,,,\n" +
+		"Carol,17,,,\n" +
+		"Dave,50,,,\n"
+	ctx, _ := NewTestContext(http.MethodPost, "/import", strings.NewReader(body))
+
+	var contacts []csvContact
+	err := ctx.BindCSV(&contacts)
+
+	var csvErr *CSVError
+	if !errors.As(err, &csvErr) {
+		t.Fatalf("expected *CSVError, got %v", err)
+	}
+	if len(csvErr.Rows) != 3 {
+		t.Fatalf("expected 3 row errors, got %d: %v", len(csvErr.Rows), csvErr.Rows)
+	}
+	if csvErr.Rows[0].Line != 3 || csvErr.Rows[1].Line != 4 || csvErr.Rows[1].Column != "age" || csvErr.Rows[2].Line != 5 {
+		t.Errorf("unexpected row errors: %v", csvErr.Rows)
+	}
+	if len(contacts) != 2 || contacts[0].Name != "Alice" || contacts[1].Name != "Dave" {
+		t.Fatalf("unexpected contacts: %+v", contacts)
+	}
+	if strings.Join(contacts[0].Tags, "|") != "a|b" || contacts[0].JoinedAt.Year() != 2024 {
+		t.Errorf("unexpected first contact: %+v", contacts[0])
+	}
+
+	t.Run("options", func(t *testing.T) {
+		ctx, _ := NewTestContext(http.MethodPost, "/import", strings.NewReader("Alice;30\n# note\nBob;31\nCarol;32\n"))
+		var rows []*csvContact
+		if err := ctx.BindCSV(&rows, CSVOptions{Comma: ';', Comment: '#', NoHeader: true, MaxRows: 2}); err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 2 || rows[1].Name != "Bob" || rows[1].Age != 31 {
+			t.Errorf("unexpected rows: %+v", rows)
+		}
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		ctx, _ := NewTestContext(http.MethodPost, "/import", strings.NewReader("name\nAlice\n"))
+		var c csvContact
+		if err := ctx.BindCSV(&c); err == nil {
+			t.Error("expected an error for a non-slice target")
+		}
+	})
+}
+
+func TestContext_CSV(t *testing.T) {
+	ctx, rec := NewTestContext(http.MethodGet, "/export", nil)
+	joined := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	err := ctx.CSV(http.StatusOK, []csvContact{
+		{Name: "Alice", Age: 30, Tags: []string{"a", "b"}, JoinedAt: joined, Internal: "secret"},
+		{Name: "Bob, Jr.", Age: 31},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+	want := "name,age,tags,joined_at\n" +
+		"Alice,30,\"a,b\",2024-01-02T00:00:00Z\n" +
+		"\"Bob, Jr.\",31,,0001-01-01T00:00:00Z\n"
+	if rec.Body.String() != want {
+		t.Errorf("unexpected body:\n%s", rec.Body.String())
+	}
+
+	// The export reads back into the same type.
+	in, _ := NewTestContext(http.MethodPost, "/import", strings.NewReader(rec.Body.String()))
+	var contacts []csvContact
+	if err := in.BindCSV(&contacts); err != nil {
+		t.Fatal(err)
+	}
+	if len(contacts) != 2 || contacts[1].Name != "Bob, Jr." || !contacts[0].JoinedAt.Equal(joined) {
+		t.Errorf("unexpected round trip: %+v", contacts)
+	}
+}
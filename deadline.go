@@ -0,0 +1,119 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestDeadline attaches a deadline to the request context, so that database
+// drivers, HTTP clients and other downstream calls given c.Context() stop once
+// the request's time is up. Handlers read the time left with c.RemainingBudget.
+//
+// The deadline is the route's Route.WithMaxDuration, or Default when the route
+// has none. Trusted callers may shorten it with an X-Request-Timeout header,
+// holding a duration ("1.5s", "250ms") or a number of seconds.
+//
+// Example:
+//
+//	deadline := okapi.RequestDeadline{
+//		Default: 10 * time.Second,
+//		TrustCaller: func(c *okapi.Context) bool {
+//			return c.Header("X-Internal-Token") == internalToken
+//		},
+//	}
+//	o.Use(deadline.Middleware)
+type RequestDeadline struct {
+	// Default applies to routes without a maximum duration. Zero means none.
+	Default time.Duration
+	// Max caps the timeout requested by callers. Zero means no cap.
+	Max time.Duration
+	// TrustCaller reports whether the request's X-Request-Timeout header is honored.
+	// When nil, the header is ignored.
+	TrustCaller func(c *Context) bool
+}
+
+// Middleware applies the deadline to the rest of the chain.
+func (d RequestDeadline) Middleware(c *Context) error {
+	timeout := d.Default
+	if c.route != nil && c.route.budget != nil && c.route.budget.maxDuration > 0 {
+		timeout = c.route.budget.maxDuration
+	}
+	if d.TrustCaller != nil {
+		if requested, ok := parseRequestTimeout(c.Header(requestTimeoutHeader)); ok && d.TrustCaller(c) {
+			if d.Max > 0 {
+				requested = min(requested, d.Max)
+			}
+			if timeout == 0 || requested < timeout {
+				timeout = requested
+			}
+		}
+	}
+	if timeout <= 0 {
+		return c.Next()
+	}
+	ctx, cancel := context.WithTimeout(c.request.Context(), timeout)
+	defer cancel()
+	original := c.request
+	c.request = c.request.WithContext(ctx)
+	defer func() { c.request = original }()
+	return c.Next()
+}
+
+// RemainingBudget returns the time left before the request context's deadline,
+// and false when the request has no deadline. See RequestDeadline.
+func (c *Context) RemainingBudget() (time.Duration, bool) {
+	deadline, ok := c.request.Context().Deadline()
+	if !ok {
+		return 0, false
+	}
+	return max(time.Until(deadline), 0), true
+}
+
+// parseRequestTimeout parses an X-Request-Timeout value, either a duration
+// or a number of seconds.
+func parseRequestTimeout(v string) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		seconds, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		d = time.Duration(seconds * float64(time.Second))
+	}
+	return d, d > 0
+}
+
+// formatRequestTimeout formats d as an X-Request-Timeout value.
+func formatRequestTimeout(d time.Duration) string {
+	return strconv.FormatInt(max(d.Milliseconds(), 1), 10) + "ms"
+}
@@ -0,0 +1,113 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestDeadline(t *testing.T) {
+	o := New()
+	deadline := RequestDeadline{
+		Default: time.Minute,
+		Max:     10 * time.Second,
+		TrustCaller: func(c *Context) bool {
+			return c.Header("X-Internal") == "yes"
+		},
+	}
+	o.Use(deadline.Middleware)
+
+	remaining := func(c *Context) error {
+		left, ok := c.RemainingBudget()
+		if !ok {
+			return c.String(http.StatusOK, "none")
+		}
+		return c.String(http.StatusOK, left.Round(time.Second).String())
+	}
+	o.Get("/default", remaining)
+	o.Get("/route", remaining).WithMaxDuration(5 * time.Second)
+
+	serve := func(path string, header map[string]string) string {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	assert.Equal(t, "1m0s", serve("/default", nil))
+	assert.Equal(t, "5s", serve("/route", nil))
+	assert.Equal(t, "1m0s", serve("/default", map[string]string{"X-Request-Timeout": "2s"}), "untrusted callers are ignored")
+	assert.Equal(t, "2s", serve("/default", map[string]string{"X-Request-Timeout": "2s", "X-Internal": "yes"}))
+	assert.Equal(t, "3s", serve("/route", map[string]string{"X-Request-Timeout": "3", "X-Internal": "yes"}))
+	assert.Equal(t, "5s", serve("/route", map[string]string{"X-Request-Timeout": "30s", "X-Internal": "yes"}))
+	assert.Equal(t, "10s", serve("/default", map[string]string{"X-Request-Timeout": "30s", "X-Internal": "yes"}), "capped by Max")
+	assert.Equal(t, "1m0s", serve("/default", map[string]string{"X-Request-Timeout": "soon", "X-Internal": "yes"}))
+}
+
+func TestRemainingBudgetWithoutDeadline(t *testing.T) {
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	_, ok := ctx.RemainingBudget()
+	assert.False(t, ok)
+}
+
+func TestHTTPClientPropagatesDeadline(t *testing.T) {
+	received := make(chan string, 1)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("X-Request-Timeout")
+	}))
+	defer upstream.Close()
+
+	o := New()
+	o.Get("/", func(c *Context) error {
+		req, err := http.NewRequestWithContext(c.Context(), http.MethodGet, upstream.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := HTTPClient(HTTPClientOptions{}).Do(req)
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		return c.NoContent()
+	}, UseMiddleware(RequestDeadline{Default: 2 * time.Second}.Middleware))
+
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	timeout, ok := parseRequestTimeout(<-received)
+	require.True(t, ok)
+	assert.InDelta(t, 2*time.Second, timeout, float64(500*time.Millisecond))
+}
@@ -0,0 +1,264 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// decimalPattern is the lexical form accepted by ParseDecimal and documented
+// in the OpenAPI schema of Decimal values.
+const decimalPattern = `^-?[0-9]+(\.[0-9]+)?$`
+
+var (
+	decimalRegexp = regexp.MustCompile(decimalPattern)
+	decimalType   = reflect.TypeFor[Decimal]()
+)
+
+// Decimal is an exact decimal number, for money and other values that must not
+// go through float64. It is the unscaled integer value divided by 10^scale, so
+// "12.30" keeps its two decimals.
+//
+// Decimal binds from path, query, header, cookie and form values, accepts both
+// JSON strings and numbers, and is written to JSON as a string ("12.30") so
+// clients do not lose precision. The OpenAPI schema of a Decimal field is a
+// string with format "decimal"; the min, max, exclusiveMin, exclusiveMax and
+// multipleOf tags are checked exactly.
+//
+// The zero value is 0.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+// NewDecimal returns value / 10^scale, e.g. NewDecimal(1230, 2) is 12.30.
+func NewDecimal(value int64, scale int32) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	return Decimal{value: big.NewInt(value), scale: scale}
+}
+
+// ParseDecimal parses s, an optionally signed number with an optional
+// fractional part, such as "12.30" or "-0.5". Exponents are not accepted.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if !decimalRegexp.MatchString(strings.TrimPrefix(s, "+")) {
+		return Decimal{}, fmt.Errorf("invalid decimal value '%s'", s)
+	}
+	s = strings.TrimPrefix(s, "+")
+	intPart, frac, _ := strings.Cut(s, ".")
+	v, ok := new(big.Int).SetString(intPart+frac, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal value '%s'", s)
+	}
+	return Decimal{value: v, scale: int32(len(frac))}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on error. It is meant for
+// constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// unscaled returns the unscaled value, never nil.
+func (d Decimal) unscaled() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Rat returns d as an exact rational number.
+func (d Decimal) Rat() *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(d.unscaled(), denom)
+}
+
+// Cmp compares d and other and returns -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// Equal reports whether d and other are the same number, regardless of scale.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// rescale returns the unscaled value of d at the given, larger or equal, scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	v := new(big.Int).Set(d.unscaled())
+	if scale > d.scale {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil))
+	}
+	return v
+}
+
+// Add returns d + other, at the larger scale of both.
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{value: new(big.Int).Add(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - other, at the larger scale of both.
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{value: new(big.Int).Sub(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.unscaled()), scale: d.scale}
+}
+
+// Float64 returns the nearest float64 value of d, for display or statistics.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// String returns d with its scale, e.g. "12.30".
+func (d Decimal) String() string {
+	v := d.unscaled()
+	digits := new(big.Int).Abs(v).String()
+	sign := ""
+	if v.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON writes d as a JSON string, e.g. "12.30".
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts a JSON string ("12.30") or number (12.30). A JSON
+// number is read from its text, so it does not lose precision.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	return d.UnmarshalText(bytes.Trim(data, `"`))
+}
+
+// decimalField returns the value of a Decimal or *Decimal field, or nil for a
+// nil pointer. ok is false for other types.
+func decimalField(field reflect.Value) (d *Decimal, ok bool) {
+	switch {
+	case field.Type() == decimalType:
+		v := field.Interface().(Decimal)
+		return &v, true
+	case field.Kind() == reflect.Ptr && field.Type().Elem() == decimalType:
+		if field.IsNil() {
+			return nil, true
+		}
+		return field.Interface().(*Decimal), true
+	}
+	return nil, false
+}
+
+// checkDecimalConstraints validates the numeric tags of a Decimal field exactly.
+func checkDecimalConstraints(d Decimal, sf reflect.StructField) error {
+	bounds := []struct {
+		tag  string
+		fail func(cmp int) bool
+		msg  string
+	}{
+		{tagMin, func(cmp int) bool { return cmp < 0 }, ">="},
+		{tagMax, func(cmp int) bool { return cmp > 0 }, "<="},
+		{tagExclusiveMin, func(cmp int) bool { return cmp <= 0 }, ">"},
+		{tagExclusiveMax, func(cmp int) bool { return cmp >= 0 }, "<"},
+	}
+	for _, b := range bounds {
+		tag := sf.Tag.Get(b.tag)
+		if tag == "" {
+			continue
+		}
+		bound, err := ParseDecimal(tag)
+		if err != nil {
+			return fmt.Errorf("invalid %s value: %s", b.tag, tag)
+		}
+		if b.fail(d.Cmp(bound)) {
+			return validationErrorf("value %s must be %s %s", d, b.msg, bound)
+		}
+	}
+	if tag := sf.Tag.Get(tagMultipleOf); tag != "" {
+		step, err := ParseDecimal(tag)
+		if err != nil || step.IsZero() {
+			return fmt.Errorf("invalid multipleOf value: %s", tag)
+		}
+		if !new(big.Rat).Quo(d.Rat(), step.Rat()).IsInt() {
+			return validationErrorf("value %s is not a multiple of %s", d, step)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type decimalInvoice struct {
+	Amount   Decimal   `json:"amount" min:"0.01" max:"10000" multipleOf:"0.01" required:"true"`
+	Discount *Decimal  `json:"discount,omitempty" exclusiveMax:"100"`
+	Lines    []Decimal `json:"lines,omitempty"`
+}
+
+type decimalFilter struct {
+	MinTotal Decimal `query:"min_total"`
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		in, want string
+		scale    int32
+	}{
+		{"12.30", "12.30", 2},
+		{"-0.5", "-0.5", 1},
+		{"+7", "7", 0},
+		{"0.001", "0.001", 3},
+		{"-0.05", "-0.05", 2},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, d.String())
+		assert.Equal(t, tt.scale, d.Scale())
+	}
+	for _, in := range []string{"", "1e3", "1.", ".5", "12.30 USD", "NaN"} {
+		_, err := ParseDecimal(in)
+		assert.Error(t, err, in)
+	}
+
+	a, b := MustParseDecimal("0.10"), MustParseDecimal("0.2")
+	assert.Equal(t, "0.30", a.Add(b).String())
+	assert.Equal(t, "-0.10", a.Sub(b).String())
+	assert.True(t, a.Add(b).Equal(MustParseDecimal("0.3")))
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, "12.30", NewDecimal(1230, 2).String())
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.True(t, Decimal{}.IsZero())
+
+	var inv decimalInvoice
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":19.99,"discount":"2.50","lines":["9.99","10"]}`), &inv))
+	assert.Equal(t, "19.99", inv.Amount.String())
+	assert.Equal(t, "2.50", inv.Discount.String())
+	out, err := json.Marshal(inv)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"19.99","discount":"2.50","lines":["9.99","10"]}`, string(out))
+}
+
+func TestDecimal_Binding(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Post("/invoices", Handle(func(c *Context, in *decimalInvoice) error {
+		return c.OK(M{"total": in.Amount.Sub(*in.Discount)})
+	}))
+	ts.Get("/invoices", Handle(func(c *Context, in *decimalFilter) error {
+		return c.OK(M{"min_total": in.MinTotal})
+	}))
+
+	okapitest.POST(t, ts.BaseURL+"/invoices").
+		JSONBody(M{"amount": "100.10", "discount": "0.10"}).
+		ExpectStatusOK().
+		ExpectJSONPath("total", "100.00")
+	okapitest.GET(t, ts.BaseURL+"/invoices").
+		QueryParam("min_total", "0.1").
+		ExpectStatusOK().
+		ExpectJSONPath("min_total", "0.1")
+
+	for _, body := range []M{
+		{"amount": "0.001"},
+		{"amount": "10000.01"},
+		{"amount": "5", "discount": "100"},
+	} {
+		okapitest.POST(t, ts.BaseURL+"/invoices").
+			JSONBody(body).
+			ExpectStatus(http.StatusBadRequest)
+	}
+	okapitest.GET(t, ts.BaseURL+"/invoices").
+		QueryParam("min_total", "1,5").
+		ExpectStatus(http.StatusBadRequest).
+		ExpectBodyContains("invalid decimal value")
+}
+
+func TestDecimal_OpenAPI(t *testing.T) {
+	o := New()
+	o.WithOpenAPIDocs(OpenAPI{Title: "Billing", Version: "1.0.0", License: License{Name: "MIT"}, Servers: Servers{{URL: "http://localhost:8080"}}})
+	o.Post("/invoices", anyHandler, DocRequestBody(&decimalInvoice{}))
+	o.Get("/invoices", anyHandler, Request(&decimalFilter{}))
+	o.buildOpenAPISpec()
+
+	model := o.openapiSpec.Components.Schemas["decimalInvoice"].Value
+	require.NotNil(t, model)
+	amount := model.Properties["amount"].Value
+	assert.True(t, amount.Type.Is("string"))
+	assert.Equal(t, "decimal", amount.Format)
+	assert.Equal(t, decimalPattern, amount.Pattern)
+	assert.Equal(t, "decimal", model.Properties["lines"].Value.Items.Value.Format)
+
+	params := o.openapiSpec.Paths.Find("/invoices").Get.Parameters
+	require.Len(t, params, 1)
+	assert.Equal(t, "decimal", params[0].Value.Schema.Value.Format)
+	spec, err := json.Marshal(o.openapiSpec)
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(string(spec), `"Decimal"`), "Decimal must not be documented as an object")
+
+	validateOpenAPIDoc(t, o.openapiSpec)
+}
@@ -0,0 +1,140 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+)
+
+// Sunset marks the Route as deprecated and announces the date after which it
+// will be removed.
+//
+// Responses of the route carry a Sunset header (RFC 8594) with the date, next to
+// the Deprecation header, and the OpenAPI operation gets an x-sunset extension.
+func (r *Route) Sunset(date time.Time) *Route {
+	r.deprecated = true
+	r.sunset = date
+	return r
+}
+
+// SuccessorRoute marks the Route as deprecated in favor of the route called
+// name (see Route.WithName).
+//
+// Responses of the route carry a Link header with rel="successor-version"
+// pointing to the successor, whose path parameters are filled from the current
+// request, and the OpenAPI operation gets an x-successor-version extension.
+func (r *Route) SuccessorRoute(name string) *Route {
+	r.deprecated = true
+	r.successor = name
+	return r
+}
+
+// Sunset is the RouteOption form of Route.Sunset.
+func Sunset(date time.Time) RouteOption {
+	return func(r *Route) {
+		r.Sunset(date)
+	}
+}
+
+// SuccessorRoute is the RouteOption form of Route.SuccessorRoute.
+func SuccessorRoute(name string) RouteOption {
+	return func(r *Route) {
+		r.SuccessorRoute(name)
+	}
+}
+
+// applyDeprecationHeaders writes the Deprecation, Sunset and successor Link
+// headers of a deprecated route.
+func (o *Okapi) applyDeprecationHeaders(route *Route, h http.Header, req *http.Request) {
+	if !route.deprecated {
+		return
+	}
+	h.Set("Deprecation", "true")
+	if !route.sunset.IsZero() {
+		h.Set("Sunset", route.sunset.UTC().Format(http.TimeFormat))
+	}
+	if link := o.successorLink(route, req); link != "" {
+		h.Add("Link", "<"+link+`>; rel="successor-version"`)
+	}
+}
+
+// successorLink returns the path of the successor of route, with the path
+// parameters it shares with the current request filled in. It returns an empty
+// string when there is no successor or a parameter cannot be resolved.
+func (o *Okapi) successorLink(route *Route, req *http.Request) string {
+	if route.successor == "" {
+		return ""
+	}
+	successor := o.namedRoute(route.successor)
+	if successor == nil {
+		return ""
+	}
+	vars := mux.Vars(req)
+	var b strings.Builder
+	path := successor.Path
+	for {
+		start := strings.IndexByte(path, '{')
+		if start < 0 {
+			b.WriteString(path)
+			break
+		}
+		end := closingBrace(path, start)
+		if end < 0 {
+			return ""
+		}
+		param, _, _ := strings.Cut(path[start+1:end], ":")
+		value, ok := vars[param]
+		if !ok {
+			return ""
+		}
+		b.WriteString(path[:start])
+		b.WriteString(url.PathEscape(value))
+		path = path[end+1:]
+	}
+	return b.String()
+}
+
+// deprecationExtensions documents the sunset date and successor of a
+// deprecated route on its operation.
+func (o *Okapi) deprecationExtensions(op *openapi3.Operation, r *Route) {
+	if !r.deprecated || (r.sunset.IsZero() && r.successor == "") {
+		return
+	}
+	if op.Extensions == nil {
+		op.Extensions = make(map[string]any)
+	}
+	if !r.sunset.IsZero() {
+		op.Extensions["x-sunset"] = r.sunset.UTC().Format(time.RFC3339)
+	}
+	if successor := o.namedRoute(r.successor); successor != nil {
+		op.Extensions["x-successor-version"] = successor.Method + " " + successor.Path
+	}
+}
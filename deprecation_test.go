@@ -0,0 +1,88 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestRouteDeprecation(t *testing.T) {
+	sunset := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+	o := New()
+	o.Get("/v2/books/{id}", anyHandler).WithName("book-v2")
+	o.Get("/v1/books/{id}", anyHandler).Sunset(sunset).SuccessorRoute("book-v2")
+	o.Get("/v1/authors", anyHandler, Deprecated())
+	o.Get("/v1/reviews", anyHandler, SuccessorRoute("reviews-v2"))
+	o.Get("/v2/authors", anyHandler)
+	ts := NewTestServerWithOkapi(t, o)
+
+	t.Run("headers", func(t *testing.T) {
+		okapitest.GET(t, ts.BaseURL+"/v1/books/42").ExpectStatusOK().
+			ExpectHeader("Deprecation", "true").
+			ExpectHeader("Sunset", "Fri, 01 Jan 2027 00:00:00 GMT").
+			ExpectHeader("Link", `</v2/books/42>; rel="successor-version"`)
+		okapitest.GET(t, ts.BaseURL+"/v1/authors").ExpectStatusOK().ExpectHeader("Deprecation", "true")
+
+		resp, err := http.Get(ts.BaseURL + "/v1/reviews")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.Header.Get("Deprecation") != "true" || resp.Header.Get("Link") != "" {
+			t.Errorf("unknown successor: got Deprecation=%q Link=%q", resp.Header.Get("Deprecation"), resp.Header.Get("Link"))
+		}
+		resp, err = http.Get(ts.BaseURL + "/v2/authors")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		for _, h := range []string{"Deprecation", "Sunset", "Link"} {
+			if v := resp.Header.Get(h); v != "" {
+				t.Errorf("active route: unexpected %s header %q", h, v)
+			}
+		}
+	})
+
+	t.Run("openapi", func(t *testing.T) {
+		o.buildOpenAPISpec()
+		op := o.openapiSpec.Paths.Find("/v1/books/{id}").Get
+		if !op.Deprecated {
+			t.Error("expected the operation to be deprecated")
+		}
+		if got := op.Extensions["x-sunset"]; got != "2027-01-01T00:00:00Z" {
+			t.Errorf("x-sunset = %v", got)
+		}
+		if got := op.Extensions["x-successor-version"]; got != "GET /v2/books/{id}" {
+			t.Errorf("x-successor-version = %v", got)
+		}
+		if ext := o.openapiSpec.Paths.Find("/v1/authors").Get.Extensions; len(ext) != 0 {
+			t.Errorf("expected no extensions without sunset or successor, got %v", ext)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"sync"
+)
+
+// disconnectHooks holds the callbacks registered with Context.OnDisconnect.
+type disconnectHooks struct {
+	mu    sync.Mutex
+	done  bool
+	stops []func() bool
+}
+
+// OnDisconnect registers fn to run, in its own goroutine, when the client goes
+// away before the handler completes, so long-running work such as report
+// generation can be abandoned early. fn is not called once the request is
+// answered, nor when a route deadline expires. The returned stop function
+// unregisters fn and reports whether it did so before fn started.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	c.OnDisconnect(cancel)
+//	return c.OK(buildReport(ctx))
+func (c *Context) OnDisconnect(fn func()) (stop func() bool) {
+	ctx := c.clientContext()
+	if c.disconnects == nil {
+		c.disconnects = &disconnectHooks{}
+	}
+	h := c.disconnects
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.done {
+		return func() bool { return false }
+	}
+	stop = context.AfterFunc(ctx, func() {
+		h.mu.Lock()
+		done := h.done
+		h.mu.Unlock()
+		if !done {
+			fn()
+		}
+	})
+	h.stops = append(h.stops, stop)
+	return stop
+}
+
+// Disconnected reports whether the client went away while the request is
+// being handled.
+func (c *Context) Disconnected() bool {
+	ctx := c.clientContext()
+	if c.disconnects != nil {
+		c.disconnects.mu.Lock()
+		defer c.disconnects.mu.Unlock()
+		if c.disconnects.done {
+			return false
+		}
+	}
+	return ctx.Err() != nil
+}
+
+// clientContext returns the context net/http cancels when the client
+// disconnects, ignoring the deadlines added by middlewares.
+func (c *Context) clientContext() context.Context {
+	if c.clientCtx != nil {
+		return c.clientCtx
+	}
+	if c.request != nil {
+		return c.request.Context()
+	}
+	return context.Background()
+}
+
+// completeDisconnectHooks unregisters the OnDisconnect callbacks once the
+// request is answered.
+func (c *Context) completeDisconnectHooks() {
+	h := c.disconnects
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.done = true
+	for _, stop := range h.stops {
+		stop()
+	}
+	h.stops = nil
+}
@@ -0,0 +1,85 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestContext_OnDisconnect(t *testing.T) {
+	ts := NewTestServer(t)
+	started := make(chan struct{}, 1)
+	result := make(chan string, 1)
+	called := make(chan struct{}, 1)
+
+	ts.Get("/report", func(c *Context) error {
+		disconnected := make(chan struct{})
+		c.OnDisconnect(func() { close(disconnected) })
+		started <- struct{}{}
+		select {
+		case <-disconnected:
+			if !c.Disconnected() {
+				result <- "Disconnected() is false"
+			} else if c.Context().Err() == nil {
+				result <- "request context not canceled"
+			} else {
+				result <- "disconnected"
+			}
+		case <-time.After(2 * time.Second):
+			result <- "timeout"
+		}
+		return c.OK(M{"ok": true})
+	}, MaxResponseBytes(1<<10))
+
+	ts.Get("/done", func(c *Context) error {
+		c.OnDisconnect(func() { called <- struct{}{} })
+		return c.OK(M{"ok": true})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.BaseURL+"/report", nil)
+	go func() {
+		<-started
+		cancel()
+	}()
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		_ = resp.Body.Close()
+	}
+	if got := <-result; got != "disconnected" {
+		t.Errorf("expected the disconnect hook to run, got %s", got)
+	}
+
+	okapitest.GET(t, ts.BaseURL+"/done").ExpectStatusOK()
+	select {
+	case <-called:
+		t.Error("disconnect hook called after the response")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
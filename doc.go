@@ -61,8 +61,11 @@ package okapi
 
 import (
 	_ "embed"
+	"fmt"
 	"html/template"
 	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
 )
 
 // okapiFavicon is the default favicon served for the documentation UIs at
@@ -95,7 +98,7 @@ const (
     </style>
   </head>
   <body>
-    <redoc spec-URL='/openapi.json'></redoc>
+    <redoc spec-URL='{{.SpecURL}}'></redoc>
     <script src="https://cdn.redoc.ly/redoc/latest/bundles/redoc.standalone.js"> </script>
   </body>
 </html>
@@ -119,7 +122,7 @@ const (
 <script>
   window.onload = () => {
     window.ui = SwaggerUIBundle({
-      url: '/openapi.json',
+      url: '{{.SpecURL}}',
       dom_id: '#swagger-ui',
       deepLinking: true,
     });
@@ -146,7 +149,7 @@ const (
     <!-- Initialize the Scalar API Reference -->
     <script>
       Scalar.createApiReference('#app', {
-        url: '/openapi.json',
+        url: '{{.SpecURL}}',
       });
     </script>
   </body>
@@ -201,7 +204,13 @@ func (o *Okapi) docData() M {
 	if favicon == "" {
 		favicon = docFaviconPath
 	}
-	return M{"Title": o.openAPI.Title, "Favicon": favicon}
+	return M{"Title": o.openAPI.Title, "Favicon": favicon, "SpecURL": openApiDocPath}
+}
+
+// namedSpec holds the OpenAPI documents generated for a named group of routes.
+type namedSpec struct {
+	spec   *openapi3.T // OpenAPI 3.0
+	spec31 *openapi3.T // OpenAPI 3.1
 }
 
 // registerDocRoutes registers the OpenAPI documentation routes for the Okapi instance.
@@ -212,13 +221,6 @@ func (o *Okapi) registerDocRoutes() {
 	o.docRoutesRegistered = true
 	o.openApiEnabled = true
 
-	enabled := func(c *Context) error {
-		if !o.openApiEnabled {
-			return c.AbortNotFound("Not Found")
-		}
-		return c.Next()
-	}
-
 	strict := func(c *Context) error {
 		if o.openAPI.StrictDocUI {
 			return c.AbortNotFound("Not Found")
@@ -226,46 +228,123 @@ func (o *Okapi) registerDocRoutes() {
 		return c.Next()
 	}
 
-	doc := func(path string, h HandlerFunc, mw ...Middleware) {
-		route := o.Get(path, h)
-		route.internalRoute().Hide() // Hide the route from the OpenAPI documentation
-		route.Use(mw...)
-	}
-
 	// Default favicon endpoint, suppressed when a custom favicon is configured.
-	doc(docFaviconPath, func(c *Context) error {
+	o.docRoute(docFaviconPath, func(c *Context) error {
 		if o.openAPI.Favicon != "" {
 			return c.AbortNotFound("Not Found")
 		}
 		return c.Data(http.StatusOK, "image/png", okapiFavicon)
-	}, enabled)
+	})
 	// Default OpenAPI routes serve the latest version (3.1).
-	doc(openApiDocPath, func(c *Context) error {
+	o.docRoute(openApiDocPath, func(c *Context) error {
 		return c.JSON(http.StatusOK, o.openapiSpec31)
-	}, enabled)
-	doc(openApiYamlPath, func(c *Context) error {
+	})
+	o.docRoute(openApiYamlPath, func(c *Context) error {
 		return c.YAML(http.StatusOK, o.openapiSpec31)
-	}, enabled)
+	})
 	// Version-pinned OpenAPI 3.0 routes
-	doc(openApiDocPath30, func(c *Context) error {
+	o.docRoute(openApiDocPath30, func(c *Context) error {
 		return c.JSON(http.StatusOK, o.openapiSpec)
-	}, enabled)
-	doc(openApiYamlPath30, func(c *Context) error {
+	})
+	o.docRoute(openApiYamlPath30, func(c *Context) error {
 		return c.YAML(http.StatusOK, o.openapiSpec)
-	}, enabled)
+	})
 	// Main docs route.
-	doc(openApiDocPrefix, func(c *Context) error {
+	o.docRoute(openApiDocPrefix, func(c *Context) error {
 		return c.renderHTML(http.StatusOK, o.docsTemplate(), o.docData())
-	}, enabled)
+	})
 
 	// Dedicated UI routes additionally respect StrictDocUI.
-	doc(docSwaggerPath, func(c *Context) error {
+	o.docRoute(docSwaggerPath, func(c *Context) error {
 		return c.renderHTML(http.StatusOK, swaggerTemplate, o.docData())
-	}, enabled, strict)
-	doc(docRedocPath, func(c *Context) error {
+	}, strict)
+	o.docRoute(docRedocPath, func(c *Context) error {
 		return c.renderHTML(http.StatusOK, redocTemplate, o.docData())
-	}, enabled, strict)
-	doc(docScalarPath, func(c *Context) error {
+	}, strict)
+	o.docRoute(docScalarPath, func(c *Context) error {
 		return c.renderHTML(http.StatusOK, scalarTemplate, o.docData())
-	}, enabled, strict)
+	}, strict)
+}
+
+// registerNamedDocRoutes registers the spec and UI routes of a named OpenAPI
+// document: /openapi/{name}.json, /openapi/{name}.yaml, their -3.0 variants,
+// and /docs/{name}.
+func (o *Okapi) registerNamedDocRoutes(name string) {
+	if o.namedSpecRoutes[name] {
+		return
+	}
+	if o.namedSpecRoutes == nil {
+		o.namedSpecRoutes = make(map[string]bool)
+	}
+	o.namedSpecRoutes[name] = true
+
+	base := "/openapi/" + name
+	serve := func(write func(c *Context, ns *namedSpec) error) HandlerFunc {
+		return func(c *Context) error {
+			ns := o.namedSpecs[name]
+			if ns == nil {
+				return c.AbortNotFound("Not Found")
+			}
+			return write(c, ns)
+		}
+	}
+	o.docRoute(base+".json", serve(func(c *Context, ns *namedSpec) error {
+		return c.JSON(http.StatusOK, ns.spec31)
+	}))
+	o.docRoute(base+".yaml", serve(func(c *Context, ns *namedSpec) error {
+		return c.YAML(http.StatusOK, ns.spec31)
+	}))
+	o.docRoute(base+"-3.0.json", serve(func(c *Context, ns *namedSpec) error {
+		return c.JSON(http.StatusOK, ns.spec)
+	}))
+	o.docRoute(base+"-3.0.yaml", serve(func(c *Context, ns *namedSpec) error {
+		return c.YAML(http.StatusOK, ns.spec)
+	}))
+	o.docRoute(openApiDocPrefix+"/"+name, serve(func(c *Context, _ *namedSpec) error {
+		data := o.docData()
+		data["Title"] = fmt.Sprintf("%s (%s)", o.openAPI.Title, name)
+		data["SpecURL"] = base + ".json"
+		return c.renderHTML(http.StatusOK, o.docsTemplate(), data)
+	}))
+}
+
+// docRoute registers a hidden documentation route, guarded by the docs
+// enablement flag and access control, followed by any extra middlewares.
+func (o *Okapi) docRoute(path string, h HandlerFunc, mw ...Middleware) {
+	route := o.registerRoute(methodGet, path, nil, h)
+	route.internalRoute().Hide() // Hide the route from the OpenAPI documentation
+	route.Use(o.docEnabled, o.docAccess)
+	route.Use(mw...)
+}
+
+// docEnabled responds 404 when the documentation is disabled.
+func (o *Okapi) docEnabled(c *Context) error {
+	if !o.openApiEnabled {
+		return c.AbortNotFound("Not Found")
+	}
+	return c.Next()
+}
+
+// docAccess runs the configured documentation guards, resolved per request so
+// that later WithOpenAPIDocs calls take effect.
+func (o *Okapi) docAccess(c *Context) error {
+	guards := o.docMiddlewares()
+	if len(guards) == 0 {
+		return c.Next()
+	}
+	handlers := make([]HandlerFunc, 0, len(c.handlers)+len(guards))
+	handlers = append(handlers, c.handlers[:c.index+1]...)
+	handlers = append(handlers, guards...)
+	handlers = append(handlers, c.handlers[c.index+1:]...)
+	c.handlers = handlers
+	return c.Next()
+}
+
+// docMiddlewares returns the middlewares guarding the documentation routes.
+func (o *Okapi) docMiddlewares() []Middleware {
+	var guards []Middleware
+	if o.openAPI.BasicAuth != nil {
+		guards = append(guards, o.openAPI.BasicAuth.Middleware)
+	}
+	return append(guards, o.openAPI.Middlewares...)
 }
@@ -103,3 +103,32 @@ func TestWithDocUIAfterDocs(t *testing.T) {
 		ExpectStatusOK().
 		ExpectBodyContains("@scalar/api-reference")
 }
+
+// TestDocsAccessControl verifies that documentation routes honor the configured
+// BasicAuth and Middlewares, while application routes stay unaffected.
+func TestDocsAccessControl(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/", func(c *Context) error {
+		return c.Text(http.StatusOK, "Hello World!")
+	})
+	ts.WithOpenAPIDocs(OpenAPI{
+		BasicAuth: &BasicAuth{Username: "admin", Password: "secret"},
+		Middlewares: []Middleware{func(c *Context) error {
+			c.SetHeader("X-Docs-Guard", "ok")
+			return c.Next()
+		}},
+	})
+
+	for _, path := range []string{"/docs", "/openapi.json", "/openapi-3.0.yaml", "/swagger"} {
+		okapitest.GET(t, ts.BaseURL+path).ExpectStatusUnauthorized()
+		okapitest.GET(t, ts.BaseURL+path).
+			SetBasicAuth("admin", "secret").
+			ExpectStatusOK().
+			ExpectHeader("X-Docs-Guard", "ok")
+	}
+	okapitest.GET(t, ts.BaseURL+"/").ExpectStatusOK()
+
+	// Reconfiguring the docs without guards opens them again.
+	ts.WithOpenAPIDocs(OpenAPI{})
+	okapitest.GET(t, ts.BaseURL+"/openapi.json").ExpectStatusOK()
+}
@@ -0,0 +1,141 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const constZIP = "application/zip"
+
+// Attachment marks the response as a download saved under filename.
+func (c *Context) Attachment(filename string) {
+	c.SetHeader("Content-Disposition", contentDisposition("attachment", filename))
+}
+
+// Inline marks the response to be displayed by the browser, suggesting filename when saved.
+func (c *Context) Inline(filename string) {
+	c.SetHeader("Content-Disposition", contentDisposition("inline", filename))
+}
+
+// Stream writes the response body as it is produced by write, without buffering it,
+// which suits generated documents such as PDFs:
+//
+//	o.Get("/invoices/:id.pdf", func(c *okapi.Context) error {
+//	  c.Attachment("invoice.pdf")
+//	  return c.Stream(http.StatusOK, "application/pdf", func(w io.Writer) error {
+//	    return renderInvoice(w, c.Param("id"))
+//	  })
+//	})
+//
+// Writes are flushed according to SetFlushInterval, and each of them extends the
+// connection's write deadline by the server's WriteTimeout, so long streams are
+// not cut off.
+//
+// Once write starts, the status line is on the wire: a failure aborts the stream
+// and is returned, but no error body is appended to the partial output.
+func (c *Context) Stream(code int, contentType string, write func(w io.Writer) error) error {
+	if c.committed() {
+		c.logDiscardedWrite(code)
+		return nil
+	}
+	c.response.Header().Set(constContentTypeHeader, contentType)
+	c.response.WriteHeader(code)
+	if c.request != nil && c.request.Method == http.MethodHead {
+		return nil
+	}
+	sw := &streamWriter{c: c, interval: c.streamFlushInterval(contentType)}
+	err := write(sw)
+	sw.stop()
+	if err != nil {
+		c.Logger().Error("[okapi] response stream aborted", "error", err, "path", c.request.URL.Path)
+		return err
+	}
+	if flusher, ok := c.response.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// Zip streams a zip archive named filename while files adds entries to it, so bulk
+// downloads need neither temporary files nor an in-memory copy of the archive.
+//
+//	return c.Zip(http.StatusOK, "photos.zip", func(zw *zip.Writer) error {
+//	  for _, p := range photos {
+//	    w, err := zw.Create(p.Name)
+//	    if err != nil {
+//	      return err
+//	    }
+//	    if err := p.WriteTo(w); err != nil {
+//	      return err
+//	    }
+//	  }
+//	  return nil
+//	})
+//
+// When files fails the archive is left without its central directory, so clients
+// see a corrupt download rather than a silently incomplete one.
+func (c *Context) Zip(code int, filename string, files func(zw *zip.Writer) error) error {
+	if filename != "" {
+		if !strings.HasSuffix(strings.ToLower(filename), ".zip") {
+			filename += ".zip"
+		}
+		c.Attachment(filename)
+	}
+	return c.Stream(code, constZIP, func(w io.Writer) error {
+		zw := zip.NewWriter(w)
+		if err := files(zw); err != nil {
+			return fmt.Errorf("zip: %w", err)
+		}
+		return zw.Close()
+	})
+}
+
+// contentDisposition formats a Content-Disposition value, encoding filename per
+// RFC 6266 when it cannot be sent as a plain quoted string.
+func contentDisposition(kind, filename string) string {
+	if filename == "" {
+		return kind
+	}
+	plain := true
+	for _, r := range filename {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return fmt.Sprintf("%s; filename=%q", kind, filename)
+	}
+	if v := mime.FormatMediaType(kind, map[string]string{"filename": filename}); v != "" {
+		return v
+	}
+	return kind
+}
@@ -0,0 +1,99 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext_Zip(t *testing.T) {
+	ctx, rec := NewTestContext(http.MethodGet, "/export", nil)
+	err := ctx.Zip(http.StatusOK, "export", func(zw *zip.Writer) error {
+		for _, name := range []string{"a.txt", "dir/b.txt"} {
+			w, err := zw.Create(name)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, "content of "+name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, constZIP, rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="export.zip"`, rec.Header().Get("Content-Disposition"))
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	require.Len(t, zr.File, 2)
+	f, err := zr.Open("dir/b.txt")
+	require.NoError(t, err)
+	b, _ := io.ReadAll(f)
+	assert.Equal(t, "content of dir/b.txt", string(b))
+
+	t.Run("failure leaves a corrupt archive", func(t *testing.T) {
+		ctx, rec := NewTestContext(http.MethodGet, "/export", nil)
+		boom := errors.New("boom")
+		err := ctx.Zip(http.StatusOK, "", func(zw *zip.Writer) error {
+			w, _ := zw.Create("a.txt")
+			_, _ = io.WriteString(w, "partial")
+			return boom
+		})
+		assert.ErrorIs(t, err, boom)
+		assert.Empty(t, rec.Header().Get("Content-Disposition"))
+		assert.NotContains(t, rec.Body.String(), "boom")
+		_, err = zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+		assert.Error(t, err)
+	})
+}
+
+func TestContext_Stream(t *testing.T) {
+	ctx, rec := NewTestContext(http.MethodGet, "/invoice", nil)
+	ctx.Inline("facture-été.pdf")
+	err := ctx.Stream(http.StatusOK, "application/pdf", func(w io.Writer) error {
+		_, err := io.WriteString(w, "%PDF-1.7")
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "inline; filename*=utf-8''facture-%C3%A9t%C3%A9.pdf", rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, "%PDF-1.7", rec.Body.String())
+
+	head, rec := NewTestContext(http.MethodHead, "/invoice", nil)
+	require.NoError(t, head.Stream(http.StatusOK, "application/pdf", func(w io.Writer) error {
+		t.Error("write must not run for HEAD requests")
+		return nil
+	}))
+	assert.Empty(t, rec.Body.String())
+}
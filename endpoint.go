@@ -0,0 +1,43 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import "github.com/jkaninda/okapi/client"
+
+// ClientEndpoint returns a typed client endpoint calling r, whose input I and
+// output O are usually the structs the route binds and returns. Inputs are
+// encoded the way Context.Bind decodes them, see client.RequestBuilder.Input.
+//
+// It lets internal services build typed clients from the routes they register,
+// without generating code:
+//
+//	getBook := app.Get("/books/{id}", okapi.H(getBookHandler))
+//	endpoint := okapi.ClientEndpoint[GetBookInput, Book](getBook)
+//
+//	books := client.New("http://books.internal")
+//	book, err := endpoint.Call(ctx, books, &GetBookInput{ID: 42})
+func ClientEndpoint[I, O any](r *Route) client.Endpoint[I, O] {
+	return client.Endpoint[I, O]{Method: r.Method, Path: r.Path}
+}
@@ -0,0 +1,93 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jkaninda/okapi/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type endpointBookInput struct {
+	ID      int      `path:"id"`
+	Tags    []string `query:"tags"`
+	Tenant  string   `header:"X-Tenant" required:"true"`
+	Session string   `cookie:"session"`
+	Body    struct {
+		Name  string `json:"name" required:"true"`
+		Price int    `json:"price"`
+	}
+}
+
+type endpointBookOutput struct {
+	Status  int    `status:"true"`
+	Version string `header:"X-Version"`
+	Body    struct {
+		ID      int      `json:"id"`
+		Name    string   `json:"name"`
+		Tags    []string `json:"tags"`
+		Tenant  string   `json:"tenant"`
+		Session string   `json:"session"`
+	}
+}
+
+func TestClientEndpoint(t *testing.T) {
+	ts := NewTestServer(t)
+	route := ts.Put("/books/{id}", H(func(c *Context, in *endpointBookInput) error {
+		out := endpointBookOutput{Status: http.StatusAccepted, Version: "v2"}
+		out.Body.ID = in.ID
+		out.Body.Name = in.Body.Name
+		out.Body.Tags = in.Tags
+		out.Body.Tenant = in.Tenant
+		out.Body.Session = in.Session
+		return c.Respond(out)
+	}))
+
+	books := client.New(ts.BaseURL)
+	endpoint := ClientEndpoint[endpointBookInput, endpointBookOutput](route)
+
+	in := &endpointBookInput{ID: 42, Tags: []string{"go", "web"}, Tenant: "acme", Session: "s1"}
+	in.Body.Name = "Okapi"
+	out, err := endpoint.Call(context.Background(), books, in)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, out.Status)
+	assert.Equal(t, "v2", out.Version)
+	assert.Equal(t, 42, out.Body.ID)
+	assert.Equal(t, "Okapi", out.Body.Name)
+	assert.Equal(t, []string{"go", "web"}, out.Body.Tags)
+	assert.Equal(t, "acme", out.Body.Tenant)
+	assert.Equal(t, "s1", out.Body.Session)
+
+	// Missing required header, rejected by the binder
+	_, err = endpoint.Call(context.Background(), books, &endpointBookInput{ID: 1})
+	var httpErr *client.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+}
@@ -0,0 +1,111 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FromEnv configures the instance from environment variables, 12-factor style,
+// so containerized deployments need no configuration code:
+//
+//	PORT        listen port, e.g. 8080
+//	HOST        listen host, default all interfaces (0.0.0.0)
+//	LOG_FORMAT  "json" for JSON logs on stdout (see WithJSONLogger), "text" for text logs on stdout
+//	DEBUG       enables debug mode when true
+//
+// Unset variables leave the current settings unchanged, and invalid values are
+// logged and ignored. Options applied after FromEnv take precedence.
+//
+// Example:
+//
+//	o := okapi.New(okapi.FromEnv())
+func FromEnv() OptionFunc {
+	return func(o *Okapi) {
+		if debug, ok := os.LookupEnv("DEBUG"); ok {
+			enabled, err := strconv.ParseBool(debug)
+			if err != nil {
+				o.logger.Warn("Invalid DEBUG environment variable", "value", debug)
+			} else if enabled {
+				WithDebug()(o)
+			}
+		}
+		switch format := strings.ToLower(os.Getenv("LOG_FORMAT")); format {
+		case "":
+		case "json":
+			WithJSONLogger()(o)
+		case "text":
+			o.logger = slog.New(slog.NewTextHandler(os.Stdout, o.logOptions()))
+		default:
+			o.logger.Warn("Invalid LOG_FORMAT environment variable", "value", format)
+		}
+		host, port, err := net.SplitHostPort(o.server.Addr)
+		if err != nil {
+			host, port = "", strconv.Itoa(defaultPort)
+		}
+		if v := os.Getenv("PORT"); v != "" {
+			if p, err := strconv.Atoi(v); err != nil || p <= 0 || p > 65535 {
+				o.logger.Warn("Invalid PORT environment variable", "value", v)
+			} else {
+				port = v
+			}
+		}
+		if v, ok := os.LookupEnv("HOST"); ok {
+			host = v
+		}
+		o.server.Addr = net.JoinHostPort(host, port)
+	}
+}
+
+// WithJSONLogger logs as JSON to stdout, the format expected by container log
+// collectors. Debug messages are included in debug mode.
+func WithJSONLogger() OptionFunc {
+	return func(o *Okapi) {
+		o.logger = slog.New(slog.NewJSONHandler(os.Stdout, o.logOptions()))
+	}
+}
+
+// FromEnv configures the instance from environment variables, see FromEnv.
+func (o *Okapi) FromEnv() *Okapi {
+	return o.apply(FromEnv())
+}
+
+// WithJSONLogger logs as JSON to stdout.
+func (o *Okapi) WithJSONLogger() *Okapi {
+	return o.apply(WithJSONLogger())
+}
+
+// logOptions returns the handler options of the stdout logger presets.
+func (o *Okapi) logOptions() *slog.HandlerOptions {
+	level := slog.LevelInfo
+	if o.debug {
+		level = slog.LevelDebug
+	}
+	return &slog.HandlerOptions{Level: level}
+}
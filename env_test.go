@@ -0,0 +1,76 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		o := New(FromEnv())
+		assert.Equal(t, ":8080", o.server.Addr)
+		assert.False(t, o.debug)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		t.Setenv("PORT", "9090")
+		t.Setenv("HOST", "0.0.0.0")
+		t.Setenv("LOG_FORMAT", "JSON")
+		t.Setenv("DEBUG", "true")
+		o := New(FromEnv())
+		assert.Equal(t, "0.0.0.0:9090", o.server.Addr)
+		assert.True(t, o.debug)
+		assert.IsType(t, &slog.JSONHandler{}, o.logger.Handler())
+		assert.True(t, o.logger.Enabled(context.Background(), slog.LevelDebug))
+	})
+
+	t.Run("keeps address", func(t *testing.T) {
+		t.Setenv("PORT", "9090")
+		o := New(WithAddr("127.0.0.1:3000")).FromEnv()
+		assert.Equal(t, "127.0.0.1:9090", o.server.Addr)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		t.Setenv("PORT", "http")
+		t.Setenv("DEBUG", "maybe")
+		t.Setenv("LOG_FORMAT", "xml")
+		o := New(FromEnv())
+		assert.Equal(t, ":8080", o.server.Addr)
+		assert.False(t, o.debug)
+		assert.Equal(t, slog.Default(), o.logger)
+	})
+
+	t.Run("text logs", func(t *testing.T) {
+		t.Setenv("LOG_FORMAT", "text")
+		o := New(FromEnv())
+		assert.IsType(t, &slog.TextHandler{}, o.logger.Handler())
+		assert.False(t, o.logger.Enabled(context.Background(), slog.LevelDebug))
+	})
+}
@@ -53,6 +53,34 @@ type ValidationErrorResponse struct {
 	Errors []ValidationError `json:"errors"`
 }
 
+// msgValidationFailed is the default message of validation error responses.
+const msgValidationFailed = "Validation failed"
+
+// RequiredFieldError is returned by the binder when a field tagged
+// `required:"true"` has no value.
+type RequiredFieldError struct {
+	// Field is the struct field name, prefixed by its parent for nested body fields.
+	Field string
+}
+
+func (e *RequiredFieldError) Error() string {
+	return fmt.Sprintf(MsgFieldRequired, e.Field)
+}
+
+// FieldError is returned by the binder when a field fails a validation
+// constraint such as `min`, `maxLength` or `pattern`.
+type FieldError struct {
+	// Field is the struct field name, prefixed by its parent for nested body fields.
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf(MsgFieldInvalid, e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
 // ProblemDetail represents RFC 7807 Problem Details for HTTP APIs
 // See: https://tools.ietf.org/html/rfc7807
 type ProblemDetail struct {
@@ -116,8 +144,20 @@ type ErrorHandlerConfig struct {
 	CustomFields map[string]any
 }
 
-// DefaultErrorHandler provides the standard error response format
+// DefaultErrorHandler provides the standard error response format.
+// ValidationErrors are written as a ValidationErrorResponse.
 func DefaultErrorHandler(c *Context, code int, message string, err error) error {
+	var verrs ValidationErrors
+	if errors.As(err, &verrs) {
+		return c.JSON(code, ValidationErrorResponse{
+			ErrorResponse: ErrorResponse{
+				Code:      code,
+				Message:   message,
+				Timestamp: time.Now(),
+			},
+			Errors: verrs,
+		})
+	}
 	details := ""
 	if err != nil {
 		details = err.Error()
@@ -167,6 +207,11 @@ func ProblemDetailErrorHandler(config *ErrorHandlerConfig) ErrorHandler {
 			problem.Extensions["timestamp"] = time.Now().Format(time.RFC3339)
 		}
 
+		var verrs ValidationErrors
+		if errors.As(err, &verrs) {
+			problem.Extensions["errors"] = verrs
+		}
+
 		// Add custom fields
 		for k, v := range config.CustomFields {
 			problem.Extensions[k] = v
@@ -283,6 +328,12 @@ func (c *Context) AbortWithError(code int, err error) error {
 
 // abortWithError writes a standardized error response with custom message using the configured error handler.
 func (c *Context) abortWithError(code int, msg string, err error) error {
+	c.abortErr = err
+	if code >= http.StatusInternalServerError && err != nil && c.okapi != nil && c.okapi.hideErrorDetails {
+		c.Logger().Error("[okapi] error details hidden from response", "status", code, "error", err,
+			"method", c.request.Method, "path", c.request.URL.Path)
+		err = nil
+	}
 	return c.getContextErrorHandler()(c, code, msg, err)
 }
 
@@ -317,6 +368,7 @@ func (c *Context) abortWithStatus(code int, defaultMsg string, msg string, err .
 	if len(msg) > 0 && msg != "" {
 		message = msg
 	}
+	message = c.T(message)
 
 	if len(err) > 0 && err[0] != nil {
 		internalErr = err[0]
@@ -542,7 +594,7 @@ func (c *Context) AbortValidationError(msg string, err ...error) error {
 // Note: This method always uses the default ValidationErrorResponse structure
 // regardless of custom error handlers, as it has a specific format for validation errors.
 func (c *Context) AbortValidationErrors(errors []ValidationError, msg ...string) error {
-	message := "Validation failed"
+	message := msgValidationFailed
 	if len(msg) > 0 && msg[0] != "" {
 		message = msg[0]
 	}
@@ -559,7 +611,7 @@ func (c *Context) AbortValidationErrors(errors []ValidationError, msg ...string)
 
 // AbortValidationErrorsWithProblemDetail writes validation errors as RFC 7807 Problem Details
 func (c *Context) AbortValidationErrorsWithProblemDetail(errors []ValidationError, msg ...string) error {
-	message := "Validation failed"
+	message := msgValidationFailed
 	if len(msg) > 0 && msg[0] != "" {
 		message = msg[0]
 	}
@@ -811,3 +863,67 @@ func IsServerError(code int) bool {
 func IsError(code int) bool {
 	return IsClientError(code) || IsServerError(code)
 }
+
+// ********** Error Hooks **********
+
+// ErrorHook is notified of an error response, see Okapi.OnError.
+type ErrorHook func(c *Context, status int, err error)
+
+// OnError registers hooks called once for every 4xx and 5xx response, whether
+// it comes from an Abort call, a handler error, a validation failure or a
+// request answered without a route (404, 405, rejections). err is the error
+// given to the Abort call or returned by the handler, or the status text.
+//
+// Hooks run after the response is written, on the request goroutine: hand
+// slow work, such as sending to an error tracker, to a goroutine. A panicking
+// hook is recovered and logged.
+//
+// Example:
+//
+//	o.OnError(func(c *okapi.Context, status int, err error) {
+//		if status >= 500 {
+//			sentry.CaptureException(err)
+//		}
+//	})
+func (o *Okapi) OnError(hooks ...ErrorHook) {
+	o.errorHooks = append(o.errorHooks, hooks...)
+}
+
+// reportError calls the OnError hooks when c was answered with an error
+// status, unless they were already called for the response.
+func (o *Okapi) reportError(c *Context, err error) {
+	status := c.response.StatusCode()
+	if len(o.errorHooks) == 0 || status < http.StatusBadRequest {
+		return
+	}
+	// The writers of a request are nested, e.g. route inside ServeHTTP: mark
+	// them all so the error is reported once.
+	var w http.ResponseWriter = c.response
+	for {
+		rw, ok := w.(*responseWriter)
+		if !ok {
+			break
+		}
+		if rw.errorReported {
+			return
+		}
+		rw.errorReported = true
+		w = rw.writer
+	}
+	if err == nil {
+		err = c.abortErr
+	}
+	if err == nil {
+		err = errors.New(http.StatusText(status))
+	}
+	for _, hook := range o.errorHooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					o.logger.Error("[okapi] error hook panicked", "panic", r)
+				}
+			}()
+			hook(c, status, err)
+		}()
+	}
+}
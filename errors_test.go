@@ -859,3 +859,55 @@ func TestProblemDetailMarshalJSON(t *testing.T) {
 		t.Errorf("Expected retry_after, got %v", result["retry_after"])
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Error hooks
+// ---------------------------------------------------------------------------
+
+func TestOnError(t *testing.T) {
+	type report struct {
+		status int
+		err    string
+		route  string
+	}
+	var reports []report
+	ts := NewTestServer(t)
+	ts.OnError(func(c *Context, status int, err error) {
+		route := ""
+		if c.route != nil {
+			route = c.route.Path
+		}
+		reports = append(reports, report{status, err.Error(), route})
+	}, func(c *Context, status int, err error) {
+		panic("broken notifier")
+	})
+	ts.Get("/books/{id}", func(c *Context) error {
+		switch c.Param("id") {
+		case "missing":
+			return c.AbortNotFound("Book not found")
+		case "db":
+			return c.AbortInternalServerError("Internal Server Error", errors.New("connection refused"))
+		case "raw":
+			return errors.New("unhandled")
+		}
+		return c.OK(M{"id": c.Param("id")})
+	})
+
+	okapitest.GET(t, ts.BaseURL+"/books/1").ExpectStatusOK()
+	okapitest.GET(t, ts.BaseURL+"/books/missing").ExpectStatus(http.StatusNotFound)
+	okapitest.GET(t, ts.BaseURL+"/books/db").ExpectStatus(http.StatusInternalServerError)
+	okapitest.GET(t, ts.BaseURL+"/books/raw").ExpectStatus(http.StatusInternalServerError)
+	okapitest.GET(t, ts.BaseURL+"/authors").ExpectStatus(http.StatusNotFound)
+	okapitest.POST(t, ts.BaseURL+"/books/1").ExpectStatus(http.StatusMethodNotAllowed)
+
+	want := []report{
+		{http.StatusNotFound, "Book not found", "/books/{id}"},
+		{http.StatusInternalServerError, "connection refused", "/books/{id}"},
+		{http.StatusInternalServerError, "unhandled", "/books/{id}"},
+		{http.StatusNotFound, "Not Found", ""},
+		{http.StatusMethodNotAllowed, "Method Not Allowed", ""},
+	}
+	if fmt.Sprint(reports) != fmt.Sprint(want) {
+		t.Errorf("reports = %v, want %v", reports, want)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"reflect"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	componentErrorResponse           = "ErrorResponse"
+	componentValidationError         = "ValidationError"
+	componentValidationErrorResponse = "ValidationErrorResponse"
+	componentProblemDetail           = "ProblemDetail"
+)
+
+// errorComponentTypes maps the built-in error types to the component names
+// Okapi registers them under.
+var errorComponentTypes = map[string]reflect.Type{
+	componentErrorResponse:           reflect.TypeOf(ErrorResponse{}),
+	componentValidationError:         reflect.TypeOf(ValidationError{}),
+	componentValidationErrorResponse: reflect.TypeOf(ValidationErrorResponse{}),
+	componentProblemDetail:           reflect.TypeOf(ProblemDetail{}),
+}
+
+// errorComponentName reports the managed component name of schema when it
+// was reflected from one of the built-in error types.
+func (o *Okapi) errorComponentName(schema *openapi3.SchemaRef) (string, bool) {
+	if schema == nil || schema.Value == nil {
+		return "", false
+	}
+	t, ok := errorComponentTypes[schema.Value.Title]
+	if !ok || !o.schemasEqual(schema, typeToSchemaWithInfo(t)) {
+		return "", false
+	}
+	return schema.Value.Title, true
+}
+
+// addErrorComponent registers the managed component name, and the components
+// it references, once per spec. A component of the same name registered by
+// the application is left untouched.
+func (o *Okapi) addErrorComponent(name string, registry map[string]*SchemaInfo, components openapi3.Schemas) *openapi3.SchemaRef {
+	ref := &openapi3.SchemaRef{Ref: componentRef(name)}
+	if _, exists := components[name]; exists {
+		return ref
+	}
+	t := errorComponentTypes[name]
+	schema := typeToSchemaWithInfo(t)
+	switch name {
+	case componentValidationErrorResponse:
+		// The items reference ValidationError instead of repeating it inline.
+		errs := openapi3.NewArraySchema()
+		errs.Items = o.addErrorComponent(componentValidationError, registry, components)
+		schema.Value.Properties["errors"] = errs.NewRef()
+	case componentProblemDetail:
+		// Extensions are marshaled as top-level members.
+		schema.Value.AdditionalProperties = openapi3.AdditionalProperties{Has: ptr(true)}
+	}
+	registry[name] = &SchemaInfo{Schema: schema, TypeName: t.Name(), Package: t.PkgPath()}
+	components[name] = schema
+	return ref
+}
+
+// errorContentType returns the media type of a documented error response.
+func errorContentType(schema *openapi3.SchemaRef) string {
+	if schema != nil && schema.Ref == componentRef(componentProblemDetail) {
+		return constJSONProblem
+	}
+	return constJSON
+}
+
+// componentRef returns the reference to the schema component name.
+func componentRef(name string) string {
+	return "#/components/schemas/" + name
+}
@@ -0,0 +1,240 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// eventDeliveryTimeout bounds the delivery of the events of a single request.
+const eventDeliveryTimeout = 30 * time.Second
+
+// ErrNoEventBus is returned by Events.Publish when no EventBus is configured.
+var ErrNoEventBus = errors.New("okapi: no event bus configured, see WithEventBus")
+
+type (
+	// Event is a domain event published by a handler.
+	Event struct {
+		// ID uniquely identifies the event, so consumers can deduplicate redeliveries.
+		ID string
+		// Topic is the subject, channel or topic the event is delivered to.
+		Topic string
+		// Payload is the encoded event: []byte and string payloads are kept as is,
+		// anything else is JSON encoded.
+		Payload []byte
+		// Time is when the event was published.
+		Time time.Time
+	}
+
+	// Events publishes domain events from a handler, see Context.Events.
+	Events interface {
+		Publish(topic string, payload any) error
+	}
+
+	// EventBus delivers the events of a request to a messaging backend.
+	// Events are handed over together once the response is sent, which makes it
+	// straightforward to write them to an outbox table in a single transaction.
+	EventBus interface {
+		Deliver(ctx context.Context, events []Event) error
+	}
+
+	// EventBusFunc adapts a function to an EventBus, e.g. to publish with a NATS
+	// or Kafka client without okapi depending on it:
+	//
+	//	okapi.EventBusFunc(func(ctx context.Context, events []okapi.Event) error {
+	//		for _, e := range events {
+	//			if err := nc.Publish(e.Topic, e.Payload); err != nil {
+	//				return err
+	//			}
+	//		}
+	//		return nil
+	//	})
+	EventBusFunc func(ctx context.Context, events []Event) error
+
+	// MemoryEventBus is an in-process EventBus delivering events to subscribers,
+	// useful for tests and single-instance applications.
+	MemoryEventBus struct {
+		mu          sync.RWMutex
+		subscribers map[string][]func(Event)
+	}
+
+	// requestEvents buffers the events published while serving a request.
+	requestEvents struct {
+		bus    EventBus
+		mu     sync.Mutex
+		events []Event
+	}
+)
+
+// Deliver calls f(ctx, events).
+func (f EventBusFunc) Deliver(ctx context.Context, events []Event) error {
+	return f(ctx, events)
+}
+
+// NewMemoryEventBus returns an empty in-memory event bus.
+func NewMemoryEventBus() *MemoryEventBus {
+	return &MemoryEventBus{subscribers: make(map[string][]func(Event))}
+}
+
+// Subscribe registers fn for the events published on topic.
+// Subscribers are called synchronously, in registration order.
+func (b *MemoryEventBus) Subscribe(topic string, fn func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[topic] = append(b.subscribers[topic], fn)
+}
+
+// Deliver passes each event to the subscribers of its topic.
+func (b *MemoryEventBus) Deliver(_ context.Context, events []Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, e := range events {
+		for _, fn := range b.subscribers[e.Topic] {
+			fn(e)
+		}
+	}
+	return nil
+}
+
+// WithEventBus sets the bus receiving the events published through Context.Events.
+//
+// Events published by a handler are buffered and delivered in the background
+// once the response is sent. They are discarded when the handler returns an
+// error or the response status is 4xx or 5xx, so events only describe work that
+// actually succeeded. Delivery errors are logged.
+func WithEventBus(bus EventBus) OptionFunc {
+	return func(o *Okapi) {
+		o.eventBus = bus
+	}
+}
+
+// WithEventBus sets the bus receiving the events published through Context.Events.
+func (o *Okapi) WithEventBus(bus EventBus) *Okapi {
+	return o.apply(WithEventBus(bus))
+}
+
+// Events returns the request-scoped event publisher.
+//
+// Example:
+//
+//	o.Post("/orders", func(c *okapi.Context) error {
+//		order := createOrder(c)
+//		if err := c.Events().Publish("orders.created", order); err != nil {
+//			return err
+//		}
+//		return c.Created(order)
+//	})
+func (c *Context) Events() Events {
+	if c.events == nil {
+		var bus EventBus
+		if c.okapi != nil {
+			bus = c.okapi.eventBus
+		}
+		c.events = &requestEvents{bus: bus}
+	}
+	return c.events
+}
+
+// Publish buffers an event until the response is sent.
+func (e *requestEvents) Publish(topic string, payload any) error {
+	if e.bus == nil {
+		return ErrNoEventBus
+	}
+	var data []byte
+	switch p := payload.(type) {
+	case []byte:
+		data = p
+	case string:
+		data = []byte(p)
+	default:
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("encode event %q: %w", topic, err)
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, Event{
+		ID:      uuid.NewString(),
+		Topic:   topic,
+		Payload: data,
+		Time:    time.Now(),
+	})
+	return nil
+}
+
+// take returns and clears the buffered events.
+func (e *requestEvents) take() []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	events := e.events
+	e.events = nil
+	return events
+}
+
+// deliverEvents hands the events published while serving c to the event bus,
+// in the background, provided the request succeeded.
+func (o *Okapi) deliverEvents(c *Context, err error) {
+	if c.events == nil {
+		return
+	}
+	events := c.events.take()
+	if len(events) == 0 || err != nil || c.response.StatusCode() >= 400 {
+		return
+	}
+	ctx := context.WithoutCancel(c.request.Context())
+	o.eventsWG.Add(1)
+	go func() {
+		defer o.eventsWG.Done()
+		ctx, cancel := context.WithTimeout(ctx, eventDeliveryTimeout)
+		defer cancel()
+		if err := c.events.bus.Deliver(ctx, events); err != nil {
+			o.logger.Error("event delivery failed", slog.Int("events", len(events)), slog.String("error", err.Error()))
+		}
+	}()
+}
+
+// waitEvents waits for the pending event deliveries, or until ctx is done.
+func (o *Okapi) waitEvents(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		o.eventsWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for event delivery: %w", ctx.Err())
+	}
+}
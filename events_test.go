@@ -0,0 +1,102 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestEvents(t *testing.T) {
+	bus := NewMemoryEventBus()
+	received := make(chan Event, 10)
+	bus.Subscribe("orders.created", func(e Event) { received <- e })
+
+	ts := NewTestServer(t)
+	ts.WithEventBus(bus)
+	ts.Post("/orders", func(c *Context) error {
+		if err := c.Events().Publish("orders.created", M{"id": 1}); err != nil {
+			return err
+		}
+		return c.Created(M{"id": 1})
+	})
+	ts.Post("/orders/invalid", func(c *Context) error {
+		_ = c.Events().Publish("orders.created", M{"id": 2})
+		return c.AbortBadRequest("invalid order")
+	})
+
+	okapitest.POST(t, ts.BaseURL+"/orders/invalid").ExpectStatusBadRequest()
+	okapitest.POST(t, ts.BaseURL+"/orders").ExpectStatusCreated()
+
+	select {
+	case e := <-received:
+		if e.Topic != "orders.created" || string(e.Payload) != `{"id":1}` || e.ID == "" {
+			t.Errorf("unexpected event %+v", e)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("event was not delivered")
+	}
+	select {
+	case e := <-received:
+		t.Errorf("event of a failed request was delivered: %s", e.Payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestEventsWithoutBus(t *testing.T) {
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	if err := ctx.Events().Publish("topic", "payload"); !errors.Is(err, ErrNoEventBus) {
+		t.Errorf("Publish error = %v, want ErrNoEventBus", err)
+	}
+}
+
+func TestEventBusFunc(t *testing.T) {
+	batches := make(chan []Event, 1)
+	o := New(WithEventBus(EventBusFunc(func(_ context.Context, events []Event) error {
+		batches <- events
+		return nil
+	})))
+	o.Get("/", func(c *Context) error {
+		_ = c.Events().Publish("a", []byte("raw"))
+		_ = c.Events().Publish("b", "text")
+		return c.NoContent()
+	})
+	o.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	select {
+	case events := <-batches:
+		if len(events) != 2 || string(events[0].Payload) != "raw" || string(events[1].Payload) != "text" {
+			t.Errorf("unexpected events %+v", events)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("events were not delivered")
+	}
+}
@@ -29,7 +29,6 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
@@ -205,7 +204,7 @@ func main() {
 		okapi.DocRequestBody(Book{}))
 
 	adminApiV2.Delete("/books/:id", func(c *okapi.Context) error {
-		id, err := strconv.Atoi(c.Param("id"))
+		id, err := c.ParamInt("id")
 		if err != nil {
 			return c.AbortBadRequest("invalid request", err)
 		}
@@ -191,6 +191,11 @@ func (r *Router) v1BookRoutes() []okapi.RouteDefinition {
 	apiGroup := r.group.Group("/books").WithTags([]string{"V1Books"})
 	// Apply custom middleware
 	// apiGroup.Use(middlewares.CustomMiddleware)
+	// Document the error responses shared by every route of the group
+	apiGroup.WithRouteDefaults(
+		okapi.DocResponse(http.StatusBadRequest, &models.ErrorResponseDto{}),
+		okapi.DocResponse(http.StatusNotFound, &models.ErrorResponseDto{}),
+	)
 	return []okapi.RouteDefinition{
 		{
 			Method:      http.MethodGet,
@@ -201,9 +206,6 @@ func (r *Router) v1BookRoutes() []okapi.RouteDefinition {
 			Summary:     "Get Books",
 			Description: "Retrieve a list of books",
 			Response:    &models.BooksResponse{},
-			Options: []okapi.RouteOption{
-				okapi.DocResponse(http.StatusBadRequest, models.ErrorResponseDto{}),
-			},
 		},
 		{
 			Method:  http.MethodGet,
@@ -214,8 +216,6 @@ func (r *Router) v1BookRoutes() []okapi.RouteDefinition {
 				okapi.DocSummary("Get Book by ID"),
 				okapi.DocDescription("Retrieve a book by its ID"),
 				okapi.DocResponse(models.Book{}),
-				okapi.DocResponse(http.StatusBadRequest, &models.ErrorResponseDto{}),
-				okapi.DocResponse(http.StatusNotFound, &models.ErrorResponseDto{}),
 			},
 		},
 	}
@@ -25,8 +25,6 @@
 package main
 
 import (
-	"strconv"
-
 	"github.com/jkaninda/okapi"
 )
 
@@ -91,7 +89,10 @@ func main() {
 		okapi.Response(&Book{}), // Success Response body
 	)
 	o.Get("/books/{id:int}", func(c *okapi.Context) error {
-		id, _ := strconv.Atoi(c.Param("id"))
+		id, err := c.ParamInt("id")
+		if err != nil {
+			return c.AbortBadRequest("Invalid book id", err)
+		}
 		for _, book := range books {
 			if book.ID == id {
 				return c.OK(book)
@@ -195,7 +195,7 @@ func main() {
 				}
 			}
 		}
-	})
+	}).Streaming() // Not cut off by the server's WriteTimeout
 
 	// Run server with lifecycle hooks
 	if err := cli.RunServer(&okapicli.RunOptions{
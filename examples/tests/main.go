@@ -25,9 +25,9 @@
 package main
 
 import (
+	"context"
 	"github.com/jkaninda/okapi"
 	"net/http"
-	"strconv"
 )
 
 type Book struct {
@@ -48,17 +48,23 @@ type BooksResponse struct {
 	Body    []Book
 }
 
-var books = Books{
-	{ID: 1, Name: "The Go Programming Language", Price: 30, Qty: 100, Year: 2014},
-	{ID: 2, Name: "Learning Go", Price: 25, Qty: 50, Year: 2021},
-	{ID: 3, Name: "Go in Action", Price: 40, Qty: 75, Year: 2015},
-	{ID: 4, Name: "Go Web Programming", Price: 35, Qty: 60, Year: 2016},
-	{ID: 5, Name: "Go Design Patterns", Price: 45, Qty: 80, Year: 2017},
+var books Books
+
+// loadBooks resets the in-memory store to the demo books.
+func loadBooks(_ context.Context) error {
+	books = Books{
+		{ID: 1, Name: "The Go Programming Language", Price: 30, Qty: 100, Year: 2014},
+		{ID: 2, Name: "Learning Go", Price: 25, Qty: 50, Year: 2021},
+		{ID: 3, Name: "Go in Action", Price: 40, Qty: 75, Year: 2015},
+		{ID: 4, Name: "Go Web Programming", Price: 35, Qty: 60, Year: 2016},
+		{ID: 5, Name: "Go Design Patterns", Price: 45, Qty: 80, Year: 2017},
+	}
+	return nil
 }
 
 func main() {
-	// Create a new Okapi instance with default config
-	o := okapi.Default()
+	// Create a new Okapi instance with default config, seeded with the demo books
+	o := okapi.Default().WithFixtures(loadBooks)
 
 	o.Get("/", func(c *okapi.Context) error {
 		return c.String(http.StatusOK, "Hello, World!")
@@ -84,7 +90,10 @@ func GetBooksHandler(c *okapi.Context) error {
 	return c.Respond(output)
 }
 func GetBookHandler(c *okapi.Context) error {
-	id, _ := strconv.Atoi(c.Param("id"))
+	id, err := c.ParamInt("id")
+	if err != nil {
+		return c.AbortBadRequest("Invalid book id", err)
+	}
 	for _, book := range books {
 		if book.ID == id {
 			return c.OK(book)
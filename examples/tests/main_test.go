@@ -34,7 +34,7 @@ import (
 
 func TestBooksAPI(t *testing.T) {
 	// Setup test server
-	server := okapi.NewTestServer(t)
+	server := okapi.NewTestServerWithOkapi(t, okapi.New(okapi.WithFixtures(loadBooks)))
 	server.Get("/books", GetBooksHandler)
 	server.Get("/books/:id", GetBookHandler)
 	server.Post("/books", CreateBookHandler)
@@ -75,7 +75,7 @@ func TestBooksAPI(t *testing.T) {
 // ************* Using Standalone Request Helpers ****************
 
 func TestGetBookHandler(t *testing.T) {
-	server := okapi.NewTestServerOn(t, 8000)
+	server := okapi.NewTestServerWithOkapi(t, okapi.New(okapi.WithFixtures(loadBooks)))
 	server.Get("/books/:id", GetBookHandler)
 
 	// Test successful retrieval
@@ -90,7 +90,7 @@ func TestGetBookHandler(t *testing.T) {
 }
 
 func TestCreateBookHandler(t *testing.T) {
-	server := okapi.NewTestServerOn(t, 8000)
+	server := okapi.NewTestServerWithOkapi(t, okapi.New(okapi.WithFixtures(loadBooks)))
 	server.Post("/books", CreateBookHandler)
 
 	book := Book{
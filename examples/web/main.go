@@ -27,7 +27,6 @@ package main
 import (
 	"embed"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/jkaninda/okapi"
@@ -63,7 +62,7 @@ func main() {
 
 	// Get a single user by ID
 	api.Get("/users/:id", func(c *okapi.Context) error {
-		id, err := strconv.Atoi(c.Param("id"))
+		id, err := c.ParamInt("id")
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, okapi.M{"error": "invalid user id"})
 		}
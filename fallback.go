@@ -0,0 +1,64 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+// FallbackFunc serves a degraded response after the route handler failed
+// with err, e.g. stale cached data or a static message.
+type FallbackFunc func(c *Context, err error) error
+
+// Fallback sets a handler invoked when the route handler returns an error
+// before writing a response. Its response replaces the 500 that the error
+// would otherwise produce, and the original error is logged.
+//
+// Example:
+//
+//	o.Get("/rates", liveRates).Fallback(func(c *okapi.Context, err error) error {
+//		c.SetHeader("Warning", `110 - "Response is Stale"`)
+//		return c.OK(lastKnownRates())
+//	})
+func (r *Route) Fallback(fn FallbackFunc) *Route {
+	r.fallback = fn
+	return r
+}
+
+// Fallback is the RouteOption form of Route.Fallback.
+func Fallback(fn FallbackFunc) RouteOption {
+	return func(r *Route) {
+		r.Fallback(fn)
+	}
+}
+
+// wrapFallback runs the route fallback when h fails without responding.
+func (r *Route) wrapFallback(h HandlerFunc) HandlerFunc {
+	return func(c *Context) error {
+		err := h(c)
+		if err == nil || c.response.Written() {
+			return err
+		}
+		c.Logger().Warn("[okapi] serving route fallback",
+			"method", c.request.Method, "path", c.request.URL.Path, "error", err)
+		return r.fallback(c, err)
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestRouteFallback(t *testing.T) {
+	ts := NewTestServer(t)
+	errUpstream := errors.New("upstream unavailable")
+	stale := func(c *Context, err error) error {
+		if !errors.Is(err, errUpstream) {
+			t.Errorf("fallback error = %v, want %v", err, errUpstream)
+		}
+		c.SetHeader("Warning", `110 - "Response is Stale"`)
+		return c.OK(M{"rate": 1.08})
+	}
+	ts.Get("/rates", func(c *Context) error { return errUpstream }).Fallback(stale)
+	ts.Get("/live", func(c *Context) error { return c.OK(M{"rate": 1.09}) }, Fallback(stale))
+	ts.Get("/written", func(c *Context) error {
+		_ = c.Text(http.StatusServiceUnavailable, "busy")
+		return errUpstream
+	}, Fallback(stale))
+
+	okapitest.GET(t, ts.BaseURL+"/rates").
+		ExpectStatusOK().
+		ExpectHeader("Warning", `110 - "Response is Stale"`).
+		ExpectBodyContains("1.08")
+	okapitest.GET(t, ts.BaseURL+"/live").
+		ExpectStatusOK().
+		ExpectBodyContains("1.09")
+	// A handler that already responded keeps its response
+	okapitest.GET(t, ts.BaseURL+"/written").
+		ExpectStatus(http.StatusServiceUnavailable).
+		ExpectBodyContains("busy")
+}
@@ -0,0 +1,97 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// FixtureFunc loads demo or test data, typically resetting an in-memory store
+// before filling it, so that running it again restores the initial state.
+type FixtureFunc func(ctx context.Context) error
+
+// WithFixtures registers fixtures loaded before the server starts, in
+// registration order, when running in debug mode or in the development
+// environment. They are skipped in other environments, so seeding examples and
+// local setups cannot touch production data.
+//
+// Test servers created with NewTestServerWithOkapi load them too; reload them
+// between tests with TestServer.ResetFixtures.
+//
+// Example:
+//
+//	var books []Book
+//
+//	o := okapi.New(okapi.WithFixtures(func(ctx context.Context) error {
+//		books = []Book{{ID: 1, Name: "The Go Programming Language"}}
+//		return nil
+//	}))
+func WithFixtures(fixtures ...FixtureFunc) OptionFunc {
+	return func(o *Okapi) {
+		for _, fixture := range fixtures {
+			if fixture != nil {
+				o.fixtures = append(o.fixtures, fixture)
+			}
+		}
+	}
+}
+
+// WithFixtures registers fixtures loaded before the server starts in debug or development mode.
+func (o *Okapi) WithFixtures(fixtures ...FixtureFunc) *Okapi {
+	return o.apply(WithFixtures(fixtures...))
+}
+
+// LoadFixtures runs the registered fixtures, whatever the environment, and
+// stops at the first error.
+func (o *Okapi) LoadFixtures(ctx context.Context) error {
+	for i, fixture := range o.fixtures {
+		start := time.Now()
+		if err := fixture(ctx); err != nil {
+			return fmt.Errorf("fixture %d failed: %w", i+1, err)
+		}
+		o.logger.Debug("[okapi] Fixture loaded", slog.Int("step", i+1), slog.Duration("duration", time.Since(start)))
+	}
+	return nil
+}
+
+// loadStartupFixtures loads the fixtures when starting in debug or development mode.
+func (o *Okapi) loadStartupFixtures() error {
+	if len(o.fixtures) == 0 {
+		return nil
+	}
+	if env := o.environment(); !o.debug && env != constDevelopment {
+		o.logger.Info("[okapi] Fixtures skipped", slog.String("environment", env))
+		return nil
+	}
+	if err := o.LoadFixtures(cmp.Or(o.ctx, context.Background())); err != nil {
+		o.logger.Error("[okapi] Loading fixtures failed", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestWithFixtures(t *testing.T) {
+	var books []string
+	loads := 0
+	o := New(WithFixtures(func(context.Context) error {
+		books = []string{"Learning Go"}
+		loads++
+		return nil
+	}))
+	o.Get("/books", func(c *Context) error { return c.OK(books) })
+	o.Post("/books", func(c *Context) error {
+		books = append(books, c.Query("name"))
+		return c.Created(books)
+	})
+
+	ts := NewTestServerWithOkapi(t, o)
+	okapitest.POST(t, ts.BaseURL+"/books?name=Go+in+Action").ExpectStatus(http.StatusCreated)
+	okapitest.GET(t, ts.BaseURL+"/books").ExpectBody(`["Learning Go","Go in Action"]` + "\n")
+
+	ts.ResetFixtures()
+	okapitest.GET(t, ts.BaseURL+"/books").ExpectBody(`["Learning Go"]` + "\n")
+	if loads != 2 {
+		t.Errorf("expected 2 loads, got %d", loads)
+	}
+
+	t.Run("environments", func(t *testing.T) {
+		loads = 0
+		o.profile = "production"
+		if err := o.loadStartupFixtures(); err != nil || loads != 0 {
+			t.Errorf("fixtures loaded in production: loads=%d err=%v", loads, err)
+		}
+		o.debug = true
+		if err := o.loadStartupFixtures(); err != nil || loads != 1 {
+			t.Errorf("fixtures not loaded in debug mode: loads=%d err=%v", loads, err)
+		}
+		o.profile = constDevelopment
+		o.debug = false
+		if err := o.loadStartupFixtures(); err != nil || loads != 2 {
+			t.Errorf("fixtures not loaded in development: loads=%d err=%v", loads, err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		boom := errors.New("boom")
+		o := New(WithFixtures(func(context.Context) error { return boom }))
+		if err := o.LoadFixtures(context.Background()); !errors.Is(err, boom) {
+			t.Errorf("expected fixture error, got %v", err)
+		}
+	})
+}
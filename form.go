@@ -0,0 +1,233 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"html"
+	htmltemplate "html/template"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// FormView is the template data used by RenderWithErrors to re-render a form
+// after a failed submission.
+//
+// Templates read errors and previously submitted values by form field name,
+// either with the view methods or the field_error and old_value helpers that
+// okapi templates register:
+//
+//	<input name="email" value="{{ old_value . "email" }}">
+//	{{ if has_error . "email" }}<p class="error">{{ field_error . "email" }}</p>{{ end }}
+//
+// Returned values are HTML-escaped.
+type FormView struct {
+	// Form is the struct the submission was bound to.
+	Form any
+	// Errors lists the validation errors, keyed by form field name.
+	// Errors that do not belong to a field have an empty Field.
+	Errors []ValidationError
+	values url.Values
+}
+
+// formFuncs are registered on every okapi Template so form pages can use them.
+var formFuncs = template.FuncMap{
+	"field_error": func(v *FormView, name string) htmltemplate.HTML { return v.FieldError(name) },
+	"has_error":   func(v *FormView, name string) bool { return v.HasError(name) },
+	"old_value":   func(v *FormView, name string) htmltemplate.HTML { return v.OldValue(name) },
+	// nonce_field is bound to the request by Template.Render, see FormNonce.
+	"nonce_field": func() htmltemplate.HTML { return "" },
+	// The format functions are bound to the locale and time zone of the request
+	// by Template.Render, see WithLocaleOptions.
+	"format_number":   func(v float64, decimals int) string { return formatNumber(v, decimals, localeFormats["en"]) },
+	"format_date":     func(t time.Time) string { return t.UTC().Format(localeFormats["en"].Date) },
+	"format_datetime": func(t time.Time) string { return t.UTC().Format(localeFormats["en"].DateTime) },
+}
+
+// ValidationErrors is a list of validation errors usable as an error, e.g. to
+// report checks done by the handler itself to RenderWithErrors.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, ve := range e {
+		msgs[i] = ve.Message
+		if ve.Field != "" {
+			msgs[i] = ve.Field + ": " + ve.Message
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// RenderWithErrors re-renders the form template name with status 422, passing a
+// *FormView built from the bound form and the error returned by Bind (or a
+// ValidationErrors). Submitted values are taken from the request so that input
+// that failed to parse is shown back as typed.
+//
+//	o.Post("/signup", func(c *okapi.Context) error {
+//	  var form SignupForm
+//	  if err := c.Bind(&form); err != nil {
+//	    return c.RenderWithErrors("signup.html", &form, err)
+//	  }
+//	  // ...
+//	})
+func (c *Context) RenderWithErrors(name string, form any, err error) error {
+	if c.request.Form == nil {
+		_ = c.request.ParseForm()
+	}
+	view := &FormView{
+		Form:   form,
+		Errors: c.formErrors(form, err),
+		values: c.request.Form,
+	}
+	return c.Render(http.StatusUnprocessableEntity, name, view)
+}
+
+// formErrors converts a binding or validation error into errors keyed by form field name.
+func (c *Context) formErrors(form any, err error) []ValidationError {
+	if err == nil {
+		return nil
+	}
+	var list ValidationErrors
+	if errors.As(err, &list) {
+		return list
+	}
+	var required *RequiredFieldError
+	if errors.As(err, &required) {
+		return []ValidationError{{Field: formFieldName(form, required.Field), Message: c.localizeError(required).Error()}}
+	}
+	var field *FieldError
+	if errors.As(err, &field) {
+		return []ValidationError{{Field: formFieldName(form, field.Field), Message: c.localizeMessage(field.Err)}}
+	}
+	return []ValidationError{{Message: err.Error()}}
+}
+
+// HasErrors reports whether the submission has any error.
+func (v *FormView) HasErrors() bool {
+	return v != nil && len(v.Errors) > 0
+}
+
+// HasError reports whether the named field has an error.
+func (v *FormView) HasError(name string) bool {
+	return v.FieldError(name) != ""
+}
+
+// FieldError returns the error message of the named field, or "" when it is valid.
+func (v *FormView) FieldError(name string) htmltemplate.HTML {
+	if v == nil {
+		return ""
+	}
+	for _, e := range v.Errors {
+		if e.Field == name {
+			return htmltemplate.HTML(html.EscapeString(e.Message))
+		}
+	}
+	return ""
+}
+
+// OldValue returns the value previously submitted for the named field.
+func (v *FormView) OldValue(name string) htmltemplate.HTML {
+	if v == nil {
+		return ""
+	}
+	if vals, ok := v.values[name]; ok && len(vals) > 0 {
+		return htmltemplate.HTML(html.EscapeString(vals[0]))
+	}
+	if field, ok := formField(v.Form, name); ok {
+		return htmltemplate.HTML(html.EscapeString(formatCSVValue(field)))
+	}
+	return ""
+}
+
+// formTagName returns the name a form field is submitted under.
+func formTagName(sf reflect.StructField) string {
+	for _, tag := range []string{tagForm, tagQuery, tagJSON} {
+		if name, _, _ := strings.Cut(sf.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return sf.Name
+}
+
+// formStruct returns the struct holding form values: form itself or its Body field.
+func formStruct(form any) (reflect.Value, bool) {
+	v := reflect.Indirect(reflect.ValueOf(form))
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if !isBodyField(v.Type().Field(i)) {
+			continue
+		}
+		if b := reflect.Indirect(v.Field(i)); b.Kind() == reflect.Struct {
+			return b, true
+		}
+	}
+	return v, true
+}
+
+// formFieldName maps a struct field path reported by the binder, such as
+// "Email" or "Body.Email", to its form field name. Fields next to a Body field
+// are named after their query, header, path or cookie tag.
+func formFieldName(form any, path string) string {
+	name := path[strings.LastIndex(path, ".")+1:]
+	if top := reflect.Indirect(reflect.ValueOf(form)); top.Kind() == reflect.Struct && !strings.Contains(path, ".") {
+		if sf, ok := top.Type().FieldByName(name); ok && !isBodyField(sf) {
+			for _, tag := range []string{tagQuery, tagHeader, tagPath, tagParam, tagCookie} {
+				if key := sf.Tag.Get(tag); key != "" {
+					return key
+				}
+			}
+		}
+	}
+	v, ok := formStruct(form)
+	if !ok {
+		return name
+	}
+	if sf, ok := v.Type().FieldByName(name); ok {
+		return formTagName(sf)
+	}
+	return name
+}
+
+// formField finds the field of form submitted under name.
+func formField(form any, name string) (reflect.Value, bool) {
+	v, ok := formStruct(form)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); sf.IsExported() && formTagName(sf) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,93 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signupForm struct {
+	Email string `form:"email" required:"true"`
+	Age   int    `form:"age" min:"18"`
+}
+
+const signupTemplate = `email={{ old_value . "email" }}|{{ field_error . "email" }};` +
+	`age={{ .OldValue "age" }}|{{ if has_error . "age" }}{{ .FieldError "age" }}{{ end }};` +
+	`{{ range .Errors }}{{ if not .Field }}form={{ .Message }}{{ end }}{{ end }}`
+
+func submitSignup(t *testing.T, values url.Values) (*Context, *httptest.ResponseRecorder) {
+	t.Helper()
+	tmpl, err := NewTemplate(fstest.MapFS{"signup.html": {Data: []byte(signupTemplate)}}, "*.html")
+	require.NoError(t, err)
+
+	ctx, rec := NewTestContext(http.MethodPost, "/signup", strings.NewReader(values.Encode()))
+	ctx.request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	ctx.okapi = New().WithRenderer(tmpl)
+	return ctx, rec
+}
+
+func TestContext_RenderWithErrors(t *testing.T) {
+	t.Run("required field", func(t *testing.T) {
+		ctx, rec := submitSignup(t, url.Values{"email": {""}, "age": {"30"}})
+		var form signupForm
+		err := ctx.Bind(&form)
+		require.Error(t, err)
+		require.NoError(t, ctx.RenderWithErrors("signup.html", &form, err))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, "email=|field Email is required;age=30|;", rec.Body.String())
+	})
+
+	t.Run("constraint with escaped input", func(t *testing.T) {
+		ctx, rec := submitSignup(t, url.Values{"email": {"<b>a@b.c</b>"}, "age": {"12"}})
+		var form signupForm
+		err := ctx.Bind(&form)
+		require.Error(t, err)
+		require.NoError(t, ctx.RenderWithErrors("signup.html", &form, err))
+		assert.Contains(t, rec.Body.String(), "email=&lt;b&gt;a@b.c&lt;/b&gt;|;")
+		assert.Contains(t, rec.Body.String(), "age=12|")
+		assert.NotContains(t, rec.Body.String(), "age=12|;")
+	})
+
+	t.Run("handler errors", func(t *testing.T) {
+		ctx, rec := submitSignup(t, url.Values{"email": {"taken@b.c"}, "age": {"30"}})
+		form := signupForm{Email: "taken@b.c", Age: 30}
+		err := ValidationErrors{{Field: "email", Message: "already registered"}}
+		require.NoError(t, ctx.RenderWithErrors("signup.html", &form, err))
+		assert.Equal(t, "email=taken@b.c|already registered;age=30|;", rec.Body.String())
+
+		ctx, rec = submitSignup(t, url.Values{})
+		require.NoError(t, ctx.RenderWithErrors("signup.html", &form, errors.New("try again later")))
+		assert.Equal(t, "email=taken@b.c|;age=30|;form=try again later", rec.Body.String())
+	})
+}
@@ -0,0 +1,392 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// fuzzMaxFailures bounds the failures reported by a single Fuzz run.
+const fuzzMaxFailures = 10
+
+// Fuzz runs a schema-based contract test against route: it sends iterations
+// requests built from the route's documented parameters and request body,
+// alternating valid payloads with boundary-invalid ones (a missing required
+// field, a value out of range, a string too long or too short, a wrong type,
+// malformed JSON), and reports a failure when:
+//
+//   - the handler panics;
+//   - a valid request gets a status that is not documented for the route;
+//   - an invalid request is not rejected with a 4xx status;
+//   - any request gets an undocumented 5xx status.
+//
+// Requests are served in-process, so the server doesn't need to be started.
+// When the route documents no responses, any non-5xx status is accepted for
+// valid requests. Values for fields with a pattern come from the field's
+// example when available.
+//
+// Example:
+//
+//	route := o.Post("/books", createBook, okapi.DocRequestBody(Book{}), okapi.DocResponse(201, Book{}))
+//	okapi.Fuzz(t, o, route, 200)
+func Fuzz(t TestingT, o *Okapi, route *Route, iterations int) {
+	t.Helper()
+	o.buildOpenAPISpec()
+	var op *openapi3.Operation
+	if spec := o.routeSpec(route); spec != nil {
+		if item := spec.Paths.Value(route.Path); item != nil {
+			op = item.GetOperation(route.Method)
+		}
+	}
+	if op == nil {
+		t.Fatalf("fuzz: route %s %s is not documented", route.Method, route.Path)
+		return
+	}
+	f := &fuzzer{o: o, rnd: rand.New(rand.NewPCG(uint64(len(route.Path)), uint64(iterations)))}
+	failures := 0
+	for i := 0; i < iterations && failures < fuzzMaxFailures; i++ {
+		req, mutation := f.request(route, op, i%2 == 1)
+		status, panicked := fuzzServe(o, req.build())
+		var problem string
+		switch {
+		case panicked != nil:
+			problem = fmt.Sprintf("handler panicked: %v", panicked)
+		case status >= 500 && !route.documentsStatus(status):
+			problem = fmt.Sprintf("undocumented status %d", status)
+		case mutation != "" && (status < 400 || status >= 500):
+			problem = fmt.Sprintf("invalid request (%s) was not rejected, got status %d", mutation, status)
+		case mutation == "" && len(route.responses) > 0 && !route.documentsStatus(status):
+			problem = fmt.Sprintf("undocumented status %d", status)
+		}
+		if problem != "" {
+			failures++
+			t.Errorf("fuzz %s %s: %s\n  request: %s", route.Method, route.Path, problem, req)
+		}
+	}
+}
+
+// documentsStatus reports whether the route documents a response for status.
+func (r *Route) documentsStatus(status int) bool {
+	_, ok := r.responses[status]
+	return ok
+}
+
+// fuzzServe serves req in-process, recovering any panic.
+func fuzzServe(o *Okapi, req *http.Request) (status int, panicked any) {
+	defer func() {
+		panicked = recover()
+	}()
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, req)
+	return rec.Code, nil
+}
+
+// fuzzRequest is a generated request.
+type fuzzRequest struct {
+	method  string
+	path    string
+	query   url.Values
+	headers http.Header
+	body    []byte
+}
+
+func (r *fuzzRequest) build() *http.Request {
+	target := r.path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+	req := httptest.NewRequest(r.method, target, bytes.NewReader(r.body))
+	for k, v := range r.headers {
+		req.Header[k] = v
+	}
+	if r.body != nil {
+		req.Header.Set("Content-Type", constJSON)
+	}
+	return req
+}
+
+func (r *fuzzRequest) String() string {
+	s := r.method + " " + r.path
+	if len(r.query) > 0 {
+		s += "?" + r.query.Encode()
+	}
+	if r.body != nil {
+		s += " " + string(r.body)
+	}
+	return s
+}
+
+// fuzzer generates valid and boundary-invalid values from schemas.
+type fuzzer struct {
+	o   *Okapi
+	rnd *rand.Rand
+}
+
+// request generates a request for op. When invalid is true, one boundary
+// violation is applied and described by mutation; mutation is empty when the
+// request is valid, including when no violation could be generated.
+func (f *fuzzer) request(route *Route, op *openapi3.Operation, invalid bool) (req *fuzzRequest, mutation string) {
+	req = &fuzzRequest{method: route.Method, path: route.Path, query: url.Values{}, headers: http.Header{}}
+	var violations []func() string
+
+	for _, ref := range op.Parameters {
+		p := ref.Value
+		if p == nil {
+			continue
+		}
+		schema := f.o.resolveSchema(p.Schema)
+		value := fmt.Sprint(f.valid(p.Schema, 0))
+		switch p.In {
+		case openapi3.ParameterInPath:
+			req.path = strings.ReplaceAll(req.path, "{"+p.Name+"}", url.PathEscape(value))
+		case openapi3.ParameterInQuery:
+			if p.Required || f.rnd.IntN(2) == 0 {
+				req.query.Set(p.Name, value)
+			}
+			if p.Required {
+				violations = append(violations, func() string {
+					req.query.Del(p.Name)
+					return "missing required query parameter " + p.Name
+				})
+			}
+			if schema != nil && schema.Type != nil && (schema.Type.Is(openapi3.TypeInteger) || schema.Type.Is(openapi3.TypeNumber)) {
+				violations = append(violations, func() string {
+					req.query.Set(p.Name, "not-a-number")
+					return "non-numeric query parameter " + p.Name
+				})
+			}
+		case openapi3.ParameterInHeader:
+			if p.Required || f.rnd.IntN(2) == 0 {
+				req.headers.Set(p.Name, value)
+			}
+			if p.Required {
+				violations = append(violations, func() string {
+					req.headers.Del(p.Name)
+					return "missing required header " + p.Name
+				})
+			}
+		}
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		if mt := op.RequestBody.Value.Content.Get(constJSON); mt != nil {
+			body := f.valid(mt.Schema, 0)
+			req.body, _ = json.Marshal(body)
+			if obj, ok := body.(map[string]any); ok {
+				violations = append(violations, f.objectViolations(req, mt.Schema, obj)...)
+			}
+			violations = append(violations, func() string {
+				req.body = []byte(`{"`)
+				return "malformed JSON body"
+			})
+		}
+	}
+
+	if invalid && len(violations) > 0 {
+		mutation = violations[f.rnd.IntN(len(violations))]()
+	}
+	return req, mutation
+}
+
+// objectViolations returns the boundary violations applicable to a valid
+// body object; each one rewrites req.body and describes itself.
+func (f *fuzzer) objectViolations(req *fuzzRequest, ref *openapi3.SchemaRef, obj map[string]any) []func() string {
+	schema := f.o.resolveSchema(ref)
+	if schema == nil {
+		return nil
+	}
+	with := func(name string, value any, remove bool) []byte {
+		mutated := make(map[string]any, len(obj))
+		for k, v := range obj {
+			mutated[k] = v
+		}
+		if remove {
+			delete(mutated, name)
+		} else {
+			mutated[name] = value
+		}
+		data, _ := json.Marshal(mutated)
+		return data
+	}
+	var violations []func() string
+	for _, name := range schema.Required {
+		violations = append(violations, func() string {
+			req.body = with(name, nil, true)
+			return "missing required field " + name
+		})
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		prop := f.o.resolveSchema(schema.Properties[name])
+		if prop == nil || prop.Type == nil {
+			continue
+		}
+		add := func(desc string, value any) {
+			violations = append(violations, func() string {
+				req.body = with(name, value, false)
+				return desc
+			})
+		}
+		switch {
+		case prop.Type.Is(openapi3.TypeString):
+			if prop.MaxLength != nil {
+				add(fmt.Sprintf("field %s longer than %d", name, *prop.MaxLength), strings.Repeat("x", int(*prop.MaxLength)+1))
+			}
+			if prop.MinLength > 0 {
+				add(fmt.Sprintf("field %s shorter than %d", name, prop.MinLength), strings.Repeat("x", int(prop.MinLength)-1))
+			}
+			if len(prop.Enum) > 0 {
+				add("field "+name+" outside its enum", "not-in-enum")
+			}
+			add("field "+name+" of the wrong type", 12345)
+		case prop.Type.Is(openapi3.TypeInteger), prop.Type.Is(openapi3.TypeNumber):
+			if prop.Max != nil {
+				add(fmt.Sprintf("field %s above %v", name, *prop.Max), *prop.Max+1)
+			}
+			if prop.Min != nil {
+				add(fmt.Sprintf("field %s below %v", name, *prop.Min), *prop.Min-1)
+			}
+			add("field "+name+" of the wrong type", "not-a-number")
+		case prop.Type.Is(openapi3.TypeBoolean):
+			add("field "+name+" of the wrong type", "not-a-boolean")
+		case prop.Type.Is(openapi3.TypeArray):
+			if prop.MaxItems != nil {
+				items := make([]any, int(*prop.MaxItems)+1)
+				for i := range items {
+					items[i] = f.valid(prop.Items, 1)
+				}
+				add(fmt.Sprintf("field %s with more than %d items", name, *prop.MaxItems), items)
+			}
+			if prop.MinItems > 0 {
+				add(fmt.Sprintf("field %s with fewer than %d items", name, prop.MinItems), []any{})
+			}
+		}
+	}
+	return violations
+}
+
+// valid generates a value satisfying the schema.
+func (f *fuzzer) valid(ref *openapi3.SchemaRef, depth int) any {
+	s := f.o.resolveSchema(ref)
+	if s == nil || depth > mockMaxDepth {
+		return nil
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[f.rnd.IntN(len(s.Enum))]
+	}
+	switch {
+	case len(s.AllOf) > 0, len(s.OneOf) > 0, len(s.AnyOf) > 0:
+		return f.o.mockValue(ref, depth)
+	case s.Type == nil, s.Type.Is(openapi3.TypeObject):
+		obj := make(map[string]any, len(s.Properties))
+		for _, name := range sortedKeys(s.Properties) {
+			if slices.Contains(s.Required, name) || f.rnd.IntN(2) == 0 {
+				obj[name] = f.valid(s.Properties[name], depth+1)
+			}
+		}
+		return obj
+	case s.Type.Is(openapi3.TypeArray):
+		n := int(s.MinItems) + f.rnd.IntN(3)
+		if s.MaxItems != nil && n > int(*s.MaxItems) {
+			n = int(*s.MaxItems)
+		}
+		items := make([]any, n)
+		for i := range items {
+			items[i] = f.valid(s.Items, depth+1)
+		}
+		return items
+	case s.Type.Is(openapi3.TypeString):
+		return f.validString(s)
+	case s.Type.Is(openapi3.TypeInteger):
+		lo, hi := f.bounds(s)
+		return int64(lo) + f.rnd.Int64N(int64(hi-lo)+1)
+	case s.Type.Is(openapi3.TypeNumber):
+		lo, hi := f.bounds(s)
+		return lo + f.rnd.Float64()*(hi-lo)
+	case s.Type.Is(openapi3.TypeBoolean):
+		return f.rnd.IntN(2) == 0
+	}
+	return nil
+}
+
+// bounds returns the inclusive range of valid numbers for s.
+func (f *fuzzer) bounds(s *openapi3.Schema) (lo, hi float64) {
+	lo, hi = 0, 1000
+	if s.Min != nil {
+		lo = *s.Min
+		if s.Max == nil {
+			hi = lo + 1000
+		}
+	}
+	if s.Max != nil {
+		hi = *s.Max
+		if s.Min == nil {
+			lo = hi - 1000
+		}
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
+// validString generates a string satisfying the length and format of s.
+func (f *fuzzer) validString(s *openapi3.Schema) string {
+	if s.Pattern != "" {
+		if s.Example != nil {
+			return fmt.Sprint(s.Example)
+		}
+		if len(s.Examples) > 0 {
+			return fmt.Sprint(s.Examples[0])
+		}
+	}
+	if s.Format != "" {
+		if v := mockString(s.Format); v != "string" {
+			return v
+		}
+	}
+	minLen, maxLen := int(s.MinLength), int(s.MinLength)+12
+	if s.MaxLength != nil && int(*s.MaxLength) < maxLen {
+		maxLen = int(*s.MaxLength)
+	}
+	if maxLen < minLen {
+		maxLen = minLen
+	}
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, minLen+f.rnd.IntN(maxLen-minLen+1))
+	for i := range b {
+		b[i] = alphabet[f.rnd.IntN(len(alphabet))]
+	}
+	return string(b)
+}
@@ -0,0 +1,92 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type fuzzBook struct {
+	Name   string   `json:"name" required:"true" minLength:"2" maxLength:"20"`
+	Price  int      `json:"price" required:"true" min:"1" max:"500"`
+	Status string   `json:"status" enum:"available,sold"`
+	Tags   []string `json:"tags" maxItems:"3"`
+}
+
+// recordingT captures fuzz failures instead of failing the test.
+type recordingT struct {
+	TestingT
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestFuzz(t *testing.T) {
+	o := New()
+	route := o.Post("/books", func(c *Context) error {
+		var book fuzzBook
+		// Decode strictly: Bind tolerates JSON decoding errors.
+		if err := c.BindJSON(&book); err != nil {
+			return c.AbortBadRequest("Invalid book", err)
+		}
+		if err := validateStruct(&book); err != nil {
+			return c.AbortBadRequest("Invalid book", err)
+		}
+		return c.Created(book)
+	}, DocRequestBody(fuzzBook{}), DocResponse(201, fuzzBook{}), DocResponse(400, ErrorResponse{}))
+	Fuzz(t, o, route, 200)
+
+	// A handler that panics on some inputs and accepts invalid payloads is reported.
+	buggy := o.Post("/buggy", func(c *Context) error {
+		var book map[string]any
+		_ = c.Bind(&book)
+		if name, _ := book["name"].(string); strings.HasPrefix(name, "x") {
+			panic("boom")
+		}
+		return c.Created(book)
+	}, DocRequestBody(fuzzBook{}), DocResponse(201, fuzzBook{}))
+	rec := &recordingT{TestingT: t}
+	Fuzz(rec, o, buggy, 100)
+	if len(rec.errors) == 0 {
+		t.Fatal("expected fuzz failures for a handler accepting invalid payloads")
+	}
+
+	// Routes documented in a named spec are fuzzed from that spec.
+	admin := o.Post("/admin/books", func(c *Context) error {
+		var book fuzzBook
+		if err := c.BindJSON(&book); err != nil {
+			return c.AbortBadRequest("Invalid book", err)
+		}
+		if err := validateStruct(&book); err != nil {
+			return c.AbortBadRequest("Invalid book", err)
+		}
+		return c.Created(book)
+	}, DocSpec("admin"), DocRequestBody(fuzzBook{}), DocResponse(201, fuzzBook{}), DocResponse(400, ErrorResponse{}))
+	Fuzz(t, o, admin, 50)
+}
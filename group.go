@@ -24,7 +24,10 @@
 
 package okapi
 
-import "net/http"
+import (
+	"net/http"
+	"slices"
+)
 
 type Group struct {
 	// Prefix is the base path for all routes in this group.
@@ -39,19 +42,15 @@ type Group struct {
 	middlewares []Middleware
 	okapi       *Okapi
 	security    []map[string][]string
+	headers     map[string]string
+	spec        string
+	defaults    []RouteOption
 }
 
 // GroupTag describes an OpenAPI tag with a human-readable description.
 // When attached to a Group via WithTagInfo, the tag is emitted at the
 // root of the OpenAPI specification.
-type GroupTag struct {
-	// Name is the tag name (matches what appears on Operation.Tags).
-	Name string
-	// Description is the human-readable description shown in the API docs.
-	Description string
-	// ExternalDocs optionally links to additional documentation for this tag.
-	ExternalDocs *ExternalDocs
-}
+type GroupTag = TagInfo
 
 // newGroup creates a new route group with the specified base path, Okapi reference,
 // and optional middlewares.
@@ -138,6 +137,42 @@ func (g *Group) WithSecurity(security []map[string][]string) *Group {
 	return g
 }
 
+// WithOpenAPIDoc documents the Group's routes, including those of its subgroups,
+// in the named OpenAPI document instead of the public one, so internal or admin
+// endpoints stay documented without being published in the public contract.
+//
+//	internal := o.Group("/internal").WithOpenAPIDoc("internal")
+//	// Served at /openapi/internal.json, /openapi/internal.yaml and /docs/internal
+func (g *Group) WithOpenAPIDoc(name string) *Group {
+	g.spec = name
+	return g
+}
+
+// WithResponseHeaders sets default response headers for all routes in the Group.
+// Route-level headers set with Route.WithResponseHeaders take precedence.
+func (g *Group) WithResponseHeaders(headers map[string]string) *Group {
+	if g.headers == nil {
+		g.headers = make(map[string]string, len(headers))
+	}
+	for name, value := range headers {
+		g.headers[http.CanonicalHeaderKey(name)] = value
+	}
+	return g
+}
+
+// WithRouteDefaults applies opts to every route registered afterward in the Group
+// and its subgroups, after the instance defaults and before the route's own
+// options.
+//
+//	books := o.Group("/books").WithRouteDefaults(
+//		okapi.DocBearerAuth(),
+//		okapi.DocResponse(http.StatusNotFound, ErrorResponse{}),
+//	)
+func (g *Group) WithRouteDefaults(opts ...RouteOption) *Group {
+	g.defaults = append(g.defaults, opts...)
+	return g
+}
+
 // Okapi returns the parent Okapi instance associated with this group.
 func (g *Group) Okapi() *Okapi {
 	return g.okapi
@@ -154,6 +189,41 @@ func (g *Group) Use(m ...Middleware) {
 	g.middlewares = append(g.middlewares, m...)
 }
 
+// Before registers a hook that runs before the handlers of the group's routes.
+// Returning an error skips the handler; the error is handled like a handler error.
+// It is a lighter alternative to a middleware for simple concerns:
+//
+//	v2 := o.Group("/api/v2")
+//	v2.Before(func(c *okapi.Context) error {
+//	  c.SetHeader("X-API-Version", "2")
+//	  return nil
+//	})
+//
+// Hooks take their place in the group's middleware chain, apply to the routes
+// registered afterward and are inherited by subgroups.
+func (g *Group) Before(hook func(c *Context) error) *Group {
+	g.Use(func(c *Context) error {
+		if err := hook(c); err != nil {
+			return err
+		}
+		return c.Next()
+	})
+	return g
+}
+
+// After registers a hook that runs once the handlers of the group's routes
+// return, with the error they returned, e.g. for auditing or metrics.
+// The response has usually been written by then, so headers must be set in a
+// Before hook instead. Like Before, it applies to the routes registered afterward.
+func (g *Group) After(hook func(c *Context, err error)) *Group {
+	g.Use(func(c *Context) error {
+		err := c.Next()
+		hook(c, err)
+		return err
+	})
+	return g
+}
+
 // add is an internal method that handles route registration with the combined
 // middlewares from both the group and parent Okapi instance.
 func (g *Group) add(method, path string, h HandlerFunc, opts ...RouteOption) *Route {
@@ -161,6 +231,9 @@ func (g *Group) add(method, path string, h HandlerFunc, opts ...RouteOption) *Ro
 		panic("okapi instance is nil, cannot register route")
 	}
 	fullPath := joinPaths(g.Prefix, path)
+	if len(g.defaults) > 0 {
+		opts = append(slices.Clip(g.defaults), opts...)
+	}
 	// Prepend group middleware before any route-level middleware
 	if len(g.middlewares) > 0 {
 		groupMW := make([]Middleware, len(g.middlewares))
@@ -174,6 +247,7 @@ func (g *Group) add(method, path string, h HandlerFunc, opts ...RouteOption) *Ro
 		route.tags = []string{g.Prefix}
 	}
 	route.tagInfos = append(route.tagInfos, g.tagInfos...)
+	route.group = g.Prefix
 	return route.setDisabled(g.disabled)
 }
 
@@ -191,6 +265,12 @@ func (g *Group) handle(method, path string, h HandlerFunc, opts ...RouteOption)
 	if len(g.security) > 0 {
 		opts = append(opts, withSecurity(g.security))
 	}
+	if len(g.headers) > 0 {
+		opts = append([]RouteOption{ResponseHeaders(g.headers)}, opts...)
+	}
+	if g.spec != "" {
+		opts = append([]RouteOption{DocSpec(g.spec)}, opts...)
+	}
 	return g.add(method, path, h, opts...)
 }
 
@@ -232,7 +312,7 @@ func (g *Group) Head(path string, h HandlerFunc, opts ...RouteOption) *Route {
 // Group creates a nested subgroup with an additional path segment and optional middlewares.
 // The new group inherits all middlewares from its parent group.
 func (g *Group) Group(path string, middlewares ...Middleware) *Group {
-	return newGroup(
+	sub := newGroup(
 		// Combine paths
 		joinPaths(g.Prefix, path),
 		g.disabled,
@@ -240,13 +320,69 @@ func (g *Group) Group(path string, middlewares ...Middleware) *Group {
 		g.okapi,
 		// Combine middlewares
 		append(g.middlewares, middlewares...)...)
+	// Inherit default response headers
+	if len(g.headers) > 0 {
+		sub.WithResponseHeaders(g.headers)
+	}
+	sub.spec = g.spec
+	sub.defaults = slices.Clone(g.defaults)
+	return sub
+}
+
+// Static serves static files from dir under the group's prefix joined with prefix,
+// without directory listing.
+//
+// Unlike Okapi.Static, requests go through the global and group middlewares,
+// so files can be protected by the group's authentication:
+//
+//	admin := o.Group("/api/v2/admin", jwtAuth.Middleware)
+//	admin.Static("/files", "./uploads") // GET /api/v2/admin/files/report.pdf requires a valid JWT
+func (g *Group) Static(prefix, dir string) {
+	g.staticFS(prefix, noDirListing{http.Dir(dir)})
+}
+
+// StaticFS serves static files from a custom http.FileSystem (e.g., embed.FS)
+// under the group's prefix, through the group's middleware chain.
+func (g *Group) StaticFS(prefix string, fs http.FileSystem) {
+	g.staticFS(prefix, fs)
+}
+
+// StaticFile serves a single file at the specified path within the group,
+// through the group's middleware chain.
+func (g *Group) StaticFile(path, filepath string) {
+	g.add(methodGet, path, func(c *Context) error {
+		c.ServeFile(filepath)
+		return nil
+	}).internalRoute().Hide()
+}
+
+// staticFS mounts a file server on a path prefix, running the global and group
+// middlewares before serving files. The mount is not documented in OpenAPI.
+func (g *Group) staticFS(prefix string, fs http.FileSystem) {
+	if g.okapi == nil {
+		panic("okapi instance is nil, cannot register static files")
+	}
+	fullPath := joinPaths(g.Prefix, prefix)
+	fileServer := http.StripPrefix(fullPath, http.FileServer(fs))
+	route := &Route{
+		Name:        "static",
+		Path:        fullPath,
+		Method:      methodGet,
+		handle:      g.okapi.wrapHTTPHandler(fileServer),
+		chain:       g.okapi,
+		middlewares: append([]Middleware{}, g.middlewares...),
+		disabled:    g.disabled,
+		hidden:      true,
+		internal:    true,
+	}
+	g.okapi.router.muxRouter.PathPrefix(fullPath).HandlerFunc(g.okapi.serveRoute(route)).Methods(http.MethodGet, http.MethodHead)
 }
 
 // HandleStd registers a standard http.HandlerFunc and wraps it with the group's middleware chain.
 func (g *Group) HandleStd(method, path string, h func(http.ResponseWriter, *http.Request), opts ...RouteOption) {
 	// Convert standard handler to HandlerFunc
 	converted := func(c *Context) error {
-		h(c.response, c.request)
+		h(c.response, c.requestWithContext())
 		return nil
 	}
 	// Prepend group middleware
@@ -291,21 +427,7 @@ func (g *Group) HandleHTTP(method, path string, h http.Handler, opts ...RouteOpt
 // This enables compatibility with existing middleware libraries that use the
 // func(http.Handler) http.Handler pattern.
 func (g *Group) UseMiddleware(mw func(http.Handler) http.Handler) {
-	g.Use(func(c *Context) error {
-		// Convert the rest of the chain into an http.Handler
-		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Continue the Okapi middleware chain
-			c.request = r
-			if err := c.Next(); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-		})
-
-		// Apply standard middleware and serve
-		wrapped := mw(next)
-		wrapped.ServeHTTP(c.response, c.request)
-		return nil
-	})
+	g.Use(WrapMiddleware(mw))
 }
 
 // Register registers a slice of RouteDefinition with the group.
@@ -0,0 +1,302 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GroupConfig overrides the middleware settings of a route group without
+// recompiling, typically from a configuration file, see WithGroupConfigFile.
+// Zero fields keep the settings of the code.
+type GroupConfig struct {
+	// AllowedOrigins replaces the CORS origins allowed on the group's routes,
+	// replacing their Cors.CORSHandler middlewares. Other CORS settings are
+	// those of WithCors.
+	AllowedOrigins []string `json:"allowed_origins" yaml:"allowed_origins"`
+	// RateLimit is the number of requests allowed per client IP and RateWindow,
+	// replacing the rate limit middlewares of the group's routes.
+	RateLimit int `json:"rate_limit" yaml:"rate_limit"`
+	// RateWindow is the rate limit window, e.g. "1m". Default one minute.
+	RateWindow string `json:"rate_window" yaml:"rate_window"`
+	// Timeout is the maximum duration of the group's routes, e.g. "5s",
+	// see Route.WithMaxDuration.
+	Timeout string `json:"timeout" yaml:"timeout"`
+}
+
+// groupConfigFile is the section read by WithGroupConfigFile, other sections
+// of the file are ignored.
+type groupConfigFile struct {
+	Groups yaml.Node `yaml:"groups"`
+}
+
+// groupSettings are the middleware settings resolved from a GroupConfig.
+type groupSettings struct {
+	cors    *Cors
+	limiter *RateLimit
+	timeout time.Duration
+}
+
+// corsMiddleware identifies Cors.CORSHandler in a middleware chain.
+var corsMiddleware = reflect.ValueOf(Cors{}.CORSHandler).Pointer()
+
+// WithGroupConfig overrides the settings of route groups, keyed by group
+// prefix. A group's settings apply to its subgroups unless they override them.
+// The configuration is validated by Start, which fails on invalid values and
+// on prefixes matching no group.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithGroupConfig(map[string]okapi.GroupConfig{
+//		"/api": {AllowedOrigins: []string{"https://app.example.com"}, RateLimit: 100},
+//	}))
+func WithGroupConfig(groups map[string]GroupConfig) OptionFunc {
+	return func(o *Okapi) {
+		if o.groupConfigs == nil {
+			o.groupConfigs = make(map[string]GroupConfig, len(groups))
+		}
+		maps.Copy(o.groupConfigs, groups)
+	}
+}
+
+// WithGroupConfigFile reads group settings from the "groups" section of a YAML
+// or JSON file when the server starts, see WithGroupConfig. Its groups take
+// precedence over those given to WithGroupConfig.
+//
+// Example file:
+//
+//	groups:
+//	  /api:
+//	    allowed_origins: ["https://app.example.com"]
+//	    rate_limit: 100
+//	    rate_window: 1m
+//	    timeout: 5s
+func WithGroupConfigFile(path string) OptionFunc {
+	return func(o *Okapi) {
+		o.groupConfigFile = path
+	}
+}
+
+// WithGroupConfig overrides the settings of route groups, see WithGroupConfig.
+func (o *Okapi) WithGroupConfig(groups map[string]GroupConfig) *Okapi {
+	return o.apply(WithGroupConfig(groups))
+}
+
+// WithGroupConfigFile reads group settings from a file, see WithGroupConfigFile.
+func (o *Okapi) WithGroupConfigFile(path string) *Okapi {
+	return o.apply(WithGroupConfigFile(path))
+}
+
+// loadGroupConfigFile reads the groups section of a configuration file.
+// Unknown fields within the section are reported as errors.
+func loadGroupConfigFile(path string) (map[string]GroupConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file groupConfigFile
+	if err = yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if file.Groups.IsZero() {
+		return nil, nil
+	}
+	section, err := yaml.Marshal(&file.Groups)
+	if err != nil {
+		return nil, err
+	}
+	var groups map[string]GroupConfig
+	dec := yaml.NewDecoder(bytes.NewReader(section))
+	dec.KnownFields(true)
+	if err = dec.Decode(&groups); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return groups, nil
+}
+
+// applyGroupConfig validates the group configuration and applies it to the
+// routes of the configured groups.
+func (o *Okapi) applyGroupConfig() error {
+	configs := maps.Clone(o.groupConfigs)
+	if o.groupConfigFile != "" {
+		groups, err := loadGroupConfigFile(o.groupConfigFile)
+		if err != nil {
+			return fmt.Errorf("okapi: invalid group config: %w", err)
+		}
+		if configs == nil {
+			configs = make(map[string]GroupConfig, len(groups))
+		}
+		maps.Copy(configs, groups)
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+	settings := make(map[string]*groupSettings, len(configs))
+	var errs []error
+	for _, prefix := range slices.Sorted(maps.Keys(configs)) {
+		s, err := o.resolveGroupConfig(configs[prefix])
+		if err == nil && !o.hasGroup(prefix) {
+			err = errors.New("no route group has this prefix")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("group %q: %w", prefix, err))
+			continue
+		}
+		if s.limiter != nil {
+			s.limiter.Name = "group:" + prefix
+		}
+		settings[prefix] = s
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("okapi: invalid group config: %w", err)
+	}
+	for _, r := range o.routes {
+		r.applyGroupSettings(settings)
+	}
+	return nil
+}
+
+// resolveGroupConfig validates cfg and builds its middleware settings.
+func (o *Okapi) resolveGroupConfig(cfg GroupConfig) (*groupSettings, error) {
+	s := &groupSettings{}
+	var errs []error
+	if cfg.AllowedOrigins != nil {
+		for _, origin := range cfg.AllowedOrigins {
+			if err := validateOrigin(origin); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		cors := o.cors
+		cors.AllowedOrigins = slices.Clone(cfg.AllowedOrigins)
+		s.cors = &cors
+	}
+	var window time.Duration
+	if cfg.RateWindow != "" {
+		var err error
+		if window, err = time.ParseDuration(cfg.RateWindow); err != nil || window <= 0 {
+			errs = append(errs, fmt.Errorf("invalid rate_window %q", cfg.RateWindow))
+		}
+	}
+	switch {
+	case cfg.RateLimit < 0:
+		errs = append(errs, fmt.Errorf("invalid rate_limit %d", cfg.RateLimit))
+	case cfg.RateLimit > 0:
+		s.limiter = &RateLimit{Limit: cfg.RateLimit, Window: window}
+	}
+	if cfg.Timeout != "" {
+		timeout, err := time.ParseDuration(cfg.Timeout)
+		if err != nil || timeout <= 0 {
+			errs = append(errs, fmt.Errorf("invalid timeout %q", cfg.Timeout))
+		}
+		s.timeout = timeout
+	}
+	return s, errors.Join(errs...)
+}
+
+// validateOrigin reports whether origin is "*" or a scheme and host, with an
+// optional subdomain wildcard.
+func validateOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || strings.Trim(u.Path, "/") != "" {
+		return fmt.Errorf("invalid allowed origin %q", origin)
+	}
+	return nil
+}
+
+// hasGroup reports whether a route was registered on the group prefix or one
+// of its subgroups.
+func (o *Okapi) hasGroup(prefix string) bool {
+	for _, r := range o.routes {
+		if r.group != "" && inGroup(r.group, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// inGroup reports whether the group prefix group is prefix or one of its subgroups.
+func inGroup(group, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return group == prefix || strings.HasPrefix(group, prefix+"/")
+}
+
+// applyGroupSettings applies the settings of the route's group, the most
+// specific group prefix taking precedence.
+func (r *Route) applyGroupSettings(settings map[string]*groupSettings) {
+	if r.group == "" {
+		return
+	}
+	var cors *Cors
+	var limiter *RateLimit
+	var timeout time.Duration
+	prefixes := slices.SortedFunc(maps.Keys(settings), func(a, b string) int { return len(a) - len(b) })
+	for _, prefix := range prefixes {
+		if !inGroup(r.group, prefix) {
+			continue
+		}
+		s := settings[prefix]
+		if s.cors != nil {
+			cors = s.cors
+		}
+		if s.limiter != nil {
+			limiter = s.limiter
+		}
+		if s.timeout > 0 {
+			timeout = s.timeout
+		}
+	}
+	var middlewares []Middleware
+	if cors != nil {
+		middlewares = append(middlewares, cors.CORSHandler)
+	}
+	if limiter != nil {
+		middlewares = append(middlewares, limiter.Middleware)
+	}
+	if len(middlewares) > 0 {
+		r.middlewares = append(middlewares, slices.DeleteFunc(slices.Clone(r.middlewares), func(m Middleware) bool {
+			code := reflect.ValueOf(m).Pointer()
+			return (cors != nil && code == corsMiddleware) ||
+				(limiter != nil && slices.Contains(rateLimitMiddlewares, code))
+		})...)
+	}
+	if timeout > 0 {
+		r.WithMaxDuration(timeout)
+	}
+}
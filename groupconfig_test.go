@@ -0,0 +1,133 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGroupConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestGroupConfig(t *testing.T) {
+	path := writeGroupConfig(t, `
+server:
+  port: 8080
+groups:
+  /api:
+    allowed_origins: ["https://app.example.com"]
+    rate_limit: 3
+  /api/v1:
+    allowed_origins: ["https://*.example.org"]
+    timeout: 5s
+`)
+	o := New(WithGroupConfigFile(path))
+	ts := NewTestServerWithOkapi(t, o)
+	legacy := Cors{AllowedOrigins: []string{"https://old.example.com"}}
+	api := o.Group("/api", legacy.CORSHandler, (&RateLimit{Limit: 100}).Middleware)
+	api.Get("/books", helloHandler)
+	api.Group("/v1").Get("/books", helloHandler)
+	o.Get("/public", helloHandler)
+	require.NoError(t, o.applyGroupConfig())
+
+	okapitest.GET(t, ts.BaseURL+"/api/books").Header("Origin", "https://app.example.com").
+		ExpectStatusOK().
+		ExpectHeader("Access-Control-Allow-Origin", "https://app.example.com").
+		ExpectHeader(headerRateLimitLimit, "3")
+	// The configured origins replace those of the code
+	okapitest.GET(t, ts.BaseURL+"/api/books").Header("Origin", "https://old.example.com").
+		ExpectStatusOK().
+		ExpectHeader("Access-Control-Allow-Origin", "")
+
+	// Subgroups inherit the settings they do not override, sharing the limiter
+	okapitest.GET(t, ts.BaseURL+"/api/v1/books").Header("Origin", "https://shop.example.org").
+		ExpectStatusOK().
+		ExpectHeader("Access-Control-Allow-Origin", "https://shop.example.org").
+		ExpectHeader(headerRateLimitRemaining, "0")
+	okapitest.GET(t, ts.BaseURL+"/api/books").ExpectStatus(http.StatusTooManyRequests)
+	for _, r := range o.routes {
+		switch r.Path {
+		case "/api/v1/books":
+			assert.Equal(t, "5s", r.budget.maxDuration.String())
+		case "/api/books":
+			assert.Nil(t, r.budget)
+		}
+	}
+	okapitest.GET(t, ts.BaseURL+"/public").ExpectStatusOK().ExpectHeader(headerRateLimitLimit, "")
+}
+
+func TestGroupConfigValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		option  OptionFunc
+		wantErr string
+	}{
+		{
+			name:    "unknown group",
+			option:  WithGroupConfig(map[string]GroupConfig{"/admin": {RateLimit: 10}}),
+			wantErr: `group "/admin": no route group has this prefix`,
+		},
+		{
+			name:    "invalid duration",
+			option:  WithGroupConfig(map[string]GroupConfig{"/api": {Timeout: "soon"}}),
+			wantErr: `invalid timeout "soon"`,
+		},
+		{
+			name:    "invalid origin",
+			option:  WithGroupConfig(map[string]GroupConfig{"/api": {AllowedOrigins: []string{"app.example.com"}}}),
+			wantErr: `invalid allowed origin "app.example.com"`,
+		},
+		{
+			name:    "unknown field",
+			option:  WithGroupConfigFile(writeGroupConfig(t, "groups:\n  /api:\n    rate_limits: 10\n")),
+			wantErr: "field rate_limits not found",
+		},
+		{
+			name:    "missing file",
+			option:  WithGroupConfigFile(filepath.Join(t.TempDir(), "missing.yaml")),
+			wantErr: "no such file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := New(tt.option)
+			o.Group("/api").Get("/books", helloHandler)
+			err := o.Start()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
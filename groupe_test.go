@@ -28,6 +28,8 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/jkaninda/okapi/okapitest"
@@ -250,3 +252,173 @@ func TestGroupWithTagInfo_RegisterPropagates(t *testing.T) {
 		assert.Equal(t, "API", o.openapiSpec.Tags[0].Description)
 	}
 }
+
+func TestGroupWithResponseHeaders(t *testing.T) {
+	o := NewTestServer(t)
+	auth := o.Group("/auth").WithResponseHeaders(map[string]string{
+		"cache-control": "no-store",
+		"Pragma":        "no-cache",
+	})
+	auth.Post("/login", helloHandler)
+	auth.Get("/public", helloHandler, DocResponse(BookTest{})).WithResponseHeaders(map[string]string{"Cache-Control": "max-age=60"})
+	auth.Get("/override", func(c *Context) error {
+		c.SetHeader("Pragma", "custom")
+		return c.OK(M{"ok": true})
+	})
+	auth.Group("/v2").Get("/me", helloHandler)
+	// Names differing by case configure the same header, the last value wins.
+	auth.WithResponseHeaders(map[string]string{"x-frame-options": "SAMEORIGIN"}).
+		WithResponseHeaders(map[string]string{"X-Frame-Options": "DENY"})
+	assert.Equal(t, map[string]string{"Cache-Control": "no-store", "Pragma": "no-cache", "X-Frame-Options": "DENY"}, auth.headers)
+
+	okapitest.POST(t, o.BaseURL+"/auth/login").
+		ExpectHeader("Cache-Control", "no-store").
+		ExpectHeader("Pragma", "no-cache")
+	okapitest.GET(t, o.BaseURL+"/auth/public").ExpectHeader("Cache-Control", "max-age=60")
+	okapitest.GET(t, o.BaseURL+"/auth/override").ExpectHeader("Pragma", "custom")
+	okapitest.GET(t, o.BaseURL+"/auth/v2/me").ExpectHeader("Cache-Control", "no-store")
+
+	o.buildOpenAPISpec()
+	op := o.openapiSpec.Paths.Find("/auth/public").Get
+	if assert.NotNil(t, op) {
+		headers := op.Responses.Status(http.StatusOK).Value.Headers
+		if assert.Contains(t, headers, "Cache-Control") {
+			assert.Contains(t, headers["Cache-Control"].Value.Description, "max-age=60")
+		}
+		assert.Contains(t, headers, "Pragma")
+	}
+}
+
+func TestGroupBeforeAfter(t *testing.T) {
+	o := NewTestServer(t)
+	var calls []string
+	api := o.Group("/api/v2")
+	api.Before(func(c *Context) error {
+		c.SetHeader("X-API-Version", "2")
+		calls = append(calls, "before")
+		return nil
+	}).After(func(c *Context, err error) {
+		calls = append(calls, "after:"+c.Path())
+	})
+	api.Get("/books", func(c *Context) error {
+		calls = append(calls, "handler")
+		return c.OK(M{"ok": true})
+	})
+	admin := api.Group("/admin")
+	admin.Before(func(c *Context) error {
+		if c.Header("X-Tenant") == "" {
+			return c.AbortForbidden("missing tenant")
+		}
+		return nil
+	})
+	admin.Get("/stats", helloHandler)
+
+	okapitest.GET(t, o.BaseURL+"/api/v2/books").ExpectStatusOK().ExpectHeader("X-API-Version", "2")
+	assert.Equal(t, []string{"before", "handler", "after:/api/v2/books"}, calls)
+
+	calls = nil
+	okapitest.GET(t, o.BaseURL+"/api/v2/admin/stats").ExpectStatus(http.StatusForbidden).ExpectHeader("X-API-Version", "2")
+	assert.Equal(t, []string{"before", "after:/api/v2/admin/stats"}, calls)
+	okapitest.GET(t, o.BaseURL+"/api/v2/admin/stats").Header("X-Tenant", "acme").ExpectStatusOK()
+}
+
+func TestGroupStatic(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "report.txt"), []byte("secret report"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	requireToken := func(c *Context) error {
+		if c.Header("Authorization") != "Bearer token" {
+			return c.AbortUnauthorized("Unauthorized")
+		}
+		return c.Next()
+	}
+
+	o := NewTestServer(t)
+	admin := o.Group("/api/admin", requireToken)
+	admin.Static("/files", dir)
+	admin.StaticFile("/report", filepath.Join(dir, "report.txt"))
+	disabled := o.Group("/disabled").Disable()
+	disabled.Static("/files", dir)
+
+	okapitest.GET(t, o.BaseURL+"/api/admin/files/report.txt").ExpectStatusUnauthorized()
+	okapitest.GET(t, o.BaseURL+"/api/admin/files/report.txt").
+		SetBearerAuth("token").
+		ExpectStatusOK().
+		ExpectBody("secret report")
+	okapitest.GET(t, o.BaseURL+"/api/admin/report").ExpectStatusUnauthorized()
+	okapitest.GET(t, o.BaseURL+"/api/admin/report").SetBearerAuth("token").ExpectBody("secret report")
+	okapitest.GET(t, o.BaseURL+"/disabled/files/report.txt").ExpectStatusNotFound()
+
+	assert.Empty(t, o.Routes())
+}
+
+func TestGroupWithOpenAPIDoc(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/books", helloHandler)
+	internal := ts.Group("/internal").WithOpenAPIDoc("internal").WithTagInfo(
+		GroupTag{Name: "ops", Description: "Operations"},
+	)
+	internal.Get("/metrics", helloHandler)
+	internal.Group("/admin").Post("/reindex", helloHandler)
+	ts.WithOpenAPIDocs(OpenAPI{Title: "Books"})
+
+	public := ts.openapiSpec
+	assert.NotNil(t, public.Paths.Value("/books"))
+	assert.Nil(t, public.Paths.Value("/internal/metrics"))
+	assert.Nil(t, public.Paths.Value("/internal/admin/reindex"))
+	assert.Empty(t, public.Tags)
+
+	named := ts.namedSpecs["internal"]
+	if assert.NotNil(t, named) {
+		assert.Nil(t, named.spec.Paths.Value("/books"))
+		assert.NotNil(t, named.spec.Paths.Value("/internal/metrics"))
+		assert.NotNil(t, named.spec31.Paths.Value("/internal/admin/reindex"))
+		assert.Len(t, named.spec.Tags, 1)
+	}
+
+	okapitest.GET(t, ts.BaseURL+"/openapi.json").ExpectStatusOK().ExpectBodyContains("/books")
+	okapitest.GET(t, ts.BaseURL+"/openapi/internal.json").ExpectStatusOK().ExpectBodyContains("/internal/metrics")
+	okapitest.GET(t, ts.BaseURL+"/openapi/internal-3.0.yaml").ExpectStatusOK().ExpectBodyContains("/internal/admin/reindex")
+	okapitest.GET(t, ts.BaseURL+"/docs/internal").
+		ExpectStatusOK().
+		ExpectBodyContains("internal.json").
+		ExpectBodyContains("Books (internal)")
+	okapitest.GET(t, ts.BaseURL+"/docs").ExpectStatusOK().ExpectBodyContains(`openapi.json'`)
+}
+
+func TestWithRouteDefaults(t *testing.T) {
+	var calls []string
+	trace := func(name string) RouteOption {
+		return UseMiddleware(func(c *Context) error {
+			calls = append(calls, name)
+			return c.Next()
+		})
+	}
+	o := New(WithRouteDefaults(DocResponse(http.StatusBadRequest, ErrorResponse{}), trace("instance")))
+	o.Get("/books", anyHandler, DocResponse([]Book{}))
+	api := o.Group("/api").WithRouteDefaults(DocBearerAuth(), trace("group"))
+	api.Group("/v1").Get("/authors", anyHandler, trace("route"))
+	o.WithRouteDefaults(DocDeprecated())
+	o.Get("/legacy", anyHandler)
+	o.WithOpenAPIDocs()
+
+	books := o.openapiSpec.Paths.Find("/books").Get
+	assert.NotNil(t, books.Responses.Status(http.StatusOK))
+	assert.NotNil(t, books.Responses.Status(http.StatusBadRequest))
+	assert.False(t, books.Deprecated, "defaults apply to the routes registered afterward")
+
+	authors := o.openapiSpec.Paths.Find("/api/v1/authors").Get
+	assert.NotNil(t, authors.Responses.Status(http.StatusBadRequest))
+	assert.NotNil(t, authors.Security, "group defaults are inherited by subgroups")
+	assert.True(t, o.openapiSpec.Paths.Find("/legacy").Get.Deprecated)
+
+	ts := NewTestServerWithOkapi(t, o)
+	okapitest.GET(t, ts.BaseURL+"/api/v1/authors").ExpectStatusOK()
+	assert.Equal(t, []string{"instance", "group", "route"}, calls)
+
+	// Documentation routes are registered without the defaults.
+	calls = nil
+	okapitest.GET(t, ts.BaseURL+"/openapi.json").ExpectStatusOK()
+	assert.Empty(t, calls)
+}
@@ -0,0 +1,97 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	goutils "github.com/jkaninda/go-utils"
+)
+
+// WithGRPC serves gRPC on the same ports as the HTTP API. h is typically a
+// *grpc.Server, which implements http.Handler:
+//
+//	grpcServer := grpc.NewServer()
+//	pb.RegisterBookServiceServer(grpcServer, &bookService{})
+//	o := okapi.New(okapi.WithGRPC(grpcServer))
+//
+// HTTP/2 requests with an application/grpc content type are handed to h, every
+// other request is routed as usual. HTTP/2 is enabled on the servers, over TLS
+// when configured and in cleartext (h2c) otherwise. gRPC calls share the access
+// log and graceful shutdown of the instance, but not its middlewares: use gRPC
+// interceptors for authentication and the like.
+func WithGRPC(h http.Handler) OptionFunc {
+	return func(o *Okapi) {
+		o.grpcHandler = h
+	}
+}
+
+// WithGRPC serves gRPC on the same ports as the HTTP API.
+func (o *Okapi) WithGRPC(h http.Handler) *Okapi {
+	return o.apply(WithGRPC(h))
+}
+
+// isGRPCRequest reports whether r is a gRPC call.
+func isGRPCRequest(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get(constContentTypeHeader), "application/grpc")
+}
+
+// enableHTTP2 lets server accept the HTTP/2 connections gRPC clients open,
+// including cleartext ones.
+func enableHTTP2(server *http.Server) {
+	if server == nil {
+		return
+	}
+	if server.Protocols == nil {
+		server.Protocols = new(http.Protocols)
+		server.Protocols.SetHTTP1(true)
+	}
+	server.Protocols.SetHTTP2(true)
+	server.Protocols.SetUnencryptedHTTP2(true)
+}
+
+// serveGRPC hands r to the gRPC handler, logging the call like HTTP requests.
+func (o *Okapi) serveGRPC(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	o.grpcHandler.ServeHTTP(w, r)
+	if !o.accessLog {
+		return
+	}
+	status := w.Header().Get("Grpc-Status")
+	fields := []any{
+		"method", r.URL.Path,
+		"grpc_status", status,
+		"duration", goutils.FormatDuration(time.Since(start), 2),
+		"ip", r.RemoteAddr,
+		"user_agent", r.UserAgent(),
+	}
+	if status != "" && status != "0" {
+		o.logger.Warn("[okapi] Incoming gRPC request", fields...)
+		return
+	}
+	o.logger.Info("[okapi] Incoming gRPC request", fields...)
+}
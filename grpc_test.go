@@ -0,0 +1,93 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithGRPC(t *testing.T) {
+	grpcHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		_, _ = w.Write([]byte("grpc:" + r.URL.Path))
+		w.Header().Set("Grpc-Status", "0")
+	})
+	o := New(WithAddr("127.0.0.1:8093"), WithGRPC(grpcHandler))
+	o.Post("/books.v1.BookService/GetBook", func(c *Context) error {
+		return c.Text(http.StatusOK, "http")
+	})
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- o.Start() }()
+	t.Cleanup(func() {
+		_ = o.Stop()
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			t.Errorf("expected ErrServerClosed, got %v", err)
+		}
+	})
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	h2c := &http.Client{Transport: &http.Transport{Protocols: protocols}, Timeout: 2 * time.Second}
+	h1 := &http.Client{Timeout: 2 * time.Second}
+
+	call := func(c *http.Client, contentType string) (string, *http.Response) {
+		t.Helper()
+		var resp *http.Response
+		var err error
+		for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+			resp, err = c.Post("http://127.0.0.1:8093/books.v1.BookService/GetBook", contentType, strings.NewReader("payload"))
+			if err == nil {
+				break
+			}
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body), resp
+	}
+
+	body, resp := call(h2c, "application/grpc")
+	if body != "grpc:/books.v1.BookService/GetBook" || resp.ProtoMajor != 2 {
+		t.Errorf("gRPC call served by %q over HTTP/%d", body, resp.ProtoMajor)
+	}
+	if got := resp.Trailer.Get("Grpc-Status"); got != "0" {
+		t.Errorf("Grpc-Status trailer = %q", got)
+	}
+	if body, _ := call(h2c, "application/json"); body != "http" {
+		t.Errorf("HTTP/2 JSON request served by %q", body)
+	}
+	if body, _ := call(h1, "application/grpc"); body != "http" {
+		t.Errorf("HTTP/1.1 request served by %q", body)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Hardening configures server-level request checks, applied before routing
+// to every request, including those matching no route. Rejected requests are
+// logged with their remote address. See WithHardening.
+type Hardening struct {
+	// MaxHeaderCount rejects requests with more header fields with
+	// 431 Request Header Fields Too Large. Zero means no limit.
+	MaxHeaderCount int
+	// MaxHeaderBytes bounds the size of request headers, including the request
+	// line, on both the HTTP and the HTTPS server, see http.Server.MaxHeaderBytes.
+	// Zero keeps the net/http default of 1 MB.
+	MaxHeaderBytes int
+	// AllowedMethods rejects requests using other methods with 405 Method Not Allowed.
+	// Empty allows every method.
+	AllowedMethods []string
+}
+
+// WithHardening enables request hardening. Besides the configured limits,
+// requests declaring both a Content-Length and a Transfer-Encoding, or several
+// Content-Length values, are rejected with 400 Bad Request, as such ambiguous
+// framing is the basis of request smuggling between a proxy and the server.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithHardening(okapi.Hardening{
+//		MaxHeaderCount: 100,
+//		MaxHeaderBytes: 16 << 10,
+//		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodOptions},
+//	}))
+func WithHardening(h Hardening) OptionFunc {
+	return func(o *Okapi) {
+		o.hardening = &h
+		if h.MaxHeaderBytes > 0 {
+			o.maxHeaderBytes = h.MaxHeaderBytes
+			o.server.MaxHeaderBytes = h.MaxHeaderBytes
+			o.tlsServer.MaxHeaderBytes = h.MaxHeaderBytes
+		}
+	}
+}
+
+// WithHardening enables request hardening, see WithHardening.
+func (o *Okapi) WithHardening(h Hardening) *Okapi {
+	return o.apply(WithHardening(h))
+}
+
+// reject answers r with an error when it fails a hardening check, reporting
+// whether it did.
+func (h *Hardening) reject(o *Okapi, w http.ResponseWriter, r *http.Request) bool {
+	status, reason := h.check(r)
+	if status == 0 {
+		return false
+	}
+	o.logger.Warn("[okapi] request rejected", "reason", reason, "method", r.Method,
+		"path", r.URL.Path, "remote_addr", r.RemoteAddr)
+	w.Header().Set("Connection", "close")
+	http.Error(w, http.StatusText(status), status)
+	return true
+}
+
+// check returns the status and reason to reject r with, or zero.
+func (h *Hardening) check(r *http.Request) (int, string) {
+	lengths := r.Header.Values("Content-Length")
+	if len(lengths) > 0 && (len(r.TransferEncoding) > 0 || r.Header.Get("Transfer-Encoding") != "") {
+		return http.StatusBadRequest, "both Content-Length and Transfer-Encoding"
+	}
+	if len(lengths) > 1 || (len(lengths) == 1 && strings.Contains(lengths[0], ",")) {
+		return http.StatusBadRequest, "multiple Content-Length values"
+	}
+	if len(h.AllowedMethods) > 0 && !slices.Contains(h.AllowedMethods, r.Method) {
+		return http.StatusMethodNotAllowed, "method not allowed"
+	}
+	if h.MaxHeaderCount > 0 {
+		count := 0
+		for _, values := range r.Header {
+			count += len(values)
+		}
+		if count > h.MaxHeaderCount {
+			return http.StatusRequestHeaderFieldsTooLarge, "too many header fields"
+		}
+	}
+	return 0, ""
+}
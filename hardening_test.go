@@ -0,0 +1,82 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHardening(t *testing.T) {
+	o := New(WithHardening(Hardening{
+		MaxHeaderCount: 5,
+		MaxHeaderBytes: 8 << 10,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+	}))
+	o.Get("/", helloHandler)
+	o.Post("/", helloHandler)
+	assert.Equal(t, 8<<10, o.server.MaxHeaderBytes)
+
+	serve := func(r *http.Request) int {
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, r)
+		return rec.Code
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+		r.Header.Set("Content-Length", "5")
+		assert.Equal(t, http.StatusOK, serve(r))
+	})
+
+	t.Run("ambiguous framing", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("0\r\n\r\n"))
+		r.Header.Set("Content-Length", "5")
+		r.TransferEncoding = []string{"chunked"}
+		assert.Equal(t, http.StatusBadRequest, serve(r))
+
+		r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+		r.Header["Content-Length"] = []string{"5", "6"}
+		assert.Equal(t, http.StatusBadRequest, serve(r))
+	})
+
+	t.Run("disallowed method", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(httptest.NewRequest(http.MethodTrace, "/", nil)))
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(httptest.NewRequest("get", "/missing", nil)))
+	})
+
+	t.Run("too many headers", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for i := range 6 {
+			r.Header.Set("X-Header-"+strconv.Itoa(i), "v")
+		}
+		assert.Equal(t, http.StatusRequestHeaderFieldsTooLarge, serve(r))
+	})
+}
@@ -0,0 +1,141 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// headersContextKey stores the headers bound by Route.WithHeaders on the Context store.
+const headersContextKey = "okapi.headers"
+
+// WithHeaders declares the request headers of the route as a struct whose
+// fields are tagged with `header:"Name"`, e.g. If-Match, Idempotency-Key or
+// custom X- headers. Okapi will:
+//   - Document each field as a typed header parameter, with its validation tags
+//   - Bind and validate the headers before the handler runs, answering 400 Bad Request on failure
+//   - Make the bound struct available to the handler through HeadersOf
+//
+// Example:
+//
+//	type WriteHeaders struct {
+//	    IfMatch        string `header:"If-Match" required:"true" description:"Current ETag"`
+//	    IdempotencyKey string `header:"Idempotency-Key" minLength:"16"`
+//	}
+//
+//	o.Put("/books/:id", updateBook).WithHeaders(&WriteHeaders{})
+//
+//	func updateBook(c *okapi.Context) error {
+//	    h := okapi.HeadersOf[WriteHeaders](c)
+//	    ...
+//	}
+func (r *Route) WithHeaders(v any) *Route {
+	if v == nil {
+		return r
+	}
+	t := normalizeToStructPointer(v, "headers").Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := sf.Tag.Get(tagHeader)
+		if name == "" {
+			continue
+		}
+		param := createParameter(name, paramHeader, extractFieldInfo(sf))
+		applyValidationTags(param.Value.Schema.Value, sf.Tag)
+		r.headers = replaceParameter(r.headers, param)
+	}
+	r.headerType = t
+	return r
+}
+
+// WithHeaders is the RouteOption form of Route.WithHeaders.
+func WithHeaders(v any) RouteOption {
+	return func(r *Route) {
+		r.WithHeaders(v)
+	}
+}
+
+// HeadersOf returns the headers bound for a route declared with WithHeaders,
+// or nil when the route declares no headers of type T.
+func HeadersOf[T any](c *Context) *T {
+	v, _ := getAs[*T](c, headersContextKey)
+	return v
+}
+
+// BindHeaders binds the request headers to the fields of out tagged with
+// `header:"Name"`, applying their default values, then validates out.
+// Other fields are left untouched.
+func (c *Context) BindHeaders(out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("bind target must be a non-nil pointer to a struct")
+	}
+	elem := v.Elem()
+	t := elem.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		valField := elem.Field(i)
+		name := field.Tag.Get(tagHeader)
+		if name == "" || !valField.CanSet() {
+			continue
+		}
+		wasSet, err := c.bindHeaderFieldWithStatus(name, valField, field)
+		if err == nil {
+			err = c.applyDefaultAndValidate(valField, field, wasSet)
+		}
+		if err != nil {
+			return c.localizeError(err)
+		}
+	}
+	return c.localizeError(validateStruct(out))
+}
+
+// bindHeaders returns h preceded by the binding of the route's header struct.
+func (r *Route) bindHeaders(h HandlerFunc) HandlerFunc {
+	t := r.headerType
+	return func(c *Context) error {
+		out := reflect.New(t).Interface()
+		if err := c.BindHeaders(out); err != nil {
+			return c.AbortBadRequest("Bad Request", err)
+		}
+		c.Set(headersContextKey, out)
+		return h(c)
+	}
+}
+
+// replaceParameter appends param, replacing a parameter of the same name.
+func replaceParameter(params []*openapi3.ParameterRef, param *openapi3.ParameterRef) []*openapi3.ParameterRef {
+	for i, p := range params {
+		if p.Value != nil && strings.EqualFold(p.Value.Name, param.Value.Name) {
+			params[i] = param
+			return params
+		}
+	}
+	return append(params, param)
+}
@@ -0,0 +1,106 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type writeHeaders struct {
+	IfMatch        string `header:"If-Match" required:"true" description:"Current ETag"`
+	IdempotencyKey string `header:"Idempotency-Key" minLength:"8"`
+	Retries        int    `header:"X-Retries" default:"1" max:"5"`
+	Mode           string `header:"X-Mode" enum:"fast,safe"`
+}
+
+func TestRouteWithHeaders(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Put("/books/:id", func(c *Context) error {
+		h := HeadersOf[writeHeaders](c)
+		require.NotNil(t, h)
+		return c.OK(h)
+	}).WithHeaders(&writeHeaders{})
+	ts.Get("/plain", func(c *Context) error {
+		return c.OK(M{"headers": HeadersOf[writeHeaders](c) != nil})
+	})
+
+	t.Run("bound before the handler", func(t *testing.T) {
+		okapitest.PUT(t, ts.BaseURL+"/books/1").
+			Header("If-Match", `"v1"`).Header("Idempotency-Key", "0123456789").
+			ExpectStatusOK().
+			ExpectJSONPath("IfMatch", `"v1"`).
+			ExpectJSONPath("IdempotencyKey", "0123456789").
+			ExpectJSONPath("Retries", float64(1))
+	})
+
+	t.Run("rejects invalid headers", func(t *testing.T) {
+		okapitest.PUT(t, ts.BaseURL+"/books/1").ExpectStatusBadRequest()
+		okapitest.PUT(t, ts.BaseURL+"/books/1").Header("If-Match", "x").Header("X-Retries", "many").
+			ExpectStatusBadRequest()
+		okapitest.PUT(t, ts.BaseURL+"/books/1").Header("If-Match", "x").Header("X-Retries", "9").
+			ExpectStatusBadRequest()
+		okapitest.PUT(t, ts.BaseURL+"/books/1").Header("If-Match", "x").Header("X-Mode", "slow").
+			ExpectStatusBadRequest()
+		okapitest.PUT(t, ts.BaseURL+"/books/1").Header("If-Match", "x").Header("Idempotency-Key", "short").
+			ExpectStatusBadRequest()
+	})
+
+	t.Run("other routes", func(t *testing.T) {
+		okapitest.GET(t, ts.BaseURL+"/plain").ExpectStatusOK().ExpectJSONPath("headers", false)
+	})
+}
+
+func TestRouteWithHeadersDocumented(t *testing.T) {
+	o := New()
+	o.Put("/books/:id", anyHandler, DocHeader("If-Match", "string", "old", false), WithHeaders(writeHeaders{}))
+	o.buildOpenAPISpec()
+
+	params := o.openapiSpec.Paths.Find("/books/{id}").Put.Parameters
+	ifMatch := params.GetByInAndName("header", "If-Match")
+	require.NotNil(t, ifMatch)
+	assert.True(t, ifMatch.Required)
+	assert.Equal(t, "Current ETag", ifMatch.Description)
+
+	retries := params.GetByInAndName("header", "X-Retries")
+	require.NotNil(t, retries)
+	assert.Equal(t, "integer", retries.Schema.Value.Type.Slice()[0])
+	assert.Equal(t, 5.0, *retries.Schema.Value.Max)
+
+	mode := params.GetByInAndName("header", "X-Mode")
+	require.NotNil(t, mode)
+	assert.Equal(t, []any{"fast", "safe"}, mode.Schema.Value.Enum)
+
+	count := 0
+	for _, p := range params {
+		if p.Value.Name == "If-Match" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+}
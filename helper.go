@@ -218,6 +218,38 @@ func shortFuncName(full string) string {
 	return full
 }
 
+// statusAllowsBody reports whether a response with the given status may
+// include a body, per RFC 9110: 1xx, 204 and 304 responses never do.
+func statusAllowsBody(code int) bool {
+	switch {
+	case code >= 100 && code < 200:
+		return false
+	case code == http.StatusNoContent, code == http.StatusNotModified:
+		return false
+	}
+	return true
+}
+
+// callSite returns "file:line" of the first caller outside of the okapi
+// package and net/http, which is usually the handler or middleware that
+// triggered the write.
+func callSite() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		internal := strings.HasPrefix(frame.Function, "net/http.") ||
+			(strings.HasPrefix(frame.Function, "github.com/jkaninda/okapi.") && !strings.HasSuffix(frame.File, "_test.go"))
+		if !internal && frame.File != "" {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
 // normalizeEnvironment standardizes environment names to common conventions
 func normalizeEnvironment(env string) string {
 	env = strings.ToLower(strings.TrimSpace(env))
@@ -0,0 +1,112 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// HTMX request and response headers, see https://htmx.org/reference/#headers
+const (
+	hxRequest  = "HX-Request"
+	hxBoosted  = "HX-Boosted"
+	hxTarget   = "HX-Target"
+	hxRedirect = "HX-Redirect"
+	hxTrigger  = "HX-Trigger"
+)
+
+// IsHTMX reports whether the request was issued by htmx.
+func (c *Context) IsHTMX() bool {
+	return c.request.Header.Get(hxRequest) == constTRUE
+}
+
+// IsHTMXBoosted reports whether the request comes from an hx-boost link or form,
+// which expects a full page in return.
+func (c *Context) IsHTMXBoosted() bool {
+	return c.request.Header.Get(hxBoosted) == constTRUE
+}
+
+// HXTarget returns the id of the element targeted by the htmx request, if any.
+func (c *Context) HXTarget() string {
+	return c.request.Header.Get(hxTarget)
+}
+
+// HXRedirect redirects the client to url. htmx requests get an HX-Redirect header,
+// which makes htmx perform a full page navigation; other requests get a 303 See Other.
+func (c *Context) HXRedirect(url string) error {
+	if !c.IsHTMX() {
+		c.Redirect(http.StatusSeeOther, url)
+		return nil
+	}
+	c.SetHeader(hxRedirect, url)
+	return c.Status(http.StatusOK)
+}
+
+// HXTrigger asks htmx to trigger event on the client once the response is received.
+// detail is passed as the event detail and may be nil. Calling it several times
+// triggers every event.
+//
+//	c.HXTrigger("bookAdded", okapi.M{"id": book.ID})
+func (c *Context) HXTrigger(event string, detail any) {
+	events := make(map[string]any)
+	if current := c.response.Header().Get(hxTrigger); current != "" {
+		if err := json.Unmarshal([]byte(current), &events); err != nil {
+			for _, name := range strings.Split(current, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					events[name] = nil
+				}
+			}
+		}
+	}
+	events[event] = detail
+	if len(events) == 1 && detail == nil {
+		c.SetHeader(hxTrigger, event)
+		return
+	}
+	value, err := json.Marshal(events)
+	if err != nil {
+		c.Logger().Warn("invalid HX-Trigger detail", "event", event, "error", err)
+		return
+	}
+	c.SetHeader(hxTrigger, string(value))
+}
+
+// RenderPartial renders the partial template for htmx requests and the full page
+// template otherwise, so one route serves both the initial page load and htmx swaps.
+// Boosted requests get the full page.
+//
+//	o.Get("/books", func(c *okapi.Context) error {
+//	  return c.RenderPartial(http.StatusOK, "books.html", "book-list", books)
+//	})
+func (c *Context) RenderPartial(code int, page, partial string, data any) error {
+	// Caches must not serve a fragment to a full page load, or the reverse.
+	c.Vary(hxRequest)
+	if c.IsHTMX() && !c.IsHTMXBoosted() {
+		return c.Render(code, partial, data)
+	}
+	return c.Render(code, page, data)
+}
@@ -0,0 +1,79 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext_HTMX(t *testing.T) {
+	t.Run("redirect", func(t *testing.T) {
+		ctx, rec := NewTestContext(http.MethodPost, "/books", nil)
+		ctx.request.Header.Set(hxRequest, "true")
+		assert.True(t, ctx.IsHTMX())
+		require.NoError(t, ctx.HXRedirect("/books/1"))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "/books/1", rec.Header().Get(hxRedirect))
+
+		ctx, rec = NewTestContext(http.MethodPost, "/books", nil)
+		assert.False(t, ctx.IsHTMX())
+		require.NoError(t, ctx.HXRedirect("/books/1"))
+		assert.Equal(t, http.StatusSeeOther, rec.Code)
+		assert.Equal(t, "/books/1", rec.Header().Get("Location"))
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		ctx, rec := NewTestContext(http.MethodPost, "/books", nil)
+		ctx.HXTrigger("refresh", nil)
+		assert.Equal(t, "refresh", rec.Header().Get(hxTrigger))
+		ctx.HXTrigger("bookAdded", M{"id": 1})
+		assert.JSONEq(t, `{"refresh":null,"bookAdded":{"id":1}}`, rec.Header().Get(hxTrigger))
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		tmpl, err := NewTemplate(fstest.MapFS{
+			"books.html": {Data: []byte(`{{ define "book-list" }}<ul>{{ . }}</ul>{{ end }}<html>{{ template "book-list" . }}</html>`)},
+		}, "*.html")
+		require.NoError(t, err)
+		render := func(headers map[string]string) string {
+			ctx, rec := NewTestContext(http.MethodGet, "/books", nil)
+			ctx.okapi = New().WithRenderer(tmpl)
+			for k, v := range headers {
+				ctx.request.Header.Set(k, v)
+			}
+			require.NoError(t, ctx.RenderPartial(http.StatusOK, "books.html", "book-list", "Go"))
+			assert.Equal(t, hxRequest, rec.Header().Get("Vary"))
+			return rec.Body.String()
+		}
+		assert.Equal(t, "<html><ul>Go</ul></html>", render(nil))
+		assert.Equal(t, "<ul>Go</ul>", render(map[string]string{hxRequest: "true"}))
+		assert.Equal(t, "<html><ul>Go</ul></html>", render(map[string]string{hxRequest: "true", hxBoosted: "true"}))
+	})
+}
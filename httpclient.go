@@ -0,0 +1,207 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress is returned by clients created with HTTPClient when a
+// request would connect to an address blocked by BlockPrivateIPs.
+var ErrBlockedAddress = errors.New("okapi: outbound connection to a non-public address blocked")
+
+// HTTPClientOptions configures the outbound client returned by HTTPClient.
+type HTTPClientOptions struct {
+	// Timeout bounds a whole request, redirects included. Defaults to 30s.
+	Timeout time.Duration
+	// DialTimeout bounds establishing a connection. Defaults to 5s.
+	DialTimeout time.Duration
+	// MaxRedirects is the number of redirects followed. Defaults to 10,
+	// a negative value disables redirects.
+	MaxRedirects int
+	// BlockPrivateIPs refuses connections to loopback, private, link-local
+	// and other non-public addresses. Use it when calling user-supplied URLs,
+	// such as webhook callbacks. The check applies to the address actually
+	// dialed, after DNS resolution, so it also holds against DNS rebinding
+	// and redirects. Proxies from the environment are ignored in this mode.
+	BlockPrivateIPs bool
+	// DisablePropagation stops forwarding the request ID and trace headers
+	// of the incoming request.
+	DisablePropagation bool
+	// PropagateHosts limits propagation to requests for these host names,
+	// compared without port and case. When empty, headers are forwarded to
+	// every host, except with BlockPrivateIPs where the URLs are not trusted
+	// and nothing is forwarded.
+	PropagateHosts []string
+}
+
+// blockedPrefixes lists non-public ranges not covered by the netip.Addr predicates.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// propagatedHeaders are copied from the incoming request to outbound requests.
+var propagatedHeaders = []string{requestIDHeader, "Traceparent", "Tracestate"}
+
+// HTTPClient returns an *http.Client for calling external services from handlers,
+// with bounded timeouts. Requests whose context derives from c.Context() carry the
+// request ID and the W3C trace headers (traceparent, tracestate) of the incoming request,
+// and the remaining deadline as X-Request-Timeout, see RequestDeadline. Use
+// PropagateHosts to keep these headers from reaching third parties.
+//
+// Example:
+//
+//	webhooks := okapi.HTTPClient(okapi.HTTPClientOptions{BlockPrivateIPs: true})
+//
+//	o.Post("/hooks/test", func(c *okapi.Context) error {
+//		req, _ := http.NewRequestWithContext(c.Context(), http.MethodPost, hook.URL, body)
+//		resp, err := webhooks.Do(req)
+//		...
+//	})
+func HTTPClient(opts HTTPClientOptions) *http.Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	dialer := &net.Dialer{Timeout: opts.DialTimeout, KeepAlive: 30 * time.Second}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSHandshakeTimeout = opts.DialTimeout
+	if opts.BlockPrivateIPs {
+		dialer.Control = blockPrivateControl
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+
+	var rt http.RoundTripper = transport
+	if !opts.DisablePropagation && (!opts.BlockPrivateIPs || len(opts.PropagateHosts) > 0) {
+		rt = propagatingTransport{next: transport, hosts: opts.PropagateHosts}
+	}
+	client := &http.Client{Transport: rt, Timeout: opts.Timeout}
+	if opts.MaxRedirects != 0 {
+		max := opts.MaxRedirects
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if max < 0 {
+				return http.ErrUseLastResponse
+			}
+			if len(via) >= max {
+				return fmt.Errorf("stopped after %d redirects", max)
+			}
+			return nil
+		}
+	}
+	return client
+}
+
+// blockPrivateControl rejects the dial when the resolved address is not public.
+func blockPrivateControl(_, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddr(ap.Addr()) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, ap.Addr())
+	}
+	return nil
+}
+
+// isPublicAddr reports whether addr is a globally routable unicast address.
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// propagatingTransport copies the request ID and trace headers of the incoming
+// request, found in the outbound request's context, unless already set. The
+// time left before the context's deadline is sent as X-Request-Timeout.
+// When hosts is set, other hosts receive none of these headers.
+type propagatingTransport struct {
+	next  http.RoundTripper
+	hosts []string
+}
+
+func (t propagatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c, ok := req.Context().Value(okapiContextKey{}).(*Context)
+	if !ok || c.request == nil || !t.allowed(req.URL.Hostname()) {
+		return t.next.RoundTrip(req)
+	}
+	values := make(map[string]string, len(propagatedHeaders))
+	for _, name := range propagatedHeaders {
+		if req.Header.Get(name) == "" {
+			if v := c.request.Header.Get(name); v != "" {
+				values[name] = v
+			}
+		}
+	}
+	if id := c.GetString("request_id"); id != "" && req.Header.Get(requestIDHeader) == "" {
+		values[requestIDHeader] = id
+	}
+	if deadline, ok := req.Context().Deadline(); ok && req.Header.Get(requestTimeoutHeader) == "" {
+		values[requestTimeoutHeader] = formatRequestTimeout(time.Until(deadline))
+	}
+	if len(values) == 0 {
+		return t.next.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the caller's request.
+	req = req.Clone(req.Context())
+	for name, v := range values {
+		req.Header.Set(name, v)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// allowed reports whether headers may be propagated to host.
+func (t propagatingTransport) allowed(host string) bool {
+	if len(t.hosts) == 0 {
+		return true
+	}
+	for _, h := range t.hosts {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClientBlockPrivateIPs(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer upstream.Close()
+
+	_, err := HTTPClient(HTTPClientOptions{BlockPrivateIPs: true}).Get(upstream.URL)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrBlockedAddress))
+
+	resp, err := HTTPClient(HTTPClientOptions{}).Get(upstream.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":             true,
+		"2606:4700::1111":     true,
+		"127.0.0.1":           false,
+		"10.1.2.3":            false,
+		"172.16.0.1":          false,
+		"192.168.1.1":         false,
+		"169.254.169.254":     false,
+		"100.64.0.1":          false,
+		"0.0.0.0":             false,
+		"::1":                 false,
+		"fd00::1":             false,
+		"fe80::1":             false,
+		"::ffff:127.0.0.1":    false,
+		"::ffff:93.184.216.1": true,
+	}
+	for addr, want := range tests {
+		assert.Equal(t, want, isPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestHTTPClientPropagation(t *testing.T) {
+	received := make(chan http.Header, 1)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+	}))
+	defer upstream.Close()
+
+	client := HTTPClient(HTTPClientOptions{Timeout: 5 * time.Second})
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	ctx.request.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx.Set("request_id", "req-42")
+
+	req, err := http.NewRequestWithContext(ctx.Context(), http.MethodGet, upstream.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	h := <-received
+	assert.Equal(t, "req-42", h.Get("X-Request-ID"))
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", h.Get("Traceparent"))
+	assert.Empty(t, req.Header.Get("X-Request-ID"), "the caller's request must not be modified")
+}
+
+func TestHTTPClientPropagateHosts(t *testing.T) {
+	received := make(chan http.Header, 1)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+	}))
+	defer upstream.Close()
+
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	ctx.request.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx.Set("request_id", "req-42")
+	send := func(client *http.Client) http.Header {
+		req, err := http.NewRequestWithContext(ctx.Context(), http.MethodGet, upstream.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return <-received
+	}
+
+	h := send(HTTPClient(HTTPClientOptions{PropagateHosts: []string{"api.example.com"}}))
+	assert.Empty(t, h.Get("X-Request-ID"))
+	assert.Empty(t, h.Get("Traceparent"))
+
+	h = send(HTTPClient(HTTPClientOptions{PropagateHosts: []string{"127.0.0.1"}}))
+	assert.Equal(t, "req-42", h.Get("X-Request-ID"))
+
+	// Clients for untrusted URLs propagate nothing unless hosts are listed.
+	_, ok := HTTPClient(HTTPClientOptions{BlockPrivateIPs: true}).Transport.(*http.Transport)
+	assert.True(t, ok)
+	_, ok = HTTPClient(HTTPClientOptions{BlockPrivateIPs: true, PropagateHosts: []string{"api.example.com"}}).Transport.(propagatingTransport)
+	assert.True(t, ok)
+}
+
+func TestHTTPClientMaxRedirects(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/next", http.StatusFound)
+	}))
+	defer upstream.Close()
+
+	resp, err := HTTPClient(HTTPClientOptions{MaxRedirects: -1}).Get(upstream.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+
+	_, err = HTTPClient(HTTPClientOptions{MaxRedirects: 2}).Get(upstream.URL)
+	assert.ErrorContains(t, err, "stopped after 2 redirects")
+}
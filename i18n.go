@@ -0,0 +1,287 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Messages is a message catalog for a single locale. Keys are the built-in
+// English messages (or your own message keys), values are their translations.
+// Values may contain fmt verbs when the key does, e.g.:
+//
+//	okapi.Messages{
+//		"Bad request":             "Requête invalide",
+//		okapi.MsgFieldRequired:    "le champ %s est obligatoire",
+//		okapi.MsgFieldInvalid:     "champ %s : %s",
+//		okapi.MsgValueMin:         "la valeur %v doit être supérieure ou égale à %v",
+//	}
+type Messages map[string]string
+
+// Message keys of the validation errors, translated with Messages. The other
+// validation messages, such as the format ones, are keyed by their English
+// template, e.g. "invalid email format: %s".
+const (
+	// MsgFieldRequired is reported when a required field is missing. Its
+	// single argument is the field name.
+	MsgFieldRequired = "field %s is required"
+	// MsgFieldInvalid prefixes the error of an invalid field. Its arguments are
+	// the field name and the translated error.
+	MsgFieldInvalid = "field %s: %s"
+	// MsgValueMin and MsgValueMax report a number out of the min or max tag
+	// bounds. Their arguments are the value and the bound.
+	MsgValueMin = "value %v must be >= %v"
+	MsgValueMax = "value %v must be <= %v"
+	// MsgLengthMin and MsgLengthMax report a slice or map whose length is out of
+	// the min or max tag bounds. Their arguments are the length and the bound.
+	MsgLengthMin = "length %d must be >= %d"
+	MsgLengthMax = "length %d must be <= %d"
+	// MsgStringMinLength and MsgStringMaxLength report a string whose length is
+	// out of the minLength or maxLength tag bounds. Their arguments are the
+	// length and the bound.
+	MsgStringMinLength = "string length %d must be at least %d characters"
+	MsgStringMaxLength = "string length %d must be at most %d characters"
+	// MsgEnum reports a value missing from the enum tag. Its arguments are the
+	// value and the allowed values.
+	MsgEnum = "value '%s' is not one of the allowed values: [%s]"
+	// MsgPattern reports a value not matching the pattern tag. Its arguments
+	// are the pattern and the value.
+	MsgPattern = "value does not match pattern '%s': %s"
+)
+
+// validationError is a validation failure whose message is translatable, its
+// template being the message key.
+type validationError struct {
+	key  string
+	args []any
+}
+
+func (e *validationError) Error() string { return fmt.Sprintf(e.key, e.args...) }
+
+// validationErrorf returns a validation failure formatting args with the
+// message key.
+func validationErrorf(key string, args ...any) error {
+	return &validationError{key: key, args: args}
+}
+
+// localizedError carries a translated message while keeping the original
+// error available to errors.Is and errors.As.
+type localizedError struct {
+	msg string
+	err error
+}
+
+func (e *localizedError) Error() string { return e.msg }
+func (e *localizedError) Unwrap() error { return e.err }
+
+// localeContextKey caches the negotiated locale on the Context store.
+const localeContextKey = "okapi.locale"
+
+// WithMessages registers a message catalog for the given locale (e.g. "fr", "de-CH").
+//
+// Built-in error messages, such as the default Abort* messages and validation
+// errors, are translated according to the locale negotiated from the
+// Accept-Language request header. Calling it again for the same locale merges
+// the catalogs.
+func WithMessages(locale string, messages Messages) OptionFunc {
+	return func(o *Okapi) {
+		locale = normalizeLocale(locale)
+		if locale == "" {
+			return
+		}
+		if o.messages == nil {
+			o.messages = make(map[string]Messages)
+		}
+		catalog := o.messages[locale]
+		if catalog == nil {
+			catalog = make(Messages, len(messages))
+			o.messages[locale] = catalog
+		}
+		for k, v := range messages {
+			catalog[k] = v
+		}
+	}
+}
+
+// WithDefaultLocale sets the locale used when none of the locales accepted by
+// the client has a registered catalog.
+func WithDefaultLocale(locale string) OptionFunc {
+	return func(o *Okapi) {
+		o.defaultLocale = normalizeLocale(locale)
+	}
+}
+
+// WithMessages registers a message catalog for the given locale.
+func (o *Okapi) WithMessages(locale string, messages Messages) *Okapi {
+	return o.apply(WithMessages(locale, messages))
+}
+
+// WithDefaultLocale sets the fallback locale for message translation.
+func (o *Okapi) WithDefaultLocale(locale string) *Okapi {
+	return o.apply(WithDefaultLocale(locale))
+}
+
+// Locale returns the locale of the request: the first one requested by the
+// caller's profile, the locale cookie (see LocaleOptions) or the
+// Accept-Language header that has a registered message catalog, falling back
+// to the default locale. Without catalogs, the first requested locale is
+// returned as-is. It returns an empty string when no locale applies.
+func (c *Context) Locale() string {
+	if c.okapi == nil {
+		return ""
+	}
+	if c.store != nil {
+		if v, ok := c.Get(localeContextKey); ok {
+			if locale, ok := v.(string); ok {
+				return locale
+			}
+		}
+	}
+	locale := c.okapi.defaultLocale
+	candidates := c.localeCandidates()
+	if len(c.okapi.messages) == 0 && len(candidates) > 0 {
+		locale = candidates[0]
+	}
+	for _, tag := range candidates {
+		if len(c.okapi.messages) == 0 {
+			break
+		}
+		if _, ok := c.okapi.messages[tag]; ok {
+			locale = tag
+			break
+		}
+		// Fall back from a regional variant to its base language (fr-CA -> fr).
+		if base, _, found := strings.Cut(tag, "-"); found {
+			if _, ok := c.okapi.messages[base]; ok {
+				locale = base
+				break
+			}
+		}
+	}
+	if c.store != nil {
+		c.Set(localeContextKey, locale)
+	}
+	return locale
+}
+
+// T translates key using the catalog of the negotiated locale and formats it
+// with args. Untranslated keys are used as-is. The locale is only negotiated
+// when message catalogs are registered.
+func (c *Context) T(key string, args ...any) string {
+	msg := key
+	if c.okapi != nil && len(c.okapi.messages) > 0 {
+		if catalog, ok := c.okapi.messages[c.Locale()]; ok {
+			if translated, ok := catalog[key]; ok {
+				msg = translated
+			}
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// localizeError translates the built-in parts of err for the negotiated locale.
+func (c *Context) localizeError(err error) error {
+	if err == nil || c.okapi == nil || len(c.okapi.messages) == 0 {
+		return err
+	}
+	var (
+		required   *RequiredFieldError
+		field      *FieldError
+		original   string
+		translated string
+	)
+	switch {
+	case errors.As(err, &required):
+		original, translated = required.Error(), c.T(MsgFieldRequired, required.Field)
+	case errors.As(err, &field):
+		original, translated = field.Error(), c.T(MsgFieldInvalid, field.Field, c.localizeMessage(field.Err))
+	default:
+		return err
+	}
+	if translated == original {
+		return err
+	}
+	return &localizedError{msg: strings.Replace(err.Error(), original, translated, 1), err: err}
+}
+
+// localizeMessage returns the message of err with its validation failure
+// translated, keeping the context added by wrapping errors, such as the index
+// of a slice element.
+func (c *Context) localizeMessage(err error) string {
+	msg := err.Error()
+	var failure *validationError
+	if errors.As(err, &failure) {
+		msg = strings.Replace(msg, failure.Error(), c.T(failure.key, failure.args...), 1)
+	}
+	return msg
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header,
+// normalized and ordered by descending quality.
+func parseAcceptLanguage(header string) []string {
+	if header == "" {
+		return nil
+	}
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = normalizeLocale(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	out := make([]string, len(tags))
+	for i, t := range tags {
+		out[i] = t.tag
+	}
+	return out
+}
+
+// normalizeLocale lower-cases a language tag and uses "-" as separator.
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,112 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Nil(t, parseAcceptLanguage(""))
+	assert.Equal(t, []string{"fr-ca", "fr", "en"}, parseAcceptLanguage("en;q=0.5, fr-CA, fr;q=0.8, *;q=0.1, de;q=0"))
+}
+
+func TestLocalizedMessages(t *testing.T) {
+	type createBook struct {
+		Body struct {
+			Name  string   `json:"name" required:"true"`
+			Pages int      `json:"pages" min:"1"`
+			Tags  []string `json:"tags" enum:"novel,essay"`
+		}
+	}
+	o := NewTestServer(t)
+	o.WithMessages("fr", Messages{
+		"Not Found":      "Introuvable",
+		MsgFieldRequired: "le champ %s est obligatoire",
+		MsgFieldInvalid:  "champ %s : %s",
+		MsgValueMin:      "la valeur %v doit être >= %v",
+		MsgEnum:          "la valeur '%s' n'est pas parmi [%s]",
+	}).WithMessages("de", Messages{"Not Found": "Nicht gefunden"})
+
+	o.Get("/missing", func(c *Context) error {
+		return c.AbortNotFound("")
+	})
+	o.Post("/books", func(c *Context) error {
+		var in createBook
+		if err := c.Bind(&in); err != nil {
+			return c.AbortBadRequest(err.Error())
+		}
+		return c.Created(in.Body)
+	})
+
+	okapitest.GET(t, o.BaseURL+"/missing").
+		Header("Accept-Language", "fr-CA,fr;q=0.9,en;q=0.8").
+		ExpectStatusNotFound().
+		ExpectBodyContains("Introuvable")
+	okapitest.GET(t, o.BaseURL+"/missing").
+		Header("Accept-Language", "es, de;q=0.5").
+		ExpectBodyContains("Nicht gefunden")
+	okapitest.GET(t, o.BaseURL+"/missing").
+		ExpectBodyContains("Not Found")
+	okapitest.POST(t, o.BaseURL+"/books").
+		Header("Accept-Language", "fr").
+		JSONBody(M{}).
+		ExpectStatus(http.StatusBadRequest).
+		ExpectBodyContains("le champ Name est obligatoire")
+	okapitest.POST(t, o.BaseURL+"/books").
+		Header("Accept-Language", "fr").
+		JSONBody(M{"name": "Dune", "pages": 0}).
+		ExpectStatus(http.StatusBadRequest).
+		ExpectBodyContains("champ Pages : la valeur 0 doit être")
+	okapitest.POST(t, o.BaseURL+"/books").
+		Header("Accept-Language", "fr").
+		JSONBody(M{"name": "Dune", "pages": 1, "tags": []string{"novel", "poem"}}).
+		ExpectStatus(http.StatusBadRequest).
+		ExpectBodyContains("champ Tags : element [1]: la valeur 'poem' n'est pas parmi [novel, essay]")
+	okapitest.POST(t, o.BaseURL+"/books").
+		JSONBody(M{"name": "Dune", "pages": 0}).
+		ExpectStatus(http.StatusBadRequest).
+		ExpectBodyContains("field Pages: value 0 must be")
+
+	o.WithDefaultLocale("de")
+	okapitest.GET(t, o.BaseURL+"/missing").ExpectBodyContains("Nicht gefunden")
+}
+
+func TestTranslateWithoutCatalogs(t *testing.T) {
+	profileCalls := 0
+	o := New(WithLocaleOptions(LocaleOptions{Profile: func(c *Context) (string, string) {
+		profileCalls++
+		return "fr", ""
+	}}))
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	ctx.okapi = o
+	assert.Equal(t, "Not Found", ctx.T("Not Found"))
+	_ = ctx.AbortNotFound("")
+	assert.Zero(t, profileCalls)
+}
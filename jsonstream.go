@@ -0,0 +1,181 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrTooManyItems is returned by BindJSONStream when the array holds more
+// items than JSONStreamOptions.MaxItems.
+var ErrTooManyItems = errors.New("okapi: too many JSON items")
+
+// JSONStreamOptions configures how BindJSONStream reads a JSON array.
+type JSONStreamOptions struct {
+	// MaxItems caps the number of items read; 0 means no limit. An array with
+	// more items fails with ErrTooManyItems once the first MaxItems items were
+	// passed to the callback.
+	MaxItems int
+	// MaxErrors stops reading once this many items are invalid; 0 collects them all.
+	MaxErrors int
+	// DisallowUnknownFields rejects items with fields the item type does not declare.
+	DisallowUnknownFields bool
+}
+
+// JSONItemError describes why a single item of a JSON array could not be bound.
+type JSONItemError struct {
+	// Index is the zero-based position of the item in the array.
+	Index int
+	Err   error
+}
+
+func (e JSONItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e JSONItemError) Unwrap() error { return e.Err }
+
+// JSONStreamError is returned by BindJSONStream when one or more items are
+// invalid. Valid items are still passed to the callback.
+type JSONStreamError struct {
+	Items []JSONItemError
+}
+
+func (e *JSONStreamError) Error() string {
+	if len(e.Items) == 1 {
+		return "invalid JSON item: " + e.Items[0].Error()
+	}
+	return fmt.Sprintf("%d invalid JSON items, first: %s", len(e.Items), e.Items[0].Error())
+}
+
+// BindJSONStream decodes a request body holding a top-level JSON array one
+// item at a time and calls fn with each, so bulk imports use bounded memory
+// whatever the size of the payload.
+//
+// Each item is validated like any other bound struct, including Validatable.
+// Invalid items, such as a string given for a number or a failed tag check, are
+// skipped and reported together in a *JSONStreamError once the array is read.
+// Malformed JSON, including a missing closing bracket or data after the array,
+// stops the decoding, as does an error returned by fn, which is returned as is.
+//
+//	type Contact struct {
+//	    Name  string `json:"name" required:"true"`
+//	    Email string `json:"email" format:"email"`
+//	}
+//
+//	o.Post("/contacts/import", func(c *okapi.Context) error {
+//	    var imported int
+//	    err := okapi.BindJSONStream(c, func(contact *Contact) error {
+//	        imported++
+//	        return store.Save(c.Context(), contact)
+//	    }, okapi.JSONStreamOptions{MaxItems: 100000})
+//	    var invalid *okapi.JSONStreamError
+//	    if errors.As(err, &invalid) {
+//	        return c.JSON(http.StatusUnprocessableEntity, okapi.M{"imported": imported, "error": invalid.Error()})
+//	    }
+//	    if err != nil {
+//	        return c.AbortBadRequest("Invalid import", err)
+//	    }
+//	    return c.OK(okapi.M{"imported": imported})
+//	})
+func BindJSONStream[T any](c *Context, fn func(item *T) error, opts ...JSONStreamOptions) error {
+	var opt JSONStreamOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	dec := json.NewDecoder(c.request.Body)
+	if opt.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		if err == nil {
+			err = errors.New("the body is not a JSON array")
+		}
+		return fmt.Errorf("invalid JSON stream: %w", err)
+	}
+
+	var itemErrs []JSONItemError
+	index := 0
+	for ; dec.More(); index++ {
+		if opt.MaxItems > 0 && index >= opt.MaxItems {
+			return fmt.Errorf("%w: more than %d", ErrTooManyItems, opt.MaxItems)
+		}
+		item := new(T)
+		if err := dec.Decode(item); err != nil {
+			// The decoder reads an item whole before filling it, so after a
+			// value of the wrong type or an unknown field the next item can
+			// still be read; a syntax error leaves the stream broken.
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) && !isUnknownFieldError(err) {
+				return fmt.Errorf("invalid JSON at item %d: %w", index, err)
+			}
+			itemErrs = append(itemErrs, JSONItemError{Index: index, Err: err})
+		} else if err := c.validateItem(item); err != nil {
+			itemErrs = append(itemErrs, JSONItemError{Index: index, Err: err})
+		} else if err := fn(item); err != nil {
+			return err
+		}
+		if opt.MaxErrors > 0 && len(itemErrs) >= opt.MaxErrors {
+			return &JSONStreamError{Items: itemErrs}
+		}
+	}
+	if tok, err := dec.Token(); err != nil || tok != json.Delim(']') {
+		if err == nil {
+			err = fmt.Errorf("unexpected %v", tok)
+		}
+		return fmt.Errorf("invalid JSON at item %d: %w", index, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("data after the array")
+		}
+		return fmt.Errorf("invalid JSON stream: %w", err)
+	}
+	if len(itemErrs) > 0 {
+		return &JSONStreamError{Items: itemErrs}
+	}
+	return nil
+}
+
+// validateItem validates an item decoded by BindJSONStream.
+func (c *Context) validateItem(item any) error {
+	if err := validateStruct(item); err != nil {
+		return c.localizeError(err)
+	}
+	if v, ok := item.(Validatable); ok {
+		return v.Validate(c)
+	}
+	return nil
+}
+
+// isUnknownFieldError reports whether err was caused by DisallowUnknownFields,
+// which encoding/json reports without a dedicated type.
+func isUnknownFieldError(err error) bool {
+	return strings.HasPrefix(err.Error(), "json: unknown field ")
+}
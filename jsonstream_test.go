@@ -0,0 +1,143 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+type streamContact struct {
+	Name  string `json:"name" required:"true"`
+	Email string `json:"email" format:"email"`
+	Age   int    `json:"age"`
+}
+
+func TestBindJSONStream(t *testing.T) {
+	body := `[
+		{"name": "Ada", "email": "ada@example.com"},
+		{"email": "nobody@example.com"},
+		{"name": "Bob", "age": "old"},
+		{"name": "Eve", "email": "not-an-email"},
+		{"name": "Joe", "email": "joe@example.com", "age": 40}
+	]`
+	c, _ := NewTestContext(http.MethodPost, "/import", strings.NewReader(body))
+
+	var names []string
+	err := BindJSONStream(c, func(contact *streamContact) error {
+		names = append(names, contact.Name)
+		return nil
+	})
+	if got := strings.Join(names, ","); got != "Ada,Joe" {
+		t.Errorf("valid items = %q, want Ada,Joe", got)
+	}
+	var invalid *JSONStreamError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("expected a *JSONStreamError, got %v", err)
+	}
+	var indexes []int
+	for _, item := range invalid.Items {
+		indexes = append(indexes, item.Index)
+	}
+	if fmt.Sprint(indexes) != "[1 2 3]" {
+		t.Errorf("invalid items = %v, want [1 2 3]", indexes)
+	}
+	var required *RequiredFieldError
+	if !errors.As(invalid.Items[0], &required) || required.Field != "Name" {
+		t.Errorf("item 1: expected a required field error, got %v", invalid.Items[0])
+	}
+}
+
+func TestBindJSONStream_Options(t *testing.T) {
+	count := func(body string, opt JSONStreamOptions) (int, error) {
+		c, _ := NewTestContext(http.MethodPost, "/import", strings.NewReader(body))
+		n := 0
+		err := BindJSONStream(c, func(*streamContact) error { n++; return nil }, opt)
+		return n, err
+	}
+
+	if n, err := count(`[{"name":"a"},{"name":"b"},{"name":"c"}]`, JSONStreamOptions{MaxItems: 2}); n != 2 || !errors.Is(err, ErrTooManyItems) {
+		t.Errorf("MaxItems: got %d items, %v", n, err)
+	}
+	if n, err := count(`[{"name":"a"},{"name":"b"}]`, JSONStreamOptions{MaxItems: 2}); n != 2 || err != nil {
+		t.Errorf("MaxItems reached: got %d items, %v", n, err)
+	}
+	if n, err := count(`[{},{},{"name":"c"}]`, JSONStreamOptions{MaxErrors: 1}); n != 0 || err == nil {
+		t.Errorf("MaxErrors: got %d items, %v", n, err)
+	}
+	if n, err := count(`[{"name":"a","extra":1},{"name":"b"}]`, JSONStreamOptions{DisallowUnknownFields: true}); n != 1 || err == nil {
+		t.Errorf("DisallowUnknownFields: got %d items, %v", n, err)
+	}
+	if _, err := count(`{"name":"a"}`, JSONStreamOptions{}); err == nil || !strings.Contains(err.Error(), "not a JSON array") {
+		t.Errorf("object body: got %v", err)
+	}
+	if n, err := count(`[{"name":"a"},{"name":`, JSONStreamOptions{}); n != 1 || err == nil || !strings.Contains(err.Error(), "item 1") {
+		t.Errorf("truncated body: got %d items, %v", n, err)
+	}
+	if n, err := count(`[{"name":"a"}`, JSONStreamOptions{}); n != 1 || err == nil {
+		t.Errorf("missing closing bracket: got %d items, %v", n, err)
+	}
+	if n, err := count(`[{"name":"a"}] {"name":"b"}`, JSONStreamOptions{}); n != 1 || err == nil || !strings.Contains(err.Error(), "after the array") {
+		t.Errorf("trailing data: got %d items, %v", n, err)
+	}
+	if n, err := count("[{\"name\":\"a\"}]\n", JSONStreamOptions{}); n != 1 || err != nil {
+		t.Errorf("trailing whitespace: got %d items, %v", n, err)
+	}
+	if n, err := count(`[]`, JSONStreamOptions{}); n != 0 || err != nil {
+		t.Errorf("empty array: got %d items, %v", n, err)
+	}
+}
+
+func TestBindJSONStream_CallbackError(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Post("/import", func(c *Context) error {
+		imported := 0
+		err := BindJSONStream(c, func(contact *streamContact) error {
+			if contact.Name == "stop" {
+				return errors.New("storage full")
+			}
+			imported++
+			return nil
+		})
+		if err != nil {
+			return c.AbortBadRequest("Invalid import", err)
+		}
+		return c.OK(M{"imported": imported})
+	})
+
+	okapitest.POST(t, ts.BaseURL+"/import").
+		JSONBody([]M{{"name": "a"}, {"name": "b"}}).
+		ExpectStatusOK().
+		ExpectJSONPath("imported", float64(2))
+	okapitest.POST(t, ts.BaseURL+"/import").
+		JSONBody([]M{{"name": "a"}, {"name": "stop"}, {"name": "c"}}).
+		ExpectStatus(http.StatusBadRequest).
+		ExpectBodyContains("storage full")
+}
@@ -25,6 +25,7 @@
 package okapi
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"strings"
@@ -221,6 +222,21 @@ func (jwtAuth *JWTAuth) forwardContextFromClaims(token *jwt.Token, c *Context) e
 	return nil
 }
 
+// principal returns the caller identified by token.
+func (jwtAuth *JWTAuth) principal(token *jwt.Token) Principal {
+	var p Principal
+	if sub, err := token.Claims.GetSubject(); err == nil {
+		p.Subject = sub
+	}
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		if roles, err := jwtAuth.extractNestedClaimValue(claims, cmp.Or(jwtAuth.RolesClaim, "roles")); err == nil {
+			p.Roles = rolesFromClaim(roles)
+		}
+		p.AuthMethods = rolesFromClaim(claims["amr"])
+	}
+	return p
+}
+
 // extractNestedClaimValue extracts a value from JWT claims using dot notation for nested keys
 func (jwtAuth *JWTAuth) extractNestedClaimValue(claims jwt.MapClaims, claimKey string) (interface{}, error) {
 	// Handle nested keys using dot notation (e.g., "user.profile.email")
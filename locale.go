@@ -0,0 +1,343 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timezoneContextKey caches the resolved time zone on the Context store.
+const timezoneContextKey = "okapi.timezone"
+
+// maxLocalizeDepth bounds the traversal of JSON responses by LocalizeJSONTimes,
+// which also stops reference cycles.
+const maxLocalizeDepth = 32
+
+// timeTypes caches whether a type may hold time.Time values.
+var timeTypes sync.Map
+
+// LocaleOptions configures how the locale and time zone of a request are
+// resolved, see Context.Locale and Context.Timezone, and how values are
+// formatted for them.
+type LocaleOptions struct {
+	// Profile returns the locale and IANA time zone saved in the profile of the
+	// caller, e.g. looked up from c.Principal(). Empty values fall back to the
+	// cookies and headers. Optional.
+	Profile func(c *Context) (locale, timezone string)
+	// LocaleCookie is the name of the cookie holding the locale chosen by the
+	// user, e.g. "lang". It takes precedence over Accept-Language.
+	LocaleCookie string
+	// TimezoneCookie is the name of the cookie holding the user's IANA time
+	// zone, e.g. "tz". It takes precedence over the Time-Zone header.
+	TimezoneCookie string
+	// DefaultTimezone is used when the request names no valid time zone.
+	// Defaults to UTC.
+	DefaultTimezone *time.Location
+	// Formats adds or overrides number and date formats, keyed by locale.
+	Formats map[string]LocaleFormat
+	// LocalizeJSONTimes converts the time.Time values of JSON responses to the
+	// request's time zone.
+	LocalizeJSONTimes bool
+}
+
+// LocaleFormat describes how numbers and dates are written in a locale.
+type LocaleFormat struct {
+	// Decimal is the decimal separator, e.g. ".".
+	Decimal string
+	// Group is the thousands separator, e.g. ",".
+	Group string
+	// Date is the time.Layout of dates, e.g. "01/02/2006".
+	Date string
+	// DateTime is the time.Layout of dates with a time, e.g. "01/02/2006 3:04 PM".
+	DateTime string
+}
+
+// localeFormats are the built-in formats, keyed by locale.
+var localeFormats = map[string]LocaleFormat{
+	"en":    {Decimal: ".", Group: ",", Date: "01/02/2006", DateTime: "01/02/2006 3:04 PM"},
+	"en-gb": {Decimal: ".", Group: ",", Date: "02/01/2006", DateTime: "02/01/2006 15:04"},
+	"fr":    {Decimal: ",", Group: "\u202f", Date: "02/01/2006", DateTime: "02/01/2006 15:04"},
+	"de":    {Decimal: ",", Group: ".", Date: "02.01.2006", DateTime: "02.01.2006 15:04"},
+	"es":    {Decimal: ",", Group: ".", Date: "02/01/2006", DateTime: "02/01/2006 15:04"},
+	"it":    {Decimal: ",", Group: ".", Date: "02/01/2006", DateTime: "02/01/2006 15:04"},
+	"pt":    {Decimal: ",", Group: ".", Date: "02/01/2006", DateTime: "02/01/2006 15:04"},
+	"nl":    {Decimal: ",", Group: ".", Date: "02-01-2006", DateTime: "02-01-2006 15:04"},
+	"ja":    {Decimal: ".", Group: ",", Date: "2006/01/02", DateTime: "2006/01/02 15:04"},
+	"zh":    {Decimal: ".", Group: ",", Date: "2006/01/02", DateTime: "2006/01/02 15:04"},
+}
+
+// WithLocaleOptions configures the resolution of the locale and time zone of
+// requests, and the formatting of values for them.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithLocaleOptions(okapi.LocaleOptions{
+//		LocaleCookie:   "lang",
+//		TimezoneCookie: "tz",
+//		Profile: func(c *okapi.Context) (string, string) {
+//			user := users.Get(c.Principal().Subject)
+//			return user.Locale, user.Timezone
+//		},
+//	}))
+func WithLocaleOptions(opts LocaleOptions) OptionFunc {
+	return func(o *Okapi) {
+		o.localeOptions = &opts
+	}
+}
+
+// WithLocaleOptions configures the resolution of the locale and time zone of requests.
+func (o *Okapi) WithLocaleOptions(opts LocaleOptions) *Okapi {
+	return o.apply(WithLocaleOptions(opts))
+}
+
+// Timezone returns the time zone of the request, from the caller's profile,
+// the time zone cookie or the Time-Zone header (e.g. "Africa/Kinshasa"), in
+// that order, falling back to LocaleOptions.DefaultTimezone or UTC.
+func (c *Context) Timezone() *time.Location {
+	if c.store != nil {
+		if v, ok := c.Get(timezoneContextKey); ok {
+			if loc, ok := v.(*time.Location); ok {
+				return loc
+			}
+		}
+	}
+	loc := time.UTC
+	opts := c.localeOptions()
+	if opts.DefaultTimezone != nil {
+		loc = opts.DefaultTimezone
+	}
+	var names []string
+	if opts.Profile != nil {
+		_, timezone := opts.Profile(c)
+		names = append(names, timezone)
+	}
+	if opts.TimezoneCookie != "" {
+		if cookie, err := c.request.Cookie(opts.TimezoneCookie); err == nil {
+			names = append(names, cookie.Value)
+		}
+	}
+	names = append(names, c.request.Header.Get("Time-Zone"))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if zone, err := time.LoadLocation(name); err == nil {
+			loc = zone
+			break
+		}
+	}
+	if c.store != nil {
+		c.Set(timezoneContextKey, loc)
+	}
+	return loc
+}
+
+// LocalTime returns t in the time zone of the request.
+func (c *Context) LocalTime(t time.Time) time.Time {
+	return t.In(c.Timezone())
+}
+
+// FormatDate formats the date of t, in the time zone of the request, for its
+// locale, e.g. "02/01/2006" in French.
+func (c *Context) FormatDate(t time.Time) string {
+	return c.LocalTime(t).Format(c.localeFormat().Date)
+}
+
+// FormatDateTime formats t, in the time zone of the request, for its locale,
+// e.g. "02/01/2006 15:04" in French.
+func (c *Context) FormatDateTime(t time.Time) string {
+	return c.LocalTime(t).Format(c.localeFormat().DateTime)
+}
+
+// FormatNumber formats v with the given number of decimals for the locale of
+// the request, e.g. "1,234.50" in English and "1.234,50" in German.
+func (c *Context) FormatNumber(v float64, decimals int) string {
+	return formatNumber(v, decimals, c.localeFormat())
+}
+
+// localeOptions returns the locale options of the instance, or the defaults.
+func (c *Context) localeOptions() *LocaleOptions {
+	if c.okapi == nil || c.okapi.localeOptions == nil {
+		return &LocaleOptions{}
+	}
+	return c.okapi.localeOptions
+}
+
+// localeCandidates returns the locales requested by the caller, by preference.
+func (c *Context) localeCandidates() []string {
+	var candidates []string
+	opts := c.localeOptions()
+	if opts.Profile != nil {
+		if locale, _ := opts.Profile(c); locale != "" {
+			candidates = append(candidates, normalizeLocale(locale))
+		}
+	}
+	if opts.LocaleCookie != "" {
+		if cookie, err := c.request.Cookie(opts.LocaleCookie); err == nil && cookie.Value != "" {
+			candidates = append(candidates, normalizeLocale(cookie.Value))
+		}
+	}
+	return append(candidates, parseAcceptLanguage(c.request.Header.Get("Accept-Language"))...)
+}
+
+// localeFormat returns the format of the request's locale, falling back from
+// a regional variant to its base language, and to English.
+func (c *Context) localeFormat() LocaleFormat {
+	locale := c.Locale()
+	formats := c.localeOptions().Formats
+	base, _, _ := strings.Cut(locale, "-")
+	for _, tag := range []string{locale, base} {
+		if f, ok := formats[tag]; ok {
+			return f
+		}
+		if f, ok := localeFormats[tag]; ok {
+			return f
+		}
+	}
+	return localeFormats["en"]
+}
+
+// formatNumber formats v with decimals digits after the separator of f.
+func formatNumber(v float64, decimals int, f LocaleFormat) string {
+	s := strconv.FormatFloat(v, 'f', max(decimals, 0), 64)
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(f.Group)
+		}
+		b.WriteRune(digit)
+	}
+	if hasFraction {
+		b.WriteString(f.Decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// localizeTimes returns v with its time.Time values converted to the time
+// zone of the request, when LocalizeJSONTimes is enabled.
+func (c *Context) localizeTimes(v any) any {
+	if v == nil || !c.localeOptions().LocalizeJSONTimes || !hasTimeValues(reflect.TypeOf(v)) {
+		return v
+	}
+	return inTimezone(reflect.ValueOf(v), c.Timezone(), 0).Interface()
+}
+
+// hasTimeValues reports whether values of t may hold time.Time values.
+func hasTimeValues(t reflect.Type) bool {
+	if cached, ok := timeTypes.Load(t); ok {
+		return cached.(bool)
+	}
+	found := typeHasTime(t, map[reflect.Type]bool{})
+	timeTypes.Store(t, found)
+	return found
+}
+
+func typeHasTime(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if t == reflect.TypeFor[time.Time]() {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return typeHasTime(t.Elem(), seen)
+	case reflect.Struct:
+		if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) || seen[t] {
+			return false
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			if field := t.Field(i); field.IsExported() && typeHasTime(field.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inTimezone returns a copy of v, of the same type, with its time.Time values
+// converted to loc.
+func inTimezone(v reflect.Value, loc *time.Location, depth int) reflect.Value {
+	if depth > maxLocalizeDepth || !v.IsValid() || !hasTimeValues(v.Type()) {
+		return v
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return reflect.ValueOf(t.In(loc))
+	}
+	out := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out.Set(inTimezone(v.Elem(), loc, depth+1))
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out.Set(reflect.New(v.Type().Elem()))
+		out.Elem().Set(inTimezone(v.Elem(), loc, depth+1))
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(inTimezone(v.Index(i), loc, depth+1))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), inTimezone(iter.Value(), loc, depth+1))
+		}
+	case reflect.Struct:
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				out.Field(i).Set(inTimezone(v.Field(i), loc, depth+1))
+			}
+		}
+	default:
+		return v
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocaleAndTimezone(t *testing.T) {
+	o := New(WithLocaleOptions(LocaleOptions{
+		LocaleCookie:   "lang",
+		TimezoneCookie: "tz",
+		Profile: func(c *Context) (string, string) {
+			if c.Header("X-User") == "alice" {
+				return "de", "Europe/Berlin"
+			}
+			return "", ""
+		},
+	}))
+	newContext := func(headers map[string]string, cookies ...*http.Cookie) *Context {
+		c, _ := NewTestContext(http.MethodGet, "/", nil)
+		c.okapi = o
+		for k, v := range headers {
+			c.request.Header.Set(k, v)
+		}
+		for _, cookie := range cookies {
+			c.request.AddCookie(cookie)
+		}
+		return c
+	}
+
+	c := newContext(map[string]string{"Accept-Language": "fr-CA,fr;q=0.9", "Time-Zone": "Africa/Kinshasa"})
+	assert.Equal(t, "fr-ca", c.Locale())
+	assert.Equal(t, "Africa/Kinshasa", c.Timezone().String())
+
+	c = newContext(map[string]string{"Accept-Language": "fr"}, &http.Cookie{Name: "lang", Value: "en_GB"}, &http.Cookie{Name: "tz", Value: "Europe/London"})
+	assert.Equal(t, "en-gb", c.Locale())
+	assert.Equal(t, "Europe/London", c.Timezone().String())
+
+	c = newContext(map[string]string{"X-User": "alice", "Accept-Language": "fr"}, &http.Cookie{Name: "lang", Value: "es"})
+	assert.Equal(t, "de", c.Locale())
+	assert.Equal(t, "Europe/Berlin", c.Timezone().String())
+
+	c = newContext(map[string]string{"Time-Zone": "Mars/Olympus_Mons"})
+	assert.Equal(t, "", c.Locale())
+	assert.Equal(t, time.UTC, c.Timezone())
+}
+
+func TestLocaleFormatting(t *testing.T) {
+	o := New(WithLocaleOptions(LocaleOptions{
+		Formats: map[string]LocaleFormat{"sw": {Decimal: ".", Group: ",", Date: "02/01/2006", DateTime: "02/01/2006 15:04"}},
+	}))
+	moment := time.Date(2026, 3, 1, 22, 30, 0, 0, time.UTC)
+	tests := []struct {
+		language, timezone, number, date, dateTime string
+	}{
+		{"en-US", "America/New_York", "-1,234,567.89", "03/01/2026", "03/01/2026 5:30 PM"},
+		{"de-CH", "Europe/Zurich", "-1.234.567,89", "01.03.2026", "01.03.2026 23:30"},
+		{"fr", "Africa/Kinshasa", "-1\u202f234\u202f567,89", "01/03/2026", "01/03/2026 23:30"},
+		{"sw", "Africa/Nairobi", "-1,234,567.89", "02/03/2026", "02/03/2026 01:30"},
+		{"xx", "", "-1,234,567.89", "03/01/2026", "03/01/2026 10:30 PM"},
+	}
+	for _, tt := range tests {
+		c, _ := NewTestContext(http.MethodGet, "/", nil)
+		c.okapi = o
+		c.request.Header.Set("Accept-Language", tt.language)
+		c.request.Header.Set("Time-Zone", tt.timezone)
+		assert.Equal(t, tt.number, c.FormatNumber(-1234567.891, 2), tt.language)
+		assert.Equal(t, tt.date, c.FormatDate(moment), tt.language)
+		assert.Equal(t, tt.dateTime, c.FormatDateTime(moment), tt.language)
+	}
+
+	c, _ := NewTestContext(http.MethodGet, "/", nil)
+	assert.Equal(t, "999", c.FormatNumber(999, 0))
+	assert.Equal(t, "1,000.5", c.FormatNumber(1000.5, 1))
+}
+
+type localizedEvent struct {
+	Name     string
+	At       time.Time
+	Reminder *time.Time
+	Extra    any
+	Sessions []struct{ Start time.Time }
+}
+
+func TestLocalizeJSONTimes(t *testing.T) {
+	tmpl, err := NewTemplate(fstest.MapFS{
+		"event.html": {Data: []byte(`{{ format_datetime .At }} {{ format_number 1234.5 1 }}`)},
+	}, "*.html")
+	require.NoError(t, err)
+	o := New(WithLocaleOptions(LocaleOptions{LocalizeJSONTimes: true})).WithRenderer(tmpl)
+
+	at := time.Date(2026, 3, 1, 22, 30, 0, 0, time.UTC)
+	event := localizedEvent{Name: "launch", At: at, Reminder: &at, Extra: M{"ends": at}}
+	event.Sessions = append(event.Sessions, struct{ Start time.Time }{at})
+	o.Get("/event", func(c *Context) error { return c.OK(event) })
+	o.Get("/event.html", func(c *Context) error { return c.Render(http.StatusOK, "event.html", event) })
+	ts := NewTestServerWithOkapi(t, o)
+
+	okapitest.GET(t, ts.BaseURL+"/event").
+		Header("Time-Zone", "Africa/Kinshasa").
+		ExpectStatusOK().
+		ExpectJSONPath("At", "2026-03-01T23:30:00+01:00").
+		ExpectJSONPath("Reminder", "2026-03-01T23:30:00+01:00").
+		ExpectJSONPath("Extra.ends", "2026-03-01T23:30:00+01:00")
+	var decoded struct{ Sessions []struct{ Start string } }
+	okapitest.GET(t, ts.BaseURL+"/event").Header("Time-Zone", "Africa/Kinshasa").ParseJSON(&decoded)
+	require.Len(t, decoded.Sessions, 1)
+	assert.Equal(t, "2026-03-01T23:30:00+01:00", decoded.Sessions[0].Start)
+	assert.Equal(t, time.UTC, event.At.Location(), "the response value must not be modified")
+
+	okapitest.GET(t, ts.BaseURL+"/event.html").
+		Header("Accept-Language", "de").
+		Header("Time-Zone", "Africa/Kinshasa").
+		ExpectStatusOK().
+		ExpectBody("01.03.2026 23:30 1.234,5")
+}
@@ -0,0 +1,303 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotatedTimeFormat is the timestamp appended to rotated log file names.
+const rotatedTimeFormat = "2006-01-02T15-04-05.000"
+
+type (
+	// RotationConfig configures a RotatingFile.
+	RotationConfig struct {
+		// MaxSize rotates the file before it grows beyond this many bytes.
+		// Defaults to 100 MB.
+		MaxSize int64
+		// MaxAge rotates the file once it is older than this, e.g. 24h for
+		// daily files. Zero disables age-based rotation.
+		MaxAge time.Duration
+		// MaxBackups is the number of rotated files kept, the oldest being
+		// removed first. Zero keeps them all.
+		MaxBackups int
+		// Compress gzips rotated files in the background.
+		Compress bool
+	}
+
+	// RotatingFile is an io.WriteCloser appending to a file that is rotated by
+	// size and age. Rotated files are renamed with a timestamp, e.g.
+	// access-2025-01-02T15-04-05.000.log, next to the file.
+	RotatingFile struct {
+		path    string
+		cfg     RotationConfig
+		mu      sync.Mutex
+		file    *os.File // nil when it could not be reopened
+		closed  bool
+		size    int64
+		opened  time.Time
+		archive sync.WaitGroup
+	}
+)
+
+// NewRotatingFile opens, or creates, the log file at path.
+//
+// Example:
+//
+//	logs, err := okapi.NewRotatingFile("/var/log/api/access.log", okapi.RotationConfig{
+//		MaxSize:    50 << 20,
+//		MaxAge:     24 * time.Hour,
+//		MaxBackups: 14,
+//		Compress:   true,
+//	})
+func NewRotatingFile(path string, cfg RotationConfig) (*RotatingFile, error) {
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = 100 << 20
+	}
+	f := &RotatingFile{path: path, cfg: cfg}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write appends p to the file, rotating it first when p would exceed MaxSize
+// or the file is older than MaxAge.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.ready(); err != nil {
+		return 0, err
+	}
+	if (f.size > 0 && f.size+int64(len(p)) > f.cfg.MaxSize) ||
+		(f.cfg.MaxAge > 0 && time.Since(f.opened) > f.cfg.MaxAge) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file now, e.g. on SIGHUP.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.ready(); err != nil {
+		return err
+	}
+	return f.rotate()
+}
+
+// Close closes the file, waiting for rotated files being compressed.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.closed = true
+	f.mu.Unlock()
+	f.archive.Wait()
+	return err
+}
+
+// ready reopens the file when a rotation could not, failing once closed.
+// The caller holds f.mu.
+func (f *RotatingFile) ready() error {
+	if f.closed {
+		return os.ErrClosed
+	}
+	if f.file == nil {
+		return f.open()
+	}
+	return nil
+}
+
+// open opens the file for appending.
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("create log directory: %w", err)
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+	f.file, f.size, f.opened = file, info.Size(), time.Now()
+	if f.size > 0 {
+		f.opened = info.ModTime()
+	}
+	return nil
+}
+
+// rotate renames the current file and opens a new one. Compression and
+// cleanup of rotated files run in the background.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	rotated := f.rotatedName()
+	if err := os.Rename(f.path, rotated); err != nil {
+		// Keep writing to the current file, the next write retries otherwise.
+		_ = f.open()
+		return fmt.Errorf("rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.archive.Add(1)
+	go func() {
+		defer f.archive.Done()
+		if f.cfg.Compress {
+			_ = compressFile(rotated)
+		}
+		f.removeOldBackups()
+	}()
+	return nil
+}
+
+// rotatedName returns the name of the file being rotated. The timestamp is
+// moved forward when several rotations happen within the same millisecond.
+func (f *RotatingFile) rotatedName() string {
+	ext := filepath.Ext(f.path)
+	base := strings.TrimSuffix(f.path, ext)
+	for at := time.Now(); ; at = at.Add(time.Millisecond) {
+		name := fmt.Sprintf("%s-%s%s", base, at.Format(rotatedTimeFormat), ext)
+		if !exists(name) && !exists(name+".gz") {
+			return name
+		}
+	}
+}
+
+// exists reports whether a file exists at path.
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// backups returns the rotated files, oldest first.
+func (f *RotatingFile) backups() []string {
+	ext := filepath.Ext(f.path)
+	prefix := filepath.Base(strings.TrimSuffix(f.path, ext)) + "-"
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		stamp, ok := strings.CutPrefix(name, prefix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		stamp = strings.TrimSuffix(strings.TrimSuffix(stamp, ".gz"), ext)
+		if _, err := time.Parse(rotatedTimeFormat, stamp); err == nil {
+			files = append(files, filepath.Join(filepath.Dir(f.path), name))
+		}
+	}
+	// Timestamps sort chronologically.
+	slices.Sort(files)
+	return files
+}
+
+// removeOldBackups removes the rotated files beyond MaxBackups.
+func (f *RotatingFile) removeOldBackups() {
+	if f.cfg.MaxBackups <= 0 {
+		return
+	}
+	files := f.backups()
+	for len(files) > f.cfg.MaxBackups {
+		_ = os.Remove(files[0])
+		files = files[1:]
+	}
+}
+
+// compressFile replaces path with a gzipped copy.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err == nil {
+		err = zw.Close()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
+
+// WithAccessLogOutput writes access logs to w as JSON lines, separately from
+// the application logs, e.g. to a RotatingFile.
+//
+// Example:
+//
+//	logs, err := okapi.NewRotatingFile("logs/access.log", okapi.RotationConfig{MaxAge: 24 * time.Hour, Compress: true})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer logs.Close()
+//	o := okapi.New(okapi.WithAccessLogOutput(logs))
+func WithAccessLogOutput(w io.Writer) OptionFunc {
+	return func(o *Okapi) {
+		if w == nil {
+			o.accessLogger = nil
+			return
+		}
+		o.accessLogger = slog.New(slog.NewJSONHandler(w, nil))
+	}
+}
+
+// WithAccessLogOutput writes access logs to w, see WithAccessLogOutput.
+func (o *Okapi) WithAccessLogOutput(w io.Writer) *Okapi {
+	return o.apply(WithAccessLogOutput(w))
+}
@@ -0,0 +1,154 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestRotatingFileSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "access.log")
+	f, err := NewRotatingFile(path, RotationConfig{MaxSize: 10, MaxBackups: 2, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"line-1\n", "line-2\n", "line-3\n", "line-4\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond) // distinct rotation timestamps
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	current, _ := os.ReadFile(path)
+	if string(current) != "line-4\n" {
+		t.Errorf("current file = %q", current)
+	}
+	backups := f.backups()
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 backups, got %v", backups)
+	}
+	for i, want := range []string{"line-2\n", "line-3\n"} {
+		if !strings.HasSuffix(backups[i], ".log.gz") {
+			t.Fatalf("backup %s is not compressed", backups[i])
+		}
+		file, err := os.Open(backups[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := io.ReadAll(zr)
+		_ = file.Close()
+		if string(got) != want {
+			t.Errorf("backup %d = %q, want %q", i, got, want)
+		}
+	}
+	if _, err := f.Write([]byte("late")); err == nil {
+		t.Error("expected an error writing to a closed file")
+	}
+}
+
+func TestRotatingFileAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	f, err := NewRotatingFile(path, RotationConfig{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	_, _ = f.Write([]byte("old\n"))
+	f.opened = time.Now().Add(-2 * time.Hour)
+	_, _ = f.Write([]byte("new\n"))
+
+	current, _ := os.ReadFile(path)
+	if string(current) != "new\n" {
+		t.Errorf("current file = %q", current)
+	}
+	if backups := f.backups(); len(backups) != 1 {
+		t.Errorf("expected 1 backup, got %v", backups)
+	}
+}
+
+func TestRotatingFileRecovers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := NewRotatingFile(path, RotationConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// Rotations within the same millisecond keep every file.
+	for _, line := range []string{"one\n", "two\n", "three\n"} {
+		_, _ = f.Write([]byte(line))
+		if err := f.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if backups := f.backups(); len(backups) != 3 {
+		t.Fatalf("expected 3 backups, got %v", backups)
+	}
+
+	// A failed rotation leaves the file writable.
+	_ = os.Remove(path)
+	if err := f.Rotate(); err == nil {
+		t.Fatal("expected the rotation of a removed file to fail")
+	}
+	if _, err := f.Write([]byte("after\n")); err != nil {
+		t.Fatalf("write after a failed rotation: %v", err)
+	}
+	current, _ := os.ReadFile(path)
+	if string(current) != "after\n" {
+		t.Errorf("current file = %q", current)
+	}
+}
+
+func TestWithAccessLogOutput(t *testing.T) {
+	var buf strings.Builder
+	ts := NewTestServerWithOkapi(t, New(WithAccessLogOutput(&buf)))
+	ts.Get("/books", helloHandler)
+	okapitest.GET(t, ts.BaseURL+"/books").ExpectStatusOK()
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(buf.String()), &entry); err != nil {
+		t.Fatalf("expected a JSON access log line, got %q: %v", buf.String(), err)
+	}
+	if entry["path"] != "/books" || entry["status"] != float64(200) {
+		t.Errorf("unexpected access log entry: %v", entry)
+	}
+}
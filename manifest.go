@@ -0,0 +1,142 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HandlerRegistry binds the names used in a route manifest to Go values.
+// See LoadRoutes.
+type HandlerRegistry struct {
+	// Handlers maps handler names to handler functions.
+	Handlers map[string]HandlerFunc
+	// Middlewares maps middleware names to middleware functions.
+	Middlewares map[string]Middleware
+	// Groups maps group names to route groups.
+	Groups map[string]*Group
+}
+
+// routeManifest is the document read by LoadRoutes.
+type routeManifest struct {
+	Routes []RouteDefinition `json:"routes" yaml:"routes"`
+}
+
+var manifestMethods = []string{methodGet, methodPost, methodPut, methodDelete, methodPatch, methodHead, methodOptions}
+
+// LoadRoutes reads route definitions from a YAML or JSON manifest and binds their
+// handler, middleware and group names using registry. Unknown fields and names
+// are reported as errors, so an operator typo fails at startup rather than at request time.
+//
+// Example manifest:
+//
+//	routes:
+//	  - method: GET
+//	    path: /books
+//	    handler: listBooks
+//	    group: api
+//	    middlewares: [auth]
+//	    summary: List books
+//	    tags: [Books]
+//
+// Example:
+//
+//	api := app.Group("/api")
+//	routes, err := okapi.LoadRoutes(manifest, okapi.HandlerRegistry{
+//		Handlers:    map[string]okapi.HandlerFunc{"listBooks": listBooks},
+//		Middlewares: map[string]okapi.Middleware{"auth": jwtAuth.Middleware},
+//		Groups:      map[string]*okapi.Group{"api": api},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	app.Register(routes...)
+func LoadRoutes(data []byte, registry HandlerRegistry) ([]RouteDefinition, error) {
+	var manifest routeManifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&manifest); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("okapi: invalid route manifest: %w", err)
+	}
+	for i := range manifest.Routes {
+		if err := registry.bind(&manifest.Routes[i]); err != nil {
+			return nil, fmt.Errorf("okapi: route %d (%s %s): %w", i, manifest.Routes[i].Method, manifest.Routes[i].Path, err)
+		}
+	}
+	return manifest.Routes, nil
+}
+
+// LoadRoutesFromFile reads a YAML or JSON route manifest from path, see LoadRoutes.
+func LoadRoutesFromFile(path string, registry HandlerRegistry) ([]RouteDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadRoutes(data, registry)
+}
+
+// bind resolves the names of a route definition.
+func (reg HandlerRegistry) bind(r *RouteDefinition) error {
+	r.Method = strings.ToUpper(r.Method)
+	if !slices.Contains(manifestMethods, r.Method) {
+		return fmt.Errorf("unsupported HTTP method %q", r.Method)
+	}
+	if r.Path == "" && r.GroupName == "" {
+		return errors.New("either path or group must be specified")
+	}
+	if r.Handler == nil {
+		if r.HandlerName == "" {
+			return errors.New("missing handler")
+		}
+		h, ok := reg.Handlers[r.HandlerName]
+		if !ok {
+			return fmt.Errorf("unknown handler %q", r.HandlerName)
+		}
+		r.Handler = h
+	}
+	for _, name := range r.MiddlewareNames {
+		m, ok := reg.Middlewares[name]
+		if !ok {
+			return fmt.Errorf("unknown middleware %q", name)
+		}
+		r.Middlewares = append(r.Middlewares, m)
+	}
+	if r.Group == nil && r.GroupName != "" {
+		g, ok := reg.Groups[r.GroupName]
+		if !ok {
+			return fmt.Errorf("unknown group %q", r.GroupName)
+		}
+		r.Group = g
+	}
+	return nil
+}
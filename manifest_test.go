@@ -0,0 +1,123 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRouteManifest = `
+routes:
+  - method: get
+    path: /books
+    handler: listBooks
+    group: api
+    middlewares: [version]
+    summary: List books
+    tags: [Books]
+  - method: POST
+    path: /books
+    handler: createBook
+    group: api
+    operationId: createBook
+    security:
+      - bearerAuth: []
+`
+
+func TestLoadRoutes(t *testing.T) {
+	o := New()
+	api := o.Group("/api")
+	registry := HandlerRegistry{
+		Handlers: map[string]HandlerFunc{
+			"listBooks":  func(c *Context) error { return c.OK(M{"books": []string{}}) },
+			"createBook": func(c *Context) error { return c.Created(M{}) },
+		},
+		Middlewares: map[string]Middleware{
+			"version": func(c *Context) error {
+				c.SetHeader("X-Version", "1")
+				return c.Next()
+			},
+		},
+		Groups: map[string]*Group{"api": api},
+	}
+
+	path := filepath.Join(t.TempDir(), "routes.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testRouteManifest), 0o600))
+	routes, err := LoadRoutesFromFile(path, registry)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	assert.Equal(t, http.MethodGet, routes[0].Method)
+	o.Register(routes...)
+
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/books", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("X-Version"))
+
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/books", nil))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	o.buildOpenAPISpec()
+	item := o.openapiSpec.Paths.Find("/api/books")
+	require.NotNil(t, item)
+	assert.Equal(t, "List books", item.Get.Summary)
+	assert.Equal(t, []string{"Books"}, item.Get.Tags)
+	assert.Equal(t, "createBook", item.Post.OperationID)
+}
+
+func TestLoadRoutesJSON(t *testing.T) {
+	routes, err := LoadRoutes([]byte(`{"routes": [{"method": "DELETE", "path": "/books/{id}", "handler": "deleteBook"}]}`),
+		HandlerRegistry{Handlers: map[string]HandlerFunc{"deleteBook": anyHandler}})
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+	assert.Equal(t, "/books/{id}", routes[0].Path)
+	assert.NotNil(t, routes[0].Handler)
+}
+
+func TestLoadRoutesErrors(t *testing.T) {
+	registry := HandlerRegistry{Handlers: map[string]HandlerFunc{"ok": anyHandler}}
+	tests := map[string]string{
+		"unknown handler":    "routes:\n  - {method: GET, path: /a, handler: missing}",
+		"missing handler":    "routes:\n  - {method: GET, path: /a}",
+		"unknown middleware": "routes:\n  - {method: GET, path: /a, handler: ok, middlewares: [auth]}",
+		"unknown group":      "routes:\n  - {method: GET, path: /a, handler: ok, group: admin}",
+		"unsupported method": "routes:\n  - {method: BREW, path: /a, handler: ok}",
+		"unknown field":      "routes:\n  - {method: GET, path: /a, handler: ok, sumary: typo}",
+	}
+	for name, manifest := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadRoutes([]byte(manifest), registry)
+			assert.Error(t, err)
+		})
+	}
+}
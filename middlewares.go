@@ -31,6 +31,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -145,6 +146,12 @@ type (
 		//     "uid":   "user.id",
 		//   }
 		ForwardClaims map[string]string
+		// RolesClaim is the claim path holding the caller's roles, either a list or a
+		// space separated string, recorded on c.Principal(). Defaults to "roles".
+		//
+		// Example:
+		//   RolesClaim: "realm_access.roles"
+		RolesClaim string
 		// ClaimsExpression defines a custom expression to validate JWT claims.
 		// Useful for enforcing advanced conditions on claims such as role, scope, or custom fields.
 		//
@@ -261,6 +268,7 @@ func (b *BasicAuth) Middleware(c *Context) error {
 		contextKey = "username"
 	}
 	c.Set(contextKey, username)
+	c.SetPrincipal(Principal{Subject: username})
 	return c.Next()
 }
 
@@ -277,6 +285,12 @@ func (b BodyLimit) Middleware(c *Context) error {
 	const errReadBody = "Failed to read request body"
 	const errTooLarge = "Request body too large"
 
+	// A declared oversized body is rejected before it is read, which also spares
+	// clients waiting on "Expect: 100-continue" from sending it.
+	if c.request.ContentLength > b.MaxBytes {
+		c.Logger().Warn("Request body too large", "size", c.request.ContentLength, "max_size", b.MaxBytes, "ip", c.RealIP())
+		return c.String(http.StatusRequestEntityTooLarge, errTooLarge)
+	}
 	// LimitReader prevents reading more than MaxBytes+1
 	body, err := io.ReadAll(io.LimitReader(c.request.Body, b.MaxBytes+1))
 	if err != nil {
@@ -367,6 +381,10 @@ func (jwtAuth *JWTAuth) Middleware(c *Context) error {
 			return c.AbortForbidden("Insufficient permissions", err)
 		}
 	}
+	// Long-lived connections end with the token, see StreamAuth
+	if exp, err := token.Claims.GetExpirationTime(); err == nil && exp != nil {
+		c.SetAuthExpiry(exp.Time)
+	}
 	// Store claims in context
 	if jwtAuth.ContextKey != "" && token.Claims != nil {
 		c.Set(jwtAuth.ContextKey, token.Claims)
@@ -377,6 +395,7 @@ func (jwtAuth *JWTAuth) Middleware(c *Context) error {
 			c.Logger().Error("Failed to forward context from claims", "error", err)
 		}
 	}
+	c.SetPrincipal(jwtAuth.principal(token))
 	return c.Next()
 }
 
@@ -393,3 +412,119 @@ func RequestID() Middleware {
 		return c.Next()
 	}
 }
+
+// WrapMiddleware adapts a standard func(http.Handler) http.Handler middleware
+// (gorilla/handlers, chi, ...) into an Okapi Middleware.
+//
+// The same Context, and therefore its store, flows through the whole chain:
+//   - the wrapped middleware can reach it with FromRequest(r), e.g. to call Set or Get;
+//   - the request it passes on, including values it added to r.Context(), becomes c.Request();
+//   - a response writer it wraps (compression, metrics, ...) is used for subsequent writes.
+//
+// Values stored with c.Set are also readable through r.Context() when
+// WithContextPropagation is enabled, and c.Get falls back to values set
+// under ContextKey(key) on the request context.
+func WrapMiddleware(mw func(http.Handler) http.Handler) Middleware {
+	return func(c *Context) error {
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Continue the Okapi middleware chain with the request and writer
+			// handed over by the standard middleware.
+			c.request = r
+			if w != http.ResponseWriter(c.response) {
+				if rw, ok := w.(ResponseWriter); ok {
+					c.response = rw
+				} else {
+					c.response = newResponseWriter(w).withDiagnostics(c.okapi)
+				}
+			}
+			if err := c.Next(); err != nil && !c.response.Written() {
+				http.Error(c.response, err.Error(), http.StatusInternalServerError)
+			}
+		})
+
+		// Apply standard middleware and serve, then restore the writer seen by
+		// the outer middlewares.
+		original := c.response
+		mw(next).ServeHTTP(c.response, c.requestWithContext())
+		c.response = original
+		return nil
+	}
+}
+
+// MiddlewareCondition reports whether a conditional middleware applies to a request.
+type MiddlewareCondition func(c *Context) bool
+
+// When applies middlewares only to requests matching condition; other
+// requests skip straight to the next handler.
+//
+// Example:
+//
+//	o.Use(okapi.When(okapi.MethodIs(http.MethodPost, http.MethodPut), okapi.BodyLimit{MaxBytes: 1 << 20}.Middleware))
+func When(condition MiddlewareCondition, middlewares ...Middleware) Middleware {
+	return func(c *Context) error {
+		if !condition(c) {
+			return c.Next()
+		}
+		return runConditional(c, middlewares)
+	}
+}
+
+// Unless applies middlewares to every request except those matching condition.
+//
+// Example:
+//
+//	o.Use(okapi.Unless(okapi.ContentTypeIs("multipart/form-data"), bodyLimit))
+func Unless(condition MiddlewareCondition, middlewares ...Middleware) Middleware {
+	return When(func(c *Context) bool { return !condition(c) }, middlewares...)
+}
+
+// MethodIs matches requests using one of the given HTTP methods.
+func MethodIs(methods ...string) MiddlewareCondition {
+	return func(c *Context) bool {
+		for _, m := range methods {
+			if strings.EqualFold(c.request.Method, m) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ContentTypeIs matches requests whose Content-Type has one of the given
+// media types, ignoring parameters. A "type/*" pattern matches any subtype.
+func ContentTypeIs(mediaTypes ...string) MiddlewareCondition {
+	return func(c *Context) bool {
+		mediaType, _, _ := strings.Cut(c.ContentType(), ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		if mediaType == "" {
+			return false
+		}
+		for _, t := range mediaTypes {
+			t = strings.ToLower(t)
+			if t == mediaType || (strings.HasSuffix(t, "/*") && strings.HasPrefix(mediaType, t[:len(t)-1])) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// runConditional runs middlewares as a nested chain ending with the rest of
+// the request's handler chain.
+func runConditional(c *Context, middlewares []Middleware) error {
+	switch len(middlewares) {
+	case 0:
+		return c.Next()
+	case 1:
+		return middlewares[0](c)
+	}
+	handlers, index := c.handlers, c.index
+	chain := make([]HandlerFunc, 0, len(middlewares)+1)
+	chain = append(chain, middlewares...)
+	chain = append(chain, func(c *Context) error {
+		c.handlers, c.index = handlers, index
+		return c.Next()
+	})
+	c.handlers, c.index = chain, 0
+	return chain[0](c)
+}
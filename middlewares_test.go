@@ -25,6 +25,7 @@
 package okapi
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -547,6 +548,61 @@ func TestStdMiddleware(t *testing.T) {
 	})
 }
 
+// statusRecorder mimics third-party middlewares that wrap the response writer.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+type tenantKey struct{}
+
+func TestStdMiddleware_SharesContext(t *testing.T) {
+	ts := NewTestServer(t)
+	var recorded int
+	ts.Use(func(c *Context) error {
+		c.Set("user", "alice")
+		return c.Next()
+	})
+	ts.UseMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c, ok := FromRequest(r)
+			if !ok {
+				http.Error(w, "no okapi context", http.StatusInternalServerError)
+				return
+			}
+			// Okapi -> std: read the store. std -> Okapi: write to the store
+			// and the request context.
+			c.Set("greeting", "hello "+c.GetString("user"))
+			ctx := context.WithValue(r.Context(), tenantKey{}, "acme")
+			ctx = context.WithValue(ctx, ContextKey("plan"), "pro")
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+			recorded = rec.status
+		})
+	})
+	ts.Get("/", func(c *Context) error {
+		return c.OK(M{
+			"greeting": c.GetString("greeting"),
+			"tenant":   c.Request().Context().Value(tenantKey{}),
+			"plan":     c.GetString("plan"),
+		})
+	})
+
+	okapitest.GET(t, ts.BaseURL+"/").
+		ExpectStatusOK().
+		ExpectJSONPath("greeting", "hello alice").
+		ExpectJSONPath("tenant", "acme").
+		ExpectJSONPath("plan", "pro")
+	if recorded != http.StatusOK {
+		t.Errorf("wrapped writer saw status %d, want %d", recorded, http.StatusOK)
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Shared helpers
 // -----------------------------------------------------------------------------
@@ -581,3 +637,60 @@ func whoAmIHandler(c *Context) error {
 }
 
 func helloMiddleware(c *Context) error { return c.Next() }
+
+// -----------------------------------------------------------------------------
+// Conditional middleware
+// -----------------------------------------------------------------------------
+
+func TestWhenUnless(t *testing.T) {
+	limit := BodyLimit{MaxBytes: 5}
+	tag := func(name string) Middleware {
+		return func(c *Context) error {
+			c.SetHeader("X-"+name, "1")
+			return c.Next()
+		}
+	}
+
+	ts := NewTestServer(t)
+	ts.Use(When(MethodIs(http.MethodPost), limit.Middleware))
+	ts.Use(Unless(ContentTypeIs("multipart/*", "application/octet-stream"), tag("Parsed"), tag("Logged")))
+	handler := func(c *Context) error {
+		return c.String(http.StatusOK, c.ContentType())
+	}
+	ts.Post("/echo", handler)
+	ts.Put("/echo", handler)
+
+	t.Run("matching method applies the middleware", func(t *testing.T) {
+		okapitest.POST(t, ts.BaseURL+"/echo").
+			Body(strings.NewReader("0123456789")).
+			ExpectStatus(http.StatusRequestEntityTooLarge)
+	})
+
+	t.Run("other methods skip it", func(t *testing.T) {
+		okapitest.PUT(t, ts.BaseURL+"/echo").
+			Body(strings.NewReader("0123456789")).
+			ExpectStatusOK()
+	})
+
+	t.Run("unless runs the chain for other content types", func(t *testing.T) {
+		resp, _ := okapitest.PUT(t, ts.BaseURL+"/echo").
+			Header("Content-Type", "application/json; charset=utf-8").
+			Body(strings.NewReader("{}")).
+			ExpectStatusOK().
+			Execute()
+		if resp.Header.Get("X-Parsed") != "1" || resp.Header.Get("X-Logged") != "1" {
+			t.Errorf("expected both middlewares to run, got %v", resp.Header)
+		}
+	})
+
+	t.Run("unless skips matching content types", func(t *testing.T) {
+		resp, _ := okapitest.PUT(t, ts.BaseURL+"/echo").
+			Header("Content-Type", "multipart/form-data; boundary=x").
+			Body(strings.NewReader("--x--")).
+			ExpectStatusOK().
+			Execute()
+		if resp.Header.Get("X-Parsed") != "" || resp.Header.Get("X-Logged") != "" {
+			t.Errorf("expected middlewares to be skipped, got %v", resp.Header)
+		}
+	})
+}
@@ -0,0 +1,278 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// mockMaxDepth bounds the nesting of synthesized mock payloads, so recursive
+// schemas terminate.
+const mockMaxDepth = 6
+
+// StartMock starts the server in mock mode: every documented route responds
+// with its documented example response instead of running its handler, so
+// frontend teams can develop against realistic payloads before handlers are
+// implemented. Middlewares still run.
+//
+// The response is the first documented 2xx response. Its example is used when
+// set; otherwise a payload is synthesized from the schema, using field examples,
+// defaults and enum values where available. A specific documented response can
+// be requested with the Prefer header:
+//
+//	curl -H "Prefer: code=404" http://localhost:8080/books/1
+func (o *Okapi) StartMock() error {
+	o.mock = true
+	o.buildOpenAPISpec()
+	return o.Start()
+}
+
+// mockHandler returns the handler serving the documented example response of route.
+func (o *Okapi) mockHandler(route *Route) HandlerFunc {
+	return func(c *Context) error {
+		var op *openapi3.Operation
+		if spec := o.routeSpec(route); spec != nil {
+			if item := spec.Paths.Value(route.Path); item != nil {
+				op = item.GetOperation(route.Method)
+			}
+		}
+		if op == nil || op.Responses == nil {
+			return c.AbortNotImplemented("No documented response for " + route.Method + " " + route.Path)
+		}
+		code, resp := mockResponse(op.Responses, preferredCode(c.Header("Prefer")))
+		if resp == nil {
+			return c.AbortNotImplemented("No documented response for " + route.Method + " " + route.Path)
+		}
+		if mt := resp.Content.Get(constJSON); mt != nil {
+			return c.JSON(code, o.mockMediaType(mt))
+		}
+		if mt := resp.Content.Get(constJSONProblem); mt != nil {
+			return c.jsonProblemError(code, o.mockMediaType(mt))
+		}
+		return c.Status(code)
+	}
+}
+
+// routeSpec returns the OpenAPI document route is documented in, see DocSpec.
+func (o *Okapi) routeSpec(route *Route) *openapi3.T {
+	if named := o.namedSpecs[route.specName]; route.specName != "" && named != nil {
+		return named.spec
+	}
+	return o.openapiSpec
+}
+
+// preferredCode parses the status code requested with "Prefer: code=NNN".
+func preferredCode(prefer string) int {
+	for _, part := range strings.Split(prefer, ",") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(part), "code="); ok {
+			if code, err := strconv.Atoi(v); err == nil {
+				return code
+			}
+		}
+	}
+	return 0
+}
+
+// mockResponse selects the preferred documented response, or the first 2xx one.
+func mockResponse(responses *openapi3.Responses, preferred int) (int, *openapi3.Response) {
+	if preferred != 0 {
+		if ref := responses.Status(preferred); ref != nil && ref.Value != nil {
+			return preferred, ref.Value
+		}
+	}
+	codes := make([]int, 0, responses.Len())
+	for key := range responses.Map() {
+		if code, err := strconv.Atoi(key); err == nil && code >= 200 && code < 300 {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		if ref := responses.Status(code); ref != nil && ref.Value != nil {
+			return code, ref.Value
+		}
+	}
+	return 0, nil
+}
+
+// mockMediaType returns the example of a media type, synthesized from its
+// schema when none is documented.
+func (o *Okapi) mockMediaType(mt *openapi3.MediaType) any {
+	if mt.Example != nil {
+		return mt.Example
+	}
+	for _, name := range sortedKeys(mt.Examples) {
+		if ex := mt.Examples[name]; ex != nil && ex.Value != nil {
+			return ex.Value.Value
+		}
+	}
+	return o.mockValue(mt.Schema, 0)
+}
+
+// mockValue synthesizes an example value for a schema.
+func (o *Okapi) mockValue(ref *openapi3.SchemaRef, depth int) any {
+	s := o.resolveSchema(ref)
+	if s == nil || depth > mockMaxDepth {
+		return nil
+	}
+	switch {
+	case s.Example != nil:
+		return coerceExample(s.Example, s)
+	case len(s.Examples) > 0:
+		return coerceExample(s.Examples[0], s)
+	case s.Default != nil:
+		return s.Default
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	case len(s.AllOf) > 0:
+		merged := map[string]any{}
+		for _, part := range s.AllOf {
+			if m, ok := o.mockValue(part, depth+1).(map[string]any); ok {
+				for k, v := range m {
+					merged[k] = v
+				}
+			}
+		}
+		return merged
+	case len(s.OneOf) > 0:
+		return o.mockValue(s.OneOf[0], depth+1)
+	case len(s.AnyOf) > 0:
+		return o.mockValue(s.AnyOf[0], depth+1)
+	}
+	switch {
+	case s.Type == nil, s.Type.Is(openapi3.TypeObject):
+		obj := make(map[string]any, len(s.Properties))
+		for name, prop := range s.Properties {
+			obj[name] = o.mockValue(prop, depth+1)
+		}
+		if ap := s.AdditionalProperties.Schema; ap != nil && len(obj) == 0 {
+			obj["key"] = o.mockValue(ap, depth+1)
+		}
+		return obj
+	case s.Type.Is(openapi3.TypeArray):
+		if item := o.mockValue(s.Items, depth+1); item != nil {
+			return []any{item}
+		}
+		return []any{}
+	case s.Type.Is(openapi3.TypeString):
+		return mockString(s.Format)
+	case s.Type.Is(openapi3.TypeInteger):
+		if s.Min != nil {
+			return int64(*s.Min)
+		}
+		return 0
+	case s.Type.Is(openapi3.TypeNumber):
+		if s.Min != nil {
+			return *s.Min
+		}
+		return 0.0
+	case s.Type.Is(openapi3.TypeBoolean):
+		return true
+	}
+	return nil
+}
+
+// resolveSchema returns the schema of ref, resolving component references.
+func (o *Okapi) resolveSchema(ref *openapi3.SchemaRef) *openapi3.Schema {
+	if ref == nil {
+		return nil
+	}
+	if ref.Value != nil {
+		return ref.Value
+	}
+	name, ok := strings.CutPrefix(ref.Ref, "#/components/schemas/")
+	if !ok {
+		return nil
+	}
+	// Look the component up in the public document, then in the named ones.
+	specs := []*openapi3.T{o.openapiSpec}
+	for _, specName := range sortedKeys(o.namedSpecs) {
+		specs = append(specs, o.namedSpecs[specName].spec)
+	}
+	for _, spec := range specs {
+		if spec != nil && spec.Components != nil && spec.Components.Schemas[name] != nil {
+			return o.resolveSchema(spec.Components.Schemas[name])
+		}
+	}
+	return nil
+}
+
+// mockString returns a sample string for a string format.
+func mockString(format string) string {
+	switch format {
+	case "date-time":
+		return "2025-01-01T00:00:00Z"
+	case "date":
+		return "2025-01-01"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "uri", "url":
+		return "https://example.com"
+	case "decimal":
+		return "12.30"
+	default:
+		return "string"
+	}
+}
+
+// coerceExample converts an example declared as a string (e.g. via the
+// `example` struct tag) to the schema's type.
+func coerceExample(v any, s *openapi3.Schema) any {
+	str, ok := v.(string)
+	if !ok || s.Type == nil {
+		return v
+	}
+	switch {
+	case s.Type.Is(openapi3.TypeInteger):
+		if n, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return n
+		}
+	case s.Type.Is(openapi3.TypeNumber):
+		if f, err := strconv.ParseFloat(str, 64); err == nil {
+			return f
+		}
+	case s.Type.Is(openapi3.TypeBoolean):
+		if b, err := strconv.ParseBool(str); err == nil {
+			return b
+		}
+	}
+	return v
+}
+
+// sortedKeys returns the keys of m in alphabetical order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
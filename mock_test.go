@@ -0,0 +1,74 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+type mockBook struct {
+	ID     int      `json:"id" example:"42"`
+	Name   string   `json:"name" example:"The Go Programming Language"`
+	Status string   `json:"status" enum:"available,sold"`
+	Tags   []string `json:"tags"`
+}
+
+func TestMockMode(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/books/:id", func(c *Context) error {
+		t.Error("handler must not run in mock mode")
+		return nil
+	}, DocResponse(mockBook{}), DocResponse(404, M{"message": "not found"}))
+	ts.Get("/books", nil, DocResponse([]mockBook{}))
+	ts.Get("/undocumented", nil)
+	ts.mock = true
+	ts.buildOpenAPISpec()
+
+	okapitest.GET(t, ts.BaseURL+"/books/1").
+		ExpectStatusOK().
+		ExpectJSONPath("id", float64(42)).
+		ExpectJSONPath("name", "The Go Programming Language").
+		ExpectJSONPath("status", "available")
+	okapitest.GET(t, ts.BaseURL+"/books/1").
+		Header("Prefer", "code=404").
+		ExpectStatusNotFound()
+	okapitest.GET(t, ts.BaseURL+"/books").
+		ExpectStatusOK().
+		ExpectBodyContains(`"The Go Programming Language"`)
+	okapitest.GET(t, ts.BaseURL+"/undocumented").ExpectStatus(501)
+}
+
+func TestMockModeNamedSpec(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/admin/books/:id", nil, DocSpec("admin"), DocResponse(mockBook{}))
+	ts.mock = true
+	ts.buildOpenAPISpec()
+
+	okapitest.GET(t, ts.BaseURL+"/admin/books/1").
+		ExpectStatusOK().
+		ExpectJSONPath("name", "The Go Programming Language")
+}
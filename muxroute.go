@@ -0,0 +1,104 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"log/slog"
+
+	"github.com/gorilla/mux"
+)
+
+// MuxRoute returns the underlying gorilla/mux route, to configure matching
+// features Okapi does not expose, such as Host, Schemes or MatcherFunc.
+// It returns nil when the route is not registered on a router yet, e.g. a
+// RouteDefinition before Register.
+//
+// Configure the route before the server starts: mux routes are not safe for
+// concurrent modification. Keep the handler set by Okapi, otherwise the route
+// bypasses middlewares and handler. When the path template is changed, Okapi
+// re-reads it when building the documentation, updating the documented path
+// and route URLs so they stay consistent with the route.
+//
+// Example:
+//
+//	o.Get("/books", listBooks).MuxRoute().Host("api.example.com").Schemes("https")
+func (r *Route) MuxRoute() *mux.Route {
+	return r.muxRoute
+}
+
+// WithMuxRoute is the RouteOption form of Route.MuxRoute: configure is called
+// with the mux route once the route is registered.
+func WithMuxRoute(configure func(*mux.Route)) RouteOption {
+	return func(r *Route) {
+		r.muxConfigs = append(r.muxConfigs, configure)
+	}
+}
+
+// WithMuxRoute calls configure with the mux route of every route registered in
+// the group afterwards, including its subgroups. See Route.MuxRoute.
+//
+// Example:
+//
+//	admin := o.Group("/admin").WithMuxRoute(func(r *mux.Route) {
+//		r.Host("admin.example.com")
+//	})
+func (g *Group) WithMuxRoute(configure func(*mux.Route)) *Group {
+	g.defaults = append(g.defaults, WithMuxRoute(configure))
+	return g
+}
+
+// configureMuxRoute attaches the mux route to r and applies the WithMuxRoute
+// options.
+func (r *Route) configureMuxRoute(muxRoute *mux.Route) {
+	r.muxRoute = muxRoute
+	for _, configure := range r.muxConfigs {
+		configure(muxRoute)
+	}
+}
+
+// syncMuxRoutes re-reads the path template of the mux routes, which advanced
+// users may have changed through Route.MuxRoute, so that the documentation and
+// route URLs follow the path actually matched.
+func (o *Okapi) syncMuxRoutes() {
+	for _, r := range o.routes {
+		if r.muxRoute == nil {
+			continue
+		}
+		if err := r.muxRoute.GetError(); err != nil {
+			slog.Warn("[okapi] Invalid mux route configuration", "method", r.Method, "path", r.Path, "error", err)
+			continue
+		}
+		tpl, err := r.muxRoute.GetPathTemplate()
+		if err != nil || tpl == r.docPath || normalizeRoutePath(tpl) == r.Path {
+			continue
+		}
+		r.Path = normalizeRoutePath(tpl)
+		r.docPath = tpl
+		if r.autoPathParams {
+			r.pathParams = nil
+			r.autoPathParams = false
+		}
+	}
+}
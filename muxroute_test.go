@@ -0,0 +1,93 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestMuxRoute(t *testing.T) {
+	o := New()
+	books := o.Get("/books", anyHandler).WithName("book")
+	// Path extends the template of the mux route.
+	books.MuxRoute().Path("/{id:[0-9]+}")
+	hosted := o.Get("/hosted", anyHandler)
+	hosted.MuxRoute().Host("api.example.com")
+	admin := o.Group("/admin").WithMuxRoute(func(r *mux.Route) {
+		r.Headers("X-Admin", "1")
+	})
+	admin.Get("/stats", anyHandler)
+	admin.Group("/v1").Get("/users", anyHandler)
+
+	if (&Route{}).MuxRoute() != nil {
+		t.Error("expected no mux route for an unregistered route")
+	}
+
+	serve := func(target string, header http.Header) int {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	adminHeader := http.Header{"X-Admin": {"1"}}
+	for _, tc := range []struct {
+		target string
+		header http.Header
+		want   int
+	}{
+		{"http://api.example.com/hosted", nil, http.StatusOK},
+		{"http://other.example.com/hosted", nil, http.StatusNotFound},
+		{"/admin/stats", adminHeader, http.StatusOK},
+		{"/admin/stats", nil, http.StatusNotFound},
+		{"/admin/v1/users", adminHeader, http.StatusOK},
+		{"/admin/v1/users", nil, http.StatusNotFound},
+	} {
+		if got := serve(tc.target, tc.header); got != tc.want {
+			t.Errorf("GET %s (%v): status = %d, want %d", tc.target, tc.header, got, tc.want)
+		}
+	}
+
+	o.buildOpenAPISpec()
+	if o.openapiSpec.Paths.Find("/books") != nil {
+		t.Error("expected the original path to be replaced in the spec")
+	}
+	item := o.openapiSpec.Paths.Find("/books/{id}")
+	if item == nil || item.Get == nil {
+		t.Fatal("expected the modified path template in the spec")
+	}
+	if len(item.Get.Parameters) != 1 || item.Get.Parameters[0].Value.Name != "id" {
+		t.Errorf("expected the id path parameter to be documented, got %v", item.Get.Parameters)
+	}
+	if url, err := o.URL("book", "id", "7"); err != nil || url != "/books/7" {
+		t.Errorf("URL = %q, %v; want /books/7", url, err)
+	}
+}
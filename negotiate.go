@@ -0,0 +1,124 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+)
+
+// Representation is a format a route renders its data in, see Renders.
+type Representation struct {
+	mediaType string
+	template  string
+	schema    any
+}
+
+// HTML renders the data with the named template of the configured Renderer.
+func HTML(name string) Representation {
+	return Representation{mediaType: constHTML, template: name}
+}
+
+// JSONOf encodes the data as JSON. v documents the JSON response in OpenAPI,
+// e.g. JSONOf(BooksResponse{}), and may be nil.
+func JSONOf(v any) Representation {
+	return Representation{mediaType: constJSON, schema: v}
+}
+
+// Renders lets a single handler serve both web pages and API clients: the
+// handler passes its data to Context.Negotiate, or returns it from Respond,
+// and the representation matching the Accept header is written. Listing order
+// breaks ties, so the first representation is used for Accept: */* or no
+// Accept header at all. A request accepting none of them gets 406.
+//
+// Example:
+//
+//	o.Get("/books", listBooks,
+//		okapi.Renders(okapi.HTML("books/index"), okapi.JSONOf(BooksResponse{})),
+//	)
+//
+//	func listBooks(c *okapi.Context) error {
+//		return c.Negotiate(http.StatusOK, BooksResponse{Books: books})
+//	}
+func (r *Route) Renders(representations ...Representation) *Route {
+	r.renders = append(r.renders, representations...)
+	for _, rep := range representations {
+		if rep.schema == nil {
+			continue
+		}
+		if _, ok := r.responses[http.StatusOK]; !ok {
+			r.docResponse(http.StatusOK, rep.schema)
+		}
+	}
+	return r
+}
+
+// Renders is the RouteOption form of Route.Renders.
+func Renders(representations ...Representation) RouteOption {
+	return func(r *Route) {
+		r.Renders(representations...)
+	}
+}
+
+// Negotiate writes data in the representation of the route matching the Accept
+// header, see Renders. Routes without representations write JSON.
+func (c *Context) Negotiate(code int, data any) error {
+	if c.route == nil || len(c.route.renders) == 0 {
+		return c.JSON(code, data)
+	}
+	c.Vary("Accept")
+	rep, ok := negotiateRepresentation(c.request.Header.Get("Accept"), c.route.renders)
+	if !ok {
+		return c.AbortNotAcceptable("None of the available representations is acceptable")
+	}
+	if rep.mediaType == constHTML {
+		return c.Render(code, rep.template, c.redactFields(data))
+	}
+	return c.JSON(code, data)
+}
+
+// negotiateRepresentation returns the representation the Accept header prefers,
+// the first listed one on ties.
+func negotiateRepresentation(accept string, representations []Representation) (Representation, bool) {
+	var (
+		best    Representation
+		quality float64
+	)
+	for _, rep := range representations {
+		if q := mediaTypeQuality(accept, rep.mediaType); q > quality {
+			best, quality = rep, q
+		}
+	}
+	return best, quality > 0
+}
+
+// hasHTMLRepresentation reports whether the route renders an HTML template.
+func (r *Route) hasHTMLRepresentation() bool {
+	for _, rep := range r.renders {
+		if rep.mediaType == constHTML {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type negotiateBooks struct {
+	Books []string `json:"books"`
+}
+
+func TestRoute_Renders(t *testing.T) {
+	tmpl, err := NewTemplate(fstest.MapFS{
+		"books.html": {Data: []byte(`<ul>{{ range .Books }}<li>{{ . }}</li>{{ end }}</ul>`)},
+	}, "*.html")
+	require.NoError(t, err)
+	ts := NewTestServerWithOkapi(t, New().WithRenderer(tmpl))
+	data := negotiateBooks{Books: []string{"Go", "XML"}}
+	ts.Get("/books", func(c *Context) error {
+		return c.Negotiate(http.StatusOK, data)
+	}, Renders(HTML("books.html"), JSONOf(negotiateBooks{})))
+	ts.Get("/api/books", HandleO(func(c *Context) (*struct{ Body negotiateBooks }, error) {
+		return &struct{ Body negotiateBooks }{Body: data}, nil
+	})).Renders(JSONOf(nil), HTML("books.html"))
+
+	url := ts.BaseURL + "/books"
+	okapitest.GET(t, url).Header("Accept", "text/html,application/xhtml+xml,*/*;q=0.8").
+		ExpectStatusOK().ExpectContentType(constHTML).ExpectHeader("Vary", "Accept").
+		ExpectBody("<ul><li>Go</li><li>XML</li></ul>")
+	okapitest.GET(t, url).Header("Accept", "application/json").
+		ExpectStatusOK().ExpectJSON(data)
+	okapitest.GET(t, url).Header("Accept", "text/html;q=0.5, application/json").
+		ExpectStatusOK().ExpectJSON(data)
+	okapitest.GET(t, url).ExpectStatusOK().ExpectContentType(constHTML)
+	okapitest.GET(t, url).Header("Accept", "application/xml").
+		ExpectStatus(http.StatusNotAcceptable)
+
+	// Respond negotiates too, the first representation winning on */*.
+	okapitest.GET(t, ts.BaseURL+"/api/books").Header("Accept", "*/*").
+		ExpectStatusOK().ExpectJSON(data)
+	okapitest.GET(t, ts.BaseURL+"/api/books").Header("Accept", "text/html").
+		ExpectStatusOK().ExpectBody("<ul><li>Go</li><li>XML</li></ul>")
+}
+
+func TestRoute_RendersOpenAPI(t *testing.T) {
+	o := New()
+	o.Get("/books", anyHandler, Renders(HTML("books.html"), JSONOf(negotiateBooks{})))
+	o.buildOpenAPISpec()
+
+	resp := o.openapiSpec.Paths.Find("/books").Get.Responses.Status(http.StatusOK).Value
+	require.Contains(t, resp.Content, constJSON)
+	require.Contains(t, resp.Content, constHTML)
+	assert.True(t, resp.Content[constHTML].Schema.Value.Type.Is("string"))
+}
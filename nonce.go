@@ -0,0 +1,207 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"crypto/rand"
+	htmltemplate "html/template"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	formNonceContextKey = "okapi.form_nonce"
+	formNonceKeyPrefix  = "okapi:nonce:"
+	defaultNonceField   = "_nonce"
+	defaultNonceTTL     = time.Hour
+)
+
+var (
+	nonceIssued = []byte("issued")
+	nonceUsed   = []byte("used")
+)
+
+// FormNonce prevents duplicate form submissions, such as a browser refresh
+// resending a POST or a double click on the submit button. Forms carry a
+// single-use nonce in a hidden field, rendered by the nonce_field template
+// function; a second submission with the same nonce is rejected.
+//
+// A submission answered with an error status (e.g. 422 after a failed
+// validation) releases its nonce, so the corrected form can be submitted again.
+//
+// Example:
+//
+//	nonce := &okapi.FormNonce{TTL: 30 * time.Minute}
+//	orders := o.Group("/orders", nonce.Middleware)
+//
+//	// <form method="post">{{ nonce_field }} ... </form>
+//	orders.Get("/new", func(c *okapi.Context) error {
+//		return c.Render(http.StatusOK, "order_form.html", nil)
+//	})
+//	orders.Post("/", createOrder)
+type FormNonce struct {
+	// Store keeps the issued nonces. It defaults to the instance's store set
+	// with WithCacheStore, or to memory. Checks made through a shared store are
+	// not atomic: two instances may accept the same nonce when submissions race.
+	Store CacheStore
+	// TTL is how long an issued nonce stays valid. Defaults to one hour.
+	TTL time.Duration
+	// Field is the name of the form field holding the nonce. Defaults to "_nonce".
+	Field string
+	// OnDuplicate answers duplicate, expired and missing nonces. Defaults to a
+	// 409 Conflict, as an HTML page for browsers.
+	OnDuplicate HandlerFunc
+
+	mu     sync.Mutex
+	once   sync.Once
+	memory *memoryCacheStore
+}
+
+// Middleware makes c.FormNonce and the nonce_field template function available
+// and checks the nonce of POST, PUT, PATCH and DELETE requests.
+func (n *FormNonce) Middleware(c *Context) error {
+	c.Set(formNonceContextKey, n)
+	switch c.request.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return c.Next()
+	}
+	nonce := c.request.FormValue(n.field())
+	if nonce == "" || !n.claim(c, nonce) {
+		return n.duplicate(c)
+	}
+	err := c.Next()
+	if status := c.response.StatusCode(); status >= http.StatusBadRequest || (err != nil && status == 0) {
+		n.set(c, nonce, nonceIssued)
+	}
+	return err
+}
+
+// FormNonce issues a nonce for a form handled by a FormNonce middleware, or
+// returns "" when the route has none. Most templates use nonce_field instead.
+func (c *Context) FormNonce() string {
+	n, ok := c.Get(formNonceContextKey)
+	if !ok {
+		return ""
+	}
+	nonce := rand.Text()
+	if !n.(*FormNonce).set(c, nonce, nonceIssued) {
+		return ""
+	}
+	return nonce
+}
+
+// FormNonceField returns a hidden input holding a new nonce, as rendered by the
+// nonce_field template function.
+func (c *Context) FormNonceField() htmltemplate.HTML {
+	nonce := c.FormNonce()
+	if nonce == "" {
+		return ""
+	}
+	n, _ := c.Get(formNonceContextKey)
+	return htmltemplate.HTML(`<input type="hidden" name="` + htmltemplate.HTMLEscapeString(n.(*FormNonce).field()) +
+		`" value="` + nonce + `">`)
+}
+
+// claim marks nonce as used, reporting false when it was not issued or already used.
+func (n *FormNonce) claim(c *Context, nonce string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	value, ok, err := n.store(c).Get(c.request.Context(), formNonceKeyPrefix+nonce)
+	if err != nil {
+		c.Logger().Warn("[okapi] form nonce lookup failed", "error", err)
+		return false
+	}
+	if !ok || string(value) != string(nonceIssued) {
+		return false
+	}
+	return n.set(c, nonce, nonceUsed)
+}
+
+// set stores the state of nonce.
+func (n *FormNonce) set(c *Context, nonce string, state []byte) bool {
+	if err := n.store(c).Set(c.request.Context(), formNonceKeyPrefix+nonce, state, n.ttl()); err != nil {
+		c.Logger().Warn("[okapi] form nonce store failed", "error", err)
+		return false
+	}
+	return true
+}
+
+// duplicate answers a submission whose nonce was already used or never issued.
+func (n *FormNonce) duplicate(c *Context) error {
+	if n.OnDuplicate != nil {
+		return n.OnDuplicate(c)
+	}
+	for _, accept := range c.Accept() {
+		if mediaType, _, _ := strings.Cut(strings.TrimSpace(accept), ";"); mediaType == constHTML {
+			return c.writeResponse(http.StatusConflict, constHTML, func() error {
+				_, err := io.WriteString(c.response, duplicateFormPage)
+				return err
+			})
+		}
+	}
+	return c.AbortConflict("This form was already submitted")
+}
+
+func (n *FormNonce) store(c *Context) CacheStore {
+	if n.Store != nil {
+		return n.Store
+	}
+	if c.okapi != nil && c.okapi.cacheStore != nil {
+		return c.okapi.cacheStore
+	}
+	n.once.Do(func() {
+		n.memory = &memoryCacheStore{entries: make(map[string]memoryCacheItem)}
+	})
+	return n.memory
+}
+
+func (n *FormNonce) field() string {
+	if n.Field == "" {
+		return defaultNonceField
+	}
+	return n.Field
+}
+
+func (n *FormNonce) ttl() time.Duration {
+	if n.TTL <= 0 {
+		return defaultNonceTTL
+	}
+	return n.TTL
+}
+
+// duplicateFormPage is the default answer to browsers resubmitting a form.
+const duplicateFormPage = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><title>Form already submitted</title></head>
+<body>
+<h1>Form already submitted</h1>
+<p>This form was already submitted or has expired. Go back and reload the page to submit it again.</p>
+</body>
+</html>
+`
@@ -0,0 +1,83 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestFormNonce(t *testing.T) {
+	tmpl, err := NewTemplate(fstest.MapFS{
+		"order.html": {Data: []byte(`<form method="post">{{ nonce_field }}</form>`)},
+	}, "*.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := NewTestServerWithOkapi(t, New().WithRenderer(tmpl))
+	nonce := &FormNonce{}
+	orders := ts.Group("/orders", nonce.Middleware)
+	orders.Get("/new", func(c *Context) error {
+		return c.Render(http.StatusOK, "order.html", nil)
+	})
+	orders.Post("/", func(c *Context) error {
+		if c.FormValue("qty") == "0" {
+			return c.AbortValidationError("Quantity must be positive")
+		}
+		return c.Created(M{"ok": true})
+	})
+
+	fieldRe := regexp.MustCompile(`<input type="hidden" name="_nonce" value="([A-Z2-7]+)">`)
+	newNonce := func() string {
+		_, body := okapitest.GET(t, ts.BaseURL+"/orders/new").ExpectStatusOK().Execute()
+		m := fieldRe.FindSubmatch(body)
+		if m == nil {
+			t.Fatalf("nonce field not rendered: %s", body)
+		}
+		return string(m[1])
+	}
+	submit := func(n, qty string) *okapitest.RequestBuilder {
+		return okapitest.POST(t, ts.BaseURL+"/orders").FormBody(map[string]string{"_nonce": n, "qty": qty})
+	}
+
+	first := newNonce()
+	submit(first, "1").ExpectStatus(http.StatusCreated)
+	submit(first, "1").ExpectStatus(http.StatusConflict)
+
+	// A rejected submission releases its nonce.
+	second := newNonce()
+	submit(second, "0").ExpectStatus(http.StatusUnprocessableEntity)
+	submit(second, "1").ExpectStatus(http.StatusCreated)
+
+	submit("", "1").ExpectStatus(http.StatusConflict)
+	submit("unknown", "1").
+		Header("Accept", "text/html,application/xhtml+xml").
+		ExpectStatus(http.StatusConflict).
+		ExpectBodyContains("Form already submitted")
+}
@@ -0,0 +1,175 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Null is an optional value that tells apart a field absent from the request,
+// an explicit null, and a value, including the zero value. It is meant for
+// PATCH bodies, where "not sent" and "set to null" mean different things:
+//
+//	type UpdateBook struct {
+//	    Title    okapi.Null[string] `json:"title" minLength:"2"`
+//	    Subtitle okapi.Null[string] `json:"subtitle"`
+//	}
+//
+//	// {"subtitle": null} leaves the title unchanged and clears the subtitle
+//	if in.Subtitle.Set {
+//	    book.Subtitle = in.Subtitle.Ptr()
+//	}
+//
+// Null binds from JSON bodies and from path, query, header, cookie and form
+// values. Validation tags apply to the value when there is one; required only
+// asks for the field to be sent, so an explicit null satisfies it. The OpenAPI
+// schema is the schema of T, marked nullable.
+//
+// Tag a response field with `json:",omitzero"` to leave it out when it is not
+// set; an explicit null is written as null.
+type Null[T any] struct {
+	// Value is the value, meaningful when Valid is true.
+	Value T
+	// Valid reports whether the field holds a value, not null.
+	Valid bool
+	// Set reports whether the field was present, holding a value or null.
+	Set bool
+}
+
+// NullOf returns a Null holding v.
+func NullOf[T any](v T) Null[T] {
+	return Null[T]{Value: v, Valid: true, Set: true}
+}
+
+// ExplicitNull returns a Null that is set to null.
+func ExplicitNull[T any]() Null[T] {
+	return Null[T]{Set: true}
+}
+
+// IsNull reports whether the field was explicitly set to null.
+func (n Null[T]) IsNull() bool {
+	return n.Set && !n.Valid
+}
+
+// IsZero reports whether the field was not set, so `omitzero` leaves it out.
+func (n Null[T]) IsZero() bool {
+	return !n.Set
+}
+
+// Get returns the value and whether there is one.
+func (n Null[T]) Get() (T, bool) {
+	return n.Value, n.Valid
+}
+
+// OrElse returns the value, or def when the field is null or absent.
+func (n Null[T]) OrElse(def T) T {
+	if n.Valid {
+		return n.Value
+	}
+	return def
+}
+
+// Ptr returns a pointer to a copy of the value, or nil when the field is null
+// or absent.
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Value
+	return &v
+}
+
+// MarshalJSON writes the value, or null.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// UnmarshalJSON reads a value or null, and marks the field as set.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	n.Value, n.Valid, n.Set = zero, false, true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.Value); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// nullValue gives the binder, the validator and the schema generator access to
+// the value of a Null, whatever its type parameter.
+type nullValue interface {
+	nullValue() (reflect.Value, bool)
+	bindValue(raw string) error
+}
+
+var nullValueType = reflect.TypeFor[nullValue]()
+
+// nullValue returns the addressable value and whether it is valid.
+func (n *Null[T]) nullValue() (reflect.Value, bool) {
+	return reflect.ValueOf(&n.Value).Elem(), n.Valid
+}
+
+// bindValue sets the value from a path, query, header, cookie or form value.
+func (n *Null[T]) bindValue(raw string) error {
+	if err := setWithType(reflect.ValueOf(&n.Value).Elem(), raw); err != nil {
+		return err
+	}
+	n.Valid, n.Set = true, true
+	return nil
+}
+
+// asNull returns the Null held by field, if any.
+func asNull(field reflect.Value) (nullValue, bool) {
+	if !field.CanAddr() || !reflect.PointerTo(field.Type()).Implements(nullValueType) {
+		return nil, false
+	}
+	return field.Addr().Interface().(nullValue), true
+}
+
+// unwrapNull returns the value to validate for field: the value of a Null, or
+// field itself. ok is false for a Null that is null or absent, which has no
+// value to validate.
+func unwrapNull(field reflect.Value) (value reflect.Value, ok bool) {
+	if n, isNull := asNull(field); isNull {
+		return n.nullValue()
+	}
+	return field, true
+}
+
+// nullElemType returns the type parameter of a Null type.
+func nullElemType(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Struct || !reflect.PointerTo(t).Implements(nullValueType) {
+		return nil, false
+	}
+	return t.Field(0).Type, true
+}
@@ -0,0 +1,142 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nullAddress struct {
+	City string `json:"city" required:"true"`
+}
+
+type nullPatch struct {
+	Title    Null[string]      `json:"title" minLength:"2"`
+	Subtitle Null[string]      `json:"subtitle"`
+	Stock    Null[int]         `json:"stock" min:"0"`
+	Address  Null[nullAddress] `json:"address"`
+	Version  Null[int]         `json:"version" required:"true"`
+}
+
+func TestNull(t *testing.T) {
+	var in nullPatch
+	require.NoError(t, json.Unmarshal([]byte(`{"subtitle":null,"stock":0,"version":3}`), &in))
+
+	assert.False(t, in.Title.Set)
+	assert.True(t, in.Subtitle.IsNull())
+	assert.Nil(t, in.Subtitle.Ptr())
+	stock, ok := in.Stock.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, stock)
+	assert.Equal(t, "none", in.Title.OrElse("none"))
+
+	out, err := json.Marshal(struct {
+		A Null[string] `json:"a,omitzero"`
+		B Null[string] `json:"b,omitzero"`
+		C Null[int]    `json:"c,omitzero"`
+	}{B: ExplicitNull[string](), C: NullOf(0)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"b":null,"c":0}`, string(out))
+}
+
+func TestNull_Binding(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Patch("/books/:id", Handle(func(c *Context, in *nullPatch) error {
+		return c.OK(M{
+			"title_set":     in.Title.Set,
+			"subtitle_null": in.Subtitle.IsNull(),
+			"stock":         in.Stock,
+		})
+	}))
+	ts.Get("/books", Handle(func(c *Context, in *struct {
+		Limit Null[int] `query:"limit" max:"50"`
+	}) error {
+		return c.OK(M{"set": in.Limit.Set, "limit": in.Limit})
+	}))
+
+	okapitest.PATCH(t, ts.BaseURL+"/books/1").
+		JSONBody(M{"subtitle": nil, "stock": 0, "version": 1}).
+		ExpectStatusOK().
+		ExpectJSONPath("title_set", false).
+		ExpectJSONPath("subtitle_null", true).
+		ExpectJSONPath("stock", float64(0))
+
+	// Tags validate values, not nulls; a null struct is not validated.
+	okapitest.PATCH(t, ts.BaseURL+"/books/1").
+		JSONBody(M{"title": nil, "address": nil, "version": nil}).
+		ExpectStatusOK()
+	for _, body := range []M{
+		{"title": "x", "version": 1},
+		{"stock": -1, "version": 1},
+		{"address": M{}, "version": 1},
+		{"title": "Dune"},
+	} {
+		okapitest.PATCH(t, ts.BaseURL+"/books/1").
+			JSONBody(body).
+			ExpectStatus(http.StatusBadRequest)
+	}
+
+	okapitest.GET(t, ts.BaseURL+"/books").
+		QueryParam("limit", "20").
+		ExpectStatusOK().
+		ExpectJSONPath("set", true).
+		ExpectJSONPath("limit", float64(20))
+	okapitest.GET(t, ts.BaseURL+"/books").
+		ExpectStatusOK().
+		ExpectJSONPath("set", false)
+	okapitest.GET(t, ts.BaseURL+"/books").
+		QueryParam("limit", "80").
+		ExpectStatus(http.StatusBadRequest)
+}
+
+func TestNull_OpenAPI(t *testing.T) {
+	o := New()
+	o.WithOpenAPIDocs(OpenAPI{Title: "Nulls", Version: "1.0.0", License: License{Name: "MIT"}, Servers: Servers{{URL: "http://localhost:8080"}}})
+	o.Patch("/books/:id", anyHandler, DocRequestBody(&nullPatch{}))
+	o.buildOpenAPISpec()
+
+	model30 := o.openapiSpec.Components.Schemas["nullPatch"].Value
+	require.NotNil(t, model30)
+	title := model30.Properties["title"].Value
+	assert.True(t, title.Type.Is("string"))
+	assert.True(t, title.Nullable)
+	assert.Equal(t, uint64(2), title.MinLength)
+	assert.True(t, model30.Properties["address"].Value.Nullable)
+	assert.Contains(t, model30.Properties["address"].Value.Properties, "city")
+
+	model31 := o.openapiSpec31.Components.Schemas["nullPatch"].Value
+	require.NotNil(t, model31)
+	assert.True(t, model31.Properties["stock"].Value.Type.Includes("null"))
+	assert.True(t, model31.Properties["stock"].Value.Type.Includes("integer"))
+
+	validateOpenAPIDoc(t, o.openapiSpec)
+	validateOpenAPIDoc(t, o.openapiSpec31)
+}
@@ -26,6 +26,7 @@ package okapi
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"crypto/tls"
 	"errors"
@@ -36,8 +37,12 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -70,6 +75,9 @@ type (
 		routes              []*Route
 		debug               bool
 		accessLog           bool
+		accessLogger        *slog.Logger
+		errorHooks          []ErrorHook
+		preRouteHooks       []PreRouteHook
 		strictSlash         bool
 		logger              *slog.Logger
 		renderer            Renderer
@@ -78,9 +86,20 @@ type (
 		writeTimeout        int
 		readTimeout         int
 		idleTimeout         int
+		readHeaderTimeout   int
+		maxHeaderBytes      int
+		noOptionsHandler    bool
+		connStateHooks      []func(net.Conn, http.ConnState)
+		connSlots           chan struct{}
+		noKeepAlives        bool
+		conns               connTracker
 		optionsRegistered   map[string]bool
 		openapiSpec         *openapi3.T
 		openapiSpec31       *openapi3.T
+		namedSpecs          map[string]*namedSpec
+		sourceSpec          *openapi3.T
+		mock                bool
+		namedSpecRoutes     map[string]bool
 		webhooks            []*Route
 		openAPI             *OpenAPI
 		openApiEnabled      bool
@@ -88,7 +107,35 @@ type (
 		maxMultipartMemory  int64 // Maximum memory for multipart forms
 		noRoute             HandlerFunc
 		noMethod            HandlerFunc
+		fallback            http.Handler
+		warmups             []WarmupFunc
+		fixtures            []FixtureFunc
+		groupConfigs        map[string]GroupConfig
+		groupConfigFile     string
+		xmlOptions          *XMLOptions
+		ready               atomic.Bool
 		errorHandler        ErrorHandler
+		strictWrites        bool
+		messages            map[string]Messages
+		defaultLocale       string
+		localeOptions       *LocaleOptions
+		contextPropagation  bool
+		eventBus            EventBus
+		eventsWG            sync.WaitGroup
+		workers             *Workers
+		cacheStore          CacheStore
+		limiterStore        LimiterStore
+		grpcHandler         http.Handler
+		hardening           *Hardening
+		queryLimits         *QueryLimits
+		profile             string
+		hideErrorDetails    bool
+		securityHeaders     map[string]string
+		pprofRegistered     bool
+		reporter            *routeReporter
+		reportConfig        RouteReportConfig
+		strict              *StrictMode
+		routeDefaults       []RouteOption
 	}
 
 	Router struct {
@@ -121,6 +168,8 @@ type (
 		basicAuth       bool
 		security        []map[string][]string
 		deprecated      bool
+		sunset          time.Time
+		successor       string
 		requestExample  map[string]interface{}
 		responses       map[int]*openapi3.SchemaRef
 		description     string
@@ -129,6 +178,26 @@ type (
 		internal        bool
 		handle          HandlerFunc
 		cookies         []*openapi3.ParameterRef
+		defaultHeaders  map[string]string
+		matchHeaders    []string
+		matchQueries    []string
+		noAccessLog     bool
+		specName        string
+		specOperation   bool
+		cache           *routeCache
+		protoResponses  map[int]bool
+		fileResponses   map[int][]string
+		budget          *routeBudget
+		streaming       bool
+		pool            *HandlerPool
+		renders         []Representation
+		headerType      reflect.Type
+		muxRoute        *mux.Route
+		muxConfigs      []func(*mux.Route)
+		autoPathParams  bool
+		fallback        FallbackFunc
+		// group is the prefix of the group the route was registered on
+		group string
 	}
 
 	// ResponseWriter extends http.ResponseWriter with additional utilities.
@@ -141,6 +210,10 @@ type (
 		// BytesWritten returns number of bytes written to the body.
 		BytesWritten() int
 
+		// Written reports whether the status line or any body bytes have
+		// already been sent, e.g. by a handler that streamed its output.
+		Written() bool
+
 		// Close closes the writer if supported. Returns error instead of hiding it.
 		Close() error
 
@@ -167,6 +240,14 @@ type (
 		status      int
 		wroteHeader bool
 		wroteBytes  int
+		closed      bool
+		// errorReported is set once OnError hooks were called for the response.
+		errorReported bool
+		// Diagnostics, enabled through WithDebug and WithStrictResponseWrites.
+		logger     *slog.Logger
+		debug      bool
+		strict     bool
+		headerSite string
 	}
 )
 
@@ -238,7 +319,7 @@ func (r *Route) WithIO(req any, res any) *Route {
 		r.generateRequestSchema(req)
 	}
 	if res != nil {
-		r.generateResponseSchema(res)
+		r.generateResponseSchema(res, 0)
 	}
 	return r
 }
@@ -262,11 +343,66 @@ func (r *Route) WithInput(req any) *Route {
 //   - Generate OpenAPI documentation for the response schema
 func (r *Route) WithOutput(res any) *Route {
 	if res != nil {
-		r.generateResponseSchema(res)
+		r.generateResponseSchema(res, 0)
+	}
+	return r
+}
+
+// WithResponseHeaders sets default response headers written before the handler runs,
+// e.g. Cache-Control: no-store for authentication endpoints.
+// Handlers and middlewares can still override them. The headers are also documented
+// as response headers in the OpenAPI specification.
+func (r *Route) WithResponseHeaders(headers map[string]string) *Route {
+	if len(headers) == 0 {
+		return r
+	}
+	if r.defaultHeaders == nil {
+		r.defaultHeaders = make(map[string]string, len(headers))
+	}
+	for name, value := range headers {
+		name = http.CanonicalHeaderKey(name)
+		_, overridden := r.defaultHeaders[name]
+		r.defaultHeaders[name] = value
+		// Keep explicitly documented headers, refresh the ones we documented.
+		if _, documented := r.responseHeaders[name]; !documented || overridden {
+			DocResponseHeader(name, "string", fmt.Sprintf("Defaults to `%s`", value))(r)
+		}
 	}
 	return r
 }
 
+// applyDefaultHeaders writes the route's default response headers.
+func (r *Route) applyDefaultHeaders(h http.Header) {
+	for name, value := range r.defaultHeaders {
+		h.Set(name, value)
+	}
+}
+
+// MatchHeader restricts the route to requests carrying the header name with the
+// given value. An empty value matches any request where the header is present.
+//
+// This allows registering multiple handlers for the same method and path,
+// dispatched by header:
+//
+//	o.Get("/books", listBooksV1)
+//	o.Get("/books", listBooksV2, okapi.MatchHeader("X-API-Version", "2"))
+//
+// Routes with predicates should be registered before the unrestricted route
+// for the same path, since the first matching route wins.
+func MatchHeader(name, value string) RouteOption {
+	return func(r *Route) {
+		r.matchHeaders = append(r.matchHeaders, name, value)
+	}
+}
+
+// MatchQuery restricts the route to requests carrying the query parameter name
+// with the given value. An empty value matches any request where the parameter is set.
+func MatchQuery(name, value string) RouteOption {
+	return func(r *Route) {
+		r.matchQueries = append(r.matchQueries, name, value)
+	}
+}
+
 // UseMiddleware registers one or more middleware functions to the Route.
 func UseMiddleware(m ...Middleware) RouteOption {
 	return func(r *Route) {
@@ -386,6 +522,46 @@ func WithIdleTimeout(t int) OptionFunc {
 	}
 }
 
+// WithReadHeaderTimeout returns an OptionFunc that sets the time allowed to read
+// request headers, in seconds. Unlike the read timeout, it leaves slow uploads alone
+// while still cutting off clients that trickle their headers.
+func WithReadHeaderTimeout(t int) OptionFunc {
+	return func(o *Okapi) {
+		o.readHeaderTimeout = t
+		o.server.ReadHeaderTimeout = secondsToDuration(t)
+	}
+}
+
+// WithGeneralOptionsHandlerDisabled returns an OptionFunc that passes "OPTIONS *"
+// requests to the router instead of answering them with 200 and an empty body.
+func WithGeneralOptionsHandlerDisabled() OptionFunc {
+	return func(o *Okapi) {
+		o.noOptionsHandler = true
+		o.server.DisableGeneralOptionsHandler = true
+	}
+}
+
+// WithConnState returns an OptionFunc that registers a hook called when a client
+// connection changes state, e.g. to count open connections. Hooks run in
+// registration order, see http.Server.ConnState.
+func WithConnState(hook func(net.Conn, http.ConnState)) OptionFunc {
+	return func(o *Okapi) {
+		if hook != nil {
+			o.connStateHooks = append(o.connStateHooks, hook)
+			o.server.ConnState = o.connState
+		}
+	}
+}
+
+// WithRouteDefaults returns an OptionFunc that applies opts to every route
+// registered afterward, before the route's own options, e.g. to document the
+// same error responses and security on every route. See WithRouteDefaults.
+func WithRouteDefaults(opts ...RouteOption) OptionFunc {
+	return func(o *Okapi) {
+		o.routeDefaults = append(o.routeDefaults, opts...)
+	}
+}
+
 // WithStrictSlash sets whether to enforce strict slash handling
 func WithStrictSlash(strict bool) OptionFunc {
 	return func(o *Okapi) {
@@ -487,6 +663,15 @@ func WithDocUI(ui DocUI) OptionFunc {
 	}
 }
 
+// WithOpenAPITags describes the tags of the OpenAPI specification. Documentation
+// UIs list the sections of the given tags first, in order, followed by the
+// other tags sorted by name. Only tags used by a documented route are emitted.
+func WithOpenAPITags(tags []TagInfo) OptionFunc {
+	return func(o *Okapi) {
+		o.openAPI.Tags = slices.Clone(tags)
+	}
+}
+
 // WithMaxMultipartMemory Maximum memory for multipart forms
 func WithMaxMultipartMemory(max int64) OptionFunc {
 	return func(o *Okapi) {
@@ -494,6 +679,33 @@ func WithMaxMultipartMemory(max int64) OptionFunc {
 	}
 }
 
+// WithContextPropagation mirrors every value stored with c.Set into the
+// request's context.Context under ContextKey(key).
+//
+// This lets standard-library handlers and middlewares (HandleHTTP, UseMiddleware)
+// read auth claims, request IDs and other values without Okapi-specific APIs:
+//
+//	o := okapi.New(okapi.WithContextPropagation())
+//	o.HandleHTTP("GET", "/std", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//		id, _ := r.Context().Value(okapi.ContextKey("request_id")).(string)
+//	}))
+func WithContextPropagation() OptionFunc {
+	return func(o *Okapi) {
+		o.contextPropagation = true
+	}
+}
+
+// WithStrictResponseWrites makes the response writer panic when a handler
+// calls WriteHeader more than once or writes after the response was closed.
+//
+// By default such calls are ignored, and only reported as warnings in debug mode.
+// Enable this during development or in tests to surface the offending call site.
+func WithStrictResponseWrites() OptionFunc {
+	return func(o *Okapi) {
+		o.strictWrites = true
+	}
+}
+
 // ************* Chaining methods *************
 // These methods reuse the OptionFunc implementations
 
@@ -521,6 +733,36 @@ func (o *Okapi) WithIdleTimeout(seconds int) *Okapi {
 	return o.apply(WithIdleTimeout(seconds))
 }
 
+// WithReadHeaderTimeout sets the time allowed to read request headers, in seconds.
+func (o *Okapi) WithReadHeaderTimeout(seconds int) *Okapi {
+	return o.apply(WithReadHeaderTimeout(seconds))
+}
+
+// WithGeneralOptionsHandlerDisabled passes "OPTIONS *" requests to the router.
+func (o *Okapi) WithGeneralOptionsHandlerDisabled() *Okapi {
+	return o.apply(WithGeneralOptionsHandlerDisabled())
+}
+
+// WithConnState registers a hook called when a client connection changes state.
+func (o *Okapi) WithConnState(hook func(net.Conn, http.ConnState)) *Okapi {
+	return o.apply(WithConnState(hook))
+}
+
+// WithRouteDefaults applies opts to every route registered afterward, before the
+// route's own options, instead of repeating them on each route. Groups add their
+// own defaults with Group.WithRouteDefaults.
+//
+// Example:
+//
+//	o.WithRouteDefaults(
+//		okapi.DocResponse(http.StatusBadRequest, ErrorResponse{}),
+//		okapi.DocResponse(http.StatusInternalServerError, ErrorResponse{}),
+//	)
+//	o.Get("/books", listBooks, okapi.DocResponse([]Book{})) // documents 200, 400 and 500
+func (o *Okapi) WithRouteDefaults(opts ...RouteOption) *Okapi {
+	return o.apply(WithRouteDefaults(opts...))
+}
+
 func (o *Okapi) WithStrictSlash(strict bool) *Okapi {
 	return o.apply(WithStrictSlash(strict))
 }
@@ -529,6 +771,16 @@ func (o *Okapi) WithDebug() *Okapi {
 	return o.apply(WithDebug())
 }
 
+// WithContextPropagation mirrors values stored with c.Set into the request context.
+func (o *Okapi) WithContextPropagation() *Okapi {
+	return o.apply(WithContextPropagation())
+}
+
+// WithStrictResponseWrites panics on duplicate WriteHeader calls and writes after close.
+func (o *Okapi) WithStrictResponseWrites() *Okapi {
+	return o.apply(WithStrictResponseWrites())
+}
+
 // WithOpenAPIDisabled disabled OpenAPI Docs
 func (o *Okapi) WithOpenAPIDisabled() *Okapi {
 	return o.apply(WithOpenAPIDisabled())
@@ -548,6 +800,11 @@ func (o *Okapi) WithDocUI(ui DocUI) *Okapi {
 	return o.apply(WithDocUI(ui))
 }
 
+// WithOpenAPITags describes the tags of the OpenAPI specification, see WithOpenAPITags.
+func (o *Okapi) WithOpenAPITags(tags []TagInfo) *Okapi {
+	return o.apply(WithOpenAPITags(tags))
+}
+
 // WithRenderer sets a custom Renderer for the server.
 //
 // This allows you to define how templates or views are rendered in response handlers.
@@ -580,6 +837,16 @@ func (o *Okapi) WithRenderer(renderer Renderer) *Okapi {
 	return o.apply(WithRenderer(renderer))
 }
 
+// Renderer returns the renderer set with WithRenderer, or nil.
+func (o *Okapi) Renderer() Renderer {
+	return o.renderer
+}
+
+// Logger returns the logger set with WithLogger, slog.Default() by default.
+func (o *Okapi) Logger() *slog.Logger {
+	return o.logger
+}
+
 func (o *Okapi) WithPort(port int) *Okapi {
 	return o.apply(WithPort(port))
 }
@@ -635,15 +902,32 @@ func (o *Okapi) WithOpenAPIDocs(cfg ...OpenAPI) *Okapi {
 		if config.Favicon != "" {
 			o.openAPI.Favicon = config.Favicon
 		}
+		o.openAPI.LocalServer = config.LocalServer
+		o.openAPI.BasicAuth = config.BasicAuth
+		o.openAPI.Middlewares = config.Middlewares
+		o.openAPI.OperationIDStrategy = config.OperationIDStrategy
+		if len(config.Tags) > 0 {
+			o.openAPI.Tags = slices.Clone(config.Tags)
+		}
 
 	}
 
 	o.buildOpenAPISpec()
 	// Register the OpenAPI JSON and UI routes
 	o.registerDocRoutes()
+	for name := range o.namedSpecs {
+		o.registerNamedDocRoutes(name)
+	}
 	return o
 }
 
+// OpenAPISpec builds and returns the OpenAPI 3.0 document describing the
+// registered routes, e.g. for exporting the spec or generating clients.
+func (o *Okapi) OpenAPISpec() *openapi3.T {
+	o.buildOpenAPISpec()
+	return o.openapiSpec
+}
+
 // Webhook registers an OpenAPI 3.1 webhook: an out-of-band request that the API
 // sends to a consumer-provided endpoint (e.g. an event callback).
 //
@@ -707,12 +991,26 @@ func newResponseWriter(w http.ResponseWriter) *responseWriter {
 	return &responseWriter{writer: w}
 }
 
+// withDiagnostics enables duplicate-write diagnostics according to the
+// debug and strict write settings of o.
+func (r *responseWriter) withDiagnostics(o *Okapi) *responseWriter {
+	if o != nil {
+		r.logger = o.logger
+		r.debug = o.debug
+		r.strict = o.strictWrites
+	}
+	return r
+}
+
 // Header delegates header access.
 func (r *responseWriter) Header() http.Header {
 	return r.writer.Header()
 }
 
 func (r *responseWriter) Write(b []byte) (int, error) {
+	if r.closed {
+		r.reportMisuse("write after close")
+	}
 	if !r.wroteHeader {
 		r.WriteHeader(http.StatusOK)
 	}
@@ -723,13 +1021,45 @@ func (r *responseWriter) Write(b []byte) (int, error) {
 
 func (r *responseWriter) WriteHeader(statusCode int) {
 	if r.wroteHeader {
+		r.reportMisuse("superfluous WriteHeader", "status", r.status, "attempted_status", statusCode)
+		return
+	}
+	// Informational responses (except 101 Switching Protocols) are not final,
+	// forward them without committing the response.
+	if statusCode >= 100 && statusCode < 200 && statusCode != http.StatusSwitchingProtocols {
+		r.writer.WriteHeader(statusCode)
 		return
 	}
 	r.status = statusCode
 	r.wroteHeader = true
+	if r.debug || r.strict {
+		r.headerSite = callSite()
+	}
 	r.writer.WriteHeader(statusCode)
 }
 
+// Written reports whether the header or any body bytes have been sent.
+func (r *responseWriter) Written() bool {
+	return r.wroteHeader || r.wroteBytes > 0
+}
+
+// reportMisuse logs a warning in debug mode, or panics in strict mode, with the
+// call sites of both the original header write and the offending call.
+func (r *responseWriter) reportMisuse(msg string, args ...any) {
+	if !r.debug && !r.strict {
+		return
+	}
+	site := callSite()
+	if r.strict {
+		panic(fmt.Sprintf("okapi: %s at %s (header written at %s)", msg, site, r.headerSite))
+	}
+	if r.logger == nil {
+		return
+	}
+	args = append(args, "first_write", r.headerSite, "second_write", site)
+	r.logger.Warn("[okapi] "+msg, args...)
+}
+
 func (r *responseWriter) StatusCode() int {
 	if !r.wroteHeader {
 		return 0
@@ -743,6 +1073,7 @@ func (r *responseWriter) BytesWritten() int {
 
 // Close closes if the underlying writer supports io.Closer.
 func (r *responseWriter) Close() error {
+	r.closed = true
 	if closer, ok := r.writer.(io.Closer); ok {
 		return closer.Close()
 	}
@@ -760,6 +1091,11 @@ func (r *responseWriter) Flush() {
 	}
 }
 
+// Unwrap returns the underlying writer, for http.ResponseController.
+func (r *responseWriter) Unwrap() http.ResponseWriter {
+	return r.writer
+}
+
 // Hijack supports WebSockets / raw TCP upgrades.
 func (r *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	hj, ok := r.writer.(http.Hijacker)
@@ -870,21 +1206,7 @@ func (o *Okapi) Use(middlewares ...Middleware) {
 //
 // Internally, Okapi converts between http.Handler and HandlerFunc to allow smooth interop.
 func (o *Okapi) UseMiddleware(mw func(http.Handler) http.Handler) {
-	o.Use(func(c *Context) error {
-		// Convert the rest of the chain into an http.Handler
-		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Continue the Okapi middleware chain
-			c.request = r
-			if err := c.Next(); err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-			}
-		})
-
-		// Apply standard middleware and serve
-		wrapped := mw(next)
-		wrapped.ServeHTTP(c.response, c.request)
-		return nil
-	})
+	o.Use(WrapMiddleware(mw))
 }
 
 // StartServer starts the Okapi server with the specified HTTP server
@@ -893,11 +1215,15 @@ func (o *Okapi) StartServer(server *http.Server) error {
 		o.logger.Error("Invalid server address", slog.String("addr", server.Addr))
 		panic("Invalid server address")
 	}
+	o.server = server
 	if o.openApiEnabled {
 		o.WithOpenAPIDocs()
 	}
-	o.server = server
 	server.Handler = o
+	if o.grpcHandler != nil {
+		enableHTTP2(server)
+		enableHTTP2(o.tlsServer)
+	}
 
 	// Set BaseContext so all request contexts derive from a cancellable parent.
 	baseCtx, baseCancel := context.WithCancel(context.Background())
@@ -909,16 +1235,30 @@ func (o *Okapi) StartServer(server *http.Server) error {
 	o.router.muxRouter.StrictSlash(o.strictSlash)
 	o.context.okapi = o
 	o.applyCommon()
+	if err := o.applyGroupConfig(); err != nil {
+		o.logger.Error("[okapi] Invalid group configuration", slog.String("error", err.Error()))
+		return err
+	}
+	if err := o.loadStartupFixtures(); err != nil {
+		return err
+	}
 	o.printServerInfo()
+	if o.workers != nil {
+		o.workers.Start()
+	}
 	// Serve with TLS if configured
 	if server.TLSConfig != nil {
-		return server.ListenAndServeTLS("", "")
+		return o.listenAndServe(server, true)
 	}
 
 	// Serve with separate TLS server if enabled
 	if o.withTlsServer && o.tlsServerConfig != nil {
 		go func() {
-			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			ln, err := o.listen(server, false)
+			if err == nil {
+				err = server.Serve(ln)
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
 				o.logger.Error("HTTP server error", slog.String("error", err.Error()))
 				panic(err)
 			}
@@ -926,11 +1266,11 @@ func (o *Okapi) StartServer(server *http.Server) error {
 
 		o.tlsServer.Handler = o
 		o.tlsServer.BaseContext = server.BaseContext
-		return o.tlsServer.ListenAndServeTLS("", "")
+		return o.listenAndServe(o.tlsServer, true)
 	}
 
 	// Default HTTP only
-	return server.ListenAndServe()
+	return o.listenAndServe(server, false)
 }
 
 // Stop gracefully shuts down all active Okapi servers (HTTP and HTTPS).
@@ -952,7 +1292,13 @@ func (o *Okapi) StopWithContext(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	if o.workers != nil {
+		if err := o.workers.Stop(shutdownCtx); err != nil {
+			return err
+		}
+	}
+	o.logReport()
+	return o.waitEvents(shutdownCtx)
 }
 
 // shutdownServer handles the shutdown logic for a single server.
@@ -1170,8 +1516,18 @@ func (o *Okapi) StaticFS(prefix string, fs http.FileSystem) {
 	o.router.muxRouter.PathPrefix(prefix).Handler(fileServer).Methods(http.MethodGet)
 }
 
-// addRoute adds a route with the specified method to the Okapi instance
+// addRoute adds a route with the specified method to the Okapi instance,
+// applying the route defaults before its own options
 func (o *Okapi) addRoute(method, path string, tags []string, h HandlerFunc, opts ...RouteOption) *Route {
+	if len(o.routeDefaults) > 0 {
+		opts = append(slices.Clip(o.routeDefaults), opts...)
+	}
+	return o.registerRoute(method, path, tags, h, opts...)
+}
+
+// registerRoute adds a route without the route defaults, for the routes okapi
+// registers itself, such as the documentation routes.
+func (o *Okapi) registerRoute(method, path string, tags []string, h HandlerFunc, opts ...RouteOption) *Route {
 	if path == "" {
 		panic("Path cannot be empty")
 	}
@@ -1192,26 +1548,59 @@ func (o *Okapi) addRoute(method, path string, tags []string, h HandlerFunc, opts
 	}
 	o.routes = append(o.routes, route)
 	// Main handler
-	o.router.muxRouter.StrictSlash(o.strictSlash).HandleFunc(normalizedPath, func(w http.ResponseWriter, r *http.Request) {
-		ctx := NewContext(o, w, r)
+	muxRoute := o.router.muxRouter.StrictSlash(o.strictSlash).HandleFunc(normalizedPath, o.serveRoute(route)).Methods(method)
+	if len(route.matchHeaders) > 0 {
+		muxRoute.Headers(route.matchHeaders...)
+	}
+	if len(route.matchQueries) > 0 {
+		muxRoute.Queries(route.matchQueries...)
+	}
+	route.configureMuxRoute(muxRoute)
+	// Register OPTIONS handler only once per path if CORS is enabled
+	o.registerOptionsHandler(normalizedPath)
+	return route
+}
+
+// serveRoute returns the http.HandlerFunc running the handler chain of route.
+func (o *Okapi) serveRoute(route *Route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := NewContext(o, w, r).attach()
+		ctx.route = route
+		ctx.clientCtx = r.Context()
+		defer ctx.completeDisconnectHooks()
 		// if the route is disabled, return 404 Not Found
 		if route.disabled {
 			http.Error(ctx.response, "404 Not Found", http.StatusNotFound)
 			return
 		}
+		if o.strict != nil && !route.internal && o.strict.rejectUnacceptable(ctx, route) {
+			return
+		}
+		if route.streaming {
+			ctx.clearWriteDeadline()
+		}
+		route.applyDefaultHeaders(ctx.response.Header())
+		o.applyDeprecationHeaders(route, ctx.response.Header(), r)
 		// Build the handler chain: global middlewares + route middlewares + handler
 		ctx.handlers = route.buildHandlers()
+		if o.mock && !route.internal {
+			ctx.handlers[len(ctx.handlers)-1] = o.mockHandler(route)
+		}
 		ctx.index = -1
+		start := time.Now()
 		// Any error returned by the route will result in a 500 Internal Server Error
-		if err := ctx.Next(); err != nil {
+		err := ctx.Next()
+		if err != nil {
 			if ctx.response.StatusCode() == 0 {
-				http.Error(ctx.response, err.Error(), http.StatusInternalServerError)
+				http.Error(ctx.response, o.internalErrorText(err), http.StatusInternalServerError)
 			}
 		}
-	}).Methods(method)
-	// Register OPTIONS handler only once per path if CORS is enabled
-	o.registerOptionsHandler(normalizedPath)
-	return route
+		if o.reporter != nil && !route.internal {
+			o.reporter.record(route, cmp.Or(ctx.response.StatusCode(), http.StatusOK), time.Since(start))
+		}
+		o.reportError(ctx, err)
+		o.deliverEvents(ctx, err)
+	}
 }
 
 // Handle registers a new route with the given HTTP method, path, and Okapi-style handler function.
@@ -1299,7 +1688,7 @@ func (o *Okapi) registerOptionsHandler(path string) {
 
 		o.router.muxRouter.StrictSlash(o.strictSlash).HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
-			if origin == "" || !originAllowed(o.cors.AllowedOrigins, origin) {
+			if !o.cors.allowsOrigin(origin) {
 				http.Error(w, "", http.StatusMethodNotAllowed)
 				return
 			}
@@ -1322,11 +1711,37 @@ func (o *Okapi) registerOptionsHandler(path string) {
 
 // ServeHTTP implements the http.Handler interface
 func (o *Okapi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-
-	ctx := &Context{
+	if len(o.securityHeaders) > 0 {
+		o.setSecurityHeaders(w)
+	}
+	if len(o.preRouteHooks) > 0 && o.preRoute(w, r) {
+		return
+	}
+	ctx := (&Context{
 		request:  r,
-		response: newResponseWriter(w),
+		response: newResponseWriter(w).withDiagnostics(o),
 		okapi:    o,
+	}).attach()
+	if len(o.errorHooks) > 0 {
+		// Report errors answered outside routes: rejections, 404, 405 and fallbacks.
+		defer o.reportError(ctx, nil)
+	}
+	if o.hardening != nil && o.hardening.reject(o, ctx.response, r) {
+		return
+	}
+	if o.queryLimits != nil && o.queryLimits.reject(ctx.response, r) {
+		return
+	}
+	if o.strict != nil && o.strict.rejectBody(ctx.response, r) {
+		return
+	}
+	if o.grpcHandler != nil && isGRPCRequest(r) {
+		o.serveGRPC(w, r)
+		return
+	}
+	if o.fallback != nil && !o.matchesRoute(r) {
+		o.serveFallback(ctx)
+		return
 	}
 	handler := func(c *Context) {
 		o.router.muxRouter.ServeHTTP(c.response, c.request)
@@ -1334,6 +1749,27 @@ func (o *Okapi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	handler(ctx)
 }
 
+// matchesRoute reports whether a registered route matches both the path and the method of r.
+func (o *Okapi) matchesRoute(r *http.Request) bool {
+	var match mux.RouteMatch
+	o.router.muxRouter.Match(r, &match)
+	return match.MatchErr == nil
+}
+
+// serveFallback delegates the request to the fallback handler through the
+// global middleware chain.
+func (o *Okapi) serveFallback(ctx *Context) {
+	c := NewContext(o, ctx.response, ctx.request)
+	global := o.globalMiddlewares()
+	c.handlers = make([]HandlerFunc, 0, len(global)+1)
+	c.handlers = append(c.handlers, global...)
+	c.handlers = append(c.handlers, o.wrapHTTPHandler(o.fallback))
+	c.index = -1
+	if err := c.Next(); err != nil && !c.response.Written() {
+		http.Error(c.response, o.internalErrorText(err), http.StatusInternalServerError)
+	}
+}
+
 // globalMiddlewares returns the global middleware chain.
 func (o *Okapi) globalMiddlewares() []Middleware {
 	return o.middlewares
@@ -1346,8 +1782,23 @@ func (r *Route) buildHandlers() []HandlerFunc {
 	handlers := make([]HandlerFunc, 0, len(global)+len(r.middlewares)+1)
 	handlers = append(handlers, global...)
 	handlers = append(handlers, r.middlewares...)
-	handlers = append(handlers, r.handle)
-	return handlers
+	handle := r.handle
+	if r.cache != nil {
+		handle = r.cache.wrap(r, handle)
+	}
+	if r.fallback != nil {
+		handle = r.wrapFallback(handle)
+	}
+	if r.headerType != nil {
+		handle = r.bindHeaders(handle)
+	}
+	if r.budget.active() {
+		handle = r.budget.wrap(r, handle)
+	}
+	if r.pool != nil {
+		handle = r.pool.wrap(handle)
+	}
+	return append(handlers, handle)
 }
 func (o *Okapi) Routes() []Route {
 	routes := make([]Route, 0, len(o.routes))
@@ -1427,6 +1878,29 @@ func (o *Okapi) applyServerConfig(s *http.Server) {
 	s.ReadTimeout = secondsToDuration(o.readTimeout)
 	s.WriteTimeout = secondsToDuration(o.writeTimeout)
 	s.IdleTimeout = secondsToDuration(o.idleTimeout)
+	// The tuning knobs below only override a server given to WithServer when set.
+	if o.readHeaderTimeout != 0 {
+		s.ReadHeaderTimeout = secondsToDuration(o.readHeaderTimeout)
+	}
+	if o.maxHeaderBytes != 0 {
+		s.MaxHeaderBytes = o.maxHeaderBytes
+	}
+	if o.noOptionsHandler {
+		s.DisableGeneralOptionsHandler = true
+	}
+	if len(o.connStateHooks) != 0 {
+		s.ConnState = o.connState
+	}
+	if o.noKeepAlives {
+		s.SetKeepAlivesEnabled(false)
+	}
+}
+
+// connState calls the hooks registered with WithConnState.
+func (o *Okapi) connState(conn net.Conn, state http.ConnState) {
+	for _, hook := range o.connStateHooks {
+		hook(conn, state)
+	}
 }
 
 // apply is a helper method to apply an OptionFunc to the Okapi instance
@@ -1440,7 +1914,7 @@ func (o *Okapi) applyCommon() {
 	if o.noRoute != nil {
 		o.router.muxRouter.NotFoundHandler = o.wrapHandleFunc(o.noRoute)
 	}
-	if o.noMethod != nil {
+	if o.noMethod != nil && (o.strict == nil || o.strict.RelaxMethodNotAllowed) {
 		o.router.muxRouter.MethodNotAllowedHandler = o.wrapHandleFunc(o.noMethod)
 	}
 }
@@ -1459,6 +1933,26 @@ func (o *Okapi) NoRoute(h HandlerFunc) {
 	o.noRoute = h
 }
 
+// SetFallbackHandler delegates requests that match no Okapi route, by path or
+// by method, to h instead of answering 404 or 405.
+//
+// This allows migrating an existing application incrementally: Okapi serves the
+// routes already ported, everything else falls through to the legacy router.
+// Global middlewares (access log, CORS, ...) run for delegated requests too.
+// The fallback handler takes precedence over NoRoute and NoMethod.
+//
+// Example:
+//
+//	legacy := http.NewServeMux()
+//	legacy.HandleFunc("/legacy/", legacyHandler)
+//
+//	o := okapi.New()
+//	o.Get("/books", listBooks) // already migrated
+//	o.SetFallbackHandler(legacy)
+func (o *Okapi) SetFallbackHandler(h http.Handler) {
+	o.fallback = h
+}
+
 // NoMethod sets a custom handler to be executed when the HTTP method is not allowed.
 //
 // This function is triggered when the request path exists but the method (e.g., POST, GET) is not allowed.
@@ -1475,13 +1969,19 @@ func (o *Okapi) NoMethod(h HandlerFunc) {
 
 // handleAccessLog logs the access details of the request
 func handleAccessLog(c *Context) error {
-	if c.IsWebSocketUpgrade() || c.IsSSE() || !c.okapi.accessLog {
+	if !c.okapi.accessLog || (c.route != nil && c.route.noAccessLog) {
 		return c.Next()
 	}
+	if c.IsWebSocketUpgrade() || c.IsSSE() {
+		return handleStreamAccessLog(c)
+	}
 	startTime := time.Now()
 	err := c.Next()
 	status := c.response.StatusCode()
 	logger := c.okapi.logger
+	if c.okapi.accessLogger != nil {
+		logger = c.okapi.accessLogger
+	}
 	logFields := buildBaseLogFields(c, status, time.Since(startTime))
 	if c.okapi.debug {
 		debugFields := buildDebugFields(c)
@@ -1504,7 +2004,7 @@ func buildBaseLogFields(c *Context, status int, duration time.Duration) []any {
 	if bytesIn < 0 {
 		bytesIn = 0
 	}
-	return []any{
+	fields := []any{
 		"method", c.request.Method,
 		"path", c.request.URL.Path,
 		"status", status,
@@ -1516,20 +2016,21 @@ func buildBaseLogFields(c *Context, status int, duration time.Duration) []any {
 		"referer", c.request.Referer(),
 		"user_agent", c.request.UserAgent(),
 	}
+	return append(fields, clientLogFields(c)...)
 }
 func (o *Okapi) wrapHandleFunc(h HandlerFunc) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := NewContext(o, w, r)
+		ctx := NewContext(o, w, r).attach()
 		if err := h(ctx); err != nil {
 			o.logger.Error("handler error", slog.String("error", err.Error()))
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, o.internalErrorText(err), http.StatusInternalServerError)
 
 		}
 	})
 }
 func (o *Okapi) wrapHTTPHandler(h http.Handler) HandlerFunc {
 	return func(ctx *Context) error {
-		h.ServeHTTP(ctx.response, ctx.request)
+		h.ServeHTTP(ctx.response, ctx.requestWithContext())
 		return nil
 	}
 }
@@ -1694,8 +2195,8 @@ func (o *Okapi) Register(routes ...RouteDefinition) {
 func Handle[I any](h func(*Context, *I) error) HandlerFunc {
 	return func(c *Context) error {
 		var in I
-		if err := c.Bind(&in); err != nil {
-			return c.AbortBadRequest("Bad Request", err)
+		if err := c.bindInput(&in); err != nil {
+			return c.abortBindError(&in, err)
 		}
 		return h(c, &in)
 	}
@@ -1751,8 +2252,8 @@ func H[I any](h func(*Context, *I) error) HandlerFunc {
 func HandleIO[I any, O any](h func(*Context, *I) (*O, error)) HandlerFunc {
 	return func(c *Context) error {
 		var in I
-		if err := c.Bind(&in); err != nil {
-			return c.AbortBadRequest("Bad Request", err)
+		if err := c.bindInput(&in); err != nil {
+			return c.abortBindError(&in, err)
 		}
 
 		out, err := h(c, &in)
@@ -1805,6 +2306,9 @@ func (o *Okapi) RegisterSchemas(schemas map[string]*SchemaInfo) error {
 
 // environment returns the current application environment
 func (o *Okapi) environment() string {
+	if o.profile != "" {
+		return o.profile
+	}
 	envVars := []string{
 		"OKAPI_ENV",
 		"GO_ENV",
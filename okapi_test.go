@@ -26,10 +26,13 @@ package okapi
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"strings"
@@ -37,6 +40,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jkaninda/okapi/client"
 	"github.com/jkaninda/okapi/okapitest"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -282,6 +286,41 @@ func TestWithAddr(t *testing.T) {
 	okapitest.GET(t, "http://localhost:8081").ExpectStatusOK()
 
 }
+func TestServerTuning(t *testing.T) {
+	var states []string
+	o := New(
+		WithReadHeaderTimeout(2),
+		WithHardening(Hardening{MaxHeaderBytes: 8 << 10}),
+		WithGeneralOptionsHandlerDisabled(),
+		WithConnState(func(_ net.Conn, s http.ConnState) { states = append(states, "a:"+s.String()) }),
+		WithTLSServer(":8443", &tls.Config{}),
+	).WithConnState(func(_ net.Conn, s http.ConnState) { states = append(states, "b:"+s.String()) })
+
+	for name, s := range map[string]*http.Server{"http": o.server, "https": o.tlsServer} {
+		if s.ReadHeaderTimeout != 2*time.Second {
+			t.Errorf("%s: ReadHeaderTimeout = %v", name, s.ReadHeaderTimeout)
+		}
+		if s.MaxHeaderBytes != 8<<10 {
+			t.Errorf("%s: MaxHeaderBytes = %d", name, s.MaxHeaderBytes)
+		}
+		if !s.DisableGeneralOptionsHandler {
+			t.Errorf("%s: general OPTIONS handler not disabled", name)
+		}
+		states = nil
+		s.ConnState(nil, http.StateNew)
+		if strings.Join(states, ",") != "a:new,b:new" {
+			t.Errorf("%s: ConnState hooks = %v", name, states)
+		}
+	}
+
+	// Unset knobs leave a server given to WithServer alone.
+	server := &http.Server{Addr: ":8081", MaxHeaderBytes: 4 << 10, ReadHeaderTimeout: time.Second}
+	o = New(WithServer(server))
+	if server.MaxHeaderBytes != 4<<10 || server.ReadHeaderTimeout != time.Second || server.ConnState != nil {
+		t.Errorf("custom server settings overridden: %+v", server)
+	}
+}
+
 func TestCustomConfig(t *testing.T) {
 	err := os.MkdirAll("public", 0777)
 	if err != nil {
@@ -575,3 +614,171 @@ func TestHandle(t *testing.T) {
 	okapitest.GET(t, o.BaseURL+"/books").ExpectStatusOK().ExpectBodyContains("The Go Programming Language").ExpectHeaderExists("X-Request-Id").ExpectCookie("session", "1234")
 
 }
+
+func TestResponseWriterDiagnostics(t *testing.T) {
+	var buf strings.Builder
+	o := New(WithDebug(), WithAccessLogDisabled(), WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+	o.Get("/twice", func(c *Context) error {
+		c.ResponseWriter().WriteHeader(http.StatusAccepted)
+		c.ResponseWriter().WriteHeader(http.StatusTeapot)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/twice", nil))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rec.Code)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "superfluous WriteHeader") {
+		t.Fatalf("expected a superfluous WriteHeader warning, got %q", out)
+	}
+	if strings.Count(out, "okapi_test.go:") < 2 {
+		t.Errorf("expected both call sites to be reported, got %q", out)
+	}
+}
+
+func TestResponseWriterStrictWrites(t *testing.T) {
+	o := New(WithStrictResponseWrites(), WithAccessLogDisabled())
+	o.Get("/twice", func(c *Context) error {
+		c.ResponseWriter().WriteHeader(http.StatusOK)
+		c.ResponseWriter().WriteHeader(http.StatusOK)
+		return nil
+	})
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected panic on duplicate WriteHeader")
+		}
+		if !strings.Contains(fmt.Sprint(r), "superfluous WriteHeader") {
+			t.Errorf("unexpected panic value: %v", r)
+		}
+	}()
+	o.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/twice", nil))
+}
+
+func TestResponseWriterWritten(t *testing.T) {
+	o := New(WithAccessLogDisabled())
+	var before, after bool
+	o.Use(func(c *Context) error {
+		before = c.Response().Written()
+		err := c.Next()
+		after = c.Response().Written()
+		return err
+	})
+	o.Get("/stream", func(c *Context) error {
+		_, err := c.ResponseWriter().Write([]byte("chunk"))
+		return err
+	})
+
+	o.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/stream", nil))
+	if before {
+		t.Error("expected Written to be false before the handler runs")
+	}
+	if !after {
+		t.Error("expected Written to be true after the handler streamed output")
+	}
+}
+
+func TestRoutePredicates(t *testing.T) {
+	o := NewTestServer(t)
+	o.Get("/books", func(c *Context) error { return c.Text(http.StatusOK, "v2") },
+		MatchHeader("X-API-Version", "2"))
+	o.Get("/books", func(c *Context) error { return c.Text(http.StatusOK, "full") },
+		MatchQuery("kind", "full"))
+	o.Get("/books", func(c *Context) error { return c.Text(http.StatusOK, "default") })
+
+	okapitest.GET(t, o.BaseURL+"/books").ExpectStatusOK().ExpectBody("default")
+	okapitest.GET(t, o.BaseURL+"/books").Header("X-API-Version", "2").ExpectStatusOK().ExpectBody("v2")
+	okapitest.GET(t, o.BaseURL+"/books").Header("X-API-Version", "3").ExpectStatusOK().ExpectBody("default")
+	okapitest.GET(t, o.BaseURL+"/books?kind=full").ExpectStatusOK().ExpectBody("full")
+
+	o.buildOpenAPISpec()
+	op := o.openapiSpec.Paths.Find("/books").Get
+	if op == nil {
+		t.Fatal("expected GET /books to be documented")
+	}
+	version := op.Parameters.GetByInAndName(openapi3.ParameterInHeader, "X-API-Version")
+	if version == nil {
+		t.Fatal("expected X-API-Version header parameter")
+	}
+	if version.Required {
+		t.Error("predicate header should be optional once merged with other routes")
+	}
+	if got := version.Schema.Value.Enum; len(got) != 1 || got[0] != "2" {
+		t.Errorf("unexpected enum %v", got)
+	}
+	if op.Parameters.GetByInAndName(openapi3.ParameterInQuery, "kind") == nil {
+		t.Error("expected kind query parameter")
+	}
+}
+
+func TestFallbackHandler(t *testing.T) {
+	legacy := http.NewServeMux()
+	legacy.HandleFunc("/legacy/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("legacy " + r.Method + " " + r.URL.Path))
+	})
+	legacy.HandleFunc("/books", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("legacy books"))
+	})
+
+	o := NewTestServer(t)
+	o.Get("/books", func(c *Context) error { return c.Text(http.StatusOK, "okapi books") })
+	o.NoRoute(func(c *Context) error { return c.AbortNotFound("not reached") })
+	o.SetFallbackHandler(legacy)
+
+	okapitest.GET(t, o.BaseURL+"/books").ExpectStatusOK().ExpectBody("okapi books")
+	okapitest.GET(t, o.BaseURL+"/legacy/users").ExpectStatusOK().ExpectBody("legacy GET /legacy/users")
+	okapitest.POST(t, o.BaseURL+"/books").ExpectStatusOK().ExpectBody("legacy books")
+	okapitest.GET(t, o.BaseURL+"/unknown").ExpectStatusNotFound().ExpectBodyNotContains("not reached")
+}
+
+func TestWithWarmup(t *testing.T) {
+	var readyDuringWarmup bool
+	o := New(WithAddr("127.0.0.1:8091"))
+	o.Get("/health", func(c *Context) error {
+		return c.OK(M{"status": "ok"})
+	})
+	o.WithWarmup(func(c *client.Client) error {
+		readyDuringWarmup = o.Ready()
+		resp, err := c.Get("/health").Do()
+		if err != nil {
+			return err
+		}
+		return resp.Error()
+	})
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- o.Start() }()
+	deadline := time.Now().Add(2 * time.Second)
+	for !o.Ready() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !o.Ready() {
+		t.Fatal("expected server to be ready after warm-up")
+	}
+	if readyDuringWarmup {
+		t.Error("expected server not to be ready while warming up")
+	}
+	if err := o.Stop(); err != nil {
+		t.Fatalf("failed to stop server: %v", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		t.Errorf("expected ErrServerClosed, got %v", err)
+	}
+
+	failing := New(WithAddr("127.0.0.1:8091")).WithWarmup(func(c *client.Client) error {
+		resp, err := c.Get("/missing").Do()
+		if err != nil {
+			return err
+		}
+		return resp.Error()
+	})
+	err := failing.Start()
+	if err == nil || !strings.Contains(err.Error(), "warm-up 1 failed") {
+		t.Fatalf("expected warm-up failure, got %v", err)
+	}
+	if failing.Ready() {
+		t.Error("expected server not to be ready after a failed warm-up")
+	}
+}
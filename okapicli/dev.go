@@ -0,0 +1,405 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jkaninda/okapi"
+)
+
+const (
+	// devReloadPath is the Server-Sent Events endpoint pages listen on for reloads.
+	devReloadPath = "/__okapi/dev/reload"
+	// devChildEnv marks the app process started by a `dev --go` supervisor.
+	devChildEnv = "OKAPI_DEV_CHILD"
+	// devStopTimeout bounds how long a restarted app may take to shut down.
+	devStopTimeout = 5 * time.Second
+)
+
+// devReloadScript reloads the page on a reload event, or when the server
+// comes back with a new instance id after a restart.
+const devReloadScript = `<script>(function(){var id,es=new EventSource("` + devReloadPath + `");` +
+	`es.addEventListener("hello",function(e){if(id&&id!==e.data)location.reload();id=e.data});` +
+	`es.addEventListener("reload",function(){location.reload()});})();</script>`
+
+// WithDevCommand registers the "dev" command, which serves the application for
+// local development:
+//
+//   - the --watch directories (templates and static files) are polled for changes;
+//     templates are reloaded and open pages refresh automatically
+//   - with --go, Go sources are watched too: the app is rebuilt and restarted on change
+//
+// Pages refresh through a small script injected into HTML responses, such as
+// those written by Render or served from static directories.
+//
+// Usage:
+//
+//	app dev
+//	app dev --watch views,public --go
+func (c *CLI) WithDevCommand() *CLI {
+	c.Command("dev", "Run the server with live reload for development", func(cmd *Command) error {
+		watch := splitList(cmd.GetString("watch"))
+		interval := cmd.GetDuration("interval")
+		if cmd.GetBool("go") && os.Getenv(devChildEnv) == "" {
+			return c.superviseDev(cmd.GetString("package"), interval)
+		}
+		reloader := c.enableDevReload()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go watchFiles(ctx, existingDirs(watch), interval, func(path string) bool { return true }, func(changed []string) {
+			if r, ok := c.o.Renderer().(interface{ Reload() error }); ok {
+				if err := r.Reload(); err != nil {
+					fmt.Fprintf(os.Stderr, "[okapi dev] template reload failed: %v\n", err)
+					return
+				}
+			}
+			fmt.Printf("[okapi dev] %d file(s) changed, reloading pages\n", len(changed))
+			reloader.broadcast("reload")
+		})
+		return c.Run()
+	}).
+		String("watch", "w", "templates,views,static,public", "Comma-separated directories to watch").
+		Bool("go", "g", false, "Rebuild and restart the app when Go sources change").
+		String("package", "p", ".", "Go package to build with --go").
+		Duration("interval", "i", 500*time.Millisecond, "File polling interval")
+	return c
+}
+
+// devReloader broadcasts reload events to the connected pages.
+type devReloader struct {
+	id      string
+	mu      sync.Mutex
+	clients map[chan okapi.Message]struct{}
+}
+
+// enableDevReload registers the reload endpoint and the script injection middleware.
+func (c *CLI) enableDevReload() *devReloader {
+	r := &devReloader{
+		id:      strconv.FormatInt(time.Now().UnixNano(), 36),
+		clients: make(map[chan okapi.Message]struct{}),
+	}
+	c.o.Get(devReloadPath, r.serve, okapi.DocHide())
+	c.o.UseMiddleware(injectReloadScript)
+	return r
+}
+
+func (r *devReloader) serve(c *okapi.Context) error {
+	ch := make(chan okapi.Message, 2)
+	ch <- okapi.Message{Event: "hello", Data: r.id}
+	r.mu.Lock()
+	r.clients[ch] = struct{}{}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.clients, ch)
+		r.mu.Unlock()
+	}()
+	err := c.SSEStream(c.Request().Context(), ch)
+	if err == context.Canceled {
+		return nil
+	}
+	return err
+}
+
+func (r *devReloader) broadcast(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for ch := range r.clients {
+		select {
+		case ch <- okapi.Message{Event: event, Data: r.id}:
+		default:
+			// A reload is already pending for this page.
+		}
+	}
+}
+
+// injectReloadScript adds devReloadScript to HTML responses.
+func injectReloadScript(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path == devReloadPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		iw := &injectWriter{ResponseWriter: w}
+		next.ServeHTTP(iw, r)
+		iw.finish()
+	})
+}
+
+// injectWriter buffers HTML bodies so the reload script can be inserted
+// before </body>; other responses pass through untouched.
+type injectWriter struct {
+	http.ResponseWriter
+	status  int
+	decided bool
+	html    bool
+	buf     bytes.Buffer
+}
+
+func (w *injectWriter) WriteHeader(status int) {
+	if w.decided {
+		return
+	}
+	if status >= 100 && status < 200 {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.decided = true
+	w.status = status
+	w.html = strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") && w.Header().Get("Content-Encoding") == ""
+	if w.html {
+		w.Header().Del("Content-Length")
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *injectWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.html {
+		return w.buf.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *injectWriter) Flush() {
+	if w.html {
+		return
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *injectWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *injectWriter) finish() {
+	if !w.html {
+		return
+	}
+	body := w.buf.Bytes()
+	if i := bytes.LastIndex(bytes.ToLower(body), []byte("</body>")); i >= 0 {
+		body = append(body[:i:i], append([]byte(devReloadScript), body[i:]...)...)
+	} else {
+		body = append(body, devReloadScript...)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// superviseDev builds and runs the app as a child process, rebuilding and
+// restarting it whenever Go sources change.
+func (c *CLI) superviseDev(pkg string, interval time.Duration) error {
+	bin := filepath.Join(os.TempDir(), fmt.Sprintf("okapi-dev-%d", os.Getpid()))
+	defer os.Remove(bin)
+	args := childArgs(os.Args[1:])
+
+	var child *exec.Cmd
+	restart := func() {
+		build := exec.Command("go", "build", "-o", bin, pkg)
+		build.Stdout, build.Stderr = os.Stdout, os.Stderr
+		if err := build.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "[okapi dev] build failed, waiting for changes: %v\n", err)
+			return
+		}
+		stopChild(child)
+		child = exec.Command(bin, args...)
+		child.Stdin, child.Stdout, child.Stderr = os.Stdin, os.Stdout, os.Stderr
+		child.Env = append(os.Environ(), devChildEnv+"=1")
+		if err := child.Start(); err != nil {
+			fmt.Fprintf(os.Stderr, "[okapi dev] start failed: %v\n", err)
+			child = nil
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), SIGINT, SIGTERM)
+	defer cancel()
+	restart()
+	changes := make(chan struct{}, 1)
+	go watchFiles(ctx, []string{"."}, interval, isGoSource, func(changed []string) {
+		fmt.Printf("[okapi dev] %s changed, restarting\n", changed[0])
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	})
+	for {
+		select {
+		case <-ctx.Done():
+			stopChild(child)
+			return nil
+		case <-changes:
+			restart()
+		}
+	}
+}
+
+// stopChild interrupts the app, killing it if it does not exit in time.
+func stopChild(cmd *exec.Cmd) {
+	if cmd == nil || cmd.Process == nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		_ = cmd.Process.Kill()
+	}
+	select {
+	case <-done:
+	case <-time.After(devStopTimeout):
+		_ = cmd.Process.Kill()
+		<-done
+	}
+}
+
+// childArgs returns the supervisor's arguments without the --go flag.
+func childArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch {
+		case arg == "--go", arg == "-g", strings.HasPrefix(arg, "--go="):
+			continue
+		}
+		out = append(out, arg)
+	}
+	return out
+}
+
+func isGoSource(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, ".go") && !strings.HasSuffix(base, "_test.go") || base == "go.mod" || base == "go.sum"
+}
+
+// watchFiles polls dirs every interval and calls onChange with the paths
+// matching match that were created, modified or removed since the last poll.
+func watchFiles(ctx context.Context, dirs []string, interval time.Duration, match func(string) bool, onChange func(changed []string)) {
+	if len(dirs) == 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	prev := snapshotFiles(dirs, match)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		cur := snapshotFiles(dirs, match)
+		var changed []string
+		for path, stamp := range cur {
+			if prev[path] != stamp {
+				changed = append(changed, path)
+			}
+		}
+		for path := range prev {
+			if _, ok := cur[path]; !ok {
+				changed = append(changed, path)
+			}
+		}
+		prev = cur
+		if len(changed) > 0 {
+			onChange(changed)
+		}
+	}
+}
+
+// fileStamp identifies a version of a file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func snapshotFiles(dirs []string, match func(string) bool) map[string]fileStamp {
+	files := make(map[string]fileStamp)
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if path != dir && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !match(path) {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				files[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+func existingDirs(dirs []string) []string {
+	var out []string
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			out = append(out, dir)
+		}
+	}
+	return out
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,147 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi"
+)
+
+func TestDevReload(t *testing.T) {
+	srv := okapi.NewTestServer(t)
+	srv.Get("/page", func(c *okapi.Context) error {
+		return c.HTMLView(http.StatusOK, "<html><body><h1>{{ . }}</h1></body></html>", "Hi")
+	})
+	srv.Get("/api", func(c *okapi.Context) error {
+		return c.OK(okapi.M{"body": "</body>"})
+	})
+	cli := New(srv.Okapi)
+	reloader := cli.enableDevReload()
+
+	resp, err := http.Get(srv.BaseURL + "/page")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if !strings.Contains(string(body), "<h1>Hi</h1>"+devReloadScript+"</body>") {
+		t.Errorf("reload script not injected: %s", body)
+	}
+
+	resp, err = http.Get(srv.BaseURL + "/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if strings.Contains(string(body), "<script>") {
+		t.Errorf("non-HTML response modified: %s", body)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.BaseURL+devReloadPath, nil)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	events := readEvents(bufio.NewReader(resp.Body))
+	if ev := <-events; ev != "hello" {
+		t.Fatalf("expected hello event, got %q", ev)
+	}
+	reloader.broadcast("reload")
+	select {
+	case ev := <-events:
+		if ev != "reload" {
+			t.Errorf("expected reload event, got %q", ev)
+		}
+	case <-ctx.Done():
+		t.Fatal("reload event not received")
+	}
+}
+
+// readEvents sends the event names read from an SSE stream.
+func readEvents(r *bufio.Reader) <-chan string {
+	events := make(chan string, 4)
+	go func() {
+		defer close(events)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			if name, ok := strings.CutPrefix(strings.TrimSpace(line), "event:"); ok {
+				events <- strings.TrimSpace(name)
+			}
+		}
+	}()
+	return events
+}
+
+func TestWatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	page := filepath.Join(dir, "index.html")
+	if err := os.WriteFile(page, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan []string, 4)
+	go watchFiles(ctx, []string{dir}, 10*time.Millisecond, func(string) bool { return true }, func(changed []string) {
+		changes <- changed
+	})
+	time.Sleep(30 * time.Millisecond)
+	if err := os.WriteFile(page, []byte("version 2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case changed := <-changes:
+		if len(changed) != 1 || changed[0] != page {
+			t.Errorf("unexpected changes: %v", changed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("change not detected")
+	}
+}
+
+func TestDevChildArgs(t *testing.T) {
+	got := childArgs([]string{"dev", "--go", "--watch", "views", "-g", "--go=true"})
+	if strings.Join(got, " ") != "dev --watch views" {
+		t.Errorf("unexpected child args: %v", got)
+	}
+	if !isGoSource("main.go") || isGoSource("main_test.go") || !isGoSource("go.mod") || isGoSource("index.html") {
+		t.Error("unexpected isGoSource result")
+	}
+}
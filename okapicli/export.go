@@ -0,0 +1,102 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WithExportCommand registers the "export" command, which writes the
+// application's routes as a Postman Collection (v2.1) that QA teams can import
+// into Postman or Insomnia, or as the OpenAPI document, without starting the
+// server.
+//
+// Usage:
+//
+//	app export postman --output ./postman_collection.json
+//	app export postman --output -   # write to stdout
+//	app export openapi --output ./docs/openapi.yaml
+//	app export openapi --format json-3.0 --output -
+//
+// See okapi.ExportPostmanCollection for the content of the collection, and
+// okapi.ExportOpenAPISpec for the OpenAPI formats. Without --format, the
+// OpenAPI format is taken from the output file extension.
+func (c *CLI) WithExportCommand() *CLI {
+	c.Command("export", "Export the routes as a Postman/Insomnia collection or an OpenAPI spec", func(cmd *Command) error {
+		args := cmd.Args()
+		if len(args) == 0 || (args[0] != "postman" && args[0] != "openapi") {
+			return fmt.Errorf("usage: %s export postman|openapi [--output file]", c.name)
+		}
+		if args[0] == "openapi" {
+			return c.exportOpenAPI(cmd.GetString("output"), cmd.GetString("format"))
+		}
+		path := cmp.Or(cmd.GetString("output"), "postman_collection.json")
+		if path == "-" {
+			return c.o.ExportPostmanCollection(os.Stdout)
+		}
+		if dir := filepath.Dir(path); dir != "." {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("create output directory: %w", err)
+			}
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("create collection: %w", err)
+		}
+		if err := c.o.ExportPostmanCollection(f); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("export collection: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("write collection: %w", err)
+		}
+		fmt.Printf("Exported Postman collection: %s\n", path)
+		return nil
+	}).
+		String("output", "o", "", "Output file (postman_collection.json or openapi.json by default), or - for stdout").
+		String("format", "f", "", "OpenAPI format: json, yaml, json-3.0 or yaml-3.0")
+	return c
+}
+
+// exportOpenAPI writes the OpenAPI document to path, or to stdout for "-".
+func (c *CLI) exportOpenAPI(path, format string) error {
+	path = cmp.Or(path, "openapi.json")
+	if path == "-" {
+		data, err := c.o.ExportOpenAPISpec(cmp.Or(format, "json"))
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := c.o.WriteOpenAPISpec(path, format); err != nil {
+		return fmt.Errorf("export OpenAPI spec: %w", err)
+	}
+	fmt.Printf("Exported OpenAPI spec: %s\n", path)
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collections", "books.json")
+	defer setOSArgs("export", "postman", "--output", path)()
+
+	cli := New(newGenerateApp()).WithExportCommand()
+	if err := cli.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the collection to be written: %v", err)
+	}
+	var col struct {
+		Info struct {
+			Schema string `json:"schema"`
+		} `json:"info"`
+		Item []json.RawMessage `json:"item"`
+	}
+	if err := json.Unmarshal(data, &col); err != nil {
+		t.Fatalf("invalid collection: %v", err)
+	}
+	if col.Info.Schema == "" || len(col.Item) == 0 {
+		t.Errorf("expected a populated collection, got %s", data)
+	}
+}
+
+func TestExportCommandUsage(t *testing.T) {
+	defer setOSArgs("export", "swagger")()
+
+	if err := New(newGenerateApp()).WithExportCommand().Execute(); err == nil {
+		t.Error("expected a usage error for an unknown format")
+	}
+}
+
+func TestExportCommandOpenAPI(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs", "openapi.yaml")
+	defer setOSArgs("export", "openapi", "--output", path)()
+
+	if err := New(newGenerateApp()).WithExportCommand().Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected the spec to be written: %v", err)
+	}
+	if !strings.Contains(string(data), "openapi: 3.1.0") || !strings.Contains(string(data), "/books/{id}") {
+		t.Errorf("expected a YAML OpenAPI 3.1 spec, got %s", data)
+	}
+}
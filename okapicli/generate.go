@@ -0,0 +1,391 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Supported client generator languages
+const (
+	LangGo         = "go"
+	LangTypeScript = "ts"
+)
+
+// WithGenerateCommand registers the "generate" command, which writes a typed API
+// client generated from the application's OpenAPI spec, so server and client
+// stay in the same repository workflow.
+//
+// Usage:
+//
+//	app generate client --lang go --output ./apiclient --package apiclient
+//	app generate client --lang ts --output ./web/src/api
+//
+// The Go client wraps github.com/jkaninda/okapi/client; the TypeScript client
+// relies on the standard fetch API only.
+func (c *CLI) WithGenerateCommand() *CLI {
+	c.Command("generate", "Generate a typed API client from the OpenAPI spec", func(cmd *Command) error {
+		args := cmd.Args()
+		if len(args) == 0 || args[0] != "client" {
+			return fmt.Errorf("usage: %s generate client [--lang go|ts] [--output dir] [--package name]", c.name)
+		}
+		lang := cmd.GetString("lang")
+		name, src, err := GenerateClient(c.o.OpenAPISpec(), lang, cmd.GetString("package"))
+		if err != nil {
+			return err
+		}
+		dir := cmd.GetString("output")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create output directory: %w", err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return fmt.Errorf("write client: %w", err)
+		}
+		fmt.Printf("Generated %s client: %s\n", lang, path)
+		return nil
+	}).
+		String("lang", "l", LangGo, "Client language (go|ts)").
+		String("output", "o", "apiclient", "Output directory").
+		String("package", "p", "apiclient", "Go package name")
+	return c
+}
+
+// GenerateClient generates a typed API client for spec in the given language,
+// returning the file name and source. pkg is the Go package name and is
+// ignored for TypeScript.
+func GenerateClient(spec *openapi3.T, lang, pkg string) (string, []byte, error) {
+	if spec == nil {
+		return "", nil, fmt.Errorf("OpenAPI spec is nil")
+	}
+	g := newClientGenerator(spec)
+	switch lang {
+	case LangGo:
+		if pkg == "" {
+			pkg = "apiclient"
+		}
+		src, err := g.generateGo(pkg)
+		return "client.go", src, err
+	case LangTypeScript:
+		return "client.ts", g.generateTS(), nil
+	default:
+		return "", nil, fmt.Errorf("unsupported client language %q: use %s or %s", lang, LangGo, LangTypeScript)
+	}
+}
+
+// clientOperation is a language-agnostic description of an API operation.
+type clientOperation struct {
+	name     string // exported identifier, e.g. ListBooks
+	method   string
+	path     string
+	summary  string
+	params   []*openapi3.Parameter // path, query and header parameters
+	body     *openapi3.SchemaRef
+	response *openapi3.SchemaRef
+}
+
+// clientGenerator holds the state shared by the language generators.
+type clientGenerator struct {
+	spec       *openapi3.T
+	operations []clientOperation
+	// types holds the named types to emit: component schemas and inline objects.
+	types map[string]*openapi3.Schema
+	order []string
+	// shapes maps the JSON encoding of object schemas to their type name.
+	shapes map[string]string
+}
+
+func newClientGenerator(spec *openapi3.T) *clientGenerator {
+	g := &clientGenerator{spec: spec, types: make(map[string]*openapi3.Schema), shapes: make(map[string]string)}
+	if spec.Components != nil {
+		names := make([]string, 0, len(spec.Components.Schemas))
+		for name := range spec.Components.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if ref := spec.Components.Schemas[name]; ref != nil && ref.Value != nil {
+				g.addComponent(exportedName(name), ref.Value)
+			}
+		}
+	}
+	g.collectOperations()
+	return g
+}
+
+// objectType returns the type name for an inline object schema: the type of
+// a structurally identical schema when one exists, otherwise a new type
+// registered under hint.
+func (g *clientGenerator) objectType(hint string, schema *openapi3.Schema) string {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return g.addType(hint, schema)
+	}
+	if name, ok := g.shapes[string(data)]; ok {
+		return name
+	}
+	name := g.addType(hint, schema)
+	g.shapes[string(data)] = name
+	return name
+}
+
+// addComponent registers a component schema under its own name, so that
+// references to it always resolve.
+func (g *clientGenerator) addComponent(name string, schema *openapi3.Schema) {
+	name = g.addType(name, schema)
+	if data, err := json.Marshal(schema); err == nil {
+		if _, ok := g.shapes[string(data)]; !ok {
+			g.shapes[string(data)] = name
+		}
+	}
+}
+
+// addType registers a named type, returning the (possibly de-duplicated) name.
+func (g *clientGenerator) addType(name string, schema *openapi3.Schema) string {
+	if existing, ok := g.types[name]; ok {
+		if existing == schema {
+			return name
+		}
+		for i := 2; ; i++ {
+			candidate := fmt.Sprintf("%s%d", name, i)
+			if _, taken := g.types[candidate]; !taken {
+				name = candidate
+				break
+			}
+		}
+	}
+	g.types[name] = schema
+	g.order = append(g.order, name)
+	return name
+}
+
+// collectOperations gathers the documented operations sorted by path and method.
+func (g *clientGenerator) collectOperations() {
+	if g.spec.Paths == nil {
+		return
+	}
+	paths := g.spec.Paths.Map()
+	keys := make([]string, 0, len(paths))
+	for p := range paths {
+		keys = append(keys, p)
+	}
+	sort.Strings(keys)
+	seen := make(map[string]int)
+	for _, p := range keys {
+		item := paths[p]
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead, http.MethodOptions} {
+			op := item.GetOperation(method)
+			if op == nil {
+				continue
+			}
+			name := exportedName(op.OperationID)
+			if name == "" {
+				name = exportedName(strings.ToLower(method) + " " + p)
+			}
+			if n := seen[name]; n > 0 {
+				seen[name]++
+				name = fmt.Sprintf("%s%d", name, n+1)
+			} else {
+				seen[name] = 1
+			}
+			g.operations = append(g.operations, clientOperation{
+				name:     name,
+				method:   method,
+				path:     p,
+				summary:  op.Summary,
+				params:   operationParams(item, op),
+				body:     requestSchema(op),
+				response: responseSchema(op),
+			})
+		}
+	}
+}
+
+// operationParams returns the path, query and header parameters of op,
+// including those inherited from the path item.
+func operationParams(item *openapi3.PathItem, op *openapi3.Operation) []*openapi3.Parameter {
+	var params []*openapi3.Parameter
+	seen := make(map[string]bool)
+	for _, list := range []openapi3.Parameters{op.Parameters, item.Parameters} {
+		for _, ref := range list {
+			p := ref.Value
+			if p == nil || seen[p.In+":"+p.Name] {
+				continue
+			}
+			switch p.In {
+			case openapi3.ParameterInPath, openapi3.ParameterInQuery, openapi3.ParameterInHeader:
+				seen[p.In+":"+p.Name] = true
+				params = append(params, p)
+			}
+		}
+	}
+	return params
+}
+
+// requestSchema returns the JSON request body schema of op, if any.
+func requestSchema(op *openapi3.Operation) *openapi3.SchemaRef {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return nil
+	}
+	return contentSchema(op.RequestBody.Value.Content)
+}
+
+// responseSchema returns the schema of the first documented 2xx response of op.
+func responseSchema(op *openapi3.Operation) *openapi3.SchemaRef {
+	if op.Responses == nil {
+		return nil
+	}
+	codes := make([]string, 0, op.Responses.Len())
+	for code := range op.Responses.Map() {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if resp := op.Responses.Value(code); resp != nil && resp.Value != nil {
+			if schema := contentSchema(resp.Value.Content); schema != nil {
+				return schema
+			}
+		}
+	}
+	return nil
+}
+
+// contentSchema returns the JSON schema of content, falling back to any media type.
+func contentSchema(content openapi3.Content) *openapi3.SchemaRef {
+	if mt := content.Get("application/json"); mt != nil && mt.Schema != nil {
+		return mt.Schema
+	}
+	types := make([]string, 0, len(content))
+	for t := range content {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		if mt := content[t]; mt != nil && mt.Schema != nil {
+			return mt.Schema
+		}
+	}
+	return nil
+}
+
+// refName returns the type name of a component schema reference.
+func refName(ref string) string {
+	return exportedName(ref[strings.LastIndex(ref, "/")+1:])
+}
+
+// isObject reports whether schema describes an object with properties.
+func isObject(schema *openapi3.Schema) bool {
+	return len(schema.Properties) > 0 && (schema.Type == nil || schema.Type.Is(openapi3.TypeObject))
+}
+
+// sortedProperties returns the property names of schema in alphabetical order.
+func sortedProperties(schema *openapi3.Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isRequired reports whether name is a required property of schema.
+func isRequired(schema *openapi3.Schema, name string) bool {
+	for _, r := range schema.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// exportedName converts s (e.g. "list-books", "get /books/{id}") to an
+// exported identifier (e.g. "ListBooks", "GetBooksId").
+func exportedName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name != "" && unicode.IsDigit(rune(name[0])) {
+		name = "T" + name
+	}
+	return name
+}
+
+// unexportedName converts s to an unexported identifier.
+func unexportedName(s string) string {
+	name := exportedName(s)
+	if name == "" {
+		return name
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// pathSegment is a literal or parameter part of an OpenAPI path template.
+type pathSegment struct {
+	text  string
+	param bool
+}
+
+// pathSegments splits an OpenAPI path template into literal and parameter segments.
+func pathSegments(path string) []pathSegment {
+	var segments []pathSegment
+	for path != "" {
+		start := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		if start < 0 || end < start {
+			segments = append(segments, pathSegment{text: path})
+			break
+		}
+		if start > 0 {
+			segments = append(segments, pathSegment{text: path[:start]})
+		}
+		segments = append(segments, pathSegment{text: path[start+1 : end], param: true})
+		path = path[end+1:]
+	}
+	return segments
+}
@@ -0,0 +1,291 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// goReserved lists identifiers used by the generated Go methods.
+var goReserved = map[string]bool{"c": true, "ctx": true, "params": true, "body": true, "rb": true, "out": true, "resp": true, "err": true, "v": true}
+
+// generateGo generates a Go client package wrapping the okapi client.
+func (g *clientGenerator) generateGo(pkg string) ([]byte, error) {
+	var methods strings.Builder
+	usesFmt, usesURL := false, false
+	for _, op := range g.operations {
+		f, u := g.writeGoOperation(&methods, op)
+		usesFmt = usesFmt || f
+		usesURL = usesURL || u
+	}
+
+	var types strings.Builder
+	// Emitting a type may register nested inline types, appended to g.order.
+	for i := 0; i < len(g.order); i++ {
+		g.writeGoType(&types, g.order[i], g.types[g.order[i]])
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by okapicli. DO NOT EDIT.\n\n")
+	if g.spec.Info != nil && g.spec.Info.Title != "" {
+		fmt.Fprintf(&b, "// Package %s is a typed client for the %s API.\n", pkg, g.spec.Info.Title)
+	}
+	fmt.Fprintf(&b, "package %s\n\nimport (\n\t\"context\"\n", pkg)
+	if usesFmt {
+		b.WriteString("\t\"fmt\"\n")
+	}
+	if usesURL {
+		b.WriteString("\t\"net/url\"\n")
+	}
+	b.WriteString("\n\t\"github.com/jkaninda/okapi/client\"\n)\n\n")
+	b.WriteString("// Client is a typed API client built on the okapi client.\n")
+	b.WriteString("type Client struct {\n\t*client.Client\n}\n\n")
+	b.WriteString("// New returns a Client rooted at baseURL.\n")
+	b.WriteString("func New(baseURL string, opts ...client.Option) *Client {\n\treturn &Client{Client: client.New(baseURL, opts...)}\n}\n\n")
+	b.WriteString(types.String())
+	b.WriteString(methods.String())
+
+	src, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("format generated Go client: %w", err)
+	}
+	return src, nil
+}
+
+// writeGoOperation writes the method for op, reporting whether it uses the
+// fmt and net/url packages.
+func (g *clientGenerator) writeGoOperation(b *strings.Builder, op clientOperation) (usesFmt, usesURL bool) {
+	var pathParams, optParams []*openapi3.Parameter
+	for _, p := range op.params {
+		if p.In == openapi3.ParameterInPath {
+			pathParams = append(pathParams, p)
+		} else {
+			optParams = append(optParams, p)
+		}
+	}
+
+	args := []string{"ctx context.Context"}
+	argNames := make(map[string]string, len(pathParams))
+	for _, p := range pathParams {
+		name := goParamName(p.Name)
+		argNames[p.Name] = name
+		args = append(args, name+" "+g.goType(p.Schema, op.name+exportedName(p.Name)))
+	}
+	if len(optParams) > 0 {
+		paramsType := g.goParamsType(b, op, optParams)
+		args = append(args, "params "+paramsType)
+	}
+	if op.body != nil {
+		args = append(args, "body "+g.goType(op.body, op.name+"Request"))
+	}
+
+	result, zero := "", ""
+	if op.response != nil {
+		result = g.goType(op.response, op.name+"Response")
+		if !strings.HasPrefix(result, "[]") && !strings.HasPrefix(result, "map[") && result != "any" {
+			result = "*" + result
+		}
+		zero = "nil"
+	}
+
+	// Build the request path
+	var path []string
+	for _, s := range pathSegments(op.path) {
+		if !s.param {
+			path = append(path, strconv.Quote(s.text))
+			continue
+		}
+		name, ok := argNames[s.text]
+		if !ok {
+			path = append(path, strconv.Quote("{"+s.text+"}"))
+			continue
+		}
+		path = append(path, "url.PathEscape(fmt.Sprint("+name+"))")
+		usesFmt, usesURL = true, true
+	}
+	if len(path) == 0 {
+		path = []string{`"/"`}
+	}
+
+	fmt.Fprintf(b, "// %s calls %s %s.\n", op.name, op.method, op.path)
+	if op.summary != "" {
+		fmt.Fprintf(b, "//\n// %s\n", singleLine(op.summary))
+	}
+	if result != "" {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) (%s, error) {\n", op.name, strings.Join(args, ", "), result)
+	} else {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) error {\n", op.name, strings.Join(args, ", "))
+	}
+	fmt.Fprintf(b, "\trb := c.Request(%q, %s).WithContext(ctx)\n", op.method, strings.Join(path, " + "))
+	for _, p := range optParams {
+		field := "params." + exportedName(p.Name)
+		setter := "QueryParam"
+		if p.In == openapi3.ParameterInHeader {
+			setter = "Header"
+		}
+		typ := g.goType(p.Schema, op.name+exportedName(p.Name))
+		usesFmt = true
+		if strings.HasPrefix(typ, "[]") {
+			fmt.Fprintf(b, "\tfor _, v := range %s {\n\t\trb.%s(%q, fmt.Sprint(v))\n\t}\n", field, setter, p.Name)
+			continue
+		}
+		fmt.Fprintf(b, "\tif %s != %s {\n\t\trb.%s(%q, fmt.Sprint(%s))\n\t}\n", field, g.goZero(typ), setter, p.Name, field)
+	}
+	if op.body != nil {
+		b.WriteString("\trb.JSONBody(body)\n")
+	}
+	switch {
+	case result == "":
+		b.WriteString("\tresp, err := rb.Do()\n\tif err != nil {\n\t\treturn err\n\t}\n\treturn resp.Error()\n")
+	case strings.HasPrefix(result, "*"):
+		fmt.Fprintf(b, "\tout := new(%s)\n\tif err := rb.Decode(out); err != nil {\n\t\treturn %s, err\n\t}\n\treturn out, nil\n", result[1:], zero)
+	default:
+		fmt.Fprintf(b, "\tvar out %s\n\tif err := rb.Decode(&out); err != nil {\n\t\treturn %s, err\n\t}\n\treturn out, nil\n", result, zero)
+	}
+	b.WriteString("}\n\n")
+	return usesFmt, usesURL
+}
+
+// goParamsType writes the struct holding the query and header parameters of op.
+func (g *clientGenerator) goParamsType(b *strings.Builder, op clientOperation, params []*openapi3.Parameter) string {
+	name := op.name + "Params"
+	fmt.Fprintf(b, "// %s holds the optional parameters of %s. Zero values are not sent.\n", name, op.name)
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, p := range params {
+		if p.Description != "" {
+			fmt.Fprintf(b, "\t// %s\n", singleLine(p.Description))
+		}
+		fmt.Fprintf(b, "\t%s %s\n", exportedName(p.Name), g.goType(p.Schema, op.name+exportedName(p.Name)))
+	}
+	b.WriteString("}\n\n")
+	return name
+}
+
+// writeGoType writes the declaration of a named type.
+func (g *clientGenerator) writeGoType(b *strings.Builder, name string, schema *openapi3.Schema) {
+	if schema.Description != "" {
+		fmt.Fprintf(b, "// %s: %s\n", name, singleLine(schema.Description))
+	} else {
+		fmt.Fprintf(b, "// %s is generated from the OpenAPI spec.\n", name)
+	}
+	if !isObject(schema) {
+		fmt.Fprintf(b, "type %s %s\n\n", name, g.goSchemaType(schema, name+"Item"))
+		return
+	}
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, prop := range sortedProperties(schema) {
+		tag := prop
+		if !isRequired(schema, prop) {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", exportedName(prop), g.goType(schema.Properties[prop], name+exportedName(prop)), tag)
+	}
+	b.WriteString("}\n\n")
+}
+
+// goType returns the Go type for ref, registering inline objects under hint.
+func (g *clientGenerator) goType(ref *openapi3.SchemaRef, hint string) string {
+	if ref == nil {
+		return "any"
+	}
+	if ref.Ref != "" {
+		return refName(ref.Ref)
+	}
+	if ref.Value == nil {
+		return "any"
+	}
+	if isObject(ref.Value) {
+		return g.objectType(hint, ref.Value)
+	}
+	return g.goSchemaType(ref.Value, hint)
+}
+
+// goSchemaType returns the Go type for a schema that is not a named object.
+func (g *clientGenerator) goSchemaType(s *openapi3.Schema, hint string) string {
+	switch {
+	case s.Type == nil:
+		return "any"
+	case s.Type.Is(openapi3.TypeString):
+		return "string"
+	case s.Type.Is(openapi3.TypeInteger):
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case s.Type.Is(openapi3.TypeNumber):
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case s.Type.Is(openapi3.TypeBoolean):
+		return "bool"
+	case s.Type.Is(openapi3.TypeArray):
+		return "[]" + g.goType(s.Items, hint+"Item")
+	case s.Type.Is(openapi3.TypeObject):
+		if ap := s.AdditionalProperties.Schema; ap != nil {
+			return "map[string]" + g.goType(ap, hint)
+		}
+		return "map[string]any"
+	default:
+		return "any"
+	}
+}
+
+// goZero returns the zero value expression of a Go type.
+func (g *clientGenerator) goZero(typ string) string {
+	switch {
+	case typ == "string":
+		return `""`
+	case typ == "int32", typ == "int64", typ == "float32", typ == "float64":
+		return "0"
+	case typ == "bool":
+		return "false"
+	case typ == "any", strings.HasPrefix(typ, "map["), strings.HasPrefix(typ, "[]"):
+		return "nil"
+	default:
+		// Named type: compare against its zero value.
+		return "*new(" + typ + ")"
+	}
+}
+
+// goParamName returns a Go identifier for a path parameter.
+func goParamName(name string) string {
+	id := unexportedName(name)
+	if id == "" || token.IsKeyword(id) || goReserved[id] {
+		id += "Param"
+	}
+	return id
+}
+
+// singleLine collapses whitespace in s so it fits on a single comment line.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,111 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jkaninda/okapi"
+)
+
+type genBook struct {
+	ID   int      `json:"id"`
+	Name string   `json:"name" required:"true"`
+	Tags []string `json:"tags"`
+}
+
+func newGenerateApp() *okapi.Okapi {
+	o := okapi.New()
+	noop := func(c *okapi.Context) error { return nil }
+	o.Get("/books", noop, okapi.DocSummary("List books"), okapi.DocQueryParam("limit", "int", "Max results", false), okapi.DocResponse([]genBook{}))
+	o.Post("/books", noop, okapi.DocSummary("Create book"), okapi.DocRequestBody(genBook{}), okapi.DocResponse(201, genBook{}))
+	o.Get("/books/:id", noop, okapi.DocSummary("Get book"), okapi.DocResponse(genBook{}))
+	o.Delete("/books/:id", noop, okapi.DocSummary("Delete book"))
+	return o
+}
+
+func TestGenerateClient(t *testing.T) {
+	spec := newGenerateApp().OpenAPISpec()
+
+	name, src, err := GenerateClient(spec, LangGo, "books")
+	if err != nil {
+		t.Fatalf("generate go client: %v", err)
+	}
+	if name != "client.go" {
+		t.Errorf("expected client.go, got %s", name)
+	}
+	for _, want := range []string{
+		"package books",
+		"type GenBook struct",
+		"`json:\"name\"`",
+		"func (c *Client) ListBooks(ctx context.Context, params ListBooksParams) ([]GenBook, error)",
+		"func (c *Client) CreateBook(ctx context.Context, body GenBook) (*GenBook, error)",
+		"func (c *Client) GetBook(ctx context.Context, id string) (*GenBook, error)",
+		"func (c *Client) DeleteBook(ctx context.Context, id string) error",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated Go client is missing %q", want)
+		}
+	}
+
+	name, src, err = GenerateClient(spec, LangTypeScript, "")
+	if err != nil {
+		t.Fatalf("generate ts client: %v", err)
+	}
+	if name != "client.ts" {
+		t.Errorf("expected client.ts, got %s", name)
+	}
+	for _, want := range []string{
+		"export interface GenBook {",
+		"name: string;",
+		"listBooks(params: { limit?: number } = {}): Promise<GenBook[]>",
+		"getBook(id: string): Promise<GenBook>",
+		"deleteBook(id: string): Promise<void>",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated TypeScript client is missing %q", want)
+		}
+	}
+
+	if _, _, err := GenerateClient(spec, "rust", ""); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+}
+
+func TestGenerateCommand(t *testing.T) {
+	dir := t.TempDir()
+	defer setOSArgs("generate", "client", "--lang", "ts", "--output", dir)()
+
+	cli := New(newGenerateApp()).WithGenerateCommand()
+	if err := cli.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "client.ts")); err != nil {
+		t.Errorf("expected client.ts to be written: %v", err)
+	}
+}
@@ -0,0 +1,297 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// tsReserved lists identifiers that can't be used as generated parameter names.
+var tsReserved = map[string]bool{"body": true, "params": true, "default": true, "class": true, "delete": true, "new": true, "function": true, "var": true, "in": true, "this": true}
+
+// tsRuntime is the request helper shared by the generated TypeScript methods.
+const tsRuntime = `/** ApiError is thrown when the API responds with a non-2xx status. */
+export class ApiError extends Error {
+  constructor(public readonly status: number, public readonly body: string) {
+    super(` + "`HTTP ${status}: ${body}`" + `);
+    this.name = "ApiError";
+  }
+}
+
+export interface ClientOptions {
+  /** Headers sent with every request, e.g. Authorization. */
+  headers?: Record<string, string>;
+  /** Custom fetch implementation; defaults to the global fetch. */
+  fetch?: typeof fetch;
+}
+
+interface RequestOptions {
+  query?: Record<string, unknown>;
+  headers?: Record<string, unknown>;
+  body?: unknown;
+}
+
+`
+
+// generateTS generates a dependency-free TypeScript client module.
+func (g *clientGenerator) generateTS() []byte {
+	var methods strings.Builder
+	for _, op := range g.operations {
+		g.writeTSOperation(&methods, op)
+	}
+
+	var types strings.Builder
+	// Emitting a type may register nested inline types, appended to g.order.
+	for i := 0; i < len(g.order); i++ {
+		g.writeTSType(&types, g.order[i], g.types[g.order[i]])
+	}
+
+	var b strings.Builder
+	b.WriteString("// Code generated by okapicli. DO NOT EDIT.\n")
+	if g.spec.Info != nil && g.spec.Info.Title != "" {
+		fmt.Fprintf(&b, "// Typed client for the %s API.\n", g.spec.Info.Title)
+	}
+	b.WriteString("\n")
+	b.WriteString(tsRuntime)
+	b.WriteString(types.String())
+	b.WriteString(`export class ApiClient {
+  private readonly baseUrl: string;
+  private readonly options: ClientOptions;
+
+  constructor(baseUrl: string, options: ClientOptions = {}) {
+    this.baseUrl = baseUrl.replace(/\/+$/, "");
+    this.options = options;
+  }
+
+`)
+	b.WriteString(methods.String())
+	b.WriteString(`  private async request<T>(method: string, path: string, opts: RequestOptions = {}): Promise<T> {
+    const url = new URL(this.baseUrl + path);
+    for (const [key, value] of Object.entries(opts.query ?? {})) {
+      if (value === undefined || value === null) continue;
+      for (const v of Array.isArray(value) ? value : [value]) url.searchParams.append(key, String(v));
+    }
+    const headers: Record<string, string> = { ...this.options.headers };
+    for (const [key, value] of Object.entries(opts.headers ?? {})) {
+      if (value !== undefined && value !== null) headers[key] = String(value);
+    }
+    let body: string | undefined;
+    if (opts.body !== undefined) {
+      headers["Content-Type"] = "application/json";
+      body = JSON.stringify(opts.body);
+    }
+    const res = await (this.options.fetch ?? fetch)(url.toString(), { method, headers, body });
+    const text = await res.text();
+    if (!res.ok) throw new ApiError(res.status, text);
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+}
+`)
+	return []byte(b.String())
+}
+
+// writeTSOperation writes the method for op.
+func (g *clientGenerator) writeTSOperation(b *strings.Builder, op clientOperation) {
+	var args, query, headers []string
+	argNames := make(map[string]string)
+	hasOptional := false
+	for _, p := range op.params {
+		typ := g.tsType(p.Schema, op.name+exportedName(p.Name))
+		switch p.In {
+		case openapi3.ParameterInPath:
+			name := unexportedName(p.Name)
+			if tsReserved[name] {
+				name += "Param"
+			}
+			argNames[p.Name] = name
+			args = append(args, name+": "+typ)
+		case openapi3.ParameterInQuery:
+			query = append(query, fmt.Sprintf("%s: params%s", strconv.Quote(p.Name), tsProperty(p.Name)))
+			hasOptional = true
+		case openapi3.ParameterInHeader:
+			headers = append(headers, fmt.Sprintf("%s: params%s", strconv.Quote(p.Name), tsProperty(p.Name)))
+			hasOptional = true
+		}
+	}
+	if op.body != nil {
+		args = append(args, "body: "+g.tsType(op.body, op.name+"Request"))
+	}
+	if hasOptional {
+		var fields []string
+		for _, p := range op.params {
+			if p.In != openapi3.ParameterInPath {
+				fields = append(fields, fmt.Sprintf("%s?: %s", tsKey(p.Name), g.tsType(p.Schema, op.name+exportedName(p.Name))))
+			}
+		}
+		args = append(args, "params: { "+strings.Join(fields, "; ")+" } = {}")
+	}
+
+	result := "void"
+	if op.response != nil {
+		result = g.tsType(op.response, op.name+"Response")
+	}
+
+	var path strings.Builder
+	for _, s := range pathSegments(op.path) {
+		if name, ok := argNames[s.text]; ok && s.param {
+			fmt.Fprintf(&path, "${encodeURIComponent(String(%s))}", name)
+		} else if s.param {
+			path.WriteString("{" + s.text + "}")
+		} else {
+			path.WriteString(strings.ReplaceAll(s.text, "`", "\\`"))
+		}
+	}
+
+	var opts []string
+	if len(query) > 0 {
+		opts = append(opts, "query: { "+strings.Join(query, ", ")+" }")
+	}
+	if len(headers) > 0 {
+		opts = append(opts, "headers: { "+strings.Join(headers, ", ")+" }")
+	}
+	if op.body != nil {
+		opts = append(opts, "body")
+	}
+	call := fmt.Sprintf("this.request<%s>(%q, `%s`", result, op.method, path.String())
+	if len(opts) > 0 {
+		call += ", { " + strings.Join(opts, ", ") + " }"
+	}
+
+	if op.summary != "" {
+		fmt.Fprintf(b, "  /** %s */\n", singleLine(op.summary))
+	}
+	fmt.Fprintf(b, "  %s(%s): Promise<%s> {\n    return %s);\n  }\n\n", unexportedName(op.name), strings.Join(args, ", "), result, call)
+}
+
+// writeTSType writes the declaration of a named type.
+func (g *clientGenerator) writeTSType(b *strings.Builder, name string, schema *openapi3.Schema) {
+	if schema.Description != "" {
+		fmt.Fprintf(b, "/** %s */\n", singleLine(schema.Description))
+	}
+	if !isObject(schema) {
+		fmt.Fprintf(b, "export type %s = %s;\n\n", name, g.tsSchemaType(schema, name+"Item"))
+		return
+	}
+	fmt.Fprintf(b, "export interface %s {\n", name)
+	for _, prop := range sortedProperties(schema) {
+		optional := "?"
+		if isRequired(schema, prop) {
+			optional = ""
+		}
+		fmt.Fprintf(b, "  %s%s: %s;\n", tsKey(prop), optional, g.tsType(schema.Properties[prop], name+exportedName(prop)))
+	}
+	b.WriteString("}\n\n")
+}
+
+// tsType returns the TypeScript type for ref, registering inline objects under hint.
+func (g *clientGenerator) tsType(ref *openapi3.SchemaRef, hint string) string {
+	if ref == nil {
+		return "unknown"
+	}
+	if ref.Ref != "" {
+		return refName(ref.Ref)
+	}
+	if ref.Value == nil {
+		return "unknown"
+	}
+	if isObject(ref.Value) {
+		return g.objectType(hint, ref.Value)
+	}
+	return g.tsSchemaType(ref.Value, hint)
+}
+
+// tsSchemaType returns the TypeScript type for a schema that is not a named object.
+func (g *clientGenerator) tsSchemaType(s *openapi3.Schema, hint string) string {
+	typ := "unknown"
+	switch {
+	case s.Type == nil:
+	case s.Type.Is(openapi3.TypeString):
+		typ = "string"
+		if len(s.Enum) > 0 {
+			values := make([]string, 0, len(s.Enum))
+			for _, v := range s.Enum {
+				if str, ok := v.(string); ok {
+					values = append(values, strconv.Quote(str))
+				}
+			}
+			if len(values) > 0 {
+				typ = strings.Join(values, " | ")
+			}
+		}
+	case s.Type.Is(openapi3.TypeInteger), s.Type.Is(openapi3.TypeNumber):
+		typ = "number"
+	case s.Type.Is(openapi3.TypeBoolean):
+		typ = "boolean"
+	case s.Type.Is(openapi3.TypeArray):
+		item := g.tsType(s.Items, hint+"Item")
+		if strings.Contains(item, " ") {
+			item = "(" + item + ")"
+		}
+		typ = item + "[]"
+	case s.Type.Is(openapi3.TypeObject):
+		value := "unknown"
+		if ap := s.AdditionalProperties.Schema; ap != nil {
+			value = g.tsType(ap, hint)
+		}
+		typ = "Record<string, " + value + ">"
+	}
+	if s.Nullable {
+		typ += " | null"
+	}
+	return typ
+}
+
+// tsKey returns name as a TypeScript property key, quoted when needed.
+func tsKey(name string) string {
+	if isTSIdentifier(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+// tsProperty returns an accessor for name, e.g. `.limit` or `["page-size"]`.
+func tsProperty(name string) string {
+	if isTSIdentifier(name) {
+		return "." + name
+	}
+	return "[" + strconv.Quote(name) + "]"
+}
+
+func isTSIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
@@ -95,6 +95,10 @@ type RunOptions struct {
 
 	// OnShutdown is called before shutdown begins
 	OnShutdown func()
+
+	// HealthCheck, when set, must succeed for the systemd watchdog to be pinged,
+	// so that systemd restarts a server that is running but unhealthy.
+	HealthCheck func() error
 }
 
 // New creates a new CLI manager for the Okapi
@@ -415,7 +419,11 @@ func defaultRunOptions() *RunOptions {
 }
 
 // RunServer starts Okapi and waits for shutdown signals.
-// It handles graceful shutdown automatically
+// It handles graceful shutdown automatically.
+//
+// Under systemd (Type=notify), the service manager is notified once the server
+// listens (READY=1) and when it shuts down (STOPPING=1). When WatchdogSec is set,
+// the watchdog is pinged at half its interval while RunOptions.HealthCheck succeeds.
 func (c *CLI) RunServer(opts ...*RunOptions) error {
 	options := defaultRunOptions()
 	if len(opts) > 0 && opts[0] != nil {
@@ -431,6 +439,11 @@ func (c *CLI) RunServer(opts ...*RunOptions) error {
 		options.OnStart()
 	}
 
+	// Channel to listen for interrupt signals
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, options.Signals...)
+	defer signal.Stop(quit)
+
 	// Channel to listen for errors from the server
 	serverErrors := make(chan error, 1)
 
@@ -441,39 +454,80 @@ func (c *CLI) RunServer(opts ...*RunOptions) error {
 		}
 	}()
 
-	// Call OnStarted callback if provided
-	if options.OnStarted != nil {
-		go func() {
-			time.Sleep(100 * time.Millisecond)
-			options.OnStarted()
-		}()
-	}
-
-	// Channel to listen for interrupt signals
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, options.Signals...)
+	done := make(chan struct{})
+	defer close(done)
+	ready := c.waitReady(done)
+	var watchdog <-chan time.Time
 
 	// Block until receiving a signal or an error
-	select {
-	case err := <-serverErrors:
-		return fmt.Errorf("server error: %w", err)
-	case <-quit:
-		// Call OnShutdown callback if provided
-		if options.OnShutdown != nil {
-			options.OnShutdown()
-		}
+	for {
+		select {
+		case err := <-serverErrors:
+			return fmt.Errorf("server error: %w", err)
+		case <-ready:
+			ready = nil
+			c.notify(sdReady)
+			// Call OnStarted callback if provided
+			if options.OnStarted != nil {
+				go options.OnStarted()
+			}
+			if interval := watchdogInterval(); interval > 0 {
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				watchdog = ticker.C
+			}
+		case <-watchdog:
+			if options.HealthCheck != nil {
+				if err := options.HealthCheck(); err != nil {
+					c.o.Logger().Warn("Health check failed, skipping watchdog ping", "error", err)
+					continue
+				}
+			}
+			c.notify(sdWatchdog)
+		case <-quit:
+			c.notify(sdStopping)
+			// Call OnShutdown callback if provided
+			if options.OnShutdown != nil {
+				options.OnShutdown()
+			}
 
-		// Create a context with timeout for shutdown
-		ctx, cancel := context.WithTimeout(context.Background(), options.ShutdownTimeout)
-		defer cancel()
+			// Create a context with timeout for shutdown
+			ctx, cancel := context.WithTimeout(context.Background(), options.ShutdownTimeout)
+			defer cancel()
 
-		// Attempt a graceful shutdown
-		if err := c.o.StopWithContext(ctx); err != nil {
-			return fmt.Errorf("server shutdown failed: %w", err)
+			// Attempt a graceful shutdown
+			if err := c.o.StopWithContext(ctx); err != nil {
+				return fmt.Errorf("server shutdown failed: %w", err)
+			}
+			return nil
 		}
 	}
+}
 
-	return nil
+// waitReady returns a channel closed once the server listens and its warm-ups
+// succeeded, see okapi.Okapi.Ready.
+func (c *CLI) waitReady(done <-chan struct{}) <-chan struct{} {
+	ready := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for !c.o.Ready() {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+		close(ready)
+	}()
+	return ready
+}
+
+// notify sends state to systemd, logging failures.
+func (c *CLI) notify(state string) {
+	if err := sdNotify(state); err != nil {
+		c.o.Logger().Warn("systemd notification failed", "state", state, "error", err)
+	}
 }
 
 // Run starts Okapi using default options and waits for shutdown signals.
@@ -0,0 +1,121 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapicli
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi"
+)
+
+func TestRunServerSystemdNotify(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+	t.Setenv("NOTIFY_SOCKET", socket)
+	t.Setenv("WATCHDOG_USEC", "100000")
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+
+	var healthy atomic.Bool
+	o := okapi.New(okapi.WithAddr("127.0.0.1:8094"))
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- New(o).RunServer(&RunOptions{
+			ShutdownTimeout: time.Second,
+			Signals:         []os.Signal{syscall.SIGUSR1},
+			HealthCheck: func() error {
+				if !healthy.Load() {
+					return errors.New("warming up")
+				}
+				return nil
+			},
+		})
+	}()
+
+	read := func() string {
+		t.Helper()
+		buf := make([]byte, 64)
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatalf("no notification: %v", err)
+		}
+		return string(buf[:n])
+	}
+
+	if got := read(); got != sdReady {
+		t.Fatalf("first notification = %q, want %q", got, sdReady)
+	}
+	if !o.Ready() {
+		t.Error("READY=1 sent before the server was ready")
+	}
+	// Unhealthy: no ping within a few watchdog intervals.
+	_ = conn.SetReadDeadline(time.Now().Add(150 * time.Millisecond))
+	if n, err := conn.Read(make([]byte, 64)); err == nil {
+		t.Errorf("watchdog pinged while unhealthy (%d bytes)", n)
+	}
+	healthy.Store(true)
+	if got := read(); got != sdWatchdog {
+		t.Errorf("notification = %q, want %q", got, sdWatchdog)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	for got := read(); got != sdStopping; got = read() {
+		if got != sdWatchdog {
+			t.Fatalf("notification = %q, want %q", got, sdStopping)
+		}
+	}
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "")
+	if got := watchdogInterval(); got != 0 {
+		t.Errorf("interval = %s, want 0", got)
+	}
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	if got := watchdogInterval(); got != 15*time.Second {
+		t.Errorf("interval = %s, want 15s", got)
+	}
+	t.Setenv("WATCHDOG_PID", "1")
+	if got := watchdogInterval(); got != 0 {
+		t.Errorf("interval for another pid = %s, want 0", got)
+	}
+}
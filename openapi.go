@@ -25,6 +25,7 @@
 package okapi
 
 import (
+	"cmp"
 	"crypto/sha256"
 	"fmt"
 	"log/slog"
@@ -39,6 +40,7 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	goutils "github.com/jkaninda/go-utils"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
@@ -52,6 +54,7 @@ const (
 	constDateTime = "date-time"
 	constDate     = "date"
 	constUUID     = "uuid"
+	constDecimal  = "decimal"
 	constBool     = "bool"
 	constString   = "string"
 	constEnum     = "enum"
@@ -75,6 +78,9 @@ type OpenAPI struct {
 	SecuritySchemes  SecuritySchemes
 	ExternalDocs     *ExternalDocs
 	ComponentSchemas map[string]*SchemaInfo
+	// Tags describes the tags used by the routes. Documentation UIs list their
+	// sections in this order, followed by the remaining tags sorted by name.
+	Tags []TagInfo
 
 	// Okapi: UI selects the interactive documentation UI rendered at /docs.
 	// Valid values: SwaggerUI (default), RedocUI, ScalarUI.
@@ -88,6 +94,19 @@ type OpenAPI struct {
 	StrictDocUI bool
 	// Favicon is the URL of the favicon used by the documentation UIs.
 	Favicon string
+	// OperationIDStrategy names the operations of routes declaring no OperationId.
+	// Defaults to DefaultOperationID. Duplicated operationIds get a numeric suffix.
+	OperationIDStrategy OperationIDFunc
+	// Okapi: LocalServer adds the address the server listens on (e.g.
+	// http://localhost:8080) to Servers in debug mode, so "Try it out" works
+	// locally without editing the configured servers.
+	LocalServer bool
+	// BasicAuth, when set, protects every documentation route (UIs and spec
+	// files) with HTTP Basic authentication.
+	BasicAuth *BasicAuth
+	// Middlewares are applied to every documentation route after BasicAuth,
+	// e.g. to restrict access to the docs in production.
+	Middlewares []Middleware
 }
 type SecuritySchemes []SecurityScheme
 
@@ -105,6 +124,17 @@ type SecurityScheme struct {
 	Flows       *OAuthFlows
 	Description string
 }
+
+// TagInfo describes an OpenAPI tag, emitted in the root tags array of the specification.
+type TagInfo struct {
+	// Name is the tag name (matches what appears on Operation.Tags).
+	Name string
+	// Description is the human-readable description shown in the API docs.
+	Description string
+	// ExternalDocs optionally links to additional documentation for this tag.
+	ExternalDocs *ExternalDocs
+}
+
 type ExternalDocs struct {
 	Extensions map[string]any `json:"-" yaml:"-"`
 	Origin     *Origin        `json:"__origin__,omitempty" yaml:"__origin__,omitempty"`
@@ -160,6 +190,19 @@ type Server struct {
 	URL string `json:"url" yaml:"url"`
 	// Optional server description
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Variables substituted in URL templates such as "https://{region}.api.example.com"
+	Variables map[string]ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// ServerVariable is a variable of a Server URL template, e.g. {region}
+type ServerVariable struct {
+	Extensions map[string]any `json:"-" yaml:"-"`
+	// Allowed values, optional
+	Enum []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+	// Value used when none is chosen, required and one of Enum when set
+	Default string `json:"default" yaml:"default"`
+	// Optional variable description
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 // Contact contains contact information for the API maintainers
@@ -201,10 +244,22 @@ func (s Servers) ToOpenAPI() openapi3.Servers {
 			Description: srv.Description,
 		}
 		if len(srv.Extensions) > 0 {
+			server.Extensions = make(map[string]any, len(srv.Extensions))
 			for k, v := range srv.Extensions {
 				server.Extensions[k] = v
 			}
 		}
+		if len(srv.Variables) > 0 {
+			server.Variables = make(map[string]*openapi3.ServerVariable, len(srv.Variables))
+			for name, v := range srv.Variables {
+				server.Variables[name] = &openapi3.ServerVariable{
+					Extensions:  v.Extensions,
+					Enum:        v.Enum,
+					Default:     v.Default,
+					Description: v.Description,
+				}
+			}
+		}
 		servers = append(servers, server)
 	}
 	return servers
@@ -349,6 +404,12 @@ func (b *DocBuilder) Response(statusOrValue any, vOptional ...any) *DocBuilder {
 	return b
 }
 
+// FileResponse documents a binary file response for the given status code and content type.
+func (b *DocBuilder) FileResponse(status int, contentType string) *DocBuilder {
+	b.options = append(b.options, DocFileResponse(status, contentType))
+	return b
+}
+
 // ErrorResponse defines an error response schema for a specific HTTP status code
 // in the route's OpenAPI documentation.
 // Deprecated: This function is deprecated in favor of Response(status, v).
@@ -398,6 +459,18 @@ func (b *DocBuilder) Deprecated() *DocBuilder {
 	return b
 }
 
+// Sunset marks the route as deprecated until the given removal date
+func (b *DocBuilder) Sunset(date time.Time) *DocBuilder {
+	b.options = append(b.options, Sunset(date))
+	return b
+}
+
+// SuccessorRoute marks the route as deprecated in favor of the route called name
+func (b *DocBuilder) SuccessorRoute(name string) *DocBuilder {
+	b.options = append(b.options, SuccessorRoute(name))
+	return b
+}
+
 // PathParam adds a documented path parameter to the route.
 // name: parameter name
 // typ: parameter type (e.g., "string", "int")
@@ -528,6 +601,15 @@ func Hide() RouteOption {
 	}
 }
 
+// DocSpec documents the route in the named OpenAPI document instead of the
+// public one. Named documents are served at /openapi/{name}.json and
+// /openapi/{name}.yaml, with their UI at /docs/{name}.
+func DocSpec(name string) RouteOption {
+	return func(r *Route) {
+		r.specName = name
+	}
+}
+
 // OperationId sets a unique identifier for the operation in the OpenAPI documentation.
 func OperationId(operationId string) RouteOption {
 	return func(r *Route) {
@@ -773,15 +855,31 @@ func DocResponse(statusOrValue any, vOptional ...any) RouteOption {
 			if len(vOptional) == 0 || vOptional[0] == nil {
 				return
 			}
-			doc.responses[val] = reflectToSchemaWithInfo(vOptional[0]).Schema
+			doc.docResponse(val, vOptional[0])
 
 		default:
 			// usage: DocResponse(value)
 			if val == nil {
 				return
 			}
-			doc.responses[200] = reflectToSchemaWithInfo(val).Schema
+			doc.docResponse(200, val)
+		}
+	}
+}
+
+// DocFileResponse documents a binary file response, such as a download or a report
+// export, for the given status code and content type.
+//
+// Example:
+//
+//	DocFileResponse(200, okapi.ContentTypeXLSX)
+//	DocFileResponse(200, "application/pdf")
+func DocFileResponse(status int, contentType string) RouteOption {
+	return func(doc *Route) {
+		if doc.fileResponses == nil {
+			doc.fileResponses = make(map[int][]string)
 		}
+		doc.fileResponses[status] = append(doc.fileResponses[status], contentType)
 	}
 }
 
@@ -874,7 +972,7 @@ func Request(v any) RouteOption {
 func Response(v any) RouteOption {
 	return func(r *Route) {
 		if v != nil {
-			r.generateResponseSchema(v)
+			r.generateResponseSchema(v, 0)
 		}
 	}
 }
@@ -887,11 +985,83 @@ func WithIO(req any, res any) RouteOption {
 			r.generateRequestSchema(req)
 		}
 		if res != nil {
-			r.generateResponseSchema(res)
+			r.generateResponseSchema(res, 0)
 		}
 	}
 }
 
+// appendMatchParams documents route predicates (MatchHeader, MatchQuery) as
+// required parameters, restricted to the matched value when one is set.
+func appendMatchParams(params openapi3.Parameters, in string, pairs []string) openapi3.Parameters {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		name, value := pairs[i], pairs[i+1]
+		if params.GetByInAndName(in, name) != nil {
+			continue
+		}
+		schema := openapi3.NewStringSchema()
+		if value != "" {
+			schema.Enum = []any{value}
+		}
+		params = append(params, &openapi3.ParameterRef{
+			Value: &openapi3.Parameter{
+				Name:     name,
+				In:       in,
+				Required: true,
+				Schema:   openapi3.NewSchemaRef("", schema),
+			},
+		})
+	}
+	return params
+}
+
+// mergeOperations combines two operations registered for the same method and
+// path. Parameters of the same name are merged; a predicate only present on
+// one side becomes optional, and enums are combined.
+// Responses missing from the existing operation are added.
+func mergeOperations(existing, op *openapi3.Operation) *openapi3.Operation {
+	for _, ref := range existing.Parameters {
+		if ref.Value != nil && op.Parameters.GetByInAndName(ref.Value.In, ref.Value.Name) == nil {
+			ref.Value.Required = ref.Value.In == openapi3.ParameterInPath
+		}
+	}
+	for _, ref := range op.Parameters {
+		if ref.Value == nil {
+			continue
+		}
+		current := existing.Parameters.GetByInAndName(ref.Value.In, ref.Value.Name)
+		if current == nil {
+			param := *ref.Value
+			param.Required = param.In == openapi3.ParameterInPath
+			existing.Parameters = append(existing.Parameters, &openapi3.ParameterRef{Value: &param})
+			continue
+		}
+		if current.Schema != nil && current.Schema.Value != nil && ref.Value.Schema != nil && ref.Value.Schema.Value != nil {
+			if len(current.Schema.Value.Enum) > 0 && len(ref.Value.Schema.Value.Enum) > 0 {
+				current.Schema.Value.Enum = append(current.Schema.Value.Enum, ref.Value.Schema.Value.Enum...)
+			} else {
+				current.Schema.Value.Enum = nil
+			}
+		}
+	}
+	for code, resp := range op.Responses.Map() {
+		if existing.Responses.Value(code) == nil {
+			existing.Responses.Set(code, resp)
+		}
+	}
+	if existing.Description == "" {
+		existing.Description = op.Description
+	}
+	return existing
+}
+
+// ResponseHeaders sets default response headers for the route.
+// See Route.WithResponseHeaders.
+func ResponseHeaders(headers map[string]string) RouteOption {
+	return func(r *Route) {
+		r.WithResponseHeaders(headers)
+	}
+}
+
 // DocBearerAuth marks the route as requiring Bearer token authentication
 func DocBearerAuth() RouteOption {
 	return func(doc *Route) {
@@ -930,6 +1100,54 @@ func withSecurity(security []map[string][]string) RouteOption {
 // document is the default served at /openapi.json; both remain reachable at
 // their version-pinned routes.
 func (o *Okapi) buildOpenAPISpec() {
+	o.syncMuxRoutes()
+	// Spec-first: the loaded document is served verbatim.
+	if o.sourceSpec != nil {
+		o.openapiSpec, o.openapiSpec31 = o.sourceSpec, o.sourceSpec
+		return
+	}
+	o.openapiSpec, o.openapiSpec31 = o.buildSpec("")
+
+	o.namedSpecs = make(map[string]*namedSpec)
+	for _, r := range o.routes {
+		if r.specName == "" || o.namedSpecs[r.specName] != nil {
+			continue
+		}
+		spec, spec31 := o.buildSpec(r.specName)
+		o.namedSpecs[r.specName] = &namedSpec{spec: spec, spec31: spec31}
+	}
+}
+
+// docServers returns the documented servers, preceded by the listen address
+// when OpenAPI.LocalServer is set in debug mode.
+func (o *Okapi) docServers() openapi3.Servers {
+	servers := o.openAPI.Servers.ToOpenAPI()
+	if !o.openAPI.LocalServer || !o.debug || o.server == nil {
+		return servers
+	}
+	addr := o.server.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	host, port := parseAddr(addr)
+	scheme := "http"
+	if o.server.TLSConfig != nil {
+		scheme = "https"
+	}
+	local := scheme + "://" + host + ":" + port
+	// Drop the placeholder server and a configured duplicate.
+	kept := openapi3.Servers{{URL: local, Description: "Local server"}}
+	for _, srv := range servers {
+		if srv.URL != "" && srv.URL != local {
+			kept = append(kept, srv)
+		}
+	}
+	return kept
+}
+
+// buildSpec builds the OpenAPI 3.0 and 3.1 documents for the routes belonging
+// to the named document, where the empty name is the public document.
+func (o *Okapi) buildSpec(name string) (*openapi3.T, *openapi3.T) {
 	spec := &openapi3.T{
 		OpenAPI: openApiVersion,
 		Info: &openapi3.Info{
@@ -942,7 +1160,7 @@ func (o *Okapi) buildOpenAPISpec() {
 			Contact:        o.openAPI.Contact.ToOpenAPI(),
 		},
 		Paths:   &openapi3.Paths{},
-		Servers: o.openAPI.Servers.ToOpenAPI(),
+		Servers: o.docServers(),
 		Components: &openapi3.Components{
 			SecuritySchemes: o.openAPI.SecuritySchemes.ToOpenAPI(),
 			Schemas:         make(openapi3.Schemas),
@@ -980,19 +1198,19 @@ func (o *Okapi) buildOpenAPISpec() {
 	}
 
 	// Process all registered routes
+	routes := make([]*Route, 0, len(o.routes))
 	for _, r := range o.routes {
 		// If route is disabled ignore it
-		if r.disabled || r.hidden {
-			continue
+		if !r.disabled && !r.hidden && r.specName == name {
+			routes = append(routes, r)
 		}
+	}
+	operationIDs := o.newOperationIDs(routes)
+	for _, r := range routes {
 		// Auto-extract path parameters if none are defined
 		if len(r.pathParams) == 0 {
 			docAutoPathParams()(r)
-		}
-		if len(r.operationId) == 0 {
-			if len(r.summary) != 0 {
-				r.operationId = goutils.Slug(r.summary)
-			}
+			r.autoPathParams = true
 		}
 		item := spec.Paths.Value(r.Path)
 		if item == nil {
@@ -1001,34 +1219,29 @@ func (o *Okapi) buildOpenAPISpec() {
 		}
 
 		op := o.buildOperation(spec, r, schemaRegistry)
-
-		// Assign operation to correct HTTP verb
 		switch r.Method {
-		case methodGet:
-			item.Get = op
-		case methodPost:
-			item.Post = op
-		case methodPut:
-			item.Put = op
-		case methodDelete:
-			item.Delete = op
-		case methodPatch:
-			item.Patch = op
-		case methodHead:
-			item.Head = op
-		case methodOptions:
-			item.Options = op
+		case methodGet, methodPost, methodPut, methodDelete, methodPatch, methodHead, methodOptions:
+		default:
+			continue
+		}
+		// Routes sharing a method and path, dispatched by predicates,
+		// are documented as a single operation.
+		if existing := item.GetOperation(r.Method); existing != nil {
+			op = mergeOperations(existing, op)
+		} else {
+			op.OperationID = operationIDs.assign(r)
 		}
+		item.SetOperation(r.Method, op)
 	}
 
-	spec.Tags = o.collectRootTags()
+	spec.Tags = o.collectRootTags(name)
 
 	// Derive the OpenAPI 3.1 document from the 3.0 base before the base is
 	// cleaned of internal markers (the derivation deep-copies the base).
-	o.openapiSpec31 = o.deriveSpec31(spec)
+	spec31 := o.deriveSpec31(spec)
 	// Remove internal markers so the 3.0 document stays clean and valid.
 	stripConstMarkers(spec)
-	o.openapiSpec = spec
+	return spec, spec31
 }
 
 // buildOperation builds an OpenAPI operation from a route's documentation
@@ -1045,7 +1258,11 @@ func (o *Okapi) buildOperation(spec *openapi3.T, r *Route, schemaRegistry map[st
 		Deprecated:  r.deprecated,
 	}
 
+	op.Parameters = appendMatchParams(op.Parameters, openapi3.ParameterInHeader, r.matchHeaders)
+	op.Parameters = appendMatchParams(op.Parameters, openapi3.ParameterInQuery, r.matchQueries)
+
 	addSecurity(spec, op, r)
+	o.deprecationExtensions(op, r)
 	// Handle request body
 	if r.request != nil {
 		// Generate reusable schema component if it's a complex type
@@ -1060,6 +1277,9 @@ func (o *Okapi) buildOperation(spec *openapi3.T, r *Route, schemaRegistry map[st
 		if r.requestExample != nil {
 			requestBody.Content[constJSON].Example = r.requestExample
 		}
+		if o.xmlOptions != nil {
+			requestBody.Content[constXML] = o.xmlOptions.xmlMediaType(schemaRef)
+		}
 
 		op.RequestBody = &openapi3.RequestBodyRef{Value: requestBody}
 	}
@@ -1068,20 +1288,45 @@ func (o *Okapi) buildOperation(spec *openapi3.T, r *Route, schemaRegistry map[st
 			schemaRef := o.getOrCreateSchemaComponent(resp, schemaRegistry, spec.Components.Schemas)
 			apiResponse := &openapi3.Response{
 				Description: ptr(http.StatusText(key)),
-				Content:     openapi3.NewContentWithJSONSchemaRef(schemaRef),
+				Content:     openapi3.Content{errorContentType(schemaRef): openapi3.NewMediaType().WithSchemaRef(schemaRef)},
 				Headers:     r.responseHeaders,
 			}
+			if o.xmlOptions != nil {
+				apiResponse.Content[constXML] = o.xmlOptions.xmlMediaType(schemaRef)
+			}
+			if r.protoResponses[key] {
+				binary := openapi3.NewStringSchema()
+				binary.Format = "binary"
+				apiResponse.Content[constPROTOBUF] = openapi3.NewMediaType().WithSchema(binary)
+			}
 			op.Responses.Set(strconv.Itoa(key), &openapi3.ResponseRef{
 				Value: apiResponse,
 			})
 		}
 	}
-	// Add default responses
-	op.Responses.Set("500", &openapi3.ResponseRef{
-		Value: &openapi3.Response{
-			Description: ptr("Internal Server Error"),
-		},
-	})
+	for status, contentTypes := range r.fileResponses {
+		resp := r.operationResponse(op, status)
+		for _, contentType := range contentTypes {
+			binary := openapi3.NewStringSchema()
+			binary.Format = "binary"
+			resp.Content[contentType] = openapi3.NewMediaType().WithSchema(binary)
+		}
+	}
+	if r.hasHTMLRepresentation() {
+		resp := r.operationResponse(op, http.StatusOK)
+		resp.Content[constHTML] = openapi3.NewMediaType().WithSchema(openapi3.NewStringSchema())
+	}
+	// Add default responses, keeping a documented 500
+	if op.Responses.Value("500") == nil {
+		op.Responses.Set("500", &openapi3.ResponseRef{
+			Value: &openapi3.Response{
+				Description: ptr("Internal Server Error"),
+			},
+		})
+	}
+	if r.rateLimited() {
+		documentRateLimit(op)
+	}
 	return op
 }
 
@@ -1315,13 +1560,20 @@ func walkSchemaRef(ref *openapi3.SchemaRef, seen map[*openapi3.Schema]bool, fn f
 	walkSchemaRef(s.Not, seen, fn)
 }
 
-// collectRootTags aggregates GroupTag entries from every route
-func (o *Okapi) collectRootTags() openapi3.Tags {
+// collectRootTags builds the root tags of the named document: the tags declared
+// with WithOpenAPITags that its routes use, in declaration order, followed by
+// the GroupTag entries of its routes sorted by name. Declared metadata wins
+// over the GroupTag one, which only fills the fields left empty.
+func (o *Okapi) collectRootTags(name string) openapi3.Tags {
+	used := make(map[string]bool)
 	seen := make(map[string]*openapi3.Tag)
 	for _, r := range o.routes {
-		if r.disabled || r.hidden {
+		if r.disabled || r.hidden || r.specName != name {
 			continue
 		}
+		for _, t := range r.tags {
+			used[t] = true
+		}
 		for _, t := range r.tagInfos {
 			if t.Name == "" {
 				continue
@@ -1336,15 +1588,33 @@ func (o *Okapi) collectRootTags() openapi3.Tags {
 			}
 		}
 	}
-	if len(seen) == 0 {
-		return nil
+	var tags openapi3.Tags
+	declared := make(map[string]bool, len(o.openAPI.Tags))
+	for _, t := range o.openAPI.Tags {
+		if t.Name == "" || declared[t.Name] || (!used[t.Name] && seen[t.Name] == nil) {
+			continue
+		}
+		declared[t.Name] = true
+		tag := &openapi3.Tag{
+			Name:         t.Name,
+			Description:  t.Description,
+			ExternalDocs: t.ExternalDocs.ToOpenAPI(),
+		}
+		if group := seen[t.Name]; group != nil {
+			tag.Description = cmp.Or(tag.Description, group.Description)
+			if tag.ExternalDocs == nil {
+				tag.ExternalDocs = group.ExternalDocs
+			}
+		}
+		tags = append(tags, tag)
 	}
 	names := make([]string, 0, len(seen))
 	for name := range seen {
-		names = append(names, name)
+		if !declared[name] {
+			names = append(names, name)
+		}
 	}
 	sort.Strings(names)
-	tags := make(openapi3.Tags, 0, len(names))
 	for _, name := range names {
 		tags = append(tags, seen[name])
 	}
@@ -1377,6 +1647,11 @@ func (o *Okapi) getOrCreateSchemaComponent(schema *openapi3.SchemaRef,
 		return schema
 	}
 
+	// Built-in error types share Okapi-managed components
+	if name, ok := o.errorComponentName(schema); ok {
+		return o.addErrorComponent(name, registry, components)
+	}
+
 	// Only create components for object schemas (structs)
 	if schema.Value.Type == nil || !schema.Value.Type.Is("object") || len(schema.Value.Properties) == 0 {
 		return schema
@@ -1610,6 +1885,20 @@ func typeToSchemaWithInfo(t reflect.Type) *openapi3.SchemaRef {
 
 // structToSchemaWithInfo converts a struct type to an OpenAPI schema with proper naming
 func structToSchemaWithInfo(t reflect.Type) *openapi3.SchemaRef {
+	if t == decimalType {
+		return decimalSchema()
+	}
+	// Null[T] is the schema of T, nullable
+	if elem, ok := nullElemType(t); ok {
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		ref := typeToSchemaWithInfo(elem)
+		if ref.Value != nil {
+			ref.Value.Nullable = true
+		}
+		return ref
+	}
 	// Handle time.Time
 	if t == reflect.TypeOf(time.Time{}) {
 		schema := openapi3.NewStringSchema()
@@ -1636,6 +1925,11 @@ func structToSchemaWithInfo(t reflect.Type) *openapi3.SchemaRef {
 			continue
 		}
 
+		// The XMLName field names the struct's XML element
+		if field.Type == xmlNameType {
+			schema.XML = xmlSchema(field, "")
+		}
+
 		// Handle embedded (anonymous) fields
 		if field.Anonymous {
 			embeddedType := field.Type
@@ -1705,10 +1999,15 @@ func structToSchemaWithInfo(t reflect.Type) *openapi3.SchemaRef {
 			fieldSchema.Value.Deprecated = true
 		}
 
+		if x := xmlSchema(field, jsonName); x != nil && field.Type != xmlNameType {
+			fieldSchema.Value.XML = x
+		}
+
 		schema.WithProperty(jsonName, fieldSchema.Value)
 
-		// Required, check both the required tag and standard logic
-		if isRequiredFieldWithTag(field) {
+		// Required, check both the required tag and standard logic.
+		// Scoped fields are missing from responses to other roles.
+		if isRequiredFieldWithTag(field) && field.Tag.Get(tagScope) == "" {
 			required = append(required, jsonName)
 		}
 	}
@@ -1770,16 +2069,28 @@ func applyValidationTags(schema *openapi3.Schema, tag reflect.StructTag) {
 		schema.Description = desc
 	}
 
-	applyStringSchemaTags(schema, tag)
-	applyNumericSchemaTags(schema, tag)
+	// The binder checks enum, pattern and format on each element of a slice,
+	// so they describe the items of an array.
+	value := schema
+	if schema.Type.Is(openapi3.TypeArray) && schema.Items != nil && schema.Items.Value != nil {
+		value = schema.Items.Value
+	}
+	applyStringSchemaTags(value, tag)
+	switch {
+	case schema.Type.Is(openapi3.TypeArray), schema.Type.Is(openapi3.TypeObject):
+		// min and max bound the length of slices and maps
+		applyLengthSchemaTags(schema, tag)
+	case !schema.Type.Is(openapi3.TypeString):
+		applyNumericSchemaTags(schema, tag)
+	}
 	applyArraySchemaTags(schema, tag)
 
 	// Enum validation
 	if enum := tag.Get(tagEnum); enum != "" {
 		values := strings.Split(enum, ",")
-		schema.Enum = make([]interface{}, len(values))
+		value.Enum = make([]interface{}, len(values))
 		for i, v := range values {
-			schema.Enum[i] = strings.TrimSpace(v)
+			value.Enum[i] = enumSchemaValue(value, strings.TrimSpace(v))
 		}
 	}
 	// Example
@@ -1816,6 +2127,53 @@ func applyStringSchemaTags(schema *openapi3.Schema, tag reflect.StructTag) {
 	}
 }
 
+// applyLengthSchemaTags maps min and max on a slice or map to its item or
+// property count, as the binder checks them.
+func applyLengthSchemaTags(schema *openapi3.Schema, tag reflect.StructTag) {
+	isArray := schema.Type.Is(openapi3.TypeArray)
+	if !isArray && schema.AdditionalProperties.Schema == nil {
+		return
+	}
+	if minTag := tag.Get(tagMin); minTag != "" {
+		if val, err := strconv.ParseUint(minTag, 10, 64); err == nil {
+			if isArray {
+				schema.MinItems = val
+			} else {
+				schema.MinProps = val
+			}
+		}
+	}
+	if maxTag := tag.Get(tagMax); maxTag != "" {
+		if val, err := strconv.ParseUint(maxTag, 10, 64); err == nil {
+			if isArray {
+				schema.MaxItems = ptr(val)
+			} else {
+				schema.MaxProps = ptr(val)
+			}
+		}
+	}
+}
+
+// enumSchemaValue returns an enum value typed for the schema, so the values
+// of a numeric or boolean field are not documented as strings.
+func enumSchemaValue(schema *openapi3.Schema, v string) any {
+	switch {
+	case schema.Type.Is(openapi3.TypeInteger):
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case schema.Type.Is(openapi3.TypeNumber):
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	case schema.Type.Is(openapi3.TypeBoolean):
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return v
+}
+
 // applyNumericSchemaTags applies min, max, exclusive bounds, and multipleOf.
 func applyNumericSchemaTags(schema *openapi3.Schema, tag reflect.StructTag) {
 	if maxTag := tag.Get(tagMax); maxTag != "" {
@@ -2142,14 +2500,28 @@ func generateParamDescription(name, typ string) string {
 	}
 }
 
+// decimalSchema returns the schema of a Decimal: a string in decimal notation,
+// so clients do not read it as a float.
+func decimalSchema() *openapi3.SchemaRef {
+	schema := openapi3.NewStringSchema()
+	schema.Format = constDecimal
+	schema.Pattern = decimalPattern
+	schema.Example = "12.30"
+	return openapi3.NewSchemaRef("", schema)
+}
+
 func getSchemaForType(typ string) *openapi3.SchemaRef {
 	switch strings.ToLower(typ) {
 	case "string":
 		return openapi3.NewSchemaRef("", openapi3.NewStringSchema())
-	case "int", "integer":
+	case "int", "integer", "int8", "int16", "int32":
 		return openapi3.NewSchemaRef("", openapi3.NewInt32Schema())
 	case "int64":
 		return openapi3.NewSchemaRef("", openapi3.NewInt64Schema())
+	case "uint", "uint8", "uint16", "uint32":
+		return openapi3.NewSchemaRef("", openapi3.NewInt32Schema().WithMin(0))
+	case "uint64":
+		return openapi3.NewSchemaRef("", openapi3.NewInt64Schema().WithMin(0))
 	case "float", "float32":
 		schema := openapi3.NewFloat64Schema()
 		schema.Format = "float"
@@ -2170,6 +2542,8 @@ func getSchemaForType(typ string) *openapi3.SchemaRef {
 		schema := openapi3.NewStringSchema()
 		schema.Format = constDateTime
 		return openapi3.NewSchemaRef("", schema)
+	case constDecimal:
+		return decimalSchema()
 	default:
 		return openapi3.NewSchemaRef("", openapi3.NewStringSchema())
 	}
@@ -2255,6 +2629,41 @@ func extractFieldInfo(field reflect.StructField) fieldInfo {
 	}
 }
 
+// parameterSchema returns the schema of a parameter bound from field, with
+// the constraints of its validation tags.
+func parameterSchema(field reflect.StructField) *openapi3.SchemaRef {
+	ref := parameterTypeSchema(field.Type)
+	applyValidationTags(ref.Value, field.Tag)
+	// The description belongs to the parameter
+	ref.Value.Description = ""
+	return ref
+}
+
+// parameterTypeSchema returns the schema of a parameter of type t: scalars by
+// type name (so uuid.UUID stays a uuid string), slices as arrays.
+func parameterTypeSchema(t reflect.Type) *openapi3.SchemaRef {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if elem, ok := nullElemType(t); ok {
+		return parameterTypeSchema(elem)
+	}
+	switch {
+	case t == decimalType, t == reflect.TypeOf(time.Time{}):
+		return typeToSchemaWithInfo(t)
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		schema := openapi3.NewArraySchema()
+		schema.Items = parameterTypeSchema(t.Elem())
+		return openapi3.NewSchemaRef("", schema)
+	}
+	name := t.Name()
+	if t.PkgPath() != "" && !strings.EqualFold(name, constUUID) {
+		// Named types, e.g. type Status string, by their underlying kind
+		name = t.Kind().String()
+	}
+	return getSchemaForType(name)
+}
+
 // createParameter creates an OpenAPI parameter
 func createParameter(name, location string, info fieldInfo) *openapi3.ParameterRef {
 	return &openapi3.ParameterRef{
@@ -2262,7 +2671,7 @@ func createParameter(name, location string, info fieldInfo) *openapi3.ParameterR
 			Name:        name,
 			In:          location,
 			Required:    info.required,
-			Schema:      getSchemaForType(info.field.Type.Name()),
+			Schema:      parameterSchema(info.field),
 			Description: info.description,
 		},
 	}
@@ -2275,7 +2684,7 @@ func createHeader(name string, info fieldInfo) *openapi3.HeaderRef {
 			Parameter: openapi3.Parameter{
 				Name:        name,
 				Required:    info.required,
-				Schema:      getSchemaForType(info.field.Type.Name()),
+				Schema:      parameterSchema(info.field),
 				Description: info.description,
 			},
 		},
@@ -2332,25 +2741,39 @@ func (r *Route) processField(info fieldInfo, isRequest bool) bool {
 		}
 	}
 
-	// Body field
-	if sf.Tag.Get(tagJSON) == bodyValue || sf.Name == bodyField {
-		r.processBodyField(sf, isRequest)
+	// Body field, response bodies are documented by generateResponseSchema
+	// under the response status.
+	if isBodyField(sf) {
+		if isRequest {
+			r.request = bodyFieldSchema(sf)
+		}
 		return true
 	}
 
 	return false
 }
 
-// processBodyField processes a body field
-func (r *Route) processBodyField(field reflect.StructField, isRequest bool) {
+// isBodyField reports whether the struct field carries the request or response body
+func isBodyField(field reflect.StructField) bool {
+	return field.Tag.Get(tagJSON) == bodyValue || field.Name == bodyField
+}
+
+// bodyFieldSchema generates the schema of a body field
+func bodyFieldSchema(field reflect.StructField) *openapi3.SchemaRef {
 	bodyPtr := reflect.New(field.Type)
-	schema := reflectToSchemaWithInfo(bodyPtr.Interface()).Schema
+	return reflectToSchemaWithInfo(bodyPtr.Interface()).Schema
+}
 
-	if isRequest {
-		r.request = schema
-	} else {
-		r.responses[defaultStatus] = schema
+// hasResponseBindings reports whether t declares response headers or cookies,
+// meaning it describes a whole response rather than a body.
+func hasResponseBindings(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Tag.Get(tagHeader) != "" || sf.Tag.Get(tagCookie) != "" {
+			return true
+		}
 	}
+	return false
 }
 
 // processFields processes all fields in a struct
@@ -2375,17 +2798,75 @@ func getResponseStatus(v reflect.Value) int {
 	return defaultStatus
 }
 
-func (r *Route) generateResponseSchema(input any) {
+// generateResponseSchema documents an output struct: header-tagged fields become
+// response headers and the Body field the response body. A zero status uses the
+// struct's Status field, falling back to 200.
+func (r *Route) generateResponseSchema(input any, status int) {
 	v := normalizeToStructPointer(input, "response")
 	t := v.Type()
-	status := getResponseStatus(v)
+	if status == 0 {
+		status = getResponseStatus(v)
+	}
 
 	hasExplicitBinding := r.processFields(v, t, false)
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); isBodyField(sf) {
+			r.responses[status] = bodyFieldSchema(sf)
+			r.markProtoResponse(status, sf.Type)
+		}
+	}
 
 	// Fallback: if no explicit binding, use whole struct as body
 	if !hasExplicitBinding {
 		r.responses[status] = reflectToSchemaWithInfo(input).Schema
+		r.markProtoResponse(status, t)
+	}
+}
+
+// operationResponse returns the response of op for status, adding an empty one
+// when the route documented no body for it.
+func (r *Route) operationResponse(op *openapi3.Operation, status int) *openapi3.Response {
+	key := strconv.Itoa(status)
+	if resp := op.Responses.Value(key); resp != nil {
+		return resp.Value
+	}
+	resp := &openapi3.Response{
+		Description: ptr(http.StatusText(status)),
+		Content:     openapi3.Content{},
+		Headers:     r.responseHeaders,
+	}
+	op.Responses.Set(key, &openapi3.ResponseRef{Value: resp})
+	return resp
+}
+
+// markProtoResponse records that the response for status can also be sent as
+// protobuf when its body type is a protobuf message.
+func (r *Route) markProtoResponse(status int, t reflect.Type) {
+	if t.Kind() != reflect.Ptr {
+		t = reflect.PointerTo(t)
+	}
+	if !t.Implements(reflect.TypeOf((*proto.Message)(nil)).Elem()) {
+		return
+	}
+	if r.protoResponses == nil {
+		r.protoResponses = make(map[int]bool)
+	}
+	r.protoResponses[status] = true
+}
+
+// docResponse documents v as the response for status. Output structs declaring
+// headers or cookies are documented like WithOutput; anything else is the body.
+func (r *Route) docResponse(status int, v any) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && hasResponseBindings(t) {
+		r.generateResponseSchema(v, status)
+		return
 	}
+	r.responses[status] = reflectToSchemaWithInfo(v).Schema
+	r.markProtoResponse(status, t)
 }
 
 func (r *Route) generateRequestSchema(input any) {
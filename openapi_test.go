@@ -36,6 +36,7 @@ import (
 	"github.com/jkaninda/okapi/okapitest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type input struct {
@@ -536,3 +537,247 @@ func TestOpenAPIValidationKeywords(t *testing.T) {
 	validateOpenAPIDoc(t, spec30)
 	validateOpenAPIDoc(t, spec31)
 }
+
+type versionedBooks struct {
+	Version string `header:"X-Version" description:"API version"`
+	Status  int
+	Body    []Book
+}
+
+func TestOutputHeadersDocumented(t *testing.T) {
+	o := New()
+	o.Get("/with-output", anyHandler).WithOutput(&versionedBooks{})
+	o.Get("/doc-response", anyHandler, DocResponse(&versionedBooks{}))
+	o.Post("/created", anyHandler, DocResponse(201, versionedBooks{}))
+	o.Get("/status", anyHandler).WithOutput(&versionedBooks{Status: 202})
+	o.buildOpenAPISpec()
+
+	for _, path := range []string{"/with-output", "/doc-response", "/created", "/status"} {
+		item := o.openapiSpec.Paths.Find(path)
+		require.NotNil(t, item, path)
+		op := item.GetOperation(http.MethodGet)
+		if op == nil {
+			op = item.Post
+		}
+		status := map[string]int{"/created": 201, "/status": 202}[path]
+		if status == 0 {
+			status = 200
+		}
+		resp := op.Responses.Status(status)
+		require.NotNil(t, resp, path)
+
+		header := resp.Value.Headers["X-Version"]
+		require.NotNil(t, header, path)
+		assert.Equal(t, "API version", header.Value.Description)
+		assert.True(t, header.Value.Schema.Value.Type.Is(openapi3.TypeString))
+
+		// The Body field, not the whole struct, is the documented body.
+		schema := resp.Value.Content.Get("application/json").Schema.Value
+		assert.True(t, schema.Type.Is(openapi3.TypeArray), path)
+	}
+}
+
+func TestProtoBufResponseDocumented(t *testing.T) {
+	o := New()
+	o.Get("/greeting", anyHandler).WithOutput(&wrapperspb.StringValue{})
+	o.Get("/books", anyHandler, DocResponse(Book{}))
+	o.buildOpenAPISpec()
+
+	content := o.openapiSpec.Paths.Find("/greeting").Get.Responses.Status(200).Value.Content
+	require.NotNil(t, content.Get(constJSON))
+	proto := content.Get(constPROTOBUF)
+	require.NotNil(t, proto)
+	assert.Equal(t, "binary", proto.Schema.Value.Format)
+
+	books := o.openapiSpec.Paths.Find("/books").Get.Responses.Status(200).Value.Content
+	assert.Nil(t, books.Get(constPROTOBUF))
+}
+
+func TestOpenAPIServers(t *testing.T) {
+	servers := Servers{
+		{
+			URL:         "https://{region}.api.example.com",
+			Description: "Production",
+			Variables: map[string]ServerVariable{
+				"region": {Enum: []string{"eu", "us"}, Default: "eu", Description: "Data region"},
+			},
+		},
+		{URL: "http://localhost:9000"},
+	}
+
+	t.Run("variables", func(t *testing.T) {
+		o := New()
+		o.WithOpenAPIDocs(OpenAPI{Servers: servers})
+		spec := o.openapiSpec
+		require.Len(t, spec.Servers, 2)
+		region := spec.Servers[0].Variables["region"]
+		require.NotNil(t, region)
+		assert.Equal(t, []string{"eu", "us"}, region.Enum)
+		assert.Equal(t, "eu", region.Default)
+		require.NoError(t, spec.Servers[0].Validate(context.Background()))
+		assert.Equal(t, "https://{region}.api.example.com", o.openapiSpec31.Servers[0].URL)
+	})
+
+	t.Run("local server in debug", func(t *testing.T) {
+		o := New(WithDebug(), WithPort(9000))
+		o.WithOpenAPIDocs(OpenAPI{Servers: servers, LocalServer: true})
+		urls := []string{}
+		for _, srv := range o.openapiSpec.Servers {
+			urls = append(urls, srv.URL)
+		}
+		assert.Equal(t, []string{"http://localhost:9000", "https://{region}.api.example.com"}, urls)
+
+		o = New(WithDebug(), WithPort(9000))
+		o.WithOpenAPIDocs(OpenAPI{LocalServer: true})
+		require.Len(t, o.openapiSpec.Servers, 1)
+		assert.Equal(t, "http://localhost:9000", o.openapiSpec.Servers[0].URL)
+	})
+
+	t.Run("local server outside debug", func(t *testing.T) {
+		o := New(WithPort(9000))
+		o.WithOpenAPIDocs(OpenAPI{Servers: servers, LocalServer: true})
+		assert.Len(t, o.openapiSpec.Servers, 2)
+	})
+}
+
+func TestOpenAPITags(t *testing.T) {
+	o := New().WithOpenAPITags([]TagInfo{
+		{Name: "users", Description: "User accounts"},
+		{Name: "books", ExternalDocs: &ExternalDocs{URL: "https://example.com/books"}},
+		{Name: "unused", Description: "No route uses it"},
+	})
+	o.Group("/books").WithTagInfo(GroupTag{Name: "books", Description: "Books catalog"}).Get("", helloHandler)
+	o.Group("/authors").WithTagInfo(GroupTag{Name: "authors", Description: "Authors"}).Get("", helloHandler)
+	o.Group("/admin").WithTagInfo(GroupTag{Name: "admin"}).Get("", helloHandler)
+	o.Get("/users", helloHandler, DocTags("users"))
+
+	o.buildOpenAPISpec()
+	var names []string
+	for _, tag := range o.openapiSpec.Tags {
+		names = append(names, tag.Name)
+	}
+	// Declared tags first, in order, then the other tags sorted by name.
+	assert.Equal(t, []string{"users", "books", "admin", "authors"}, names)
+
+	books := o.openapiSpec.Tags.Get("books")
+	if assert.NotNil(t, books) {
+		assert.Equal(t, "Books catalog", books.Description, "group description fills the declared tag")
+		if assert.NotNil(t, books.ExternalDocs) {
+			assert.Equal(t, "https://example.com/books", books.ExternalDocs.URL)
+		}
+	}
+	assert.Equal(t, "User accounts", o.openapiSpec.Tags.Get("users").Description)
+
+	o.WithOpenAPIDocs(OpenAPI{Tags: []TagInfo{{Name: "authors", Description: "Book authors"}}})
+	assert.Equal(t, "authors", o.openapiSpec.Tags[0].Name)
+	assert.Equal(t, "Book authors", o.openapiSpec.Tags[0].Description)
+}
+
+func TestOpenAPIErrorComponents(t *testing.T) {
+	o := New().WithOpenAPIDocs(OpenAPI{
+		Title:   "Errors",
+		Version: "1.0.0",
+		License: License{Name: "MIT"},
+		Servers: Servers{{URL: "http://localhost:8080"}},
+	})
+	o.Post("/books", anyHandler,
+		DocResponse(http.StatusBadRequest, ErrorResponse{}),
+		DocResponse(http.StatusUnprocessableEntity, ValidationErrorResponse{}),
+		DocResponse(http.StatusInternalServerError, ProblemDetail{}),
+	)
+	o.Get("/books/{id}", anyHandler, DocResponse(http.StatusNotFound, &ErrorResponse{}))
+
+	o.buildOpenAPISpec()
+	schemas := o.openapiSpec.Components.Schemas
+	for _, name := range []string{"ErrorResponse", "ValidationError", "ValidationErrorResponse", "ProblemDetail"} {
+		assert.Contains(t, schemas, name)
+	}
+	assert.Len(t, schemas, 4, "each error type is registered once")
+
+	errs := schemas["ValidationErrorResponse"].Value.Properties["errors"]
+	assert.Equal(t, "#/components/schemas/ValidationError", errs.Value.Items.Ref)
+	assert.True(t, *schemas["ProblemDetail"].Value.AdditionalProperties.Has)
+
+	post := o.openapiSpec.Paths.Find("/books").Post
+	assert.Equal(t, "#/components/schemas/ErrorResponse", post.Responses.Value("400").Value.Content.Get(constJSON).Schema.Ref)
+	assert.Equal(t, "#/components/schemas/ValidationErrorResponse", post.Responses.Value("422").Value.Content.Get(constJSON).Schema.Ref)
+	problem := post.Responses.Value("500").Value.Content.Get(constJSONProblem)
+	if assert.NotNil(t, problem, "a documented 500 is kept") {
+		assert.Equal(t, "#/components/schemas/ProblemDetail", problem.Schema.Ref)
+	}
+
+	get := o.openapiSpec.Paths.Find("/books/{id}").Get
+	assert.Equal(t, "#/components/schemas/ErrorResponse", get.Responses.Value("404").Value.Content.Get(constJSON).Schema.Ref)
+	assert.Empty(t, get.Responses.Value("500").Value.Content, "default 500 has no body")
+	validateOpenAPIDoc(t, o.openapiSpec)
+}
+
+type constrainedQuery struct {
+	Status string   `query:"status" enum:"draft,published" description:"Book status"`
+	Limit  int      `query:"limit" min:"1" max:"100"`
+	Tags   []string `query:"tags" maxItems:"5" uniqueItems:"true" pattern:"^[a-z]+$"`
+	Since  string   `query:"since" format:"date"`
+	Code   uint     `header:"X-Code" enum:"1,2,3"`
+}
+
+type constrainedBody struct {
+	Title    string         `json:"title" minLength:"2" maxLength:"80" min:"3"`
+	Priority int            `json:"priority" enum:"1,2,3"`
+	Ratio    float64        `json:"ratio" exclusiveMin:"0" max:"1"`
+	Emails   []string       `json:"emails" min:"1" max:"3" format:"email"`
+	Labels   map[string]int `json:"labels" max:"10"`
+}
+
+func TestOpenAPIValidationTags(t *testing.T) {
+	o := New()
+	o.WithOpenAPIDocs(OpenAPI{Title: "Tags", Version: "1.0.0", License: License{Name: "MIT"}, Servers: Servers{{URL: "http://localhost:8080"}}})
+	o.Get("/books", anyHandler, Request(&constrainedQuery{}))
+	o.Post("/books", anyHandler, DocRequestBody(&constrainedBody{}))
+	o.buildOpenAPISpec()
+
+	params := map[string]*openapi3.Parameter{}
+	for _, p := range o.openapiSpec.Paths.Find("/books").Get.Parameters {
+		params[p.Value.Name] = p.Value
+	}
+	status := params["status"]
+	require.NotNil(t, status)
+	assert.Equal(t, "Book status", status.Description)
+	assert.Equal(t, []any{"draft", "published"}, status.Schema.Value.Enum)
+
+	limit := params["limit"].Schema.Value
+	assert.True(t, limit.Type.Is(openapi3.TypeInteger))
+	assert.Equal(t, ptr(float64(1)), limit.Min)
+	assert.Equal(t, ptr(float64(100)), limit.Max)
+
+	tags := params["tags"].Schema.Value
+	assert.True(t, tags.Type.Is(openapi3.TypeArray))
+	assert.Equal(t, ptr(uint64(5)), tags.MaxItems)
+	assert.True(t, tags.UniqueItems)
+	assert.Equal(t, "^[a-z]+$", tags.Items.Value.Pattern)
+
+	assert.Equal(t, "date", params["since"].Schema.Value.Format)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, params["X-Code"].Schema.Value.Enum)
+
+	body := o.openapiSpec.Components.Schemas["constrainedBody"].Value
+	require.NotNil(t, body)
+	title := body.Properties["title"].Value
+	assert.Equal(t, uint64(2), title.MinLength)
+	assert.Equal(t, ptr(uint64(80)), title.MaxLength)
+	assert.Nil(t, title.Min, "min does not apply to strings")
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, body.Properties["priority"].Value.Enum)
+
+	ratio := body.Properties["ratio"].Value
+	assert.Equal(t, ptr(float64(0)), ratio.Min)
+	assert.Equal(t, ptr(true), ratio.ExclusiveMin.Bool)
+
+	emails := body.Properties["emails"].Value
+	assert.Equal(t, uint64(1), emails.MinItems)
+	assert.Equal(t, ptr(uint64(3)), emails.MaxItems)
+	assert.Nil(t, emails.Min)
+	assert.Equal(t, "email", emails.Items.Value.Format)
+
+	assert.Equal(t, ptr(uint64(10)), body.Properties["labels"].Value.MaxProps)
+
+	validateOpenAPIDoc(t, o.openapiSpec)
+	validateOpenAPIDoc(t, o.openapiSpec31)
+}
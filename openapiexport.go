@@ -0,0 +1,91 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportOpenAPISpec builds the OpenAPI document of the registered routes and
+// encodes it, without starting the server, e.g. to generate the spec in CI.
+//
+// The format is one of:
+//   - "json" or "yaml" ("yml"): the OpenAPI 3.1 document served at
+//     /openapi.json and /openapi.yaml;
+//   - "json-3.0" or "yaml-3.0": the OpenAPI 3.0 document served at
+//     /openapi-3.0.json and /openapi-3.0.yaml.
+//
+// Example:
+//
+//	spec, err := app.ExportOpenAPISpec("yaml")
+func (o *Okapi) ExportOpenAPISpec(format string) ([]byte, error) {
+	o.buildOpenAPISpec()
+	spec, encoding := o.openapiSpec31, strings.ToLower(format)
+	if base, ok := strings.CutSuffix(encoding, "-3.0"); ok {
+		spec, encoding = o.openapiSpec, base
+	}
+	switch encoding {
+	case "json":
+		return json.MarshalIndent(spec, "", "  ")
+	case "yaml", "yml":
+		return yaml.Marshal(spec)
+	}
+	return nil, fmt.Errorf("okapi: unsupported OpenAPI spec format %q (supported: json, yaml, json-3.0, yaml-3.0)", format)
+}
+
+// WriteOpenAPISpec writes the OpenAPI document to path, creating its
+// directory if needed. An empty format is taken from the file extension:
+// YAML for .yaml and .yml, JSON otherwise. See ExportOpenAPISpec for the
+// formats.
+//
+// Example:
+//
+//	if err := app.WriteOpenAPISpec("docs/openapi.yaml", ""); err != nil {
+//	    log.Fatal(err)
+//	}
+func (o *Okapi) WriteOpenAPISpec(path, format string) error {
+	if format == "" {
+		format = "json"
+		if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+	data, err := o.ExportOpenAPISpec(format)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("okapi: create spec directory: %w", err)
+		}
+	}
+	return os.WriteFile(path, data, 0o644)
+}
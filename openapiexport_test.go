@@ -0,0 +1,71 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportOpenAPISpec(t *testing.T) {
+	o := New()
+	o.Get("/books/:id", anyHandler, DocSummary("Get book"), DocResponse(Book{}))
+
+	loader := openapi3.NewLoader()
+	for format, version := range map[string]string{
+		"json":     "3.1.0",
+		"yaml":     "3.1.0",
+		"YML":      "3.1.0",
+		"json-3.0": "3.0.3",
+		"yaml-3.0": "3.0.3",
+	} {
+		data, err := o.ExportOpenAPISpec(format)
+		require.NoError(t, err, format)
+		doc, err := loader.LoadFromData(data)
+		require.NoError(t, err, format)
+		assert.Equal(t, version, doc.OpenAPI, format)
+		assert.NotNil(t, doc.Paths.Find("/books/{id}"), format)
+	}
+	_, err := o.ExportOpenAPISpec("xml")
+	assert.ErrorContains(t, err, `"xml"`)
+
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "docs", "openapi.yml")
+	require.NoError(t, o.WriteOpenAPISpec(yamlPath, ""))
+	data, err := os.ReadFile(yamlPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "openapi: 3.1.0")
+
+	jsonPath := filepath.Join(dir, "spec.txt")
+	require.NoError(t, o.WriteOpenAPISpec(jsonPath, "json-3.0"))
+	data, err = os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"openapi": "3.0.3"`)
+}
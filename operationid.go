@@ -0,0 +1,125 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+
+	goutils "github.com/jkaninda/go-utils"
+)
+
+// OperationIDFunc names the OpenAPI operation of a route declaring no OperationId,
+// see OpenAPI.OperationIDStrategy.
+type OperationIDFunc func(r *Route) string
+
+// DefaultOperationID is the default OperationIDFunc. It derives the operationId
+// from, in order:
+//   - the summary, e.g. "Create a book" gives "create-a-book";
+//   - the route name, set with WithName or taken from the handler function, e.g. "listBooks";
+//   - the method and path of routes served by anonymous functions, e.g.
+//     GET /books/{id} gives "get-books-id".
+func DefaultOperationID(r *Route) string {
+	if r.summary != "" {
+		return goutils.Slug(r.summary)
+	}
+	if r.Name != "" && !isAnonymousName(r.Name) {
+		return r.Name
+	}
+	return goutils.Slug(r.Method + " " + r.Path)
+}
+
+// isAnonymousName reports whether name is the name of an anonymous function,
+// such as func1, or a placeholder for an unknown handler.
+func isAnonymousName(name string) bool {
+	if name == "unknown" {
+		return true
+	}
+	digits := strings.TrimPrefix(name, "func")
+	_, err := strconv.Atoi(digits)
+	return err == nil
+}
+
+// operationIDs assigns the operationIds of a document, keeping them unique as
+// code generators require.
+type operationIDs struct {
+	o        *Okapi
+	used     map[string]bool
+	explicit map[string]int
+}
+
+// newOperationIDs reserves the operationIds declared by routes.
+func (o *Okapi) newOperationIDs(routes []*Route) *operationIDs {
+	ids := &operationIDs{o: o, used: make(map[string]bool), explicit: make(map[string]int)}
+	for _, r := range routes {
+		if r.operationId != "" {
+			ids.explicit[r.operationId]++
+		}
+	}
+	return ids
+}
+
+// assign returns the operationId of r: the declared one, otherwise the one
+// named by the strategy. Duplicates get a numeric suffix, e.g. "list-books-2";
+// duplicated declared operationIds are also logged.
+func (ids *operationIDs) assign(r *Route) string {
+	id := r.operationId
+	if id == "" {
+		strategy := ids.o.openAPI.OperationIDStrategy
+		if strategy == nil {
+			strategy = DefaultOperationID
+		}
+		id = strategy(r)
+		if id == "" {
+			return ""
+		}
+		if ids.explicit[id] > 0 {
+			// Declared operationIds win over generated ones.
+			id = ids.next(id)
+		}
+	}
+	if ids.used[id] {
+		unique := ids.next(id)
+		if id == r.operationId {
+			ids.o.logger.Warn("[okapi] Duplicate OpenAPI operationId, renamed",
+				slog.String("operationId", id), slog.String("renamed", unique),
+				slog.String("method", r.Method), slog.String("path", r.Path))
+		}
+		id = unique
+	}
+	ids.used[id] = true
+	return id
+}
+
+// next returns the first free id made of id and a numeric suffix.
+func (ids *operationIDs) next(id string) string {
+	for n := 2; ; n++ {
+		candidate := id + "-" + strconv.Itoa(n)
+		if !ids.used[candidate] && ids.explicit[candidate] == 0 {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func listBooksHandler(c *Context) error { return c.OK(nil) }
+
+func TestOperationIDs(t *testing.T) {
+	var logs bytes.Buffer
+	o := New(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	o.Get("/books", listBooksHandler)
+	o.Get("/books/{id}", func(c *Context) error { return nil })
+	o.Post("/books", anyHandler, DocSummary("Create a book"))
+	o.Put("/books/{id}", anyHandler, OperationId("get-books-id"))
+	o.Delete("/books/{id}", anyHandler, OperationId("get-books-id"))
+	o.Get("/authors", listBooksHandler)
+	o.buildOpenAPISpec()
+
+	operationID := func(method, path string) string {
+		return o.openapiSpec.Paths.Find(path).GetOperation(method).OperationID
+	}
+	assert.Equal(t, "listBooksHandler", operationID(http.MethodGet, "/books"))
+	assert.Equal(t, "create-a-book", operationID(http.MethodPost, "/books"))
+	assert.Equal(t, "listBooksHandler-2", operationID(http.MethodGet, "/authors"))
+	// Declared operationIds win over generated ones; declared duplicates are renamed and logged.
+	assert.Equal(t, "get-books-id", operationID(http.MethodPut, "/books/{id}"))
+	assert.Equal(t, "get-books-id-2", operationID(http.MethodGet, "/books/{id}"))
+	assert.Equal(t, "get-books-id-3", operationID(http.MethodDelete, "/books/{id}"))
+	assert.Equal(t, 1, strings.Count(logs.String(), "Duplicate OpenAPI operationId"))
+
+	// Rebuilding the document keeps the same operationIds.
+	o.buildOpenAPISpec()
+	assert.Equal(t, "get-books-id-2", operationID(http.MethodGet, "/books/{id}"))
+}
+
+func TestOperationIDStrategy(t *testing.T) {
+	o := New()
+	o.WithOpenAPIDocs(OpenAPI{
+		OperationIDStrategy: func(r *Route) string {
+			return strings.ToLower(r.Method) + "_" + strings.Trim(strings.ReplaceAll(r.Path, "/", "_"), "_")
+		},
+	})
+	o.Get("/books", listBooksHandler)
+	o.Get("/books/{id}", anyHandler, OperationId("getBook"))
+	o.buildOpenAPISpec()
+
+	assert.Equal(t, "get_books", o.openapiSpec.Paths.Find("/books").Get.OperationID)
+	assert.Equal(t, "getBook", o.openapiSpec.Paths.Find("/books/{id}").Get.OperationID)
+}
@@ -0,0 +1,169 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParamInt returns the path parameter key as an int. A missing parameter yields
+// a *RequiredFieldError and an invalid one a *FieldError, ready to be passed to
+// an Abort method:
+//
+//	id, err := c.ParamInt("id")
+//	if err != nil {
+//		return c.AbortBadRequest("Invalid book id", err)
+//	}
+func (c *Context) ParamInt(key string) (int, error) {
+	return typedValue(key, c.Param(key), "integer", strconv.Atoi)
+}
+
+// ParamIntOr returns the path parameter key as an int, or def when it is missing or invalid.
+func (c *Context) ParamIntOr(key string, def int) int {
+	if v, err := c.ParamInt(key); err == nil {
+		return v
+	}
+	return def
+}
+
+// ParamInt64 returns the path parameter key as an int64, see ParamInt.
+func (c *Context) ParamInt64(key string) (int64, error) {
+	return typedValue(key, c.Param(key), "integer", parseInt64)
+}
+
+// ParamUUID returns the path parameter key as a UUID, see ParamInt.
+func (c *Context) ParamUUID(key string) (uuid.UUID, error) {
+	return typedValue(key, c.Param(key), "UUID", uuid.Parse)
+}
+
+// ParamTime parses the path parameter key with layout, e.g. time.DateOnly, see ParamInt.
+func (c *Context) ParamTime(key, layout string) (time.Time, error) {
+	return typedValue(key, c.Param(key), "time", timeParser(layout))
+}
+
+// QueryInt returns the query parameter key as an int. A missing parameter yields
+// a *RequiredFieldError and an invalid one a *FieldError.
+func (c *Context) QueryInt(key string) (int, error) {
+	return typedValue(key, c.Query(key), "integer", strconv.Atoi)
+}
+
+// QueryIntOr returns the query parameter key as an int, or def when it is missing or invalid:
+//
+//	page := c.QueryIntOr("page", 1)
+func (c *Context) QueryIntOr(key string, def int) int {
+	if v, err := c.QueryInt(key); err == nil {
+		return v
+	}
+	return def
+}
+
+// QueryInt64 returns the query parameter key as an int64, see QueryInt.
+func (c *Context) QueryInt64(key string) (int64, error) {
+	return typedValue(key, c.Query(key), "integer", parseInt64)
+}
+
+// QueryInt64Or returns the query parameter key as an int64, or def when it is missing or invalid.
+func (c *Context) QueryInt64Or(key string, def int64) int64 {
+	if v, err := c.QueryInt64(key); err == nil {
+		return v
+	}
+	return def
+}
+
+// QueryFloat returns the query parameter key as a float64, see QueryInt.
+func (c *Context) QueryFloat(key string) (float64, error) {
+	return typedValue(key, c.Query(key), "number", parseFloat64)
+}
+
+// QueryFloatOr returns the query parameter key as a float64, or def when it is missing or invalid.
+func (c *Context) QueryFloatOr(key string, def float64) float64 {
+	if v, err := c.QueryFloat(key); err == nil {
+		return v
+	}
+	return def
+}
+
+// QueryBool returns the query parameter key as a bool, accepting the values
+// understood by strconv.ParseBool ("1", "true", "false", ...), see QueryInt.
+func (c *Context) QueryBool(key string) (bool, error) {
+	return typedValue(key, c.Query(key), "boolean", strconv.ParseBool)
+}
+
+// QueryBoolOr returns the query parameter key as a bool, or def when it is missing or invalid.
+func (c *Context) QueryBoolOr(key string, def bool) bool {
+	if v, err := c.QueryBool(key); err == nil {
+		return v
+	}
+	return def
+}
+
+// QueryUUID returns the query parameter key as a UUID, see QueryInt.
+func (c *Context) QueryUUID(key string) (uuid.UUID, error) {
+	return typedValue(key, c.Query(key), "UUID", uuid.Parse)
+}
+
+// QueryTime parses the query parameter key with layout, e.g. time.RFC3339, see QueryInt.
+func (c *Context) QueryTime(key, layout string) (time.Time, error) {
+	return typedValue(key, c.Query(key), "time", timeParser(layout))
+}
+
+// QueryTimeOr parses the query parameter key with layout, or returns def when it is missing or invalid.
+func (c *Context) QueryTimeOr(key, layout string, def time.Time) time.Time {
+	if v, err := c.QueryTime(key, layout); err == nil {
+		return v
+	}
+	return def
+}
+
+// typedValue converts the raw value of the parameter key with parse.
+func typedValue[T any](key, raw, kind string, parse func(string) (T, error)) (T, error) {
+	var zero T
+	if raw == "" {
+		return zero, &RequiredFieldError{Field: key}
+	}
+	v, err := parse(raw)
+	if err != nil {
+		return zero, &FieldError{Field: key, Err: fmt.Errorf("invalid %s %q", kind, raw)}
+	}
+	return v, nil
+}
+
+func parseInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func parseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+func timeParser(layout string) func(string) (time.Time, error) {
+	return func(s string) (time.Time, error) {
+		return time.Parse(layout, s)
+	}
+}
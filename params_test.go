@@ -0,0 +1,89 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext_TypedParams(t *testing.T) {
+	id := uuid.New()
+	c, _ := NewTestContext(http.MethodGet,
+		"/?page=3&limit=abc&ratio=0.5&active=true&since=2025-01-02&ref="+id.String(), nil)
+	c.request = mux.SetURLVars(c.request, map[string]string{
+		"id": "42", "bad": "x1", "uuid": id.String(), "date": "2025-01-02",
+	})
+
+	n, err := c.ParamInt("id")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, n)
+	n64, err := c.ParamInt64("id")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), n64)
+	u, err := c.ParamUUID("uuid")
+	assert.NoError(t, err)
+	assert.Equal(t, id, u)
+	date, err := c.ParamTime("date", time.DateOnly)
+	assert.NoError(t, err)
+	assert.Equal(t, 2025, date.Year())
+
+	_, err = c.ParamInt("bad")
+	var fieldErr *FieldError
+	assert.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, `field bad: invalid integer "x1"`, err.Error())
+	_, err = c.ParamUUID("id")
+	assert.True(t, errors.As(err, &fieldErr))
+	_, err = c.ParamInt("missing")
+	var required *RequiredFieldError
+	assert.True(t, errors.As(err, &required))
+	assert.Equal(t, 7, c.ParamIntOr("bad", 7))
+	assert.Equal(t, 42, c.ParamIntOr("id", 7))
+
+	page, err := c.QueryInt("page")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page)
+	assert.Equal(t, 20, c.QueryIntOr("limit", 20))
+	assert.Equal(t, int64(3), c.QueryInt64Or("page", 1))
+	assert.Equal(t, 0.5, c.QueryFloatOr("ratio", 1))
+	assert.True(t, c.QueryBoolOr("active", false))
+	assert.True(t, c.QueryBoolOr("missing", true))
+	_, err = c.QueryBool("limit")
+	assert.True(t, errors.As(err, &fieldErr))
+	ref, err := c.QueryUUID("ref")
+	assert.NoError(t, err)
+	assert.Equal(t, id, ref)
+	since, err := c.QueryTime("since", time.DateOnly)
+	assert.NoError(t, err)
+	assert.Equal(t, time.January, since.Month())
+	def := time.Unix(0, 0)
+	assert.Equal(t, def, c.QueryTimeOr("page", time.DateOnly, def))
+}
@@ -0,0 +1,163 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed is returned by HandlerPool.Submit once the pool is closed.
+var ErrPoolClosed = errors.New("okapi: handler pool is closed")
+
+// ErrPoolFull is returned by HandlerPool.Submit when every worker is busy and
+// the queue is full.
+var ErrPoolFull = errors.New("okapi: handler pool is full")
+
+// HandlerPool is a bounded set of goroutines running the handlers of the routes
+// attached with Route.WithWorkerPool. Expensive endpoints (image processing,
+// report generation) then compete for their own workers instead of the whole
+// server's capacity, and excess requests are rejected with a 503.
+type HandlerPool struct {
+	mu     sync.RWMutex
+	tasks  chan func()
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewHandlerPool starts a pool of workers goroutines (at least one) with a
+// queue of queueSize waiting requests. With a queue size of 0, requests are
+// rejected as soon as every worker is busy.
+//
+// Example:
+//
+//	images := okapi.NewHandlerPool(4, 16)
+//	defer images.Close()
+//	o.Post("/thumbnails", resize).WithWorkerPool(images)
+func NewHandlerPool(workers, queueSize int) *HandlerPool {
+	p := &HandlerPool{tasks: make(chan func(), max(queueSize, 0))}
+	for range max(workers, 1) {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+	return p
+}
+
+// Submit queues task without blocking. It returns ErrPoolFull when the queue is
+// full and ErrPoolClosed after Close.
+func (p *HandlerPool) Submit(task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrPoolClosed
+	}
+	select {
+	case p.tasks <- task:
+		return nil
+	default:
+		return ErrPoolFull
+	}
+}
+
+// Queued returns the number of requests waiting for a worker.
+func (p *HandlerPool) Queued() int {
+	return len(p.tasks)
+}
+
+// Close stops accepting requests and waits for the queued ones to complete.
+func (p *HandlerPool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// WithWorkerPool runs the route's handler on one of the pool's goroutines.
+// Middlewares still run on the request goroutine, which waits for the handler
+// to complete. When the pool is saturated the client gets a 503 Service
+// Unavailable with a Retry-After hint; requests canceled while queued are
+// dropped without running the handler. A panic in the handler is re-raised on
+// the request goroutine, where a recovery middleware or net/http handles it
+// instead of crashing the process.
+//
+// Example:
+//
+//	o.Post("/thumbnails", resize).WithWorkerPool(images)
+func (r *Route) WithWorkerPool(p *HandlerPool) *Route {
+	r.pool = p
+	return r
+}
+
+// WorkerPool is the RouteOption form of Route.WithWorkerPool.
+func WorkerPool(p *HandlerPool) RouteOption {
+	return func(r *Route) {
+		r.WithWorkerPool(p)
+	}
+}
+
+// wrap returns h executed on the pool.
+func (p *HandlerPool) wrap(h HandlerFunc) HandlerFunc {
+	return func(c *Context) error {
+		var (
+			err      error
+			panicked any
+			didPanic bool
+			done     = make(chan struct{})
+			ctx      = c.request.Context()
+		)
+		submitErr := p.Submit(func() {
+			defer close(done)
+			if ctx.Err() != nil {
+				err = ctx.Err()
+				return
+			}
+			defer func() {
+				if r := recover(); r != nil {
+					panicked, didPanic = r, true
+				}
+			}()
+			err = h(c)
+		})
+		if submitErr != nil {
+			c.Logger().Warn("[okapi] handler pool rejected request",
+				"method", c.request.Method, "path", c.request.URL.Path, "error", submitErr)
+			c.SetHeader("Retry-After", "1")
+			return c.AbortServiceUnavailable("Server is busy", submitErr)
+		}
+		<-done
+		if didPanic {
+			panic(panicked)
+		}
+		return err
+	}
+}
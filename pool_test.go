@@ -0,0 +1,79 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestRouteWorkerPool(t *testing.T) {
+	pool := NewHandlerPool(1, 0)
+	defer pool.Close()
+	ts := NewTestServer(t)
+	started, release := make(chan struct{}), make(chan struct{})
+
+	ts.Use(func(c *Context) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = c.AbortInternalServerError("recovered")
+			}
+		}()
+		return c.Next()
+	})
+	ts.Get("/slow", func(c *Context) error {
+		close(started)
+		<-release
+		return c.OK(M{"ok": true})
+	}).WithWorkerPool(pool)
+	ts.Get("/fast", func(c *Context) error {
+		return c.OK(M{"ok": true})
+	}, WorkerPool(pool))
+	ts.Get("/panic", func(c *Context) error {
+		panic("boom")
+	}, WorkerPool(pool))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		okapitest.GET(t, ts.BaseURL+"/slow").ExpectStatusOK()
+	}()
+	<-started
+	okapitest.GET(t, ts.BaseURL+"/fast").
+		ExpectStatus(http.StatusServiceUnavailable).
+		ExpectHeader("Retry-After", "1")
+	close(release)
+	<-done
+
+	okapitest.GET(t, ts.BaseURL+"/fast").ExpectStatusOK()
+	okapitest.GET(t, ts.BaseURL+"/panic").ExpectStatus(http.StatusInternalServerError)
+
+	pool.Close()
+	if err := pool.Submit(func() {}); err != ErrPoolClosed {
+		t.Errorf("expected ErrPoolClosed, got %v", err)
+	}
+}
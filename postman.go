@@ -0,0 +1,419 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// postmanSchemaURL identifies the Postman Collection v2.1 format, which
+// Insomnia imports as well.
+const postmanSchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type postmanCollection struct {
+	Info     postmanInfo   `json:"info"`
+	Item     []postmanItem `json:"item"`
+	Variable []postmanKV   `json:"variable,omitempty"`
+}
+
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+type postmanItem struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Item        []postmanItem   `json:"item,omitempty"`
+	Request     *postmanRequest `json:"request,omitempty"`
+}
+
+type postmanRequest struct {
+	Method      string       `json:"method"`
+	Header      []postmanKV  `json:"header"`
+	URL         postmanURL   `json:"url"`
+	Body        *postmanBody `json:"body,omitempty"`
+	Auth        *postmanAuth `json:"auth,omitempty"`
+	Description string       `json:"description,omitempty"`
+}
+
+type postmanURL struct {
+	Raw      string      `json:"raw"`
+	Host     []string    `json:"host"`
+	Path     []string    `json:"path"`
+	Query    []postmanKV `json:"query,omitempty"`
+	Variable []postmanKV `json:"variable,omitempty"`
+}
+
+type postmanKV struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+type postmanBody struct {
+	Mode       string         `json:"mode"`
+	Raw        string         `json:"raw,omitempty"`
+	URLEncoded []postmanKV    `json:"urlencoded,omitempty"`
+	FormData   []postmanKV    `json:"formdata,omitempty"`
+	Options    map[string]any `json:"options,omitempty"`
+}
+
+type postmanAuth struct {
+	Type   string      `json:"type"`
+	Bearer []postmanKV `json:"bearer,omitempty"`
+	Basic  []postmanKV `json:"basic,omitempty"`
+	APIKey []postmanKV `json:"apikey,omitempty"`
+	OAuth2 []postmanKV `json:"oauth2,omitempty"`
+}
+
+// ExportPostmanCollection writes the registered routes as a Postman Collection
+// (v2.1), which can be imported into Postman or Insomnia.
+//
+// Routes are grouped into folders by their first tag. Request bodies are
+// pre-filled with examples synthesized from their schemas, the same way the
+// mock server builds responses, and the authentication of each request is
+// derived from its security schemes. Credentials are left as collection
+// variables ({{baseUrl}}, {{bearerToken}}, {{username}}, ...) to be filled in
+// by the user.
+func (o *Okapi) ExportPostmanCollection(w io.Writer) error {
+	spec := o.OpenAPISpec()
+	if spec == nil {
+		return fmt.Errorf("OpenAPI spec is not available")
+	}
+	collection := postmanCollection{
+		Info: postmanInfo{Schema: postmanSchemaURL},
+		Item: []postmanItem{},
+	}
+	if spec.Info != nil {
+		collection.Info.Name = spec.Info.Title
+		collection.Info.Description = spec.Info.Description
+	}
+	if collection.Info.Name == "" {
+		collection.Info.Name = okapiName
+	}
+	host, port := parseAddr(cmp.Or(o.server.Addr, defaultAddr))
+	baseURL := "http://" + host + ":" + port
+	if len(spec.Servers) > 0 && spec.Servers[0].URL != "" {
+		if u := strings.TrimSuffix(spec.Servers[0].URL, "/"); strings.Contains(u, "://") {
+			baseURL = u
+		} else {
+			baseURL += u
+		}
+	}
+	vars := map[string]string{"baseUrl": baseURL}
+
+	folders := map[string]int{}
+	var paths []string
+	if spec.Paths != nil {
+		paths = spec.Paths.InMatchingOrder()
+		sort.Strings(paths)
+	}
+	for _, path := range paths {
+		item := spec.Paths.Value(path)
+		for _, method := range postmanMethods {
+			op := item.GetOperation(method)
+			if op == nil {
+				continue
+			}
+			entry := o.postmanItem(spec, method, path, item, op, vars)
+			if len(op.Tags) == 0 {
+				collection.Item = append(collection.Item, entry)
+				continue
+			}
+			idx, ok := folders[op.Tags[0]]
+			if !ok {
+				idx = len(collection.Item)
+				folders[op.Tags[0]] = idx
+				collection.Item = append(collection.Item, postmanItem{Name: op.Tags[0], Item: []postmanItem{}})
+			}
+			collection.Item[idx].Item = append(collection.Item[idx].Item, entry)
+		}
+	}
+	for _, key := range sortedKeys(vars) {
+		collection.Variable = append(collection.Variable, postmanKV{Key: key, Value: vars[key], Type: "string"})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(collection)
+}
+
+// postmanMethods lists the HTTP methods in the order requests appear within a path.
+var postmanMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodHead, http.MethodOptions,
+}
+
+// postmanItem converts an OpenAPI operation into a Postman request item.
+func (o *Okapi) postmanItem(spec *openapi3.T, method, path string, item *openapi3.PathItem, op *openapi3.Operation, vars map[string]string) postmanItem {
+	name := op.Summary
+	if name == "" {
+		name = method + " " + path
+	}
+	req := &postmanRequest{
+		Method:      method,
+		Header:      []postmanKV{},
+		Description: op.Description,
+	}
+
+	segments := []string{}
+	for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			seg = ":" + strings.TrimSuffix(strings.TrimPrefix(seg, "{"), "}")
+		}
+		segments = append(segments, seg)
+	}
+	req.URL = postmanURL{
+		Raw:  "{{baseUrl}}/" + strings.Join(segments, "/"),
+		Host: []string{"{{baseUrl}}"},
+		Path: segments,
+	}
+
+	params := append(openapi3.Parameters{}, item.Parameters...)
+	params = append(params, op.Parameters...)
+	var query []string
+	for _, ref := range params {
+		p := ref.Value
+		if p == nil {
+			continue
+		}
+		kv := postmanKV{Key: p.Name, Value: o.postmanParamValue(p), Description: p.Description}
+		switch p.In {
+		case openapi3.ParameterInPath:
+			req.URL.Variable = append(req.URL.Variable, kv)
+		case openapi3.ParameterInQuery:
+			kv.Disabled = !p.Required
+			req.URL.Query = append(req.URL.Query, kv)
+			query = append(query, p.Name+"="+kv.Value)
+		case openapi3.ParameterInHeader:
+			kv.Disabled = !p.Required
+			req.Header = append(req.Header, kv)
+		}
+	}
+	if len(query) > 0 {
+		req.URL.Raw += "?" + strings.Join(query, "&")
+	}
+
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		req.Body = o.postmanBody(op.RequestBody.Value.Content, req)
+	}
+
+	security := spec.Security
+	if op.Security != nil {
+		security = *op.Security
+	}
+	req.Auth = postmanAuthFor(spec, security, vars)
+
+	return postmanItem{Name: name, Request: req}
+}
+
+// postmanBody builds the request body from the first supported content type.
+func (o *Okapi) postmanBody(content openapi3.Content, req *postmanRequest) *postmanBody {
+	if mt := content.Get(constJSON); mt != nil {
+		raw, err := json.MarshalIndent(o.mockMediaType(mt), "", "  ")
+		if err != nil {
+			return nil
+		}
+		req.Header = append(req.Header, postmanKV{Key: "Content-Type", Value: constJSON})
+		return &postmanBody{
+			Mode:    "raw",
+			Raw:     string(raw),
+			Options: map[string]any{"raw": map[string]string{"language": "json"}},
+		}
+	}
+	if mt := content.Get(constFormURLEncoded); mt != nil {
+		req.Header = append(req.Header, postmanKV{Key: "Content-Type", Value: constFormURLEncoded})
+		return &postmanBody{Mode: "urlencoded", URLEncoded: o.postmanFields(mt, false)}
+	}
+	if mt := content.Get(constFormData); mt != nil {
+		return &postmanBody{Mode: "formdata", FormData: o.postmanFields(mt, true)}
+	}
+	for _, ct := range sortedKeys(content) {
+		if mt := content[ct]; mt != nil {
+			req.Header = append(req.Header, postmanKV{Key: "Content-Type", Value: ct})
+			return &postmanBody{Mode: "raw", Raw: postmanString(o.mockMediaType(mt))}
+		}
+	}
+	return nil
+}
+
+// postmanFields lists the properties of a form schema as form fields. Binary
+// properties become file fields when files is true.
+func (o *Okapi) postmanFields(mt *openapi3.MediaType, files bool) []postmanKV {
+	s := o.resolveSchema(mt.Schema)
+	if s == nil {
+		return nil
+	}
+	fields := make([]postmanKV, 0, len(s.Properties))
+	for _, name := range sortedKeys(s.Properties) {
+		prop := o.resolveSchema(s.Properties[name])
+		if files && prop != nil && prop.Format == "binary" {
+			fields = append(fields, postmanKV{Key: name, Type: "file"})
+			continue
+		}
+		kv := postmanKV{Key: name, Value: postmanString(o.mockValue(s.Properties[name], 0))}
+		if files {
+			kv.Type = "text"
+		}
+		fields = append(fields, kv)
+	}
+	return fields
+}
+
+// postmanParamValue returns an example value for a parameter.
+func (o *Okapi) postmanParamValue(p *openapi3.Parameter) string {
+	if p.Example != nil {
+		return postmanString(p.Example)
+	}
+	for _, name := range sortedKeys(p.Examples) {
+		if ex := p.Examples[name]; ex != nil && ex.Value != nil {
+			return postmanString(ex.Value.Value)
+		}
+	}
+	if s := o.resolveSchema(p.Schema); s != nil && (s.Example != nil || s.Default != nil || len(s.Enum) > 0) {
+		return postmanString(o.mockValue(p.Schema, 0))
+	}
+	return ""
+}
+
+// postmanString formats an example value for use in a URL, header or form field.
+func postmanString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		b, _ := json.Marshal(v)
+		return string(b)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// postmanAuthFor maps the first usable security requirement to a Postman auth
+// configuration, registering the credential variables it references. An
+// explicitly empty requirement list yields "noauth".
+func postmanAuthFor(spec *openapi3.T, security openapi3.SecurityRequirements, vars map[string]string) *postmanAuth {
+	if security == nil {
+		return nil
+	}
+	if len(security) == 0 {
+		return &postmanAuth{Type: "noauth"}
+	}
+	for _, req := range security {
+		for _, name := range sortedKeys(req) {
+			scheme := postmanBuiltinSchemes[name]
+			if spec.Components != nil {
+				if ref := spec.Components.SecuritySchemes[name]; ref != nil && ref.Value != nil {
+					scheme = ref.Value
+				}
+			}
+			if scheme == nil {
+				continue
+			}
+			if auth := postmanSchemeAuth(scheme, vars); auth != nil {
+				return auth
+			}
+		}
+	}
+	return nil
+}
+
+// postmanBuiltinSchemes describes the schemes referenced by DocBearerAuth and
+// DocBasicAuth, which are only added to the components when no custom
+// security scheme is configured.
+var postmanBuiltinSchemes = map[string]*openapi3.SecurityScheme{
+	"BearerAuth": {Type: "http", Scheme: "bearer"},
+	"BasicAuth":  {Type: "http", Scheme: "basic"},
+}
+
+// postmanSchemeAuth converts a single security scheme.
+func postmanSchemeAuth(s *openapi3.SecurityScheme, vars map[string]string) *postmanAuth {
+	switch strings.ToLower(s.Type) {
+	case "http":
+		switch strings.ToLower(s.Scheme) {
+		case "bearer":
+			vars["bearerToken"] = ""
+			return &postmanAuth{Type: "bearer", Bearer: []postmanKV{
+				{Key: "token", Value: "{{bearerToken}}", Type: "string"},
+			}}
+		case "basic":
+			vars["username"] = ""
+			vars["password"] = ""
+			return &postmanAuth{Type: "basic", Basic: []postmanKV{
+				{Key: "username", Value: "{{username}}", Type: "string"},
+				{Key: "password", Value: "{{password}}", Type: "string"},
+			}}
+		}
+	case "apikey":
+		vars["apiKey"] = ""
+		in := s.In
+		if in != "query" {
+			in = "header"
+		}
+		return &postmanAuth{Type: "apikey", APIKey: []postmanKV{
+			{Key: "key", Value: s.Name, Type: "string"},
+			{Key: "value", Value: "{{apiKey}}", Type: "string"},
+			{Key: "in", Value: in, Type: "string"},
+		}}
+	case "oauth2", "openidconnect":
+		vars["accessToken"] = ""
+		auth := &postmanAuth{Type: "oauth2", OAuth2: []postmanKV{
+			{Key: "accessToken", Value: "{{accessToken}}", Type: "string"},
+			{Key: "addTokenTo", Value: "header", Type: "string"},
+		}}
+		if f := s.Flows; f != nil {
+			switch {
+			case f.AuthorizationCode != nil:
+				auth.OAuth2 = append(auth.OAuth2,
+					postmanKV{Key: "grant_type", Value: "authorization_code", Type: "string"},
+					postmanKV{Key: "authUrl", Value: f.AuthorizationCode.AuthorizationURL, Type: "string"},
+					postmanKV{Key: "accessTokenUrl", Value: f.AuthorizationCode.TokenURL, Type: "string"})
+			case f.ClientCredentials != nil:
+				auth.OAuth2 = append(auth.OAuth2,
+					postmanKV{Key: "grant_type", Value: "client_credentials", Type: "string"},
+					postmanKV{Key: "accessTokenUrl", Value: f.ClientCredentials.TokenURL, Type: "string"})
+			}
+		}
+		return auth
+	}
+	return nil
+}
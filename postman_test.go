@@ -0,0 +1,113 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportPostmanCollection(t *testing.T) {
+	o := New().WithOpenAPIDocs(OpenAPI{
+		Title:   "Books API",
+		Servers: Servers{{URL: "https://api.example.com/"}},
+		SecuritySchemes: SecuritySchemes{
+			{Name: "X-API-Key", Type: "apiKey", In: "header"},
+		},
+	})
+	o.Get("/books", anyHandler, DocSummary("List books"), DocTags("Books"),
+		DocQueryParam("limit", "int", "Max results", false), DocResponse([]Book{}))
+	o.Post("/books", anyHandler, DocSummary("Create book"), DocTags("Books"),
+		DocBearerAuth(), DocRequestBody(Book{}), DocResponse(201, Book{}))
+	o.Get("/books/:id", anyHandler, DocSummary("Get book"), DocTags("Books"), DocBasicAuth())
+	o.Get("/health", anyHandler, DocSummary("Health")).WithSecurity(map[string][]string{"X-API-Key": {}})
+
+	var buf bytes.Buffer
+	require.NoError(t, o.ExportPostmanCollection(&buf))
+	var col postmanCollection
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &col))
+
+	assert.Equal(t, "Books API", col.Info.Name)
+	assert.Equal(t, postmanSchemaURL, col.Info.Schema)
+
+	vars := map[string]string{}
+	for _, v := range col.Variable {
+		vars[v.Key] = v.Value
+	}
+	assert.Equal(t, "https://api.example.com", vars["baseUrl"])
+	assert.Contains(t, vars, "bearerToken")
+	assert.Contains(t, vars, "username")
+	assert.Contains(t, vars, "apiKey")
+
+	items := map[string]postmanItem{}
+	for _, it := range col.Item {
+		items[it.Name] = it
+	}
+	folder, ok := items["Books"]
+	require.True(t, ok, "expected a Books folder")
+	require.Len(t, folder.Item, 3)
+	requests := map[string]*postmanRequest{}
+	for _, it := range folder.Item {
+		requests[it.Name] = it.Request
+	}
+
+	list := requests["List books"]
+	require.NotNil(t, list)
+	assert.Equal(t, "GET", list.Method)
+	assert.Equal(t, []string{"books"}, list.URL.Path)
+	require.Len(t, list.URL.Query, 1)
+	assert.Equal(t, "limit", list.URL.Query[0].Key)
+	assert.True(t, list.URL.Query[0].Disabled)
+	assert.Nil(t, list.Auth)
+
+	create := requests["Create book"]
+	require.NotNil(t, create)
+	require.NotNil(t, create.Body)
+	assert.Equal(t, "raw", create.Body.Mode)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(create.Body.Raw), &body))
+	assert.Equal(t, "The Go Programming Language", body["name"])
+	require.NotNil(t, create.Auth)
+	assert.Equal(t, "bearer", create.Auth.Type)
+	assert.Equal(t, "{{bearerToken}}", create.Auth.Bearer[0].Value)
+
+	get := requests["Get book"]
+	require.NotNil(t, get)
+	assert.Equal(t, "{{baseUrl}}/books/:id", get.URL.Raw)
+	require.Len(t, get.URL.Variable, 1)
+	assert.Equal(t, "id", get.URL.Variable[0].Key)
+	require.NotNil(t, get.Auth)
+	assert.Equal(t, "basic", get.Auth.Type)
+
+	health, ok := items["Health"]
+	require.True(t, ok, "expected untagged routes at the top level")
+	require.NotNil(t, health.Request.Auth)
+	assert.Equal(t, "apikey", health.Request.Auth.Type)
+	assert.Contains(t, health.Request.Auth.APIKey, postmanKV{Key: "key", Value: "X-API-Key", Type: "string"})
+}
@@ -0,0 +1,62 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import "net/http"
+
+// PreRouteHook inspects a request before routing, see Okapi.PreRoute. It
+// returns true when it has answered the request, ending its processing.
+type PreRouteHook func(w http.ResponseWriter, r *http.Request) (handled bool)
+
+// PreRoute registers hooks called for every request before route matching, in
+// registration order, until one reports the request as handled.
+//
+// Hooks are meant for cheap rejections of unwanted traffic, such as blocklisted
+// IPs, bad user agents or a global kill switch: they run before any route
+// matching or Context allocation, so middlewares, OnError hooks and the access
+// log do not see the requests they answer. Security headers are already set on w.
+//
+// Example:
+//
+//	o.PreRoute(func(w http.ResponseWriter, r *http.Request) bool {
+//		if maintenance.Load() {
+//			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+//			return true
+//		}
+//		return false
+//	})
+func (o *Okapi) PreRoute(hooks ...PreRouteHook) {
+	o.preRouteHooks = append(o.preRouteHooks, hooks...)
+}
+
+// preRoute runs the PreRoute hooks, reporting whether one handled the request.
+func (o *Okapi) preRoute(w http.ResponseWriter, r *http.Request) bool {
+	for _, hook := range o.preRouteHooks {
+		if hook(w, r) {
+			return true
+		}
+	}
+	return false
+}
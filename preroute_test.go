@@ -0,0 +1,97 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPreRoute(t *testing.T) {
+	var killed atomic.Bool
+	var reached, errorsSeen, hooksRun atomic.Int32
+
+	o := New()
+	o.Use(func(c *Context) error {
+		reached.Add(1)
+		return c.Next()
+	})
+	o.OnError(func(c *Context, status int, err error) {
+		errorsSeen.Add(1)
+	})
+	o.PreRoute(
+		func(w http.ResponseWriter, r *http.Request) bool {
+			hooksRun.Add(1)
+			if killed.Load() {
+				http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+				return true
+			}
+			return false
+		},
+		func(w http.ResponseWriter, r *http.Request) bool {
+			hooksRun.Add(1)
+			if strings.Contains(r.UserAgent(), "badbot") {
+				w.WriteHeader(http.StatusForbidden)
+				return true
+			}
+			return false
+		},
+	)
+	o.Get("/books", func(c *Context) error { return c.OK(M{"ok": true}) })
+
+	serve := func(userAgent string) int {
+		req := httptest.NewRequest(http.MethodGet, "/books", nil)
+		req.Header.Set("User-Agent", userAgent)
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve("curl"); code != http.StatusOK {
+		t.Fatalf("allowed request: status = %d, want 200", code)
+	}
+	if reached.Load() != 1 || hooksRun.Load() != 2 {
+		t.Fatalf("allowed request: reached=%d hooks=%d, want 1 and 2", reached.Load(), hooksRun.Load())
+	}
+
+	if code := serve("badbot/1.0"); code != http.StatusForbidden {
+		t.Errorf("blocked user agent: status = %d, want 403", code)
+	}
+
+	killed.Store(true)
+	hooksRun.Store(0)
+	if code := serve("badbot/1.0"); code != http.StatusServiceUnavailable {
+		t.Errorf("kill switch: status = %d, want 503", code)
+	}
+	if hooksRun.Load() != 1 {
+		t.Errorf("kill switch: %d hooks ran, want the chain to stop at the first", hooksRun.Load())
+	}
+	if reached.Load() != 1 || errorsSeen.Load() != 0 {
+		t.Errorf("rejected requests reached middlewares (%d) or error hooks (%d)", reached.Load()-1, errorsSeen.Load())
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"slices"
+	"strings"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the caller, e.g. the "sub" claim or the Basic Auth username.
+	Subject string
+	// Roles are the caller's roles, matched against `scope` struct tags.
+	Roles []string
+	// AuthMethods are the authentication methods the caller passed, such as
+	// "pwd" or "otp" (RFC 8176), from the "amr" claim of a JWT.
+	AuthMethods []string
+}
+
+// HasRole reports whether the principal has one of roles.
+func (p Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if slices.Contains(p.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Principal returns the authenticated caller of the request, set by JWTAuth,
+// BasicAuth or SetPrincipal. It is the zero Principal for anonymous requests.
+func (c *Context) Principal() Principal {
+	return c.principal
+}
+
+// SetPrincipal records the authenticated caller of the request. Custom
+// authentication middleware call it so that response fields tagged with
+// `scope` are shown to the caller's roles.
+func (c *Context) SetPrincipal(p Principal) {
+	c.principal = p
+}
+
+// rolesFromClaim converts a roles claim, either a list or a space or comma
+// separated string, to a list of roles.
+func rolesFromClaim(v any) []string {
+	switch roles := v.(type) {
+	case []any:
+		out := make([]string, 0, len(roles))
+		for _, role := range roles {
+			if s, ok := role.(string); ok && s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	case []string:
+		return roles
+	case string:
+		return strings.FieldsFunc(roles, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"maps"
+	"net/http"
+	"net/http/pprof"
+)
+
+// ProfileSettings is the bundle of environment-dependent behaviors toggled by Profile.
+type ProfileSettings struct {
+	// Debug enables debug mode, see WithDebug.
+	Debug bool
+	// HideErrorDetails drops the underlying error from 5xx error responses,
+	// which only carry their message; the error is logged instead.
+	HideErrorDetails bool
+	// SecurityHeaders are set on every response before routing, so handlers
+	// can still override them.
+	SecurityHeaders map[string]string
+	// Docs serves the OpenAPI documentation.
+	Docs bool
+	// Pprof serves the net/http/pprof profiles under /debug/pprof/.
+	Pprof bool
+}
+
+// DefaultSecurityHeaders returns the security headers set by the production profile.
+func DefaultSecurityHeaders() map[string]string {
+	return map[string]string{
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Referrer-Policy":           "strict-origin-when-cross-origin",
+		"Strict-Transport-Security": "max-age=63072000; includeSubDomains",
+	}
+}
+
+// profileSettings returns the settings of the named profile: "development"
+// favors visibility, and any other name gets the production settings.
+func profileSettings(name string) ProfileSettings {
+	if normalizeEnvironment(name) == constDevelopment {
+		return ProfileSettings{Debug: true, Docs: true, Pprof: true}
+	}
+	return ProfileSettings{HideErrorDetails: true, SecurityHeaders: DefaultSecurityHeaders()}
+}
+
+// Profile applies the settings of an environment profile in one switch, so
+// development settings are not shipped to production by accident:
+//
+//	development  debug mode, error details, OpenAPI docs and pprof
+//	production   no debug mode, 5xx details hidden, security headers, no docs nor pprof
+//
+// Names other than "development" (or "dev", "local") get the production settings.
+// An empty name selects the profile from the environment (OKAPI_ENV, APP_ENV, ...).
+// Customize functions adjust the settings before they are applied.
+//
+// Example:
+//
+//	o := okapi.New(okapi.Profile(os.Getenv("APP_ENV"), func(s *okapi.ProfileSettings) {
+//		s.Docs = true // keep the public API reference in production
+//	}))
+func Profile(name string, customize ...func(*ProfileSettings)) OptionFunc {
+	return func(o *Okapi) {
+		if name == "" {
+			name = o.environment()
+		}
+		s := profileSettings(name)
+		for _, fn := range customize {
+			fn(&s)
+		}
+		o.profile = normalizeEnvironment(name)
+		o.debug = s.Debug
+		if s.Debug {
+			o.accessLog = true
+		}
+		o.hideErrorDetails = s.HideErrorDetails
+		o.securityHeaders = maps.Clone(s.SecurityHeaders)
+		o.openApiEnabled = s.Docs
+		if s.Pprof {
+			o.registerPprof()
+		}
+	}
+}
+
+// WithProfile applies an environment profile, see Profile.
+func (o *Okapi) WithProfile(name string, customize ...func(*ProfileSettings)) *Okapi {
+	return o.apply(Profile(name, customize...))
+}
+
+// registerPprof serves the net/http/pprof handlers under /debug/pprof/,
+// hidden from the OpenAPI documentation.
+func (o *Okapi) registerPprof() {
+	if o.pprofRegistered {
+		return
+	}
+	o.pprofRegistered = true
+	o.HandleStd(http.MethodGet, "/debug/pprof/", pprof.Index, Hide())
+	o.HandleStd(http.MethodGet, "/debug/pprof/cmdline", pprof.Cmdline, Hide())
+	o.HandleStd(http.MethodGet, "/debug/pprof/profile", pprof.Profile, Hide())
+	o.HandleStd(http.MethodGet, "/debug/pprof/symbol", pprof.Symbol, Hide())
+	o.HandleStd(http.MethodPost, "/debug/pprof/symbol", pprof.Symbol, Hide())
+	o.HandleStd(http.MethodGet, "/debug/pprof/trace", pprof.Trace, Hide())
+	o.HandleStd(http.MethodGet, "/debug/pprof/{name}", pprof.Index, Hide())
+}
+
+// setSecurityHeaders sets the profile's security headers on w.
+func (o *Okapi) setSecurityHeaders(w http.ResponseWriter) {
+	h := w.Header()
+	for k, v := range o.securityHeaders {
+		h.Set(k, v)
+	}
+}
+
+// internalErrorText returns the body of the 500 response written for an error
+// returned by a handler, hiding it when the profile says so.
+func (o *Okapi) internalErrorText(err error) string {
+	if !o.hideErrorDetails {
+		return err.Error()
+	}
+	o.logger.Error("[okapi] error details hidden from response", "error", err)
+	return http.StatusText(http.StatusInternalServerError)
+}
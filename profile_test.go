@@ -0,0 +1,106 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestProfileProduction(t *testing.T) {
+	o := Default().WithDebug().WithProfile("prod")
+	if o.debug || o.openApiEnabled || o.environment() != "production" {
+		t.Fatalf("unexpected production settings: debug=%v docs=%v env=%s", o.debug, o.openApiEnabled, o.environment())
+	}
+	ts := NewTestServerWithOkapi(t, o)
+	ts.Get("/fail", func(c *Context) error {
+		return c.AbortInternalServerError("Something went wrong", errors.New("db password rejected"))
+	})
+	ts.Get("/raw", func(c *Context) error {
+		return errors.New("db password rejected")
+	})
+	ts.Get("/frame", func(c *Context) error {
+		c.SetHeader("X-Frame-Options", "SAMEORIGIN")
+		return c.OK("ok")
+	})
+
+	for _, path := range []string{"/fail", "/raw"} {
+		_, body := okapitest.GET(t, ts.BaseURL+path).
+			ExpectStatus(http.StatusInternalServerError).
+			ExpectHeader("X-Content-Type-Options", "nosniff").
+			Execute()
+		if strings.Contains(string(body), "password") {
+			t.Errorf("%s: error details leaked: %s", path, body)
+		}
+	}
+	okapitest.GET(t, ts.BaseURL+"/frame").
+		ExpectStatusOK().
+		ExpectHeader("X-Frame-Options", "SAMEORIGIN")
+	okapitest.GET(t, ts.BaseURL+"/docs").ExpectStatus(http.StatusNotFound)
+	okapitest.GET(t, ts.BaseURL+"/debug/pprof/").ExpectStatus(http.StatusNotFound)
+}
+
+func TestProfileDevelopment(t *testing.T) {
+	o := New(Profile("development", func(s *ProfileSettings) {
+		s.SecurityHeaders = map[string]string{"X-Frame-Options": "DENY"}
+	}))
+	if !o.debug || !o.openApiEnabled {
+		t.Fatalf("unexpected development settings: debug=%v docs=%v", o.debug, o.openApiEnabled)
+	}
+	ts := NewTestServerWithOkapi(t, o)
+	ts.Get("/fail", func(c *Context) error {
+		return c.AbortInternalServerError("Something went wrong", errors.New("db password rejected"))
+	})
+
+	okapitest.GET(t, ts.BaseURL+"/fail").
+		ExpectStatus(http.StatusInternalServerError).
+		ExpectHeader("X-Frame-Options", "DENY").
+		ExpectBodyContains("db password rejected")
+	okapitest.GET(t, ts.BaseURL+"/debug/pprof/").
+		ExpectStatusOK().
+		ExpectBodyContains("goroutine")
+	okapitest.GET(t, ts.BaseURL+"/debug/pprof/heap?debug=1").ExpectStatusOK()
+
+	for _, r := range o.Routes() {
+		if strings.HasPrefix(r.Path, "/debug/pprof") && !r.hidden {
+			t.Errorf("pprof route %s is documented", r.Path)
+		}
+	}
+}
+
+func TestProfileFromEnvironment(t *testing.T) {
+	t.Setenv("OKAPI_ENV", "local")
+	if o := New(Profile("")); !o.debug || o.hideErrorDetails {
+		t.Error("expected the development profile for OKAPI_ENV=local")
+	}
+	t.Setenv("OKAPI_ENV", "staging")
+	if o := New(Profile("")); o.debug || !o.hideErrorDetails {
+		t.Error("expected production settings for OKAPI_ENV=staging")
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// QueryArraySyntax is a set of syntaxes accepted for array query parameters.
+type QueryArraySyntax int
+
+const (
+	// QueryArrayRepeat reads repeated keys: ?tags=a&tags=b.
+	QueryArrayRepeat QueryArraySyntax = 1 << iota
+	// QueryArrayComma splits values on commas: ?tags=a,b.
+	QueryArrayComma
+	// QueryArrayBrackets reads bracketed keys: ?tags[]=a&tags[]=b.
+	QueryArrayBrackets
+
+	// defaultQueryArraySyntax is used without WithQueryLimits.
+	defaultQueryArraySyntax = QueryArrayRepeat | QueryArrayComma
+)
+
+// QueryLimits bounds the query strings accepted by the server and selects the
+// array syntaxes used by c.QueryArray and []string query fields. See WithQueryLimits.
+type QueryLimits struct {
+	// MaxLength rejects raw query strings longer than this many bytes with
+	// 414 URI Too Long. Zero means no limit.
+	MaxLength int
+	// MaxParams rejects query strings with more parameters with 400 Bad Request.
+	// Zero means no limit.
+	MaxParams int
+	// MaxKeyLength rejects parameter names longer than this many bytes with
+	// 400 Bad Request. Zero means no limit.
+	MaxKeyLength int
+	// ArraySyntax lists the accepted array syntaxes, e.g. QueryArrayRepeat|QueryArrayBrackets.
+	// Zero keeps the default of QueryArrayRepeat|QueryArrayComma. When
+	// QueryArrayRepeat is not set, only the first value of a repeated key is used.
+	ArraySyntax QueryArraySyntax
+}
+
+// WithQueryLimits enables query string limits and sets the accepted array syntaxes.
+// Limits are checked before routing, so oversized query strings never reach handlers.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithQueryLimits(okapi.QueryLimits{
+//		MaxLength:    2048,
+//		MaxParams:    50,
+//		MaxKeyLength: 64,
+//		ArraySyntax:  okapi.QueryArrayRepeat | okapi.QueryArrayBrackets,
+//	}))
+func WithQueryLimits(l QueryLimits) OptionFunc {
+	return func(o *Okapi) {
+		o.queryLimits = &l
+	}
+}
+
+// WithQueryLimits enables query string limits, see WithQueryLimits.
+func (o *Okapi) WithQueryLimits(l QueryLimits) *Okapi {
+	return o.apply(WithQueryLimits(l))
+}
+
+// reject answers r with an error when its query string exceeds the limits,
+// reporting whether it did.
+func (l *QueryLimits) reject(w http.ResponseWriter, r *http.Request) bool {
+	status, reason := l.check(r.URL.RawQuery)
+	if status == 0 {
+		return false
+	}
+	http.Error(w, reason, status)
+	return true
+}
+
+// check returns the status and reason to reject a raw query string with, or zero.
+func (l *QueryLimits) check(rawQuery string) (int, string) {
+	if l.MaxLength > 0 && len(rawQuery) > l.MaxLength {
+		return http.StatusRequestURITooLong, "query string too long"
+	}
+	if rawQuery == "" || (l.MaxParams <= 0 && l.MaxKeyLength <= 0) {
+		return 0, ""
+	}
+	params := 0
+	for pair := range strings.SplitSeq(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		params++
+		if l.MaxParams > 0 && params > l.MaxParams {
+			return http.StatusBadRequest, "too many query parameters"
+		}
+		key, _, _ := strings.Cut(pair, "=")
+		if l.MaxKeyLength > 0 && len(key) > l.MaxKeyLength {
+			if k, err := url.QueryUnescape(key); err != nil || len(k) > l.MaxKeyLength {
+				return http.StatusBadRequest, fmt.Sprintf("query parameter name longer than %d bytes", l.MaxKeyLength)
+			}
+		}
+	}
+	return 0, ""
+}
+
+// queryArraySyntax returns the array syntaxes accepted by the server.
+func (c *Context) queryArraySyntax() QueryArraySyntax {
+	if c.okapi != nil && c.okapi.queryLimits != nil && c.okapi.queryLimits.ArraySyntax != 0 {
+		return c.okapi.queryLimits.ArraySyntax
+	}
+	return defaultQueryArraySyntax
+}
+
+// arrayValues returns the trimmed values of the array parameter key in
+// values, read with the accepted array syntaxes.
+func (c *Context) arrayValues(values url.Values, key string) []string {
+	syntax := c.queryArraySyntax()
+	raw := values[key]
+	if syntax&QueryArrayRepeat == 0 && len(raw) > 1 {
+		raw = raw[:1]
+	}
+	if syntax&QueryArrayBrackets != 0 {
+		raw = append(slices.Clip(raw), values[key+"[]"]...)
+	}
+	var result []string
+	for _, v := range raw {
+		if syntax&QueryArrayComma == 0 {
+			result = append(result, strings.TrimSpace(v))
+			continue
+		}
+		for part := range strings.SplitSeq(v, ",") {
+			result = append(result, strings.TrimSpace(part))
+		}
+	}
+	return result
+}
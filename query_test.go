@@ -0,0 +1,96 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestQueryLimits(t *testing.T) {
+	o := New(WithQueryLimits(QueryLimits{MaxLength: 64, MaxParams: 3, MaxKeyLength: 8}))
+	ts := NewTestServerWithOkapi(t, o)
+	ts.Get("/search", func(c *Context) error {
+		return c.String(http.StatusOK, c.Query("q"))
+	})
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"within limits", "q=go&page=1", http.StatusOK},
+		{"too long", "q=" + strings.Repeat("a", 64), http.StatusRequestURITooLong},
+		{"too many params", "a=1&b=2&c=3&d=4", http.StatusBadRequest},
+		{"key too long", "verylongkey=1", http.StatusBadRequest},
+		{"escaped key within limit", "q%5B%5D=1", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okapitest.GET(t, ts.BaseURL+"/search?"+tt.query).ExpectStatus(tt.status)
+		})
+	}
+}
+
+func TestQueryArraySyntax(t *testing.T) {
+	type filter struct {
+		Tags []string `query:"tags"`
+	}
+	tests := []struct {
+		name   string
+		syntax QueryArraySyntax
+		query  string
+		want   string
+	}{
+		{"default repeat", 0, "tags=a&tags=b", "a|b"},
+		{"default comma", 0, "tags=a,%20b", "a|b"},
+		{"default ignores brackets", 0, "tags[]=a", ""},
+		{"brackets", QueryArrayRepeat | QueryArrayBrackets, "tags[]=a&tags[]=b&tags=c", "c|a|b"},
+		{"no comma", QueryArrayRepeat, "tags=a,b", "a,b"},
+		{"no repeat keeps the first value", QueryArrayComma, "tags=a,b&tags=c", "a|b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := New(WithQueryLimits(QueryLimits{ArraySyntax: tt.syntax}))
+			ts := NewTestServerWithOkapi(t, o)
+			ts.Get("/items", func(c *Context) error {
+				var f filter
+				if err := c.Bind(&f); err != nil {
+					return c.AbortBadRequest("Bad Request", err)
+				}
+				if got := strings.Join(c.QueryArray("tags"), "|"); got != strings.Join(f.Tags, "|") {
+					t.Errorf("QueryArray = %q, bound %q", got, f.Tags)
+				}
+				return c.String(http.StatusOK, strings.Join(f.Tags, "|"))
+			})
+			okapitest.GET(t, ts.BaseURL+"/items?"+tt.query).
+				ExpectStatusOK().
+				ExpectBody(tt.want)
+		})
+	}
+}
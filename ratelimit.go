@@ -0,0 +1,353 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+)
+
+type (
+	// RateLimit is a middleware that limits the number of requests a client
+	// can make in a fixed time window. Every response carries the
+	// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers,
+	// and requests over the limit get a 429 Too Many Requests with Retry-After.
+	//
+	// Operations using it are documented with these headers and the 429
+	// response. Counters are kept in memory, per instance, unless a shared
+	// store is set with WithLimiterStore.
+	//
+	// Example:
+	//
+	//	limiter := &okapi.RateLimit{Limit: 100, Window: time.Minute}
+	//	api := o.Group("/api", limiter.Middleware)
+	RateLimit struct {
+		// Limit is the number of requests allowed per window, zero disables the limit.
+		Limit int
+		// Window is the duration of a window, default one minute.
+		Window time.Duration
+		// KeyFunc identifies the client, default c.RealIP().
+		KeyFunc func(c *Context) string
+		// Name prefixes the limiter's keys in a shared LimiterStore, so that
+		// limiters sharing a store count their requests separately.
+		Name string
+
+		mu      sync.Mutex
+		windows map[string]*rateWindow
+		sweep   time.Time
+	}
+
+	// RateTier is the request quota of a tier of principals.
+	RateTier struct {
+		// Limit is the number of requests allowed per window, zero disables the limit.
+		Limit int
+		// Window is the duration of a window, default one minute.
+		Window time.Duration
+	}
+
+	// PrincipalRateLimit is a middleware limiting the requests of each
+	// authenticated principal, see Context.Principal, according to its tier.
+	// Anonymous requests are counted per client IP. Responses carry the same
+	// headers as RateLimit, and operations using it are documented the same way.
+	//
+	// It must run after the authentication middleware.
+	//
+	// Example:
+	//
+	//	limiter := okapi.RateLimitPerPrincipal(map[string]okapi.RateTier{
+	//		"free":    {Limit: 60},
+	//		"premium": {Limit: 1000},
+	//	})
+	//	api := o.Group("/api", jwtAuth.Middleware, limiter.Middleware)
+	PrincipalRateLimit struct {
+		// Tiers maps tier names to their quotas. Requests of a tier missing from
+		// Tiers use the DefaultRateTier quota, and are not limited without one.
+		Tiers map[string]RateTier
+		// TierFunc returns the tier of the request, e.g. from a forwarded claim.
+		// Default: the first role of the principal naming a tier.
+		TierFunc func(c *Context) string
+		// Name prefixes the limiter's keys in a shared LimiterStore, see RateLimit.Name.
+		Name string
+
+		mu       sync.Mutex
+		limiters map[string]*RateLimit
+	}
+
+	// LimiterStore counts the requests of rate limits in fixed windows. The
+	// default store keeps the counters in memory; use a shared backend such as
+	// the okapi/redis package so that every instance enforces the same limits,
+	// see WithLimiterStore.
+	LimiterStore interface {
+		// Increment counts a request under key and returns the number of
+		// requests of the current window, and when the window ends. A window of
+		// the given duration starts with the first request of key.
+		Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error)
+	}
+
+	// rateWindow counts the requests of a client in the current window.
+	rateWindow struct {
+		count int
+		reset time.Time
+	}
+)
+
+// DefaultRateTier is the tier of PrincipalRateLimit requests without a known tier.
+const DefaultRateTier = "default"
+
+// rateLimitMiddlewares identify the rate limit middlewares in a middleware
+// chain, all method values of a method sharing the same code pointer.
+var rateLimitMiddlewares = []uintptr{
+	reflect.ValueOf((&RateLimit{}).Middleware).Pointer(),
+	reflect.ValueOf((&PrincipalRateLimit{}).Middleware).Pointer(),
+}
+
+// Middleware counts the request against the client's window and rejects it
+// with 429 Too Many Requests once the limit is reached.
+func (l *RateLimit) Middleware(c *Context) error {
+	if l.Limit <= 0 {
+		return c.Next()
+	}
+	key := c.RealIP()
+	if l.KeyFunc != nil {
+		key = l.KeyFunc(c)
+	}
+	now := time.Now()
+	var count int
+	var reset time.Time
+	if store := l.store(c); store != nil {
+		var err error
+		count, reset, err = store.Increment(c.request.Context(), "okapi:ratelimit:"+l.Name+":"+key, l.window())
+		if err != nil {
+			c.Logger().Warn("rate limit store failed", slog.String("key", key), slog.String("error", err.Error()))
+			return c.Next()
+		}
+	} else {
+		count, reset = l.take(key, now)
+	}
+
+	h := c.response.Header()
+	h.Set(headerRateLimitLimit, strconv.Itoa(l.Limit))
+	h.Set(headerRateLimitRemaining, strconv.Itoa(max(l.Limit-count, 0)))
+	h.Set(headerRateLimitReset, strconv.FormatInt(reset.Unix(), 10))
+	if count > l.Limit {
+		retryAfter := int64(reset.Sub(now)+time.Second-1) / int64(time.Second)
+		h.Set(headerRetryAfter, strconv.FormatInt(max(retryAfter, 1), 10))
+		c.Logger().Warn("Rate limit exceeded", "key", key, "limit", l.Limit, "ip", c.RealIP())
+		return c.AbortTooManyRequests("Too Many Requests")
+	}
+	return c.Next()
+}
+
+// WithLimiterStore sets the store counting the requests of RateLimit and
+// PrincipalRateLimit. Store errors are logged and the request is let through.
+func WithLimiterStore(store LimiterStore) OptionFunc {
+	return func(o *Okapi) {
+		o.limiterStore = store
+	}
+}
+
+// WithLimiterStore sets the store counting the requests of rate limits.
+func (o *Okapi) WithLimiterStore(store LimiterStore) *Okapi {
+	return o.apply(WithLimiterStore(store))
+}
+
+// store returns the instance's shared store, nil to count in memory.
+func (l *RateLimit) store(c *Context) LimiterStore {
+	if c.okapi != nil {
+		return c.okapi.limiterStore
+	}
+	return nil
+}
+
+// window returns the window duration, default one minute.
+func (l *RateLimit) window() time.Duration {
+	if l.Window <= 0 {
+		return time.Minute
+	}
+	return l.Window
+}
+
+// take counts a request for key and returns the window's request count and end.
+func (l *RateLimit) take(key string, now time.Time) (int, time.Time) {
+	window := l.window()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.windows == nil {
+		l.windows = make(map[string]*rateWindow)
+	}
+	// Drop the windows of clients gone quiet, at most once per window.
+	if !now.Before(l.sweep) {
+		for k, w := range l.windows {
+			if !now.Before(w.reset) {
+				delete(l.windows, k)
+			}
+		}
+		l.sweep = now.Add(window)
+	}
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.reset) {
+		w = &rateWindow{reset: now.Add(window)}
+		l.windows[key] = w
+	}
+	w.count++
+	return w.count, w.reset
+}
+
+// RateLimitPerPrincipal returns a PrincipalRateLimit with the given tiers.
+func RateLimitPerPrincipal(tiers map[string]RateTier) *PrincipalRateLimit {
+	return &PrincipalRateLimit{Tiers: tiers}
+}
+
+// Middleware counts the request against the principal's window in its tier
+// and rejects it with 429 Too Many Requests once the tier's limit is reached.
+func (l *PrincipalRateLimit) Middleware(c *Context) error {
+	limiter := l.limiter(l.tier(c))
+	if limiter == nil {
+		return c.Next()
+	}
+	return limiter.Middleware(c)
+}
+
+// tier returns the tier of the request.
+func (l *PrincipalRateLimit) tier(c *Context) string {
+	if l.TierFunc != nil {
+		return l.TierFunc(c)
+	}
+	for _, role := range c.Principal().Roles {
+		if _, ok := l.Tiers[role]; ok {
+			return role
+		}
+	}
+	return DefaultRateTier
+}
+
+// limiter returns the limiter of tier, nil if the tier is not limited.
+func (l *PrincipalRateLimit) limiter(tier string) *RateLimit {
+	quota, ok := l.Tiers[tier]
+	if !ok {
+		tier = DefaultRateTier
+		if quota, ok = l.Tiers[tier]; !ok {
+			return nil
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limiters == nil {
+		l.limiters = make(map[string]*RateLimit)
+	}
+	limiter, ok := l.limiters[tier]
+	if !ok {
+		limiter = &RateLimit{Limit: quota.Limit, Window: quota.Window, KeyFunc: principalKey, Name: l.Name + ":" + tier}
+		l.limiters[tier] = limiter
+	}
+	return limiter
+}
+
+// principalKey identifies the principal of the request, or its client IP
+// when anonymous.
+func principalKey(c *Context) string {
+	if subject := c.Principal().Subject; subject != "" {
+		return "principal:" + subject
+	}
+	return "ip:" + c.RealIP()
+}
+
+// rateLimited reports whether a rate limit middleware applies to the route.
+func (r *Route) rateLimited() bool {
+	limited := false
+	r.eachMiddleware(func(code uintptr) {
+		limited = limited || slices.Contains(rateLimitMiddlewares, code)
+	})
+	return limited
+}
+
+// eachMiddleware calls fn with the code pointer of each global and route
+// middleware of the route.
+func (r *Route) eachMiddleware(fn func(code uintptr)) {
+	var global []Middleware
+	if r.chain != nil {
+		global = r.chain.globalMiddlewares()
+	}
+	for _, chain := range [][]Middleware{global, r.middlewares} {
+		for _, m := range chain {
+			fn(reflect.ValueOf(m).Pointer())
+		}
+	}
+}
+
+// documentRateLimit adds the rate limit headers to every response of op and
+// documents the 429 Too Many Requests response.
+func documentRateLimit(op *openapi3.Operation) {
+	header := func(desc string) *openapi3.HeaderRef {
+		return &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+			Description: desc,
+			Schema:      openapi3.NewIntegerSchema().NewRef(),
+		}}}
+	}
+	headers := openapi3.Headers{
+		headerRateLimitLimit:     header("Requests allowed in the current window"),
+		headerRateLimitRemaining: header("Requests remaining in the current window"),
+		headerRateLimitReset:     header("Unix time at which the current window resets"),
+	}
+	if op.Responses.Value("429") == nil {
+		op.Responses.Set("429", &openapi3.ResponseRef{Value: &openapi3.Response{
+			Description: ptr(http.StatusText(http.StatusTooManyRequests)),
+			Headers: openapi3.Headers{
+				headerRetryAfter: header("Seconds to wait before retrying"),
+			},
+		}})
+	}
+	for _, resp := range op.Responses.Map() {
+		if resp.Value == nil {
+			continue
+		}
+		// Response headers may be shared with the route, copy before adding.
+		merged := make(openapi3.Headers, len(resp.Value.Headers)+len(headers))
+		for name, h := range resp.Value.Headers {
+			merged[name] = h
+		}
+		for name, h := range headers {
+			if _, ok := merged[name]; !ok {
+				merged[name] = h
+			}
+		}
+		resp.Value.Headers = merged
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	ts := NewTestServer(t)
+	limiter := &RateLimit{Limit: 2, Window: time.Minute, KeyFunc: func(c *Context) string {
+		return c.Header("X-Client")
+	}}
+	ts.Get("/limited", helloHandler, UseMiddleware(limiter.Middleware))
+	ts.Get("/open", helloHandler)
+
+	resp, _ := okapitest.GET(t, ts.BaseURL+"/limited").Header("X-Client", "a").
+		ExpectStatusOK().
+		ExpectHeader(headerRateLimitLimit, "2").
+		ExpectHeader(headerRateLimitRemaining, "1").
+		Execute()
+	reset, err := strconv.ParseInt(resp.Header.Get(headerRateLimitReset), 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, time.Now().Add(time.Minute).Unix(), reset, 2)
+
+	okapitest.GET(t, ts.BaseURL+"/limited").Header("X-Client", "a").
+		ExpectStatusOK().ExpectHeader(headerRateLimitRemaining, "0")
+	okapitest.GET(t, ts.BaseURL+"/limited").Header("X-Client", "a").
+		ExpectStatus(http.StatusTooManyRequests).
+		ExpectHeader(headerRateLimitRemaining, "0").
+		ExpectHeaderExists(headerRetryAfter)
+
+	// Other clients have their own window.
+	okapitest.GET(t, ts.BaseURL+"/limited").Header("X-Client", "b").
+		ExpectStatusOK().ExpectHeader(headerRateLimitRemaining, "1")
+	okapitest.GET(t, ts.BaseURL+"/open").ExpectStatusOK().ExpectHeader(headerRateLimitLimit, "")
+}
+
+func TestRateLimitWindowReset(t *testing.T) {
+	limiter := &RateLimit{Limit: 1, Window: time.Second}
+	now := time.Now()
+	count, reset := limiter.take("client", now)
+	assert.Equal(t, 1, count)
+	count, _ = limiter.take("client", now.Add(500*time.Millisecond))
+	assert.Equal(t, 2, count)
+	count, next := limiter.take("client", reset)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, reset.Add(time.Second), next)
+}
+
+// sharedLimiterStore is a LimiterStore shared by several instances, standing in for Redis.
+type sharedLimiterStore struct {
+	mu     sync.Mutex
+	counts map[string]int
+	err    error
+}
+
+func (s *sharedLimiterStore) Increment(_ context.Context, key string, window time.Duration) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return 0, time.Time{}, s.err
+	}
+	s.counts[key]++
+	return s.counts[key], time.Now().Add(window), nil
+}
+
+func TestRateLimitSharedStore(t *testing.T) {
+	store := &sharedLimiterStore{counts: make(map[string]int)}
+	limiter := &RateLimit{Limit: 2, Name: "api"}
+	other := &RateLimit{Limit: 2, Name: "admin"}
+
+	first := NewTestServer(t)
+	first.WithLimiterStore(store)
+	first.Get("/limited", helloHandler, UseMiddleware(limiter.Middleware))
+	first.Get("/admin", helloHandler, UseMiddleware(other.Middleware))
+	second := NewTestServer(t)
+	second.WithLimiterStore(store)
+	second.Get("/limited", helloHandler, UseMiddleware(limiter.Middleware))
+
+	okapitest.GET(t, first.BaseURL+"/limited").ExpectStatusOK().ExpectHeader(headerRateLimitRemaining, "1")
+	okapitest.GET(t, second.BaseURL+"/limited").ExpectStatusOK().ExpectHeader(headerRateLimitRemaining, "0")
+	okapitest.GET(t, first.BaseURL+"/limited").ExpectStatus(http.StatusTooManyRequests)
+	// Limiters with another name count separately.
+	okapitest.GET(t, first.BaseURL+"/admin").ExpectStatusOK().ExpectHeader(headerRateLimitRemaining, "1")
+
+	// Store errors let requests through.
+	store.mu.Lock()
+	store.err = errors.New("connection refused")
+	store.mu.Unlock()
+	okapitest.GET(t, first.BaseURL+"/limited").ExpectStatusOK()
+}
+
+func TestRateLimitDocumented(t *testing.T) {
+	o := New()
+	limiter := &RateLimit{Limit: 10}
+	api := o.Group("/api", (&RateLimit{Limit: 5}).Middleware)
+	api.Get("/books", anyHandler, DocResponse(Book{}), DocResponseHeader("X-Version", "string"))
+	o.Get("/limited", anyHandler, UseMiddleware(limiter.Middleware))
+	o.Get("/open", anyHandler, DocResponse(Book{}))
+	o.buildOpenAPISpec()
+
+	books := o.openapiSpec.Paths.Find("/api/books").Get.Responses
+	ok := books.Status(200).Value
+	assert.Contains(t, ok.Headers, headerRateLimitLimit)
+	assert.Contains(t, ok.Headers, headerRateLimitRemaining)
+	assert.Contains(t, ok.Headers, headerRateLimitReset)
+	assert.Contains(t, ok.Headers, "X-Version")
+	tooMany := books.Status(http.StatusTooManyRequests)
+	require.NotNil(t, tooMany)
+	assert.Contains(t, tooMany.Value.Headers, headerRetryAfter)
+	assert.Contains(t, tooMany.Value.Headers, headerRateLimitReset)
+
+	assert.NotNil(t, o.openapiSpec.Paths.Find("/limited").Get.Responses.Status(http.StatusTooManyRequests))
+
+	open := o.openapiSpec.Paths.Find("/open").Get.Responses
+	assert.Nil(t, open.Status(http.StatusTooManyRequests))
+	assert.NotContains(t, open.Status(200).Value.Headers, headerRateLimitLimit)
+}
+
+func TestRateLimitPerPrincipal(t *testing.T) {
+	ts := NewTestServer(t)
+	// Authenticates "X-User: name:role"
+	auth := func(c *Context) error {
+		if user, role, ok := strings.Cut(c.Header("X-User"), ":"); ok {
+			c.SetPrincipal(Principal{Subject: user, Roles: []string{"staff", role}})
+		}
+		return c.Next()
+	}
+	limiter := RateLimitPerPrincipal(map[string]RateTier{
+		DefaultRateTier: {Limit: 1},
+		"premium":       {Limit: 3},
+	})
+	ts.Get("/api", helloHandler, UseMiddleware(auth, limiter.Middleware))
+
+	okapitest.GET(t, ts.BaseURL+"/api").Header("X-User", "ada:premium").
+		ExpectStatusOK().
+		ExpectHeader(headerRateLimitLimit, "3").
+		ExpectHeader(headerRateLimitRemaining, "2")
+	// Principals are counted separately, whatever their IP
+	okapitest.GET(t, ts.BaseURL+"/api").Header("X-User", "bob:free").
+		ExpectStatusOK().
+		ExpectHeader(headerRateLimitLimit, "1")
+	okapitest.GET(t, ts.BaseURL+"/api").Header("X-User", "bob:free").
+		ExpectStatus(http.StatusTooManyRequests)
+	okapitest.GET(t, ts.BaseURL+"/api").Header("X-User", "ada:premium").
+		ExpectStatusOK().
+		ExpectHeader(headerRateLimitRemaining, "1")
+	// Anonymous requests are counted per IP in the default tier
+	okapitest.GET(t, ts.BaseURL+"/api").ExpectStatusOK()
+	okapitest.GET(t, ts.BaseURL+"/api").ExpectStatus(http.StatusTooManyRequests)
+
+	// TierFunc looks the tier up, e.g. from a forwarded claim
+	plans := &PrincipalRateLimit{
+		Tiers:    map[string]RateTier{"enterprise": {Limit: 100}},
+		TierFunc: func(c *Context) string { return c.Header("X-Plan") },
+	}
+	ts.Get("/plans", helloHandler, UseMiddleware(plans.Middleware))
+	okapitest.GET(t, ts.BaseURL+"/plans").Header("X-Plan", "enterprise").
+		ExpectStatusOK().
+		ExpectHeader(headerRateLimitLimit, "100")
+	okapitest.GET(t, ts.BaseURL+"/plans").
+		ExpectStatusOK().
+		ExpectHeader(headerRateLimitLimit, "")
+
+	o := New()
+	o.Get("/limited", anyHandler, UseMiddleware(limiter.Middleware))
+	o.buildOpenAPISpec()
+	assert.NotNil(t, o.openapiSpec.Paths.Find("/limited").Get.Responses.Status(http.StatusTooManyRequests))
+}
@@ -0,0 +1,405 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	// scopedTypes caches the typeScope of types.
+	scopedTypes sync.Map
+)
+
+// typeScope tells whether values of a type hold fields tagged with `scope`.
+type typeScope uint8
+
+const (
+	// noScope types never hold scoped fields.
+	noScope typeScope = iota
+	// dynamicScope types hold interface values, scoped only when their
+	// dynamic type is.
+	dynamicScope
+	// staticScope types hold scoped fields.
+	staticScope
+)
+
+// redact returns v with the struct fields tagged `scope:"role,..."` removed
+// when the caller has none of the listed roles, for encoding as JSON. Values
+// without scoped fields are returned unchanged.
+func (c *Context) redact(v any) any {
+	if v == nil || !needsRedaction(reflect.ValueOf(v)) {
+		return v
+	}
+	return redactValue(reflect.ValueOf(v), c.principal)
+}
+
+// redactFields is redact for the other encoders: it returns a copy of v of
+// the same type, with the fields hidden from the caller set to their zero value.
+func (c *Context) redactFields(v any) any {
+	if v == nil || !needsRedaction(reflect.ValueOf(v)) {
+		return v
+	}
+	return redactCopy(reflect.ValueOf(v), c.principal).Interface()
+}
+
+// scopeOf returns the typeScope of t.
+func scopeOf(t reflect.Type) typeScope {
+	if cached, ok := scopedTypes.Load(t); ok {
+		return cached.(typeScope)
+	}
+	scope := scopeOfType(t, map[reflect.Type]bool{})
+	scopedTypes.Store(t, scope)
+	return scope
+}
+
+func scopeOfType(t reflect.Type, seen map[reflect.Type]bool) typeScope {
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return noScope
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return dynamicScope
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return scopeOfType(t.Elem(), seen)
+	case reflect.Struct:
+		if reflect.PointerTo(t).Implements(jsonMarshalerType) || seen[t] {
+			return noScope
+		}
+		seen[t] = true
+		scope := noScope
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			if field.Tag.Get(tagScope) != "" {
+				return staticScope
+			}
+			scope = max(scope, scopeOfType(field.Type, seen))
+			if scope == staticScope {
+				return scope
+			}
+		}
+		return scope
+	}
+	return noScope
+}
+
+// needsRedaction reports whether v holds scoped fields, looking into the
+// dynamic values of interfaces only when its type has some.
+func needsRedaction(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	switch scopeOf(v.Type()) {
+	case noScope:
+		return false
+	case staticScope:
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		return !v.IsNil() && needsRedaction(v.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if needsRedaction(v.Index(i)) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if needsRedaction(iter.Value()) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if field := t.Field(i); (field.IsExported() || field.Anonymous) && needsRedaction(v.Field(i)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hidden reports whether the struct field sf is hidden from p.
+func hidden(sf reflect.StructField, p Principal) bool {
+	scope := sf.Tag.Get(tagScope)
+	return scope != "" && !p.HasRole(strings.Split(scope, ",")...)
+}
+
+// redactCopy returns a copy of v of the same type, with the struct fields
+// hidden from p set to their zero value.
+func redactCopy(v reflect.Value, p Principal) reflect.Value {
+	if !needsRedaction(v) {
+		return v
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(redactCopy(v.Elem(), p))
+		return out
+	case reflect.Pointer:
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(redactCopy(v.Elem(), p))
+		return out
+	case reflect.Slice, reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		if v.Kind() == reflect.Slice {
+			out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		}
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactCopy(v.Index(i), p))
+		}
+		return out
+	case reflect.Map:
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), redactCopy(iter.Value(), p))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() && !sf.Anonymous {
+				continue
+			}
+			// Fields of unexported embedded structs are still encoded by
+			// encoding/xml, so they are redacted too.
+			field := writable(out.Field(i))
+			if hidden(sf, p) {
+				field.SetZero()
+			} else {
+				field.Set(redactCopy(field, p))
+			}
+		}
+		return out
+	}
+	return v
+}
+
+// writable returns the addressable v without the read-only flag reflect sets
+// on values reached through unexported fields, for writing into a copy.
+func writable(v reflect.Value) reflect.Value {
+	if v.CanSet() || !v.CanAddr() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// redactValue returns a JSON-encodable copy of v without the fields hidden
+// from p.
+func redactValue(v reflect.Value, p Principal) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if !needsRedaction(v) {
+		if !v.CanInterface() {
+			return nil
+		}
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem(), p)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = redactValue(v.Index(i), p)
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := reflect.MakeMapWithSize(reflect.MapOf(v.Type().Key(), reflect.TypeFor[any]()), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.ValueOf(redactValue(iter.Value(), p))
+			if !elem.IsValid() {
+				elem = reflect.Zero(out.Type().Elem())
+			}
+			out.SetMapIndex(iter.Key(), elem)
+		}
+		return out.Interface()
+	case reflect.Struct:
+		return scopedStruct{value: v, principal: p}
+	}
+	return v.Interface()
+}
+
+// scopedStruct encodes a struct as encoding/json does, leaving out the fields
+// hidden from principal.
+type scopedStruct struct {
+	value     reflect.Value
+	principal Principal
+}
+
+// scopedField is a struct field to encode.
+type scopedField struct {
+	name  string
+	depth int
+	value reflect.Value
+	quote bool
+}
+
+func (s scopedStruct) MarshalJSON() ([]byte, error) {
+	fields := s.fields(s.value, 0, nil)
+	// Shallower fields win over promoted fields of the same name
+	depths := make(map[string]int, len(fields))
+	for _, f := range fields {
+		if d, ok := depths[f.name]; !ok || f.depth < d {
+			depths[f.name] = f.depth
+		}
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, f := range fields {
+		if depths[f.name] != f.depth {
+			continue
+		}
+		depths[f.name] = -1
+		data, err := json.Marshal(redactValue(f.value, s.principal))
+		if err != nil {
+			return nil, err
+		}
+		if f.quote && len(data) > 0 && data[0] != '"' && !bytes.Equal(data, []byte("null")) {
+			if data, err = json.Marshal(string(data)); err != nil {
+				return nil, err
+			}
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(f.name)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(data)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// fields lists the fields of v visible to the principal, flattening embedded
+// structs the way encoding/json does.
+func (s scopedStruct) fields(v reflect.Value, depth int, out []scopedField) []scopedField {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if hidden(sf, s.principal) {
+			continue
+		}
+		tag := sf.Tag.Get(tagJSON)
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if fv.Kind() == reflect.Pointer {
+					if fv.IsNil() {
+						continue
+					}
+					fv = fv.Elem()
+				}
+				out = s.fields(fv, depth+1, out)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if omitted(fv, opts) {
+			continue
+		}
+		out = append(out, scopedField{name: name, depth: depth, value: fv, quote: hasTagOption(opts, "string")})
+	}
+	return out
+}
+
+// omitted reports whether v is left out by the omitempty or omitzero option.
+func omitted(v reflect.Value, opts string) bool {
+	if hasTagOption(opts, "omitzero") && v.IsZero() {
+		return true
+	}
+	if !hasTagOption(opts, "omitempty") {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+func hasTagOption(opts, option string) bool {
+	for opts != "" {
+		var name string
+		name, opts, _ = strings.Cut(opts, ",")
+		if name == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scopedAudit struct {
+	CreatedBy string `json:"created_by"`
+	Internal  string `json:"internal" scope:"admin"`
+}
+
+type scopedUser struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Email  string  `json:"email" scope:"admin,support"`
+	Salary float64 `json:"salary,omitempty" scope:"admin"`
+	Notes  string  `json:"notes,omitempty"`
+	scopedAudit
+	Manager *scopedUser `json:"manager,omitempty"`
+}
+
+func TestScopedResponseFields(t *testing.T) {
+	user := scopedUser{
+		ID: 1, Name: "Ada", Email: "ada@example.com", Salary: 100,
+		scopedAudit: scopedAudit{CreatedBy: "root", Internal: "x"},
+		Manager:     &scopedUser{ID: 2, Name: "Grace", Email: "grace@example.com"},
+	}
+	encode := func(p Principal, v any) map[string]any {
+		ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+		ctx.SetPrincipal(p)
+		data, err := json.Marshal(ctx.redact(v))
+		require.NoError(t, err)
+		var out map[string]any
+		require.NoError(t, json.Unmarshal(data, &out))
+		return out
+	}
+
+	anonymous := encode(Principal{}, user)
+	assert.Equal(t, map[string]any{
+		"id": float64(1), "name": "Ada", "created_by": "root",
+		"manager": map[string]any{"id": float64(2), "name": "Grace", "created_by": ""},
+	}, anonymous)
+
+	support := encode(Principal{Roles: []string{"support"}}, &user)
+	assert.Equal(t, "ada@example.com", support["email"])
+	assert.NotContains(t, support, "salary")
+	assert.NotContains(t, support, "internal")
+
+	admin := encode(Principal{Roles: []string{"admin"}}, user)
+	var plain map[string]any
+	data, _ := json.Marshal(user)
+	require.NoError(t, json.Unmarshal(data, &plain))
+	assert.Equal(t, plain, admin, "an admin sees the encoding/json output")
+
+	wrapped := encode(Principal{}, M{"users": []scopedUser{user}})
+	users := wrapped["users"].([]any)
+	assert.NotContains(t, users[0], "email", "values inside maps and slices are redacted")
+
+	// Types without scoped fields are encoded as is
+	book := Book{Name: "Dune"}
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	assert.Equal(t, book, ctx.redact(book))
+}
+
+type scopedSecret struct {
+	Name   string
+	Secret string `scope:"admin"`
+}
+
+func TestScopedFieldsInOtherFormats(t *testing.T) {
+	secret := scopedSecret{Name: "key", Secret: "s"}
+	user := scopedUser{Name: "Ada", Email: "ada@example.com", scopedAudit: scopedAudit{Internal: "x"}}
+	ts := NewTestServer(t)
+	ts.Get("/xml", func(c *Context) error { return c.XML(http.StatusOK, user) })
+	ts.Get("/yaml", func(c *Context) error { return c.YAML(http.StatusOK, M{"secret": &secret}) })
+	ts.Get("/output", HandleO(func(c *Context) (*struct{ Body scopedSecret }, error) {
+		return &struct{ Body scopedSecret }{Body: secret}, nil
+	}))
+	ts.Get("/admin", func(c *Context) error {
+		c.SetPrincipal(Principal{Roles: []string{"admin"}})
+		return c.XML(http.StatusOK, secret)
+	})
+
+	_, body := okapitest.GET(t, ts.BaseURL+"/xml").ExpectStatusOK().ExpectBodyContains("<Name>Ada</Name>").Execute()
+	assert.NotContains(t, string(body), "ada@example.com")
+	assert.NotContains(t, string(body), "<Internal>x</Internal>", "fields of unexported embedded structs are redacted")
+	_, body = okapitest.GET(t, ts.BaseURL+"/yaml").ExpectStatusOK().ExpectBodyContains("name: key").Execute()
+	assert.NotContains(t, string(body), "secret: s")
+	for _, accept := range []string{"application/xml", "application/yaml", "text/plain"} {
+		_, body = okapitest.GET(t, ts.BaseURL+"/output").Header("Accept", accept).ExpectStatusOK().Execute()
+		assert.Contains(t, string(body), "key", accept)
+		assert.NotRegexp(t, `\bs\b`, string(body), accept)
+	}
+	okapitest.GET(t, ts.BaseURL+"/admin").ExpectStatusOK().ExpectBodyContains("<Secret>s</Secret>")
+	assert.Equal(t, "s", secret.Secret, "the value itself is left alone")
+}
+
+func TestScopeOfDynamicValues(t *testing.T) {
+	assert.Equal(t, noScope, scopeOf(reflect.TypeFor[Book]()))
+	assert.Equal(t, dynamicScope, scopeOf(reflect.TypeFor[M]()))
+	assert.Equal(t, staticScope, scopeOf(reflect.TypeFor[[]scopedUser]()))
+
+	assert.False(t, needsRedaction(reflect.ValueOf(M{"book": Book{Name: "Dune"}})))
+	assert.True(t, needsRedaction(reflect.ValueOf(M{"users": []any{1, scopedUser{}}})))
+
+	// Values without scoped fields are not copied
+	m := M{"book": Book{Name: "Dune"}}
+	ctx, _ := NewTestContext(http.MethodGet, "/", nil)
+	assert.Equal(t, reflect.ValueOf(m).Pointer(), reflect.ValueOf(ctx.redact(m)).Pointer())
+}
+
+func TestPrincipalFromJWT(t *testing.T) {
+	ts := NewTestServer(t)
+	auth := JWTAuth{SigningSecret: jwtTestSecret, Audience: "api", Issuer: "okapi", RolesClaim: "realm.roles"}
+	ts.Get("/me", func(c *Context) error {
+		return c.OK(scopedUser{ID: 1, Name: c.Principal().Subject, Email: "ada@example.com"})
+	}, UseMiddleware(auth.Middleware))
+
+	token := func(roles any) string {
+		return signHMACToken(t, jwt.MapClaims{
+			"sub":   "ada",
+			"aud":   "api",
+			"iss":   "okapi",
+			"realm": map[string]any{"roles": roles},
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		})
+	}
+	okapitest.GET(t, ts.BaseURL+"/me").
+		Header("Authorization", "Bearer "+token([]string{"support"})).
+		ExpectStatusOK().
+		ExpectBodyContains(`"name":"ada"`).
+		ExpectBodyContains("ada@example.com")
+	okapitest.GET(t, ts.BaseURL+"/me").
+		Header("Authorization", "Bearer "+token("viewer editor")).
+		ExpectStatusOK().
+		ExpectBodyNotContains("ada@example.com")
+
+	assert.True(t, Principal{Roles: rolesFromClaim("viewer,admin")}.HasRole("admin"))
+}
@@ -0,0 +1,46 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+// Package redis implements the okapi stores on top of Redis, so that several
+// instances of an application share their state:
+//
+//   - okapi.CacheStore, for the responses cached with Route.CacheFor;
+//   - okapi.LimiterStore, for the counters of RateLimit and PrincipalRateLimit;
+//   - okapi.JobStore, for the jobs of AsyncJobs.
+//
+// The package speaks the Redis protocol itself and depends only on the Go
+// standard library. Connections are pooled, and every command is bounded by
+// Options.Timeout and the deadline of its context.
+//
+// Okapi has no session or idempotency middleware, so there is no session or
+// idempotency store to implement.
+//
+// Example:
+//
+//	store := redis.New(redis.Options{Addr: "localhost:6379", Password: os.Getenv("REDIS_PASSWORD")})
+//	defer store.Close()
+//
+//	app := okapi.New(okapi.WithCacheStore(store), okapi.WithLimiterStore(store))
+//	okapi.AsyncJobs(app.Group("/reports"), buildReport, okapi.AsyncJobsConfig{Store: store})
+package redis
@@ -0,0 +1,319 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by the commands of a closed Store.
+var ErrClosed = errors.New("redis: store closed")
+
+// Error is an error reply of the Redis server. The connection stays usable.
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Options configures a Store.
+type Options struct {
+	// Addr is the host:port of the server. Defaults to "localhost:6379".
+	Addr string
+	// Username and Password authenticate connections with AUTH when Password
+	// is set. Username is only needed with Redis ACLs.
+	Username string
+	Password string
+	// DB is the database selected on each connection.
+	DB int
+	// TLSConfig enables TLS when set.
+	TLSConfig *tls.Config
+	// PoolSize bounds the number of open connections. Defaults to 10.
+	PoolSize int
+	// DialTimeout bounds establishing a connection. Defaults to 5s.
+	DialTimeout time.Duration
+	// Timeout bounds each command, unless its context ends earlier.
+	// Defaults to 3s.
+	Timeout time.Duration
+	// JobTTL is how long jobs are kept after their last update. Defaults to one hour.
+	JobTTL time.Duration
+}
+
+// Store is a pool of connections to a Redis server, implementing the okapi
+// stores. It is safe for concurrent use.
+type Store struct {
+	opts   Options
+	slots  chan struct{} // one per open connection
+	idle   chan *conn
+	mu     sync.Mutex // guards closed against put
+	closed chan struct{}
+}
+
+// conn is a connection to the server.
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// New returns a Store for the server described by opts. Connections are
+// opened on demand.
+func New(opts Options) *Store {
+	if opts.Addr == "" {
+		opts.Addr = "localhost:6379"
+	}
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = 10
+	}
+	if opts.DialTimeout <= 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 3 * time.Second
+	}
+	if opts.JobTTL <= 0 {
+		opts.JobTTL = time.Hour
+	}
+	return &Store{
+		opts:   opts,
+		slots:  make(chan struct{}, opts.PoolSize),
+		idle:   make(chan *conn, opts.PoolSize),
+		closed: make(chan struct{}),
+	}
+}
+
+// Close closes the idle connections; the others are closed once their
+// command completes. Commands fail with ErrClosed afterward.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.closed:
+		return nil
+	default:
+	}
+	close(s.closed)
+	var err error
+	for {
+		select {
+		case cn := <-s.idle:
+			err = errors.Join(err, cn.Close())
+		default:
+			return err
+		}
+	}
+}
+
+// Ping checks that the server answers.
+func (s *Store) Ping(ctx context.Context) error {
+	_, err := s.do(ctx, "PING")
+	return err
+}
+
+// do runs a command on a pooled connection and returns its reply.
+func (s *Store) do(ctx context.Context, args ...string) (any, error) {
+	cn, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := cn.do(ctx, s.opts.Timeout, args...)
+	var reject Error
+	s.put(cn, err != nil && !errors.As(err, &reject))
+	return reply, err
+}
+
+// get returns an idle connection, or dials a new one while the pool is not
+// full, waiting for a free slot otherwise.
+func (s *Store) get(ctx context.Context) (*conn, error) {
+	select {
+	case <-s.closed:
+		return nil, ErrClosed
+	case cn := <-s.idle:
+		return cn, nil
+	default:
+	}
+	select {
+	case <-s.closed:
+		return nil, ErrClosed
+	case cn := <-s.idle:
+		return cn, nil
+	case s.slots <- struct{}{}:
+		cn, err := s.dial(ctx)
+		if err != nil {
+			<-s.slots
+			return nil, err
+		}
+		return cn, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// put returns cn to the pool, closing it when broken or the store is closed.
+func (s *Store) put(cn *conn, broken bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.closed:
+		broken = true
+	default:
+	}
+	if !broken {
+		// Never blocks: there are at most PoolSize connections.
+		s.idle <- cn
+		return
+	}
+	_ = cn.Close()
+	<-s.slots
+}
+
+// dial opens a connection, authenticated and on the configured database.
+func (s *Store) dial(ctx context.Context) (*conn, error) {
+	dialer := &net.Dialer{Timeout: s.opts.DialTimeout, KeepAlive: 30 * time.Second}
+	var (
+		nc  net.Conn
+		err error
+	)
+	if s.opts.TLSConfig != nil {
+		nc, err = (&tls.Dialer{NetDialer: dialer, Config: s.opts.TLSConfig}).DialContext(ctx, "tcp", s.opts.Addr)
+	} else {
+		nc, err = dialer.DialContext(ctx, "tcp", s.opts.Addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis: dial %s: %w", s.opts.Addr, err)
+	}
+	cn := &conn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc)}
+	if s.opts.Password != "" {
+		args := []string{"AUTH", s.opts.Password}
+		if s.opts.Username != "" {
+			args = []string{"AUTH", s.opts.Username, s.opts.Password}
+		}
+		if _, err := cn.do(ctx, s.opts.Timeout, args...); err != nil {
+			_ = nc.Close()
+			return nil, err
+		}
+	}
+	if s.opts.DB != 0 {
+		if _, err := cn.do(ctx, s.opts.Timeout, "SELECT", strconv.Itoa(s.opts.DB)); err != nil {
+			_ = nc.Close()
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+// do sends a command and reads its reply within timeout, or until ctx ends.
+func (cn *conn) do(ctx context.Context, timeout time.Duration, args ...string) (any, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := cn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	// Unblock the connection as soon as ctx is canceled.
+	stop := context.AfterFunc(ctx, func() {
+		_ = cn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	reply, err := cn.roundTrip(args)
+	if err != nil && ctx.Err() != nil {
+		var reject Error
+		if !errors.As(err, &reject) {
+			return nil, ctx.Err()
+		}
+	}
+	return reply, err
+}
+
+func (cn *conn) roundTrip(args []string) (any, error) {
+	fmt.Fprintf(cn.w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(cn.w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := cn.w.Flush(); err != nil {
+		return nil, err
+	}
+	return cn.readReply()
+}
+
+// readReply reads a RESP2 reply: a string, []byte, int64, []any or nil, or
+// an Error.
+func (cn *conn) readReply() (any, error) {
+	line, err := cn.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, line := line[0], line[1:len(line)-2]
+	switch kind {
+	case '+':
+		return line, nil
+	case '-':
+		return nil, Error(line)
+	case ':':
+		return strconv.ParseInt(line, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(cn.r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line)
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		values := make([]any, n)
+		for i := range values {
+			// Error elements are kept, so that the whole reply is read.
+			if values[i], err = cn.readReply(); err != nil {
+				var reject Error
+				if !errors.As(err, &reject) {
+					return nil, err
+				}
+				values[i] = reject
+			}
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+}
@@ -0,0 +1,309 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi"
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer speaks enough of the Redis protocol for the Store commands.
+type fakeServer struct {
+	listener net.Listener
+	password string
+	hang     bool // read commands without answering
+
+	mu       sync.Mutex
+	values   map[string]string
+	expires  map[string]time.Time
+	commands []string
+	conns    int
+}
+
+// newFakeServer starts a server requiring password when set; a hanging
+// server never answers.
+func newFakeServer(t *testing.T, password string, hang bool) *fakeServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{
+		listener: ln, password: password, hang: hang,
+		values: map[string]string{}, expires: map[string]time.Time{},
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			nc, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns++
+			s.mu.Unlock()
+			go s.serve(nc)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands...)
+}
+
+func (s *fakeServer) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+func (s *fakeServer) serve(nc net.Conn) {
+	defer func() { _ = nc.Close() }()
+	r, w := bufio.NewReader(nc), bufio.NewWriter(nc)
+	authenticated := s.password == ""
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, strings.Join(args, " "))
+		s.mu.Unlock()
+		if s.hang {
+			continue
+		}
+		command := strings.ToUpper(args[0])
+		switch {
+		case command == "AUTH":
+			authenticated = args[len(args)-1] == s.password
+			if !authenticated {
+				w.WriteString("-WRONGPASS invalid password\r\n")
+			} else {
+				w.WriteString("+OK\r\n")
+			}
+		case !authenticated:
+			w.WriteString("-NOAUTH Authentication required.\r\n")
+		default:
+			s.reply(w, command, args[1:])
+		}
+		_ = w.Flush()
+	}
+}
+
+func (s *fakeServer) reply(w *bufio.Writer, command string, args []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, at := range s.expires {
+		if !time.Now().Before(at) {
+			delete(s.values, key)
+			delete(s.expires, key)
+		}
+	}
+	switch command {
+	case "PING":
+		w.WriteString("+PONG\r\n")
+	case "SELECT":
+		w.WriteString("+OK\r\n")
+	case "GET":
+		value, ok := s.values[args[0]]
+		if !ok {
+			w.WriteString("$-1\r\n")
+			return
+		}
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		s.values[args[0]] = args[1]
+		ms, _ := strconv.Atoi(args[3])
+		s.expires[args[0]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		w.WriteString("+OK\r\n")
+	case "EVAL":
+		key := args[2]
+		n, _ := strconv.Atoi(s.values[key])
+		n++
+		s.values[key] = strconv.Itoa(n)
+		if n == 1 {
+			ms, _ := strconv.Atoi(args[3])
+			s.expires[key] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		fmt.Fprintf(w, "*2\r\n:%d\r\n:%d\r\n", n, time.Until(s.expires[key]).Milliseconds())
+	default:
+		fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", command)
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if line, err = r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestStoreCache(t *testing.T) {
+	server := newFakeServer(t, "", false)
+	store := New(Options{Addr: server.addr()})
+	defer store.Close()
+	ctx := context.Background()
+
+	require.NoError(t, store.Ping(ctx))
+	_, ok, err := store.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.Set(ctx, "key", []byte("value\r\nwith lines"), time.Minute))
+	value, ok, err := store.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "value\r\nwith lines", string(value))
+
+	require.NoError(t, store.Set(ctx, "short", []byte("x"), 20*time.Millisecond))
+	time.Sleep(40 * time.Millisecond)
+	_, ok, err = store.Get(ctx, "short")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 1, server.connections(), "the connection is reused")
+}
+
+func TestStoreIncrement(t *testing.T) {
+	server := newFakeServer(t, "", false)
+	store := New(Options{Addr: server.addr()})
+	defer store.Close()
+
+	count, reset, err := store.Increment(context.Background(), "okapi:ratelimit:api:1.2.3.4", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), reset, time.Second)
+	count, _, err = store.Increment(context.Background(), "okapi:ratelimit:api:1.2.3.4", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestStoreJobs(t *testing.T) {
+	server := newFakeServer(t, "", false)
+	store := New(Options{Addr: server.addr()})
+	defer store.Close()
+	ctx := context.Background()
+
+	job := &okapi.AsyncJob{ID: "job-1", Status: okapi.AsyncJobSucceeded, Progress: 100, Result: []byte(`{"total":3}`)}
+	require.NoError(t, store.SaveJob(ctx, job))
+	got, err := store.GetJob(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, okapi.AsyncJobSucceeded, got.Status)
+	assert.JSONEq(t, `{"total":3}`, string(got.Result))
+
+	_, err = store.GetJob(ctx, "job-2")
+	assert.ErrorIs(t, err, okapi.ErrAsyncJobNotFound)
+}
+
+func TestStoreAuthAndErrors(t *testing.T) {
+	server := newFakeServer(t, "secret", false)
+
+	store := New(Options{Addr: server.addr(), Password: "wrong"})
+	var reject Error
+	assert.ErrorAs(t, store.Ping(context.Background()), &reject)
+	_ = store.Close()
+
+	store = New(Options{Addr: server.addr(), Username: "app", Password: "secret", DB: 2})
+	defer store.Close()
+	require.NoError(t, store.Ping(context.Background()))
+	assert.Contains(t, server.received(), "AUTH app secret")
+	assert.Contains(t, server.received(), "SELECT 2")
+
+	// Error replies keep the connection.
+	_, err := store.do(context.Background(), "UNKNOWN")
+	assert.ErrorAs(t, err, &reject)
+	require.NoError(t, store.Ping(context.Background()))
+
+	require.NoError(t, store.Close())
+	assert.ErrorIs(t, store.Ping(context.Background()), ErrClosed)
+}
+
+func TestStoreTimeouts(t *testing.T) {
+	server := newFakeServer(t, "", true)
+	store := New(Options{Addr: server.addr(), Timeout: 50 * time.Millisecond, PoolSize: 1})
+	defer store.Close()
+
+	start := time.Now()
+	err := store.Ping(context.Background())
+	var netErr net.Error
+	assert.True(t, errors.As(err, &netErr) && netErr.Timeout(), "err = %v", err)
+	assert.Less(t, time.Since(start), time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	store.opts.Timeout = time.Minute
+	assert.ErrorIs(t, store.Ping(ctx), context.Canceled)
+}
+
+func TestStoreSharedRateLimit(t *testing.T) {
+	server := newFakeServer(t, "", false)
+	store := New(Options{Addr: server.addr()})
+	defer store.Close()
+	limiter := &okapi.RateLimit{Limit: 1, Name: "api"}
+
+	first := okapi.NewTestServerWithOkapi(t, okapi.New(okapi.WithLimiterStore(store)))
+	first.Get("/limited", func(c *okapi.Context) error { return c.OK(okapi.M{"ok": true}) }, okapi.UseMiddleware(limiter.Middleware))
+	second := okapi.NewTestServerWithOkapi(t, okapi.New(okapi.WithLimiterStore(store)))
+	second.Get("/limited", func(c *okapi.Context) error { return c.OK(okapi.M{"ok": true}) }, okapi.UseMiddleware(limiter.Middleware))
+
+	okapitest.GET(t, first.BaseURL+"/limited").ExpectStatusOK()
+	okapitest.GET(t, second.BaseURL+"/limited").ExpectStatus(http.StatusTooManyRequests)
+}
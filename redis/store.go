@@ -0,0 +1,133 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jkaninda/okapi"
+)
+
+var (
+	_ okapi.CacheStore   = (*Store)(nil)
+	_ okapi.LimiterStore = (*Store)(nil)
+	_ okapi.JobStore     = (*Store)(nil)
+)
+
+// jobKeyPrefix prefixes the keys of jobs.
+const jobKeyPrefix = "okapi:job:"
+
+// incrementScript counts a request and starts the window on the first one,
+// atomically, returning the count and the time left in milliseconds.
+const incrementScript = `local n = redis.call('INCR', KEYS[1])
+if n == 1 then redis.call('PEXPIRE', KEYS[1], ARGV[1]) end
+return {n, redis.call('PTTL', KEYS[1])}`
+
+// storedJob is the stored form of a job, including its result.
+type storedJob struct {
+	okapi.AsyncJob
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// Get implements okapi.CacheStore.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := s.do(ctx, "GET", key)
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, unexpected("GET", reply)
+	}
+	return value, true, nil
+}
+
+// Set implements okapi.CacheStore.
+func (s *Store) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.do(ctx, "SET", key, string(value), "PX", milliseconds(ttl))
+	return err
+}
+
+// Increment implements okapi.LimiterStore.
+func (s *Store) Increment(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	reply, err := s.do(ctx, "EVAL", incrementScript, "1", key, milliseconds(window))
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	values, ok := reply.([]any)
+	if !ok || len(values) != 2 {
+		return 0, time.Time{}, unexpected("EVAL", reply)
+	}
+	count, ok := values[0].(int64)
+	left, ok2 := values[1].(int64)
+	if !ok || !ok2 {
+		return 0, time.Time{}, unexpected("EVAL", reply)
+	}
+	if left < 0 {
+		left = window.Milliseconds()
+	}
+	return int(count), time.Now().Add(time.Duration(left) * time.Millisecond), nil
+}
+
+// SaveJob implements okapi.JobStore. Jobs expire JobTTL after their last update.
+func (s *Store) SaveJob(ctx context.Context, job *okapi.AsyncJob) error {
+	data, err := json.Marshal(storedJob{AsyncJob: *job, Result: job.Result})
+	if err != nil {
+		return err
+	}
+	_, err = s.do(ctx, "SET", jobKeyPrefix+job.ID, string(data), "PX", milliseconds(s.opts.JobTTL))
+	return err
+}
+
+// GetJob implements okapi.JobStore.
+func (s *Store) GetJob(ctx context.Context, id string) (*okapi.AsyncJob, error) {
+	data, ok, err := s.Get(ctx, jobKeyPrefix+id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, okapi.ErrAsyncJobNotFound
+	}
+	var stored storedJob
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("redis: decode job %s: %w", id, err)
+	}
+	job := stored.AsyncJob
+	job.Result = stored.Result
+	return &job, nil
+}
+
+// milliseconds formats d for PX and PEXPIRE, which require at least 1ms.
+func milliseconds(d time.Duration) string {
+	return strconv.FormatInt(max(d.Milliseconds(), 1), 10)
+}
+
+func unexpected(command string, reply any) error {
+	return fmt.Errorf("redis: unexpected %s reply %T", command, reply)
+}
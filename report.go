@@ -0,0 +1,226 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// defaultReportSamples is the number of latencies kept per route by default.
+const defaultReportSamples = 1024
+
+type (
+	// RouteReportConfig configures the per-route latency report, see WithRouteReport.
+	RouteReportConfig struct {
+		// Samples is the number of most recent latencies kept per route to
+		// compute percentiles. Defaults to 1024.
+		Samples int
+		// Path, when set, serves the report on a hidden GET endpoint: a text
+		// table, or JSON for clients accepting application/json.
+		Path string
+		// LogOnShutdown logs the report when the server is stopped.
+		LogOnShutdown bool
+	}
+
+	// RouteReport holds the latency and error aggregates of a route since start.
+	RouteReport struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+		// Requests, ClientErrors (4xx) and ServerErrors (5xx) count every request.
+		Requests     uint64 `json:"requests"`
+		ClientErrors uint64 `json:"client_errors"`
+		ServerErrors uint64 `json:"server_errors"`
+		// Percentiles are computed over the most recent samples.
+		P50 time.Duration `json:"p50"`
+		P95 time.Duration `json:"p95"`
+		P99 time.Duration `json:"p99"`
+		Max time.Duration `json:"max"`
+	}
+
+	// routeReporter collects the aggregates of every route.
+	routeReporter struct {
+		samples int
+		mu      sync.Mutex
+		routes  map[*Route]*routeStats
+	}
+
+	// routeStats holds the aggregates of one route, with its latencies in a ring buffer.
+	routeStats struct {
+		mu           sync.Mutex
+		latencies    []time.Duration
+		next         int
+		requests     uint64
+		clientErrors uint64
+		serverErrors uint64
+		max          time.Duration
+	}
+)
+
+// WithRouteReport collects per-route latency and error aggregates in memory,
+// a lightweight view of route performance without a metrics stack. The report
+// is available with o.Report and, optionally, on a debug endpoint.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithRouteReport(okapi.RouteReportConfig{
+//		Path:          "/debug/routes",
+//		LogOnShutdown: true,
+//	}))
+func WithRouteReport(cfg RouteReportConfig) OptionFunc {
+	return func(o *Okapi) {
+		if cfg.Samples <= 0 {
+			cfg.Samples = defaultReportSamples
+		}
+		o.reportConfig = cfg
+		if o.reporter == nil {
+			o.reporter = &routeReporter{routes: make(map[*Route]*routeStats)}
+		}
+		o.reporter.mu.Lock()
+		o.reporter.samples = cfg.Samples
+		o.reporter.mu.Unlock()
+		if cfg.Path != "" {
+			o.registerRoute(methodGet, cfg.Path, nil, o.serveReport, Hide()).internalRoute()
+		}
+	}
+}
+
+// WithRouteReport collects per-route latency and error aggregates, see WithRouteReport.
+func (o *Okapi) WithRouteReport(cfg RouteReportConfig) *Okapi {
+	return o.apply(WithRouteReport(cfg))
+}
+
+// Report returns the latency and error aggregates of the routes served since
+// start, ordered by path and method. It is empty unless WithRouteReport is set.
+func (o *Okapi) Report() []RouteReport {
+	if o.reporter == nil {
+		return nil
+	}
+	return o.reporter.report()
+}
+
+// WriteReport writes the route report as a text table to w.
+func (o *Okapi) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "METHOD\tPATH\tREQUESTS\t4XX\t5XX\tP50\tP95\tP99\tMAX")
+	for _, r := range o.Report() {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\n", r.Method, r.Path, r.Requests,
+			r.ClientErrors, r.ServerErrors, r.P50, r.P95, r.P99, r.Max)
+	}
+	return tw.Flush()
+}
+
+// serveReport serves the route report.
+func (o *Okapi) serveReport(c *Context) error {
+	if strings.Contains(c.Header("Accept"), constJSON) {
+		return c.OK(o.Report())
+	}
+	var b strings.Builder
+	_ = o.WriteReport(&b)
+	return c.String(http.StatusOK, b.String())
+}
+
+// logReport logs the route report, when enabled, at shutdown.
+func (o *Okapi) logReport() {
+	if o.reporter == nil || !o.reportConfig.LogOnShutdown {
+		return
+	}
+	for _, r := range o.Report() {
+		o.logger.Info("[okapi] route report", "method", r.Method, "path", r.Path, "requests", r.Requests,
+			"client_errors", r.ClientErrors, "server_errors", r.ServerErrors,
+			"p50", r.P50, "p95", r.P95, "p99", r.P99, "max", r.Max)
+	}
+}
+
+// record adds a served request to the route's aggregates.
+func (rr *routeReporter) record(route *Route, status int, elapsed time.Duration) {
+	rr.mu.Lock()
+	s, ok := rr.routes[route]
+	if !ok {
+		s = &routeStats{latencies: make([]time.Duration, 0, rr.samples)}
+		rr.routes[route] = s
+	}
+	rr.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	switch {
+	case status >= 500:
+		s.serverErrors++
+	case status >= 400:
+		s.clientErrors++
+	}
+	s.max = max(s.max, elapsed)
+	if len(s.latencies) < cap(s.latencies) {
+		s.latencies = append(s.latencies, elapsed)
+		return
+	}
+	s.latencies[s.next] = elapsed
+	s.next = (s.next + 1) % len(s.latencies)
+}
+
+// report returns the aggregates of every route.
+func (rr *routeReporter) report() []RouteReport {
+	rr.mu.Lock()
+	reports := make([]RouteReport, 0, len(rr.routes))
+	for route, s := range rr.routes {
+		s.mu.Lock()
+		latencies := slices.Clone(s.latencies)
+		r := RouteReport{
+			Method:       route.Method,
+			Path:         route.Path,
+			Requests:     s.requests,
+			ClientErrors: s.clientErrors,
+			ServerErrors: s.serverErrors,
+			Max:          s.max,
+		}
+		s.mu.Unlock()
+		slices.Sort(latencies)
+		r.P50, r.P95, r.P99 = percentile(latencies, 50), percentile(latencies, 95), percentile(latencies, 99)
+		reports = append(reports, r)
+	}
+	rr.mu.Unlock()
+	slices.SortFunc(reports, func(a, b RouteReport) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Method, b.Method))
+	})
+	return reports
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
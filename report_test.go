@@ -0,0 +1,111 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+func TestRouteReport(t *testing.T) {
+	o := New(WithRouteReport(RouteReportConfig{Samples: 4, Path: "/debug/routes"}))
+	ts := NewTestServerWithOkapi(t, o)
+	ts.Get("/books/:id", func(c *Context) error {
+		switch c.Param("id") {
+		case "missing":
+			return c.AbortNotFound("Not Found")
+		case "broken":
+			return c.AbortInternalServerError("Internal Server Error")
+		}
+		return c.OK(M{"id": c.Param("id")})
+	})
+	ts.Post("/books", func(c *Context) error {
+		time.Sleep(2 * time.Millisecond)
+		return c.Created(M{})
+	})
+
+	for _, id := range []string{"1", "2", "3", "4", "5", "missing", "broken"} {
+		okapitest.GET(t, ts.BaseURL+"/books/"+id).Execute()
+	}
+	okapitest.POST(t, ts.BaseURL+"/books").ExpectStatus(http.StatusCreated)
+
+	report := o.Report()
+	if len(report) != 2 {
+		t.Fatalf("expected 2 routes in the report, got %+v", report)
+	}
+	books, book := report[0], report[1]
+	if books.Method != http.MethodPost || books.Requests != 1 || books.P99 < 2*time.Millisecond {
+		t.Errorf("unexpected POST /books report: %+v", books)
+	}
+	if book.Path != "/books/{id}" || book.Requests != 7 || book.ClientErrors != 1 || book.ServerErrors != 1 {
+		t.Errorf("unexpected GET /books/{id} report: %+v", book)
+	}
+	if book.P50 > book.P95 || book.P95 > book.P99 || book.P99 > book.Max {
+		t.Errorf("percentiles out of order: %+v", book)
+	}
+
+	_, body := okapitest.GET(t, ts.BaseURL+"/debug/routes").ExpectStatusOK().Execute()
+	if !strings.Contains(string(body), "P95") || !strings.Contains(string(body), "/books/{id}") {
+		t.Errorf("unexpected text report: %s", body)
+	}
+	okapitest.GET(t, ts.BaseURL+"/debug/routes").
+		Header("Accept", "application/json").
+		ExpectStatusOK().
+		ExpectBodyContains(`"path":"/books/{id}"`)
+	if len(o.Report()) != 2 {
+		t.Error("the report endpoint should not be reported")
+	}
+}
+
+func TestRouteReportDisabled(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/", helloHandler)
+	okapitest.GET(t, ts.BaseURL+"/").ExpectStatusOK()
+	if report := ts.Report(); report != nil {
+		t.Errorf("expected no report, got %+v", report)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	for p, want := range map[int]time.Duration{50: 50 * time.Millisecond, 95: 95 * time.Millisecond, 99: 99 * time.Millisecond} {
+		if got := percentile(sorted, p); got != want {
+			t.Errorf("p%d = %s, want %s", p, got, want)
+		}
+	}
+	if got := percentile(sorted[:1], 99); got != time.Millisecond {
+		t.Errorf("single sample p99 = %s", got)
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("empty p50 = %s", got)
+	}
+}
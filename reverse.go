@@ -0,0 +1,153 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// WithName names the route, for URL generation with Okapi.URL.
+// Routes are named after their handler function by default.
+func (r *Route) WithName(name string) *Route {
+	r.Name = name
+	return r
+}
+
+// RouteName is the RouteOption form of Route.WithName.
+func RouteName(name string) RouteOption {
+	return func(r *Route) {
+		r.WithName(name)
+	}
+}
+
+// URL returns the path of the route called name, with its path parameters
+// replaced by params, given as key/value pairs. Pairs not matching a path
+// parameter are added as query parameters.
+//
+// Example:
+//
+//	o.Get("/books/{id}", getBook).WithName("book")
+//	path, err := o.URL("book", "id", "42") // "/books/42"
+func (o *Okapi) URL(name string, params ...string) (string, error) {
+	route := o.namedRoute(name)
+	if route == nil {
+		return "", fmt.Errorf("okapi: no route named %q", name)
+	}
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("okapi: URL for route %q: params must be key/value pairs", name)
+	}
+	values := make(map[string]string, len(params)/2)
+	var keys []string
+	for i := 0; i < len(params); i += 2 {
+		if _, ok := values[params[i]]; !ok {
+			keys = append(keys, params[i])
+		}
+		values[params[i]] = params[i+1]
+	}
+
+	var b strings.Builder
+	path := route.Path
+	for {
+		start := strings.IndexByte(path, '{')
+		if start < 0 {
+			b.WriteString(path)
+			break
+		}
+		end := closingBrace(path, start)
+		if end < 0 {
+			return "", fmt.Errorf("okapi: URL for route %q: malformed path %q", name, route.Path)
+		}
+		param, _, _ := strings.Cut(path[start+1:end], ":")
+		value, ok := values[param]
+		if !ok {
+			return "", fmt.Errorf("okapi: URL for route %q: missing path parameter %q", name, param)
+		}
+		delete(values, param)
+		b.WriteString(path[:start])
+		b.WriteString(url.PathEscape(value))
+		path = path[end+1:]
+	}
+
+	query := url.Values{}
+	for _, key := range keys {
+		if value, ok := values[key]; ok {
+			query.Set(key, value)
+		}
+	}
+	if len(query) > 0 {
+		return b.String() + "?" + query.Encode(), nil
+	}
+	return b.String(), nil
+}
+
+// URL returns the path of the route called name, see Okapi.URL.
+func (c *Context) URL(name string, params ...string) (string, error) {
+	if c.okapi == nil {
+		return "", fmt.Errorf("okapi: no route named %q", name)
+	}
+	return c.okapi.URL(name, params...)
+}
+
+// namedRoute returns the first route called name.
+func (o *Okapi) namedRoute(name string) *Route {
+	for _, r := range o.routes {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// location resolves the location arguments of Created and Accepted: a route
+// name followed by its params, or a URL used as is.
+func (c *Context) location(location []string) (string, error) {
+	if c.okapi != nil && c.okapi.namedRoute(location[0]) != nil {
+		return c.okapi.URL(location[0], location[1:]...)
+	}
+	if len(location) > 1 {
+		return "", fmt.Errorf("okapi: no route named %q", location[0])
+	}
+	return location[0], nil
+}
+
+// closingBrace returns the index of the brace closing the one at start,
+// accounting for braces nested in a pattern, or -1.
+func closingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,104 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURL(t *testing.T) {
+	o := New()
+	o.Get("/books/{id}", anyHandler).WithName("book")
+	o.Get("/books/:id/pages/{page:[0-9]{1,3}}", anyHandler, RouteName("page"))
+	o.Get("/books", anyHandler).WithName("books")
+
+	path, err := o.URL("book", "id", "42")
+	require.NoError(t, err)
+	assert.Equal(t, "/books/42", path)
+
+	path, err = o.URL("page", "id", "a b", "page", "7")
+	require.NoError(t, err)
+	assert.Equal(t, "/books/a%20b/pages/7", path)
+
+	path, err = o.URL("books", "sort", "title")
+	require.NoError(t, err)
+	assert.Equal(t, "/books?sort=title", path)
+
+	_, err = o.URL("book")
+	assert.ErrorContains(t, err, `missing path parameter "id"`)
+	_, err = o.URL("book", "id")
+	assert.Error(t, err)
+	_, err = o.URL("unknown")
+	assert.Error(t, err)
+}
+
+func TestCreatedAndAcceptedLocations(t *testing.T) {
+	o := New()
+	o.Get("/books/{id}", anyHandler).WithName("book")
+	o.Get("/jobs/{id}", anyHandler).WithName("job-status")
+	o.Post("/books", func(c *Context) error {
+		return c.Created(M{"id": 7}, "book", "id", "7")
+	})
+	o.Post("/imports", func(c *Context) error {
+		return c.Accepted(M{"job": "j1"}, 1500*time.Millisecond, "job-status", "id", "j1")
+	})
+	o.Post("/exports", func(c *Context) error {
+		return c.Accepted(nil, 0, "https://jobs.example.com/e1")
+	})
+	o.Post("/broken", func(c *Context) error {
+		return c.Created(M{}, "missing", "id", "1")
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		return rec
+	}
+
+	rec := serve("/books")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "/books/7", rec.Header().Get("Location"))
+
+	rec = serve("/imports")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "/jobs/j1", rec.Header().Get("Content-Location"))
+	assert.Equal(t, "/jobs/j1", rec.Header().Get("Location"))
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"job":"j1"}`, rec.Body.String())
+
+	rec = serve("/exports")
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "https://jobs.example.com/e1", rec.Header().Get("Content-Location"))
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+	assert.Empty(t, rec.Body.String())
+
+	assert.Equal(t, http.StatusInternalServerError, serve("/broken").Code)
+}
@@ -0,0 +1,212 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+type (
+	// RoomMember is a connection that can join rooms, such as a WebSocketConn.
+	RoomMember interface {
+		Send(data []byte) error
+	}
+
+	// RoomMiddleware authorizes a member joining a room. Returning an error denies the join.
+	RoomMiddleware func(c *Context, room string) error
+
+	// RoomBroker relays broadcasts between instances, e.g. over Redis pub/sub or NATS.
+	// Publish must deliver the message to the subscribers of every instance,
+	// including the publishing one.
+	RoomBroker interface {
+		Publish(ctx context.Context, room string, data []byte) error
+		Subscribe(deliver func(room string, data []byte)) error
+	}
+
+	// Rooms groups connections into named rooms to broadcast messages to them,
+	// for chat or presence features. It is safe for concurrent use.
+	Rooms struct {
+		mu              sync.RWMutex
+		rooms           map[string]map[RoomMember]struct{}
+		middlewares     []RoomMiddleware
+		roomMiddlewares map[string][]RoomMiddleware
+		broker          RoomBroker
+	}
+)
+
+// NewRooms returns an empty room manager.
+//
+// Example:
+//
+//	rooms := okapi.NewRooms()
+//	rooms.UseRoom("admin:*", func(c *okapi.Context, room string) error {
+//		if c.GetString("role") != "admin" {
+//			return errors.New("forbidden")
+//		}
+//		return nil
+//	})
+//
+//	o.Get("/ws/:room", func(c *okapi.Context) error {
+//		return c.WebSocket(func(ws *okapi.WebSocketConn) error {
+//			room := c.Param("room")
+//			if err := rooms.Join(c, room, ws); err != nil {
+//				return nil
+//			}
+//			defer rooms.LeaveAll(ws)
+//			return ws.ReadMessages(func(_ okapi.WebSocketMessageType, data []byte) error {
+//				return rooms.Broadcast(ws.Context(), room, data)
+//			})
+//		})
+//	})
+func NewRooms() *Rooms {
+	return &Rooms{
+		rooms:           make(map[string]map[RoomMember]struct{}),
+		roomMiddlewares: make(map[string][]RoomMiddleware),
+	}
+}
+
+// Use registers middlewares run when a member joins any room.
+func (r *Rooms) Use(mw ...RoomMiddleware) *Rooms {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middlewares = append(r.middlewares, mw...)
+	return r
+}
+
+// UseRoom registers middlewares run when a member joins the given room.
+// A pattern ending with "*" matches every room starting with the prefix.
+func (r *Rooms) UseRoom(pattern string, mw ...RoomMiddleware) *Rooms {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.roomMiddlewares[pattern] = append(r.roomMiddlewares[pattern], mw...)
+	return r
+}
+
+// WithBroker relays broadcasts through broker so members connected to other
+// instances receive them too.
+func (r *Rooms) WithBroker(broker RoomBroker) error {
+	if err := broker.Subscribe(r.deliver); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.broker = broker
+	return nil
+}
+
+// Join adds m to room once the room middlewares, run with the request context c,
+// allowed it.
+func (r *Rooms) Join(c *Context, room string, m RoomMember) error {
+	for _, mw := range r.middlewaresFor(room) {
+		if err := mw(c, room); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	members, ok := r.rooms[room]
+	if !ok {
+		members = make(map[RoomMember]struct{})
+		r.rooms[room] = members
+	}
+	members[m] = struct{}{}
+	return nil
+}
+
+// Leave removes m from room.
+func (r *Rooms) Leave(room string, m RoomMember) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.leave(room, m)
+}
+
+// LeaveAll removes m from every room, typically when its connection closes.
+func (r *Rooms) LeaveAll(m RoomMember) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for room := range r.rooms {
+		r.leave(room, m)
+	}
+}
+
+// Members returns the members of room connected to this instance.
+func (r *Rooms) Members(room string) []RoomMember {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	members := make([]RoomMember, 0, len(r.rooms[room]))
+	for m := range r.rooms[room] {
+		members = append(members, m)
+	}
+	return members
+}
+
+// Broadcast sends data to every member of room, through the broker when one is set.
+// Members whose Send fails are considered disconnected and removed from all rooms.
+func (r *Rooms) Broadcast(ctx context.Context, room string, data []byte) error {
+	r.mu.RLock()
+	broker := r.broker
+	r.mu.RUnlock()
+	if broker != nil {
+		return broker.Publish(ctx, room, data)
+	}
+	r.deliver(room, data)
+	return nil
+}
+
+// deliver sends data to the local members of room.
+func (r *Rooms) deliver(room string, data []byte) {
+	for _, m := range r.Members(room) {
+		if err := m.Send(data); err != nil {
+			r.LeaveAll(m)
+		}
+	}
+}
+
+// leave removes m from room. The caller must hold r.mu.
+func (r *Rooms) leave(room string, m RoomMember) {
+	members, ok := r.rooms[room]
+	if !ok {
+		return
+	}
+	delete(members, m)
+	if len(members) == 0 {
+		delete(r.rooms, room)
+	}
+}
+
+// middlewaresFor returns the middlewares applying to room, global ones first.
+func (r *Rooms) middlewaresFor(room string) []RoomMiddleware {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	mws := append([]RoomMiddleware(nil), r.middlewares...)
+	for _, pattern := range sortedKeys(r.roomMiddlewares) {
+		if pattern == room || (strings.HasSuffix(pattern, "*") && strings.HasPrefix(room, strings.TrimSuffix(pattern, "*"))) {
+			mws = append(mws, r.roomMiddlewares[pattern]...)
+		}
+	}
+	return mws
+}
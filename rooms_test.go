@@ -0,0 +1,140 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// fakeMember records the messages sent to it.
+type fakeMember struct {
+	mu       sync.Mutex
+	received []string
+	fail     bool
+}
+
+func (m *fakeMember) Send(data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.fail {
+		return errors.New("connection closed")
+	}
+	m.received = append(m.received, string(data))
+	return nil
+}
+
+func (m *fakeMember) messages() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.received...)
+}
+
+// localBroker relays broadcasts between Rooms of the same process.
+type localBroker struct {
+	mu          sync.Mutex
+	subscribers []func(room string, data []byte)
+}
+
+func (b *localBroker) Publish(_ context.Context, room string, data []byte) error {
+	b.mu.Lock()
+	subs := append([]func(string, []byte){}, b.subscribers...)
+	b.mu.Unlock()
+	for _, deliver := range subs {
+		deliver(room, data)
+	}
+	return nil
+}
+
+func (b *localBroker) Subscribe(deliver func(room string, data []byte)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, deliver)
+	return nil
+}
+
+func TestRooms(t *testing.T) {
+	rooms := NewRooms()
+	rooms.UseRoom("admin:*", func(c *Context, room string) error {
+		if c.Header("X-Role") != "admin" {
+			return errors.New("forbidden")
+		}
+		return nil
+	})
+	ctx, _ := NewTestContext(http.MethodGet, "/ws", nil)
+
+	alice, bob, gone := &fakeMember{}, &fakeMember{}, &fakeMember{fail: true}
+	for _, m := range []*fakeMember{alice, bob, gone} {
+		if err := rooms.Join(ctx, "lobby", m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rooms.Join(ctx, "admin:ops", alice); err == nil {
+		t.Error("expected the admin room middleware to deny the join")
+	}
+	ctx.Request().Header.Set("X-Role", "admin")
+	if err := rooms.Join(ctx, "admin:ops", alice); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = rooms.Broadcast(context.Background(), "lobby", []byte("hello"))
+	_ = rooms.Broadcast(context.Background(), "admin:ops", []byte("deploy"))
+	rooms.Leave("lobby", bob)
+	_ = rooms.Broadcast(context.Background(), "lobby", []byte("bye"))
+
+	if got := alice.messages(); len(got) != 3 {
+		t.Errorf("alice received %v", got)
+	}
+	if got := bob.messages(); len(got) != 1 || got[0] != "hello" {
+		t.Errorf("bob received %v", got)
+	}
+	if got := len(rooms.Members("lobby")); got != 1 {
+		t.Errorf("lobby has %d members, want 1 after dropping the failed one", got)
+	}
+}
+
+func TestRoomsBroker(t *testing.T) {
+	broker := &localBroker{}
+	nodeA, nodeB := NewRooms(), NewRooms()
+	for _, r := range []*Rooms{nodeA, nodeB} {
+		if err := r.WithBroker(broker); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, _ := NewTestContext(http.MethodGet, "/ws", nil)
+	onA, onB := &fakeMember{}, &fakeMember{}
+	_ = nodeA.Join(ctx, "chat", onA)
+	_ = nodeB.Join(ctx, "chat", onB)
+
+	if err := nodeA.Broadcast(context.Background(), "chat", []byte("hi")); err != nil {
+		t.Fatal(err)
+	}
+	if len(onA.messages()) != 1 || len(onB.messages()) != 1 {
+		t.Errorf("broadcast did not reach both instances: %v %v", onA.messages(), onB.messages())
+	}
+}
@@ -31,26 +31,26 @@ import (
 
 type RouteDefinition struct {
 	// Method is the HTTP method for the route (e.g., GET, POST, PUT, DELETE, etc.)
-	Method string
+	Method string `json:"method" yaml:"method"`
 	// Path is the URL path for the route, relative to the base path of the Okapi instance or group
-	Path string
+	Path string `json:"path" yaml:"path"`
 	// Handler is the function that will handle requests to this route
-	Handler HandlerFunc
+	Handler HandlerFunc `json:"-" yaml:"-"`
 	// Group attach Route to a Group // Optional
-	Group *Group
+	Group *Group `json:"-" yaml:"-"`
 	// OperationId is an optional unique identifier for the route, primarily
 	// used in OpenAPI documentation to distinguish operations.
-	OperationId string
+	OperationId string `json:"operationId,omitempty" yaml:"operationId,omitempty"`
 	// Summary is an optional short description of the route,
 	// used for OpenAPI documentation.
 	// Example: "Create a new book"
-	Summary string
+	Summary string `json:"summary,omitempty" yaml:"summary,omitempty"`
 	// Description is an optional detailed description of the route,
 	// used for OpenAPI documentation.
 	// Example:  "This endpoint allows clients to create a new book in the system by providing the necessary details."
-	Description string
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 	// Tags sets the tags for the Route, which can be used for documentation purposes. // Optional
-	Tags []string
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 
 	// Request optionally defines the expected input schema for the route.
 	// It can be a struct or pointer to a struct with binding tags (query, path, header, cookie, form, body).
@@ -75,7 +75,7 @@ type RouteDefinition struct {
 	//       Path:    "/books",
 	//       Request: &CreateBookInput{},
 	//   }
-	Request any
+	Request any `json:"-" yaml:"-"`
 
 	// Response optionally defines the output schema for the route.
 	// It can be any type (struct, slice, map, etc.). If provided, Okapi will:
@@ -95,14 +95,22 @@ type RouteDefinition struct {
 	//       Request:  &CreateBookInput{},
 	//       Response: &BookResponse{},
 	//   }
-	Response any
+	Response any `json:"-" yaml:"-"`
 	// Security defines the security requirements for the route, such as authentication schemes // Optional
 	// It can be also applied at Group level.
-	Security []map[string][]string
+	Security []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
 	// RouteOption registers one or more OpenAPI Doc and middleware functions to the Route. // Optional
-	Options []RouteOption
+	Options []RouteOption `json:"-" yaml:"-"`
 	// Middleware registers one or more middleware functions to the Route. // Optional
-	Middlewares []Middleware
+	Middlewares []Middleware `json:"-" yaml:"-"`
+
+	// HandlerName names the Handler in the HandlerRegistry given to LoadRoutes.
+	HandlerName string `json:"handler,omitempty" yaml:"handler,omitempty"`
+	// MiddlewareNames names middlewares in the HandlerRegistry given to LoadRoutes,
+	// applied after Middlewares. // Optional
+	MiddlewareNames []string `json:"middlewares,omitempty" yaml:"middlewares,omitempty"`
+	// GroupName names the Group in the HandlerRegistry given to LoadRoutes. // Optional
+	GroupName string `json:"group,omitempty" yaml:"group,omitempty"`
 }
 
 // RegisterRoutes registers a slice of RouteDefinition with the given Okapi instance.
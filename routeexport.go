@@ -0,0 +1,160 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// routeAnnotation describes a route of the manifest written by ExportRouteManifest.
+type routeAnnotation struct {
+	Name        string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Method      string   `json:"method" yaml:"method"`
+	Path        string   `json:"path" yaml:"path"`
+	PathRegexp  string   `json:"path_regexp,omitempty" yaml:"path_regexp,omitempty"`
+	Group       string   `json:"group,omitempty" yaml:"group,omitempty"`
+	Auth        []string `json:"auth,omitempty" yaml:"auth,omitempty"`
+	Timeout     string   `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	RateLimited bool     `json:"rate_limited,omitempty" yaml:"rate_limited,omitempty"`
+	Streaming   bool     `json:"streaming,omitempty" yaml:"streaming,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Sunset      string   `json:"sunset,omitempty" yaml:"sunset,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// routeAnnotations is the document written by ExportRouteManifest.
+type routeAnnotations struct {
+	Routes []routeAnnotation `json:"routes" yaml:"routes"`
+}
+
+// authMiddlewares maps the authentication middlewares to the security scheme
+// they implement.
+var authMiddlewares = map[uintptr]string{
+	reflect.ValueOf((&JWTAuth{}).Middleware).Pointer():             "BearerAuth",
+	reflect.ValueOf((&BasicAuth{}).Middleware).Pointer():           "BasicAuth",
+	reflect.ValueOf((&BasicAuthMiddleware{}).Middleware).Pointer(): "BasicAuth",
+}
+
+// ExportRouteManifest returns the served routes as a "json" or "yaml" document,
+// for generating Ingress or API gateway route tables (Kong, Envoy, ...) from the
+// routes defined in code. For each route, it lists:
+//
+//   - the method, the path template and its regular expression
+//   - the group prefix and tags
+//   - the authentication: documented security schemes, plus BearerAuth and
+//     BasicAuth for routes using JWTAuth and BasicAuth middlewares
+//   - the timeout set with Route.WithMaxDuration, and whether the route is
+//     rate limited, streaming or deprecated
+//
+// Disabled and internal routes are left out.
+//
+// Example:
+//
+//	routes:
+//	  - method: GET
+//	    path: /api/books/{id}
+//	    path_regexp: ^/api/books/(?P<v0>[^/]+)$
+//	    group: /api
+//	    auth: [BearerAuth]
+//	    timeout: 5s
+func (o *Okapi) ExportRouteManifest(format string) ([]byte, error) {
+	doc := routeAnnotations{Routes: make([]routeAnnotation, 0, len(o.routes))}
+	for _, r := range o.routes {
+		if r.disabled || r.internal {
+			continue
+		}
+		doc.Routes = append(doc.Routes, r.annotation())
+	}
+	slices.SortStableFunc(doc.Routes, func(a, b routeAnnotation) int {
+		return cmp.Or(strings.Compare(a.Path, b.Path), strings.Compare(a.Method, b.Method))
+	})
+	switch strings.ToLower(format) {
+	case "json":
+		return json.MarshalIndent(doc, "", "  ")
+	case "yaml", "yml":
+		return yaml.Marshal(doc)
+	}
+	return nil, fmt.Errorf("okapi: unsupported route manifest format %q (supported: json, yaml)", format)
+}
+
+// annotation describes the route for ExportRouteManifest.
+func (r *Route) annotation() routeAnnotation {
+	a := routeAnnotation{
+		Name:        r.Name,
+		Method:      r.Method,
+		Path:        r.Path,
+		Group:       r.group,
+		Auth:        r.authSchemes(),
+		RateLimited: r.rateLimited(),
+		Streaming:   r.streaming,
+		Deprecated:  r.deprecated,
+		Tags:        slices.Clone(r.tags),
+	}
+	if r.muxRoute != nil {
+		a.PathRegexp, _ = r.muxRoute.GetPathRegexp()
+	}
+	if r.budget != nil && r.budget.maxDuration > 0 {
+		a.Timeout = r.budget.maxDuration.String()
+	}
+	if !r.sunset.IsZero() {
+		a.Sunset = r.sunset.UTC().Format(time.RFC3339)
+	}
+	return a
+}
+
+// authSchemes returns the sorted security schemes of the route, documented or
+// implemented by its middlewares.
+func (r *Route) authSchemes() []string {
+	schemes := make(map[string]bool)
+	if r.bearerAuth {
+		schemes["BearerAuth"] = true
+	}
+	if r.basicAuth {
+		schemes["BasicAuth"] = true
+	}
+	for _, requirement := range r.security {
+		for scheme := range requirement {
+			schemes[scheme] = true
+		}
+	}
+	r.eachMiddleware(func(code uintptr) {
+		if scheme, ok := authMiddlewares[code]; ok {
+			schemes[scheme] = true
+		}
+	})
+	if len(schemes) == 0 {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(schemes))
+}
@@ -0,0 +1,78 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportRouteManifest(t *testing.T) {
+	o := New()
+	jwtAuth := &JWTAuth{SigningSecret: []byte("secret")}
+
+	api := o.Group("/api")
+	api.Get("/books/{id}", anyHandler, MaxDuration(5*time.Second)).Use(jwtAuth.Middleware)
+	api.Get("/books", anyHandler, Tags("books")).Use((&RateLimit{Limit: 10, Window: time.Minute}).Middleware)
+	o.Post("/login", anyHandler).WithName("login").Sunset(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	o.Get("/hidden", anyHandler).Disable()
+
+	data, err := o.ExportRouteManifest("json")
+	require.NoError(t, err)
+	var doc routeAnnotations
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Len(t, doc.Routes, 3)
+
+	books, book, login := doc.Routes[0], doc.Routes[1], doc.Routes[2]
+	assert.Equal(t, "/api/books", books.Path)
+	assert.True(t, books.RateLimited)
+	assert.Equal(t, []string{"books"}, books.Tags)
+
+	assert.Equal(t, "GET", book.Method)
+	assert.Equal(t, "/api/books/{id}", book.Path)
+	assert.Regexp(t, book.PathRegexp, "/api/books/42")
+	assert.Equal(t, "/api", book.Group)
+	assert.Equal(t, []string{"BearerAuth"}, book.Auth)
+	assert.Equal(t, "5s", book.Timeout)
+
+	assert.Equal(t, "login", login.Name)
+	assert.True(t, login.Deprecated)
+	assert.Equal(t, "2027-01-01T00:00:00Z", login.Sunset)
+	assert.Empty(t, login.Auth)
+
+	data, err = o.ExportRouteManifest("yaml")
+	require.NoError(t, err)
+	var fromYAML routeAnnotations
+	require.NoError(t, yaml.Unmarshal(data, &fromYAML))
+	assert.Equal(t, doc, fromYAML)
+
+	_, err = o.ExportRouteManifest("toml")
+	assert.ErrorContains(t, err, "unsupported route manifest format")
+}
@@ -0,0 +1,143 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gorilla/mux"
+)
+
+// FromSpec creates an Okapi instance from an existing OpenAPI document (design-first).
+//
+// A route is registered for every operation in the document. Until a handler is
+// attached with Operation, an operation responds 501 Not Implemented. Incoming
+// requests are validated against the operation's parameters and request body,
+// and rejected with 400 Bad Request when they don't match. Security requirements
+// are not enforced by validation; protect operations with middlewares instead.
+//
+// When documentation is enabled, the loaded document is served verbatim.
+//
+// Example:
+//
+//	o, err := okapi.FromSpec("openapi.yaml")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	o.Operation("listBooks", listBooks)
+//	o.Operation("createBook", createBook).Use(jwtAuth.Middleware)
+func FromSpec(path string, options ...OptionFunc) (*Okapi, error) {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load OpenAPI spec %s: %w", path, err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec %s: %w", path, err)
+	}
+	o := New(options...)
+	o.sourceSpec = doc
+
+	paths := doc.Paths.Map()
+	keys := make([]string, 0, len(paths))
+	for p := range paths {
+		keys = append(keys, p)
+	}
+	sort.Strings(keys)
+	for _, p := range keys {
+		item := paths[p]
+		ops := item.Operations()
+		methods := make([]string, 0, len(ops))
+		for method := range ops {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			o.addSpecOperation(p, item, method, ops[method])
+		}
+	}
+	return o, nil
+}
+
+// Operation attaches a handler to the spec operation with the given operationId
+// and returns its route. It panics if the instance was not created with FromSpec
+// or the operation does not exist in the document.
+func (o *Okapi) Operation(operationId string, h HandlerFunc) *Route {
+	if o.sourceSpec == nil {
+		panic("Operation requires an Okapi instance created with FromSpec")
+	}
+	for _, r := range o.routes {
+		if r.specOperation && r.operationId == operationId {
+			r.handle = h
+			r.Name = handleName(h)
+			return r
+		}
+	}
+	panic(fmt.Sprintf("operation %q not found in the OpenAPI spec", operationId))
+}
+
+// addSpecOperation registers the route serving a spec operation.
+func (o *Okapi) addSpecOperation(path string, item *openapi3.PathItem, method string, op *openapi3.Operation) {
+	id := op.OperationID
+	notImplemented := func(c *Context) error {
+		return c.AbortNotImplemented(fmt.Sprintf("Operation %s %s is not implemented", method, path))
+	}
+	route := o.addRoute(strings.ToUpper(method), path, nil, notImplemented, Hide(), OperationId(id))
+	route.specOperation = true
+	route.Use(validateSpecRequest(&routers.Route{
+		Spec:      o.sourceSpec,
+		Path:      path,
+		PathItem:  item,
+		Method:    method,
+		Operation: op,
+	}))
+}
+
+// validateSpecRequest rejects requests that don't match the parameters and
+// request body of the spec operation.
+func validateSpecRequest(route *routers.Route) Middleware {
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	return func(c *Context) error {
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.request,
+			PathParams: mux.Vars(c.request),
+			Route:      route,
+			Options:    options,
+		}
+		if err := openapi3filter.ValidateRequest(c.request.Context(), input); err != nil {
+			return c.AbortBadRequest("Request does not match the API specification", err)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,134 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jkaninda/okapi/okapitest"
+)
+
+const bookSpec = `openapi: 3.0.3
+info:
+  title: Books
+  version: 1.0.0
+paths:
+  /books:
+    get:
+      operationId: listBooks
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createBook
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        "201":
+          description: Created
+  /books/{id}:
+    get:
+      operationId: getBook
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+`
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write spec: %v", err)
+	}
+	return path
+}
+
+func TestFromSpec(t *testing.T) {
+	o, err := FromSpec(writeSpec(t, bookSpec))
+	if err != nil {
+		t.Fatalf("FromSpec: %v", err)
+	}
+	o.Operation("listBooks", func(c *Context) error {
+		return c.OK(M{"limit": c.Query("limit")})
+	})
+	o.Operation("createBook", func(c *Context) error {
+		return c.Created(M{"created": true})
+	})
+	o.WithOpenAPIDocs()
+	ts := NewTestServerWithOkapi(t, o)
+
+	okapitest.GET(t, ts.BaseURL+"/books?limit=10").ExpectStatusOK().ExpectJSONPath("limit", "10")
+	okapitest.GET(t, ts.BaseURL+"/books?limit=1000").ExpectStatusBadRequest()
+	okapitest.POST(t, ts.BaseURL+"/books").JSONBody(M{"name": "Go"}).ExpectStatusCreated()
+	okapitest.POST(t, ts.BaseURL+"/books").JSONBody(M{"title": "Go"}).ExpectStatusBadRequest()
+	// Unbound operations are routed but not implemented.
+	okapitest.GET(t, ts.BaseURL+"/books/1").ExpectStatus(http.StatusNotImplemented)
+	okapitest.GET(t, ts.BaseURL+"/books/abc").ExpectStatusBadRequest()
+	// The loaded document is served as the API documentation.
+	okapitest.GET(t, ts.BaseURL+"/openapi.json").ExpectStatusOK().ExpectJSONPath("openapi", "3.0.3")
+}
+
+func TestFromSpecErrors(t *testing.T) {
+	if _, err := FromSpec(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing spec")
+	}
+	if _, err := FromSpec(writeSpec(t, "openapi: 3.0.3\ninfo: {}\npaths: {}\n")); err == nil {
+		t.Error("expected an error for an invalid spec")
+	}
+
+	o, err := FromSpec(writeSpec(t, bookSpec))
+	if err != nil {
+		t.Fatalf("FromSpec: %v", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an unknown operationId")
+		}
+	}()
+	o.Operation("deleteBook", func(c *Context) error { return nil })
+}
@@ -31,7 +31,9 @@ import (
 	"github.com/google/uuid"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -57,6 +59,72 @@ type StreamOptions struct {
 	PingInterval time.Duration
 	// OnError is a callback function to handle errors during streaming.
 	OnError func(error)
+	// Store replays the messages a client missed when it reconnects with a
+	// Last-Event-ID header. Producers append messages to the store before
+	// sending them to the streams, see EventStore.
+	Store EventStore
+	// Auth re-validates the client's credentials while the stream is open and
+	// ends the stream, after an ExpiredEvent, once they expire. See StreamAuth.
+	Auth *StreamAuth
+}
+
+// EventStore keeps recently published SSE messages so that clients reconnecting
+// with Last-Event-ID can resume where they left off.
+//
+// Append messages once, where they are produced, then send the returned message
+// (which carries its ID) to the connected streams.
+type EventStore interface {
+	// Append stores msg, assigning an ID when it has none, and returns it.
+	Append(msg Message) (Message, error)
+	// Since returns the messages stored after the one with lastID, oldest first.
+	// When lastID is no longer stored, every stored message is returned.
+	Since(lastID string) ([]Message, error)
+}
+
+// RingEventStore is an in-memory EventStore keeping the last size messages.
+type RingEventStore struct {
+	mu       sync.Mutex
+	messages []Message
+	size     int
+	next     uint64
+}
+
+// NewRingEventStore returns an EventStore keeping the last size messages.
+func NewRingEventStore(size int) *RingEventStore {
+	if size <= 0 {
+		size = 100
+	}
+	return &RingEventStore{size: size}
+}
+
+// Append stores msg, assigning a sequential ID when it has none.
+func (s *RingEventStore) Append(msg Message) (Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	if msg.ID == "" {
+		msg.ID = strconv.FormatUint(s.next, 10)
+	}
+	if len(s.messages) == s.size {
+		copy(s.messages, s.messages[1:])
+		s.messages = s.messages[:s.size-1]
+	}
+	s.messages = append(s.messages, msg)
+	return msg, nil
+}
+
+// Since returns the messages stored after lastID.
+func (s *RingEventStore) Since(lastID string) ([]Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start := 0
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if s.messages[i].ID == lastID {
+			start = i + 1
+			break
+		}
+	}
+	return append([]Message(nil), s.messages[start:]...), nil
 }
 
 // Serializer defines how to convert data to string format
@@ -120,6 +188,7 @@ func (m *Message) Send(w http.ResponseWriter) (string, error) {
 	}
 
 	m.flush(w)
+	countStreamMessage(w)
 
 	return m.ID, nil
 }
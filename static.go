@@ -26,13 +26,20 @@ package okapi
 
 import (
 	"io/fs"
+	"mime"
 	"net/http"
+	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// longCacheControl is the Cache-Control value for rarely changing assets
+// such as the favicon and robots.txt.
+const longCacheControl = "public, max-age=31536000"
+
 // noDirListing wraps http.FileSystem to disable directory listing
 type noDirListing struct {
 	fs http.FileSystem
@@ -260,3 +267,57 @@ func firstPathSegment(p string) string {
 	}
 	return p
 }
+
+// Favicon serves the favicon at /favicon.ico from a file on disk, or from fsys
+// when provided (e.g. an embed.FS). The content type is derived from the file
+// extension, so PNG or SVG icons are served correctly.
+//
+// The route is served with long cache headers and is excluded from access logs
+// and the OpenAPI documentation. It panics if the file cannot be read.
+//
+// Example:
+//
+//	o.Favicon("./public/favicon.ico")
+//	o.Favicon("static/favicon.png", assets) // embed.FS
+func (o *Okapi) Favicon(file string, fsys ...fs.FS) {
+	var (
+		data []byte
+		err  error
+	)
+	if len(fsys) > 0 && fsys[0] != nil {
+		data, err = fs.ReadFile(fsys[0], file)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		panic("okapi: cannot read favicon: " + err.Error())
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(file))
+	if contentType == "" || strings.EqualFold(filepath.Ext(file), ".ico") {
+		contentType = "image/x-icon"
+	}
+	o.assetRoute("/favicon.ico", contentType, data)
+}
+
+// RobotsTxt serves content at /robots.txt as text/plain.
+//
+// The route is served with long cache headers and is excluded from access logs
+// and the OpenAPI documentation.
+//
+// Example:
+//
+//	o.RobotsTxt("User-agent: *\nDisallow: /api/\n")
+func (o *Okapi) RobotsTxt(content string) {
+	o.assetRoute("/robots.txt", constPLAINTEXT, []byte(content))
+}
+
+// assetRoute registers an undocumented GET route serving data with long cache
+// headers, skipping the access log.
+func (o *Okapi) assetRoute(path, contentType string, data []byte) {
+	route := o.registerRoute(methodGet, path, nil, func(c *Context) error {
+		c.SetHeader("Cache-Control", longCacheControl)
+		return c.Data(http.StatusOK, contentType, data)
+	})
+	route.noAccessLog = true
+	route.internalRoute().Hide()
+}
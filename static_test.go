@@ -3,6 +3,7 @@ package okapi
 import (
 	"bytes"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -238,3 +239,40 @@ func TestSPA(t *testing.T) {
 	okapitest.GET(t, ts.BaseURL+"/scalar").ExpectStatusOK().ExpectBodyContains("@scalar/api-reference")
 
 }
+
+func TestFaviconAndRobotsTxt(t *testing.T) {
+	var logs strings.Builder
+	o := New(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	o.Favicon("icons/favicon.png", fstest.MapFS{"icons/favicon.png": {Data: []byte("png")}})
+	o.RobotsTxt("User-agent: *\nDisallow: /api/\n")
+
+	rec := serveSPARequest(o, "/favicon.ico")
+	if rec.Code != http.StatusOK || rec.Body.String() != "png" {
+		t.Fatalf("favicon = %d %q", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("favicon Content-Type = %q, want image/png", ct)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != longCacheControl {
+		t.Errorf("favicon Cache-Control = %q", cc)
+	}
+
+	rec = serveSPARequest(o, "/robots.txt")
+	if rec.Body.String() != "User-agent: *\nDisallow: /api/\n" {
+		t.Fatalf("robots.txt body = %q", rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain" {
+		t.Errorf("robots.txt Content-Type = %q", ct)
+	}
+
+	if logs.Len() != 0 {
+		t.Errorf("expected no access log entries, got %q", logs.String())
+	}
+	if len(o.Routes()) != 0 {
+		t.Errorf("expected asset routes to be internal, got %d routes", len(o.Routes()))
+	}
+	o.buildOpenAPISpec()
+	if o.openapiSpec.Paths.Find("/robots.txt") != nil {
+		t.Error("robots.txt should not be documented")
+	}
+}
@@ -0,0 +1,157 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"cmp"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// streamWriter flushes the body written through Context.Stream at the
+// context's flush interval and keeps the connection's write deadline ahead of
+// the server's WriteTimeout.
+type streamWriter struct {
+	mu       sync.Mutex
+	c        *Context
+	interval time.Duration
+	timer    *time.Timer
+	pending  bool
+	stopped  bool
+}
+
+// SetFlushInterval controls how the body written with Stream reaches the client:
+// a positive interval flushes buffered writes at most that long after they are
+// made, a negative one flushes after every write. By default, event streams and
+// NDJSON responses are flushed after every write and other bodies when complete,
+// which lets proxies buffering the response deliver events late.
+//
+// Example:
+//
+//	c.SetFlushInterval(100 * time.Millisecond)
+//	return c.Stream(http.StatusOK, "application/x-ndjson", writeRows)
+func (c *Context) SetFlushInterval(d time.Duration) {
+	c.flushInterval = d
+}
+
+// streamFlushInterval returns the flush interval applied to a body of contentType.
+func (c *Context) streamFlushInterval(contentType string) time.Duration {
+	if c.flushInterval != 0 {
+		return c.flushInterval
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/event-stream", "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return -1
+	}
+	return 0
+}
+
+// extendWriteDeadline pushes the connection's write deadline a WriteTimeout
+// ahead, so a long-lived stream is not cut off while it keeps writing.
+func (c *Context) extendWriteDeadline() {
+	if c.okapi == nil {
+		return
+	}
+	timeout := secondsToDuration(c.okapi.writeTimeout)
+	if c.okapi.server != nil {
+		timeout = cmp.Or(timeout, c.okapi.server.WriteTimeout)
+	}
+	if timeout <= 0 {
+		return
+	}
+	_ = http.NewResponseController(c.response).SetWriteDeadline(time.Now().Add(timeout))
+}
+
+// clearWriteDeadline lifts the connection's write deadline for the current
+// request, see Route.Streaming.
+func (c *Context) clearWriteDeadline() {
+	_ = http.NewResponseController(c.response).SetWriteDeadline(time.Time{})
+}
+
+// Streaming marks the route as long-lived, e.g. an SSE or NDJSON endpoint:
+// its requests are exempt from the server's WriteTimeout, which would otherwise
+// cut the connection off after that many seconds. Other routes keep the timeout.
+//
+// Example:
+//
+//	o.Get("/events", streamEvents).Streaming()
+func (r *Route) Streaming() *Route {
+	r.streaming = true
+	return r
+}
+
+// Streaming is the RouteOption form of Route.Streaming.
+func Streaming() RouteOption {
+	return func(r *Route) {
+		r.Streaming()
+	}
+}
+
+func (w *streamWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.c.extendWriteDeadline()
+	n, err := w.c.response.Write(b)
+	if err != nil {
+		return n, err
+	}
+	switch {
+	case w.interval < 0:
+		w.c.response.Flush()
+	case w.interval > 0 && !w.pending:
+		w.pending = true
+		if w.timer == nil {
+			w.timer = time.AfterFunc(w.interval, w.flush)
+		} else {
+			w.timer.Reset(w.interval)
+		}
+	}
+	return n, nil
+}
+
+// flush sends the writes buffered since the last flush.
+func (w *streamWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stopped || !w.pending {
+		return
+	}
+	w.pending = false
+	w.c.extendWriteDeadline()
+	w.c.response.Flush()
+}
+
+// stop cancels the pending flush, once the stream is complete.
+func (w *streamWriter) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
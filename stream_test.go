@@ -0,0 +1,156 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContext_SetFlushInterval(t *testing.T) {
+	ts := NewTestServer(t)
+	release := make(chan struct{})
+	defer close(release)
+
+	stream := func(contentType string, interval time.Duration) HandlerFunc {
+		return func(c *Context) error {
+			if interval != 0 {
+				c.SetFlushInterval(interval)
+			}
+			return c.Stream(http.StatusOK, contentType, func(w io.Writer) error {
+				if _, err := io.WriteString(w, "first\n"); err != nil {
+					return err
+				}
+				<-release
+				return nil
+			})
+		}
+	}
+	ts.Get("/interval", stream("text/plain", 20*time.Millisecond))
+	ts.Get("/ndjson", stream("application/x-ndjson", 0))
+
+	for _, path := range []string{"/interval", "/ndjson"} {
+		resp, err := http.Get(ts.BaseURL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		line := make(chan string, 1)
+		go func() {
+			l, _ := bufio.NewReader(resp.Body).ReadString('\n')
+			line <- l
+		}()
+		select {
+		case l := <-line:
+			if l != "first\n" {
+				t.Errorf("%s: unexpected line %q", path, l)
+			}
+		case <-time.After(2 * time.Second):
+			t.Errorf("%s: first write was not flushed", path)
+		}
+		_ = resp.Body.Close()
+	}
+}
+
+func TestContext_StreamExtendsWriteDeadline(t *testing.T) {
+	o := New()
+	o.server.WriteTimeout = 300 * time.Millisecond
+	o.Get("/ticks", func(c *Context) error {
+		return c.Stream(http.StatusOK, "application/x-ndjson", func(w io.Writer) error {
+			for i := range 8 {
+				if _, err := fmt.Fprintf(w, "%d\n", i); err != nil {
+					return err
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+			return nil
+		})
+	})
+	srv := httptest.NewUnstartedServer(o)
+	srv.Config.WriteTimeout = o.server.WriteTimeout
+	srv.Start()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/ticks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("stream was cut off: %v", err)
+	}
+	if lines := strings.Count(string(body), "\n"); lines != 8 {
+		t.Errorf("expected 8 lines, got %d: %q", lines, body)
+	}
+}
+
+func TestRoute_Streaming(t *testing.T) {
+	o := New()
+	ticks := func(c *Context) error {
+		for i := range 8 {
+			if _, err := fmt.Fprintf(c.response, "%d\n", i); err != nil {
+				return err
+			}
+			c.response.Flush()
+			time.Sleep(100 * time.Millisecond)
+		}
+		return nil
+	}
+	o.Get("/events", ticks).Streaming()
+	o.Get("/events/option", ticks, Streaming())
+	o.Get("/regular", ticks)
+	srv := httptest.NewUnstartedServer(o)
+	srv.Config.WriteTimeout = 300 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	get := func(path string) (string, error) {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+	for _, path := range []string{"/events", "/events/option"} {
+		body, err := get(path)
+		if err != nil {
+			t.Fatalf("%s: stream was cut off: %v", path, err)
+		}
+		if lines := strings.Count(body, "\n"); lines != 8 {
+			t.Errorf("%s: expected 8 lines, got %d", path, lines)
+		}
+	}
+	if _, err := get("/regular"); err == nil {
+		t.Error("expected the WriteTimeout to cut off a regular route")
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrAuthExpired is the cause of a context returned by Context.AuthContext once
+// the connection's credentials expired or failed re-validation.
+var ErrAuthExpired = errors.New("okapi: connection authentication expired")
+
+// defaultAuthInterval is the default interval between StreamAuth checks.
+const defaultAuthInterval = time.Minute
+
+// StreamAuth re-validates the credentials of a long-lived connection, such as an
+// SSE stream or a WebSocket, which middleware only checks when it opens.
+//
+// The connection ends when the expiry recorded with Context.SetAuthExpiry passes,
+// e.g. the "exp" claim of the token validated by JWTAuth, or when Check fails.
+type StreamAuth struct {
+	// Interval between two calls to Check. Defaults to one minute.
+	Interval time.Duration
+	// Check re-validates the connection, e.g. against a revocation list; an error
+	// ends it. Check can keep the connection open past its expiry by calling
+	// SetAuthExpiry, e.g. once the client refreshed its session. Check runs on
+	// its own goroutine.
+	Check func(c *Context) error
+	// ExpiredEvent is the SSE event sent before the stream ends, telling the
+	// client to reconnect with fresh credentials. Defaults to "auth_expired".
+	ExpiredEvent string
+}
+
+// AuthExpiry returns when the credentials of the request expire, or the zero
+// time when unknown. JWTAuth sets it from the token's "exp" claim.
+func (c *Context) AuthExpiry() time.Time {
+	if n := c.authExpiry.Load(); n != 0 {
+		return time.Unix(0, n)
+	}
+	return time.Time{}
+}
+
+// SetAuthExpiry records when the credentials of the request expire, see StreamAuth.
+// Custom authentication middleware call it so that long-lived connections end
+// with the credentials; the zero time clears it.
+func (c *Context) SetAuthExpiry(t time.Time) {
+	if t.IsZero() {
+		c.authExpiry.Store(0)
+		return
+	}
+	c.authExpiry.Store(t.UnixNano())
+}
+
+// AuthContext returns a copy of ctx canceled, with ErrAuthExpired as its cause,
+// once the credentials of the request expire or auth.Check fails. Long-lived
+// handlers, e.g. a WebSocket read loop, stop when it is done. SSE streams use
+// StreamOptions.Auth instead.
+//
+// Example:
+//
+//	ctx, cancel := c.AuthContext(c.Context(), okapi.StreamAuth{Interval: 30 * time.Second})
+//	defer cancel()
+//	for ctx.Err() == nil {
+//		// read and write messages
+//	}
+func (c *Context) AuthContext(ctx context.Context, auth StreamAuth) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	interval := auth.Interval
+	if interval <= 0 {
+		interval = defaultAuthInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		expiry := time.NewTimer(0)
+		defer expiry.Stop()
+		for {
+			if err := c.checkAuthExpiry(expiry); err != nil {
+				cancel(err)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-expiry.C:
+			case <-ticker.C:
+				if auth.Check == nil {
+					continue
+				}
+				if err := auth.Check(c); err != nil {
+					cancel(fmt.Errorf("%w: %w", ErrAuthExpired, err))
+					return
+				}
+			}
+		}
+	}()
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// checkAuthExpiry returns ErrAuthExpired once the credentials expired, and
+// otherwise arms timer to fire at the expiry.
+func (c *Context) checkAuthExpiry(timer *time.Timer) error {
+	expiry := c.AuthExpiry()
+	if expiry.IsZero() {
+		timer.Stop()
+		return nil
+	}
+	wait := time.Until(expiry)
+	if wait <= 0 {
+		return ErrAuthExpired
+	}
+	timer.Reset(wait)
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContext_AuthContext(t *testing.T) {
+	t.Run("ends at the expiry", func(t *testing.T) {
+		c, _ := NewTestContext(http.MethodGet, "/", nil)
+		c.SetAuthExpiry(time.Now().Add(50 * time.Millisecond))
+		ctx, cancel := c.AuthContext(context.Background(), StreamAuth{})
+		defer cancel()
+		select {
+		case <-ctx.Done():
+			assert.ErrorIs(t, context.Cause(ctx), ErrAuthExpired)
+		case <-time.After(time.Second):
+			t.Fatal("context not canceled at expiry")
+		}
+	})
+
+	t.Run("ends when the check fails", func(t *testing.T) {
+		c, _ := NewTestContext(http.MethodGet, "/", nil)
+		revoked := errors.New("session revoked")
+		var calls atomic.Int32
+		ctx, cancel := c.AuthContext(context.Background(), StreamAuth{
+			Interval: 10 * time.Millisecond,
+			Check: func(*Context) error {
+				if calls.Add(1) == 3 {
+					return revoked
+				}
+				return nil
+			},
+		})
+		defer cancel()
+		<-ctx.Done()
+		assert.ErrorIs(t, context.Cause(ctx), ErrAuthExpired)
+		assert.ErrorIs(t, context.Cause(ctx), revoked)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("check refreshes the expiry", func(t *testing.T) {
+		c, _ := NewTestContext(http.MethodGet, "/", nil)
+		c.SetAuthExpiry(time.Now().Add(40 * time.Millisecond))
+		ctx, cancel := c.AuthContext(context.Background(), StreamAuth{
+			Interval: 10 * time.Millisecond,
+			Check: func(c *Context) error {
+				c.SetAuthExpiry(time.Now().Add(40 * time.Millisecond))
+				return nil
+			},
+		})
+		time.Sleep(150 * time.Millisecond)
+		assert.NoError(t, ctx.Err())
+		cancel()
+		assert.ErrorIs(t, context.Cause(ctx), context.Canceled)
+	})
+}
+
+func TestSSEStream_AuthExpired(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Get("/events", func(c *Context) error {
+		c.SetAuthExpiry(time.Now().Add(50 * time.Millisecond))
+		err := c.SSEStreamWithOptions(c.Context(), make(chan Message), &StreamOptions{
+			Auth: &StreamAuth{ExpiredEvent: "reauth"},
+		})
+		if !errors.Is(err, ErrAuthExpired) {
+			t.Errorf("stream error = %v", err)
+		}
+		return nil
+	})
+
+	resp, err := http.Get(ts.BaseURL + "/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(body), "event: reauth\n"), "body: %s", body)
+}
+
+func TestJWTAuth_SetsAuthExpiry(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	auth := JWTAuth{SigningSecret: jwtTestSecret, TokenLookup: "header:Authorization", Audience: "okapi"}
+	ts := NewTestServer(t)
+	var got time.Time
+	ts.Get("/me", func(c *Context) error {
+		got = c.AuthExpiry()
+		return c.NoContent()
+	}, UseMiddleware(auth.Middleware))
+
+	req, err := http.NewRequest(http.MethodGet, ts.BaseURL+"/me", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+signHMACToken(t, jwt.MapClaims{"sub": "1", "aud": "okapi", "exp": exp.Unix()}))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	b, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode, string(b))
+	assert.True(t, exp.Equal(got), "AuthExpiry = %v", got)
+}
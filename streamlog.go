@@ -0,0 +1,197 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goutils "github.com/jkaninda/go-utils"
+)
+
+// streamRecorder records the traffic of an SSE or WebSocket connection for the
+// access log.
+type streamRecorder struct {
+	ResponseWriter
+	// messages counts the SSE messages sent, or the writes to a hijacked connection.
+	messages atomic.Int64
+	conn     *streamConn
+}
+
+// streamConn is a hijacked connection whose writes are recorded.
+type streamConn struct {
+	net.Conn
+	recorder *streamRecorder
+	bytes    atomic.Int64
+	// handshake is set once the 101 Switching Protocols response was written.
+	handshake atomic.Bool
+
+	mu      sync.Mutex
+	closed  bool
+	onClose func()
+}
+
+// Unwrap returns the recorded writer, for http.ResponseController.
+func (r *streamRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Hijack records the writes made to the hijacked connection.
+func (r *streamRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := r.ResponseWriter.Hijack()
+	if err != nil {
+		return conn, rw, err
+	}
+	r.conn = &streamConn{Conn: conn, recorder: r}
+	if rw != nil {
+		rw.Writer.Reset(r.conn)
+	}
+	return r.conn, rw, nil
+}
+
+// status returns the response status, 101 for an upgraded connection.
+func (r *streamRecorder) status() int {
+	if status := r.StatusCode(); status != 0 || r.conn == nil {
+		return status
+	}
+	return http.StatusSwitchingProtocols
+}
+
+func (c *streamConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.bytes.Add(int64(n))
+	// The upgrade response is not a message
+	if !c.handshake.Load() && bytes.HasPrefix(b, []byte("HTTP/")) {
+		c.handshake.Store(true)
+		return n, err
+	}
+	c.recorder.messages.Add(1)
+	return n, err
+}
+
+func (c *streamConn) Close() error {
+	err := c.Conn.Close()
+	c.mu.Lock()
+	onClose := c.onClose
+	c.closed, c.onClose = true, nil
+	c.mu.Unlock()
+	if onClose != nil {
+		onClose()
+	}
+	return err
+}
+
+// afterClose defers fn until the connection is closed. It reports false if
+// the connection is already closed.
+func (c *streamConn) afterClose(fn func()) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return false
+	}
+	c.onClose = fn
+	return true
+}
+
+// countStreamMessage records a message sent through w on the stream recorder
+// it wraps, if any.
+func countStreamMessage(w http.ResponseWriter) {
+	for w != nil {
+		if r, ok := w.(*streamRecorder); ok {
+			r.messages.Add(1)
+			return
+		}
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return
+		}
+		w = u.Unwrap()
+	}
+}
+
+// handleStreamAccessLog logs an SSE or WebSocket request once its connection
+// closes, with the connection duration, the messages sent and the bytes written.
+func handleStreamAccessLog(c *Context) error {
+	startTime := time.Now()
+	recorder := &streamRecorder{ResponseWriter: c.response}
+	c.response = recorder
+	err := c.Next()
+	c.response = recorder.ResponseWriter
+
+	logger := c.okapi.logger
+	if c.okapi.accessLogger != nil {
+		logger = c.okapi.accessLogger
+	}
+	stream := "sse"
+	if c.IsWebSocketUpgrade() {
+		stream = "websocket"
+	}
+	// The context is recycled once the handler returns, read it now
+	status := recorder.status()
+	bytesOut := int64(recorder.BytesWritten())
+	req, ip := c.request, c.RealIP()
+	var debugFields []any
+	if c.okapi.debug {
+		debugFields = buildDebugFields(c)
+	}
+	logStream := func() {
+		if recorder.conn != nil {
+			bytesOut += recorder.conn.bytes.Load()
+		}
+		logFields := []any{
+			"method", req.Method,
+			"path", req.URL.Path,
+			"stream", stream,
+			"status", status,
+			"duration", goutils.FormatDuration(time.Since(startTime), 2),
+			"messages", recorder.messages.Load(),
+			"ip", ip,
+			"host", req.Host,
+			"bytes_out", bytesOut,
+			"referer", req.Referer(),
+			"user_agent", req.UserAgent(),
+		}
+		logFields = append(logFields, debugFields...)
+		switch {
+		case status >= 500:
+			logger.Error("[okapi] Stream closed", logFields...)
+		case status >= 400:
+			logger.Warn("[okapi] Stream closed", logFields...)
+		default:
+			logger.Info("[okapi] Stream closed", logFields...)
+		}
+	}
+	// A hijacked connection may outlive its handler
+	if recorder.conn != nil && recorder.conn.afterClose(logStream) {
+		return err
+	}
+	logStream()
+	return err
+}
@@ -0,0 +1,144 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accessLogBuffer collects access log lines written from server goroutines.
+type accessLogBuffer struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (b *accessLogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// entries returns the decoded log lines.
+func (b *accessLogBuffer) entries(t *testing.T) []map[string]any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestStreamAccessLog(t *testing.T) {
+	logs := &accessLogBuffer{}
+	ts := NewTestServerWithOkapi(t, New(WithAccessLogOutput(logs)))
+
+	t.Run("sse", func(t *testing.T) {
+		ts.Get("/events", func(c *Context) error {
+			for _, text := range []string{"one", "two", "three"} {
+				if err := c.SSESendText(text); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		req, err := http.NewRequest(http.MethodGet, ts.BaseURL+"/events", nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		entries := logs.entries(t)
+		require.Len(t, entries, 1)
+		entry := entries[0]
+		assert.Equal(t, "[okapi] Stream closed", entry["msg"])
+		assert.Equal(t, "sse", entry["stream"])
+		assert.Equal(t, float64(http.StatusOK), entry["status"])
+		assert.Equal(t, float64(3), entry["messages"])
+		assert.Equal(t, float64(len(body)), entry["bytes_out"])
+		assert.NotEmpty(t, entry["duration"])
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		logs.buf.Reset()
+		hijacked := make(chan net.Conn, 1)
+		ts.Get("/ws", func(c *Context) error {
+			conn, rw, err := c.Response().Hijack()
+			if err != nil {
+				return err
+			}
+			_, _ = conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n"))
+			_, _ = conn.Write([]byte{0x81, 0x02, 'h', 'i'})
+			_, _ = rw.Write([]byte{0x81, 0x03, 'b', 'y', 'e'})
+			_ = rw.Flush()
+			// The connection outlives the handler
+			hijacked <- conn
+			return nil
+		})
+
+		conn, err := net.Dial("tcp", strings.TrimPrefix(ts.BaseURL, "http://"))
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write([]byte("GET /ws HTTP/1.1\r\nHost: localhost\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n"))
+		require.NoError(t, err)
+		reader := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(reader, nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+		frames := make([]byte, 9)
+		_, err = io.ReadFull(reader, frames)
+		require.NoError(t, err)
+
+		server := <-hijacked
+		assert.Empty(t, logs.entries(t), "the stream is logged once the connection closes")
+		require.NoError(t, server.Close())
+
+		entries := logs.entries(t)
+		require.Len(t, entries, 1)
+		entry := entries[0]
+		assert.Equal(t, "websocket", entry["stream"])
+		assert.Equal(t, float64(http.StatusSwitchingProtocols), entry["status"])
+		assert.Equal(t, float64(2), entry["messages"], "the upgrade response is not a message")
+		assert.Greater(t, entry["bytes_out"], float64(len(frames)))
+	})
+}
@@ -0,0 +1,256 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingLocation is returned by Context.Created in strict mode when the
+// response has no Location header.
+var ErrMissingLocation = errors.New("okapi: 201 Created response without a Location header")
+
+// StrictMode enforces strict REST semantics, see WithStrictMode. Every rule
+// applies by default; the Relax fields disable them individually.
+type StrictMode struct {
+	// RelaxMethodNotAllowed omits the Allow header listing the supported methods
+	// from 405 Method Not Allowed responses.
+	RelaxMethodNotAllowed bool
+	// RelaxAccept sends successful responses even when their content type is not
+	// accepted by the request's Accept header, instead of 406 Not Acceptable.
+	RelaxAccept bool
+	// RelaxBodylessMethods accepts GET and HEAD requests carrying a body,
+	// instead of rejecting them with 400 Bad Request.
+	RelaxBodylessMethods bool
+	// RelaxCreatedLocation lets Context.Created answer without a Location header.
+	RelaxCreatedLocation bool
+}
+
+// WithStrictMode enables strict REST semantics:
+//
+//   - a known path requested with an unsupported method gets 405 Method Not Allowed
+//     with an Allow header, through NoMethod when set;
+//   - a request whose Accept header excludes every content type the route
+//     documents for its successful responses gets 406 Not Acceptable before the
+//     handler runs; for undocumented routes, a successful response whose
+//     content type the Accept header excludes is replaced by 406;
+//   - GET and HEAD requests with a body are rejected with 400 Bad Request;
+//   - Context.Created requires a Location header, see Context.CreatedAt.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithStrictMode(okapi.StrictMode{RelaxAccept: true}))
+func WithStrictMode(s StrictMode) OptionFunc {
+	return func(o *Okapi) {
+		o.strict = &s
+		if !s.RelaxMethodNotAllowed {
+			o.router.muxRouter.MethodNotAllowedHandler = http.HandlerFunc(o.serveMethodNotAllowed)
+		}
+	}
+}
+
+// WithStrictMode enables strict REST semantics, see WithStrictMode.
+func (o *Okapi) WithStrictMode(s StrictMode) *Okapi {
+	return o.apply(WithStrictMode(s))
+}
+
+// CreatedAt writes a JSON response with 201 status code and a Location header
+// pointing to the created resource.
+func (c *Context) CreatedAt(location string, v any) error {
+	c.response.Header().Set("Location", location)
+	return c.Created(v)
+}
+
+// serveMethodNotAllowed answers a request whose path matches a route but not
+// its method, listing the supported methods in the Allow header.
+func (o *Okapi) serveMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	if allowed := o.allowedMethods(r); len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+	}
+	if o.noMethod != nil {
+		o.wrapHandleFunc(o.noMethod).ServeHTTP(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusMethodNotAllowed)
+}
+
+// allowedMethods returns the methods of the routes matching the path of r.
+func (o *Okapi) allowedMethods(r *http.Request) []string {
+	var allowed []string
+	for _, method := range []string{methodGet, methodHead, methodPost, methodPut, methodPatch, methodDelete, methodOptions} {
+		probe := r.Clone(r.Context())
+		probe.Method = method
+		if o.matchesRoute(probe) {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// rejectBody answers GET and HEAD requests carrying a body with 400 Bad
+// Request, reporting whether it did.
+func (s *StrictMode) rejectBody(w http.ResponseWriter, r *http.Request) bool {
+	if s.RelaxBodylessMethods || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+		return false
+	}
+	if r.ContentLength <= 0 && len(r.TransferEncoding) == 0 {
+		return false
+	}
+	http.Error(w, "request body not allowed for "+r.Method, http.StatusBadRequest)
+	return true
+}
+
+// rejectUnacceptable answers 406 Not Acceptable, before the handler runs, to a
+// request accepting none of the content types documented for the successful
+// responses of route, reporting whether it did. Routes documenting none are
+// checked when writing the response, see Context.acceptable.
+func (s *StrictMode) rejectUnacceptable(c *Context, route *Route) bool {
+	accept := c.request.Header.Get("Accept")
+	if s.RelaxAccept || strings.TrimSpace(accept) == "" {
+		return false
+	}
+	contentTypes := route.successContentTypes(c.okapi)
+	if len(contentTypes) == 0 {
+		return false
+	}
+	for _, contentType := range contentTypes {
+		if acceptsMediaType(accept, contentType) {
+			return false
+		}
+	}
+	c.writeNotAcceptable()
+	return true
+}
+
+// successContentTypes returns the content types documented for the 2xx
+// responses of r that carry a body.
+func (r *Route) successContentTypes(o *Okapi) []string {
+	var types []string
+	for status := range r.responses {
+		if status < 200 || status >= 300 || !statusAllowsBody(status) {
+			continue
+		}
+		types = append(types, constJSON)
+		if o.xmlOptions != nil {
+			types = append(types, constXML)
+		}
+		if r.protoResponses[status] {
+			types = append(types, constPROTOBUF)
+		}
+	}
+	for status, contentTypes := range r.fileResponses {
+		if status >= 200 && status < 300 {
+			types = append(types, contentTypes...)
+		}
+	}
+	for _, rep := range r.renders {
+		types = append(types, rep.mediaType)
+	}
+	return types
+}
+
+// missingLocation reports whether c.Created must fail for lack of a Location header.
+func (c *Context) missingLocation() bool {
+	return c.okapi != nil && c.okapi.strict != nil && !c.okapi.strict.RelaxCreatedLocation &&
+		c.response.Header().Get("Location") == ""
+}
+
+// acceptable reports whether a response with the given status and content
+// type may be sent, as far as strict mode is concerned. Requests are normally
+// negotiated before the handler runs, see StrictMode.rejectUnacceptable; this
+// is the fallback for undocumented content types, logged as the handler has
+// already run.
+func (c *Context) acceptable(code int, contentType string) bool {
+	if c.okapi == nil || c.okapi.strict == nil || c.okapi.strict.RelaxAccept || code >= 300 || c.request == nil {
+		return true
+	}
+	if acceptsMediaType(c.request.Header.Get("Accept"), contentType) {
+		return true
+	}
+	c.Logger().Warn("response content type not acceptable after the handler ran, document the route's responses to reject the request before",
+		slog.String("content_type", contentType), slog.String("accept", c.request.Header.Get("Accept")))
+	return false
+}
+
+// writeNotAcceptable answers 406 Not Acceptable.
+func (c *Context) writeNotAcceptable() {
+	body := http.StatusText(http.StatusNotAcceptable) + "\n"
+	h := c.response.Header()
+	h.Set(constContentTypeHeader, "text/plain; charset=utf-8")
+	h.Set("Content-Length", strconv.Itoa(len(body)))
+	c.response.WriteHeader(http.StatusNotAcceptable)
+	_, _ = c.response.Write([]byte(body))
+}
+
+// acceptsMediaType reports whether an Accept header allows contentType. The
+// most specific matching media range decides; q=0 excludes it. An empty
+// header accepts anything.
+func acceptsMediaType(accept, contentType string) bool {
+	return mediaTypeQuality(accept, contentType) > 0
+}
+
+// mediaTypeQuality returns the quality an Accept header gives contentType,
+// taken from the most specific matching media range: 0 when it is not
+// accepted, 1 when the header is empty.
+func mediaTypeQuality(accept, contentType string) float64 {
+	if strings.TrimSpace(accept) == "" {
+		return 1
+	}
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	typ, _, _ := strings.Cut(mediaType, "/")
+
+	best, quality := 0, 0.0
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		r := strings.ToLower(strings.TrimSpace(params[0]))
+		specificity := 0
+		switch r {
+		case mediaType:
+			specificity = 3
+		case typ + "/*":
+			specificity = 2
+		case "*/*":
+			specificity = 1
+		}
+		if specificity <= best {
+			continue
+		}
+		q := 1.0
+		for _, p := range params[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(p), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		best, quality = specificity, max(q, 0)
+	}
+	return quality
+}
@@ -0,0 +1,145 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrictMode(t *testing.T) {
+	o := New(WithStrictMode(StrictMode{}))
+	o.Get("/books", func(c *Context) error { return c.OK(M{"books": 0}) })
+	o.Post("/books", func(c *Context) error { return c.Created(M{"id": 1}) })
+	o.Put("/books/{id}", func(c *Context) error { return c.CreatedAt("/books/"+c.Param("id"), M{}) })
+
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, r)
+		return rec
+	}
+
+	t.Run("method not allowed", func(t *testing.T) {
+		rec := serve(httptest.NewRequest(http.MethodDelete, "/books", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, POST", rec.Header().Get("Allow"))
+		assert.Equal(t, http.StatusNotFound, serve(httptest.NewRequest(http.MethodGet, "/authors", nil)).Code)
+	})
+
+	t.Run("not acceptable", func(t *testing.T) {
+		for accept, want := range map[string]int{
+			"":                                http.StatusOK,
+			"application/json":                http.StatusOK,
+			"text/html, application/*;q=0.5":  http.StatusOK,
+			"*/*":                             http.StatusOK,
+			"text/html":                       http.StatusNotAcceptable,
+			"*/*, application/json;q=0":       http.StatusNotAcceptable,
+			"application/xml, text/plain;q=1": http.StatusNotAcceptable,
+		} {
+			r := httptest.NewRequest(http.MethodGet, "/books", nil)
+			r.Header.Set("Accept", accept)
+			assert.Equal(t, want, serve(r).Code, accept)
+		}
+	})
+
+	t.Run("body on GET", func(t *testing.T) {
+		rec := serve(httptest.NewRequest(http.MethodGet, "/books", strings.NewReader("{}")))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("created location", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, serve(httptest.NewRequest(http.MethodPost, "/books", nil)).Code)
+		rec := serve(httptest.NewRequest(http.MethodPut, "/books/7", nil))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "/books/7", rec.Header().Get("Location"))
+	})
+}
+
+func TestStrictModeNegotiatesBeforeHandler(t *testing.T) {
+	o := New(WithStrictMode(StrictMode{}))
+	var calls int
+	o.Post("/books", func(c *Context) error {
+		calls++
+		return c.CreatedAt("/books/1", M{"id": 1})
+	}, DocResponse(http.StatusCreated, M{}))
+	o.Get("/export", func(c *Context) error {
+		calls++
+		return c.String(http.StatusOK, "id,title")
+	}, DocFileResponse(http.StatusOK, "text/csv"))
+
+	serve := func(method, path, accept string) int {
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, r)
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusNotAcceptable, serve(http.MethodPost, "/books", "text/html"))
+	assert.Zero(t, calls, "handler ran for an unacceptable request")
+	assert.Equal(t, http.StatusCreated, serve(http.MethodPost, "/books", "application/*"))
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, http.StatusNotAcceptable, serve(http.MethodGet, "/export", "application/json"))
+	assert.Equal(t, 1, calls)
+}
+
+func TestStrictModeRelaxed(t *testing.T) {
+	o := New().WithStrictMode(StrictMode{
+		RelaxMethodNotAllowed: true,
+		RelaxAccept:           true,
+		RelaxBodylessMethods:  true,
+		RelaxCreatedLocation:  true,
+	})
+	o.Get("/books", func(c *Context) error { return c.OK(M{}) })
+	o.Post("/books", func(c *Context) error { return c.Created(M{}) })
+
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		o.ServeHTTP(rec, r)
+		return rec
+	}
+	assert.Empty(t, serve(httptest.NewRequest(http.MethodDelete, "/books", nil)).Header().Get("Allow"))
+	r := httptest.NewRequest(http.MethodGet, "/books", strings.NewReader("{}"))
+	r.Header.Set("Accept", "text/html")
+	assert.Equal(t, http.StatusOK, serve(r).Code)
+	assert.Equal(t, http.StatusCreated, serve(httptest.NewRequest(http.MethodPost, "/books", nil)).Code)
+}
+
+func TestStrictModeNoMethod(t *testing.T) {
+	o := New(WithStrictMode(StrictMode{}))
+	o.NoMethod(func(c *Context) error { return c.AbortMethodNotAllowed("custom") })
+	o.Get("/books", anyHandler)
+	o.applyCommon()
+
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/books", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET", rec.Header().Get("Allow"))
+	assert.Contains(t, rec.Body.String(), "custom")
+}
@@ -29,15 +29,73 @@ import (
 	"io"
 	"io/fs"
 	"path/filepath"
+	"sync"
 	"text/template"
 )
 
 type Template struct {
+	mu        sync.RWMutex
 	templates *template.Template
+	// load parses the templates again from their source, see Reload.
+	load func() (*template.Template, error)
+	// added replays the templates added after creation on reload.
+	added []func(*template.Template) error
 }
 
-func (t *Template) Render(w io.Writer, name string, data interface{}, _ *Context) error {
-	return t.templates.ExecuteTemplate(w, name, data)
+func (t *Template) Render(w io.Writer, name string, data interface{}, c *Context) error {
+	t.mu.RLock()
+	tmpl := t.templates
+	t.mu.RUnlock()
+	if c != nil {
+		funcs := template.FuncMap{}
+		if _, ok := c.Get(formNonceContextKey); ok {
+			funcs["nonce_field"] = c.FormNonceField
+		}
+		if c.okapi != nil && c.okapi.localeOptions != nil {
+			funcs["format_number"] = c.FormatNumber
+			funcs["format_date"] = c.FormatDate
+			funcs["format_datetime"] = c.FormatDateTime
+		}
+		if len(funcs) > 0 {
+			clone, err := tmpl.Clone()
+			if err != nil {
+				return err
+			}
+			tmpl = clone.Funcs(funcs)
+		}
+	}
+	return tmpl.ExecuteTemplate(w, name, data)
+}
+
+// Reload parses the templates again from the files they were loaded from,
+// including those added with AddTemplate and AddTemplateFile. On error the
+// current templates are kept. It is used by the dev server to pick up edits.
+func (t *Template) Reload() error {
+	if t.load == nil {
+		return nil
+	}
+	tmpl, err := t.load()
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, add := range t.added {
+		if err := add(tmpl); err != nil {
+			return err
+		}
+	}
+	t.templates = tmpl
+	return nil
+}
+
+// newReloadableTemplate loads the templates once and keeps load for Reload.
+func newReloadableTemplate(load func() (*template.Template, error)) (*Template, error) {
+	tmpl, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &Template{templates: tmpl, load: load}, nil
 }
 
 // TemplateConfig holds configuration for template loading
@@ -54,11 +112,13 @@ type TemplateConfig struct {
 
 // NewTemplate creates a template from embedded filesystem
 func NewTemplate(fsys fs.FS, pattern string) (*Template, error) {
-	tmpl, err := template.ParseFS(fsys, pattern)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse templates from fs: %w", err)
-	}
-	return &Template{templates: tmpl}, nil
+	return newReloadableTemplate(func() (*template.Template, error) {
+		tmpl, err := template.New("").Funcs(formFuncs).ParseFS(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse templates from fs: %w", err)
+		}
+		return tmpl, nil
+	})
 }
 
 // NewTemplateFromFiles creates a template from file system with pattern
@@ -71,11 +131,13 @@ func NewTemplate(fsys fs.FS, pattern string) (*Template, error) {
 //	 }
 //		o := okapi.New().WithRenderer(tmpl)
 func NewTemplateFromFiles(pattern string) (*Template, error) {
-	tmpl, err := template.ParseGlob(pattern)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse template files: %w", err)
-	}
-	return &Template{templates: tmpl}, nil
+	return newReloadableTemplate(func() (*template.Template, error) {
+		tmpl, err := template.New("").Funcs(formFuncs).ParseGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template files: %w", err)
+		}
+		return tmpl, nil
+	})
 }
 
 // NewTemplateFromDirectory creates a template from a directory
@@ -88,6 +150,13 @@ func NewTemplateFromFiles(pattern string) (*Template, error) {
 //	 }
 //		o := okapi.New().WithRenderer(tmpl)
 func NewTemplateFromDirectory(dir string, extensions ...string) (*Template, error) {
+	return newReloadableTemplate(func() (*template.Template, error) {
+		return parseTemplateDirectory(dir, extensions...)
+	})
+}
+
+// parseTemplateDirectory parses the templates with the given extensions found in dir.
+func parseTemplateDirectory(dir string, extensions ...string) (*template.Template, error) {
 	if len(extensions) == 0 {
 		extensions = []string{".html", ".tmpl"}
 	}
@@ -98,7 +167,7 @@ func NewTemplateFromDirectory(dir string, extensions ...string) (*Template, erro
 		patterns = append(patterns, filepath.Join(dir, "*"+ext))
 	}
 
-	tmpl := template.New("")
+	tmpl := template.New("").Funcs(formFuncs)
 	for _, pattern := range patterns {
 		matches, err := filepath.Glob(pattern)
 		if err != nil {
@@ -117,7 +186,7 @@ func NewTemplateFromDirectory(dir string, extensions ...string) (*Template, erro
 		return nil, fmt.Errorf("no templates found in directory: %s", dir)
 	}
 
-	return &Template{templates: tmpl}, nil
+	return tmpl, nil
 }
 
 // NewTemplateWithConfig creates a template using configuration
@@ -135,14 +204,20 @@ func NewTemplateFromDirectory(dir string, extensions ...string) (*Template, erro
 //	 }
 //		o := okapi.New().WithRenderer(tmpl)
 func NewTemplateWithConfig(config TemplateConfig) (*Template, error) {
+	return newReloadableTemplate(func() (*template.Template, error) {
+		return parseTemplateConfig(config)
+	})
+}
+
+// parseTemplateConfig parses the templates described by config.
+func parseTemplateConfig(config TemplateConfig) (*template.Template, error) {
 	var tmpl *template.Template
 	var err error
 
-	// Initialize with custom functions if provided
+	// Initialize with the form helpers, then custom functions if provided
+	tmpl = template.New("").Funcs(formFuncs)
 	if config.Funcs != nil {
-		tmpl = template.New("").Funcs(config.Funcs)
-	} else {
-		tmpl = template.New("")
+		tmpl = tmpl.Funcs(config.Funcs)
 	}
 
 	// Parse templates based on source
@@ -166,7 +241,7 @@ func NewTemplateWithConfig(config TemplateConfig) (*Template, error) {
 		return nil, fmt.Errorf("no templates found with config: %+v", config)
 	}
 
-	return &Template{templates: tmpl}, nil
+	return tmpl, nil
 }
 
 // AddTemplate allows adding templates dynamically after creation
@@ -178,11 +253,13 @@ func NewTemplateWithConfig(config TemplateConfig) (*Template, error) {
 //		// handle error
 //	}
 func (t *Template) AddTemplate(name, content string) error {
-	_, err := t.templates.New(name).Parse(content)
-	if err != nil {
-		return fmt.Errorf("failed to add template %s: %w", name, err)
-	}
-	return nil
+	return t.add(func(tmpl *template.Template) error {
+		_, err := tmpl.New(name).Parse(content)
+		if err != nil {
+			return fmt.Errorf("failed to add template %s: %w", name, err)
+		}
+		return nil
+	})
 }
 
 // AddTemplateFile adds a template from a file
@@ -194,10 +271,23 @@ func (t *Template) AddTemplate(name, content string) error {
 //		// handle error
 //	}
 func (t *Template) AddTemplateFile(filepath string) error {
-	_, err := t.templates.ParseFiles(filepath)
-	if err != nil {
-		return fmt.Errorf("failed to add template file %s: %w", filepath, err)
+	return t.add(func(tmpl *template.Template) error {
+		_, err := tmpl.ParseFiles(filepath)
+		if err != nil {
+			return fmt.Errorf("failed to add template file %s: %w", filepath, err)
+		}
+		return nil
+	})
+}
+
+// add applies fn to the current templates and records it for Reload.
+func (t *Template) add(fn func(*template.Template) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := fn(t.templates); err != nil {
+		return err
 	}
+	t.added = append(t.added, fn)
 	return nil
 }
 
@@ -557,3 +557,52 @@ func TestOkapiWithRendererMethods(t *testing.T) {
 		}
 	})
 }
+
+func TestTemplateReload(t *testing.T) {
+	dir := t.TempDir()
+	page := filepath.Join(dir, "page.html")
+	if err := os.WriteFile(page, []byte("v1 {{.}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := NewTemplateFromFiles(filepath.Join(dir, "*.html"))
+	if err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+	if err := tmpl.AddTemplate("extra", "extra {{.}}"); err != nil {
+		t.Fatal(err)
+	}
+
+	render := func(name string) string {
+		var buf bytes.Buffer
+		if err := tmpl.Render(&buf, name, "ok", nil); err != nil {
+			t.Fatalf("Failed to render %s: %v", name, err)
+		}
+		return buf.String()
+	}
+
+	if err := os.WriteFile(page, []byte("v2 {{.}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := render("page.html"); got != "v1 ok" {
+		t.Errorf("Expected cached template before reload, got %q", got)
+	}
+	if err := tmpl.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if got := render("page.html"); got != "v2 ok" {
+		t.Errorf("Expected reloaded template, got %q", got)
+	}
+	if got := render("extra"); got != "extra ok" {
+		t.Errorf("Expected added template to survive reload, got %q", got)
+	}
+
+	if err := os.WriteFile(page, []byte("v3 {{.}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpl.Reload(); err == nil {
+		t.Error("Expected reload error for an invalid template")
+	}
+	if got := render("page.html"); got != "v2 ok" {
+		t.Errorf("Expected previous templates to be kept on error, got %q", got)
+	}
+}
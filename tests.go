@@ -25,6 +25,7 @@
 package okapi
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net"
@@ -61,7 +62,7 @@ func NewTestContext(method, url string, body io.Reader) (*Context, *httptest.Res
 		store:    newStoreData(),
 	}
 
-	return ctx, w
+	return ctx.attach(), w
 }
 
 // NewTestServer creates and starts a new Okapi test server.
@@ -113,12 +114,31 @@ func NewTestServerWithOkapi(t TestingT, o *Okapi) *TestServer {
 	srv := httptest.NewServer(o)
 	t.Cleanup(srv.Close)
 
-	return &TestServer{
+	ts := &TestServer{
 		Okapi:       o,
 		BaseURL:     srv.URL,
 		t:           t,
 		httptestSrv: srv,
 	}
+	ts.ResetFixtures()
+	return ts
+}
+
+// ResetFixtures loads the fixtures registered with WithFixtures again, so each
+// test starts from the same data. The test fails if a fixture returns an error.
+//
+// Example:
+//
+//	ts := okapi.NewTestServerWithOkapi(t, newApp())
+//	t.Run("delete", func(t *testing.T) {
+//		defer ts.ResetFixtures()
+//		okapitest.DELETE(t, ts.BaseURL+"/books/1").ExpectStatus(http.StatusNoContent)
+//	})
+func (ts *TestServer) ResetFixtures() {
+	ts.t.Helper()
+	if err := ts.LoadFixtures(context.Background()); err != nil {
+		ts.t.Fatalf("okapi: %v", err)
+	}
 }
 
 // NewTestServerOn creates and starts a new Okapi test server.
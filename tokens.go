@@ -0,0 +1,154 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+// defaultTokenTTL is the default validity of a token signed by Tokens.
+const defaultTokenTTL = 24 * time.Hour
+
+var (
+	// ErrInvalidToken is returned by Tokens.Verify for a malformed or tampered
+	// token, or a token signed for another purpose.
+	ErrInvalidToken = errors.New("okapi: invalid token")
+	// ErrTokenExpired is returned by Tokens.Verify for an expired token.
+	ErrTokenExpired = errors.New("okapi: token expired")
+	// errNoTokenSecret is returned by Tokens.Sign when no secret is set.
+	errNoTokenSecret = errors.New("okapi: token secret is required")
+)
+
+// Tokens signs and verifies expiring, URL-safe tokens identifying a subject,
+// such as the user ID in an email verification or password reset link.
+//
+// Tokens are bound to a purpose, so a token issued to verify an email address
+// cannot reset a password. They are signed with HMAC-SHA256 and carry the
+// subject in clear: do not put secrets in it.
+//
+// Example:
+//
+//	tokens := &okapi.Tokens{Secret: []byte(os.Getenv("TOKEN_SECRET")), TTL: time.Hour}
+//
+//	token, err := tokens.Sign("invite", team.ID)
+//	// ...
+//	teamID, err := tokens.Verify("invite", c.Query("token"))
+type Tokens struct {
+	// Secret is the HMAC key signing the tokens. Required.
+	Secret []byte
+	// PreviousSecrets verify tokens signed before the Secret was rotated.
+	PreviousSecrets [][]byte
+	// TTL is how long a token stays valid. Defaults to 24 hours.
+	TTL time.Duration
+}
+
+// Sign returns a token for subject, valid for purpose until TTL elapses.
+func (t *Tokens) Sign(purpose, subject string) (string, error) {
+	return t.sign(purpose, subject, nil)
+}
+
+// Verify returns the subject of token, checking that it was signed for purpose
+// and has not expired.
+func (t *Tokens) Verify(purpose, token string) (string, error) {
+	return t.verify(purpose, token, nil)
+}
+
+// sign is Sign for a token also bound to bind(subject), such as
+// PasswordReset.Fingerprint, with a second signature.
+func (t *Tokens) sign(purpose, subject string, bind func(subject string) string) (string, error) {
+	if len(t.Secret) == 0 {
+		return "", errNoTokenSecret
+	}
+	ttl := t.TTL
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+	payload := binary.BigEndian.AppendUint64(nil, uint64(time.Now().Add(ttl).Unix()))
+	payload = append(payload, subject...)
+	token := base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(tokenSignature(t.Secret, purpose, payload))
+	if bind != nil {
+		token += "." + base64.RawURLEncoding.EncodeToString(tokenSignature(t.Secret, purpose+"\x00"+bind(subject), payload))
+	}
+	return token, nil
+}
+
+// verify is Verify for a token signed with bind. bind is only called once the
+// token is authenticated and unexpired, so forged tokens cannot make it look
+// up arbitrary subjects.
+func (t *Tokens) verify(purpose, token string, bind func(subject string) string) (string, error) {
+	segments := 2
+	if bind != nil {
+		segments = 3
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != segments {
+		return "", ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(payload) < 8 {
+		return "", ErrInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	var key []byte
+	for _, secret := range append([][]byte{t.Secret}, t.PreviousSecrets...) {
+		if len(secret) > 0 && hmac.Equal(signature, tokenSignature(secret, purpose, payload)) {
+			key = secret
+			break
+		}
+	}
+	if key == nil {
+		return "", ErrInvalidToken
+	}
+	if time.Now().Unix() > int64(binary.BigEndian.Uint64(payload[:8])) {
+		return "", ErrTokenExpired
+	}
+	subject := string(payload[8:])
+	if bind != nil {
+		binding, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil || !hmac.Equal(binding, tokenSignature(key, purpose+"\x00"+bind(subject), payload)) {
+			return "", ErrInvalidToken
+		}
+	}
+	return subject, nil
+}
+
+// tokenSignature signs payload for purpose.
+func tokenSignature(secret []byte, purpose string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
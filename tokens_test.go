@@ -0,0 +1,70 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokens(t *testing.T) {
+	tokens := &Tokens{Secret: []byte("secret"), TTL: time.Hour}
+	token, err := tokens.Sign("invite", "team-42")
+	require.NoError(t, err)
+	assert.NotContains(t, token, "=")
+
+	subject, err := tokens.Verify("invite", token)
+	require.NoError(t, err)
+	assert.Equal(t, "team-42", subject)
+
+	_, err = tokens.Verify("password_reset", token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = tokens.Verify("invite", token[:len(token)-2]+"AA")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = tokens.Verify("invite", "garbage")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	rotated := &Tokens{Secret: []byte("new secret"), PreviousSecrets: [][]byte{[]byte("secret")}}
+	subject, err = rotated.Verify("invite", token)
+	require.NoError(t, err)
+	assert.Equal(t, "team-42", subject)
+	_, err = (&Tokens{Secret: []byte("other")}).Verify("invite", token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	payload := binary.BigEndian.AppendUint64(nil, uint64(time.Now().Add(-time.Minute).Unix()))
+	payload = append(payload, "team-42"...)
+	expired := base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(tokenSignature([]byte("secret"), "invite", payload))
+	_, err = tokens.Verify("invite", expired)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+
+	_, err = (&Tokens{}).Sign("invite", "team-42")
+	assert.Error(t, err)
+}
@@ -0,0 +1,312 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"cmp"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTOTPDigits      = 6
+	maxTOTPDigits          = 8
+	defaultTOTPPeriod      = 30 * time.Second
+	defaultTOTPSecretBytes = 20
+	recoveryCodeLength     = 10
+)
+
+// ErrInvalidTOTPSecret is returned for a secret that is not valid base32.
+var ErrInvalidTOTPSecret = errors.New("okapi: invalid TOTP secret")
+
+// totpEncoding encodes secrets as authenticator apps expect them.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP implements time-based one-time passwords (RFC 6238) for two-factor
+// authentication: secret generation, provisioning URIs for authenticator apps,
+// code verification and recovery codes. Its Middleware restricts routes to
+// callers who passed the second factor.
+//
+// The defaults (SHA-1, 6 digits, 30 seconds) are the ones supported by every
+// authenticator app.
+//
+// Example:
+//
+//	totp := &okapi.TOTP{Issuer: "Okapi Books"}
+//
+//	// Enrollment: store the secret, show the URI as a QR code
+//	secret, _ := totp.GenerateSecret()
+//	uri := totp.ProvisioningURI("alice@example.com", secret)
+//
+//	// Login: issue a token with "amr": ["pwd", "otp"] once the code is valid,
+//	// and store the counter so that the code cannot be replayed
+//	counter, ok := totp.VerifyCounter(secret, code, user.LastTOTPCounter)
+//	if !ok { ... }
+//
+//	admin := o.Group("/admin", jwtAuth.Middleware, totp.Middleware)
+type TOTP struct {
+	// Issuer names the application in authenticator apps.
+	Issuer string
+	// Digits is the length of the codes, between 6 and 8. Defaults to 6.
+	Digits int
+	// Period is how long a code is valid, in whole seconds. Defaults to 30
+	// seconds; shorter periods than a second are raised to one second.
+	Period time.Duration
+	// Skew is the number of periods accepted before and after the current one,
+	// to tolerate clock drift between the server and the device. Defaults to 0;
+	// 1 is a common choice.
+	Skew int
+	// Verified reports whether the caller passed the second factor. Defaults to
+	// checking that the principal's AuthMethods contain "otp" or "mfa", as set
+	// by JWTAuth from the "amr" claim.
+	Verified func(c *Context) bool
+	// OnUnverified answers callers who did not pass the second factor. Defaults
+	// to a 403 Forbidden.
+	OnUnverified HandlerFunc
+}
+
+// GenerateSecret returns a random base32 secret to share with the user's
+// authenticator app.
+func (t *TOTP) GenerateSecret() (string, error) {
+	secret := make([]byte, defaultTOTPSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI registering secret for account in
+// an authenticator app. It is usually shown as a QR code.
+func (t *TOTP) ProvisioningURI(account, secret string) string {
+	label := account
+	if t.Issuer != "" {
+		label = t.Issuer + ":" + account
+	}
+	params := url.Values{}
+	params.Set("secret", secret)
+	if t.Issuer != "" {
+		params.Set("issuer", t.Issuer)
+	}
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", strconv.Itoa(t.digits()))
+	params.Set("period", strconv.Itoa(int(t.period().Seconds())))
+	return (&url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + label, RawQuery: params.Encode()}).String()
+}
+
+// Code returns the code of secret at the given time.
+func (t *TOTP) Code(secret string, at time.Time) (string, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return t.code(key, t.counter(at)), nil
+}
+
+// Verify reports whether code is the current code of secret, within the Skew
+// window. It does not prevent a code from being used twice while it is valid;
+// use VerifyCounter for that.
+func (t *TOTP) Verify(secret, code string) bool {
+	return t.VerifyAt(secret, code, time.Now())
+}
+
+// VerifyAt reports whether code is the code of secret at the given time, within
+// the Skew window.
+func (t *TOTP) VerifyAt(secret, code string, at time.Time) bool {
+	_, ok := t.match(secret, code, at)
+	return ok
+}
+
+// VerifyCounter reports whether code is the current code of secret, within the
+// Skew window, and was issued after the code accepted last. It returns the
+// counter of the matched code: store it with the secret and pass it as
+// lastUsed on the next verification, so that a code cannot be replayed. Pass 0
+// when no code was accepted yet.
+func (t *TOTP) VerifyCounter(secret, code string, lastUsed uint64) (uint64, bool) {
+	return t.VerifyCounterAt(secret, code, lastUsed, time.Now())
+}
+
+// VerifyCounterAt is VerifyCounter at the given time.
+func (t *TOTP) VerifyCounterAt(secret, code string, lastUsed uint64, at time.Time) (uint64, bool) {
+	counter, ok := t.match(secret, code, at)
+	if !ok || counter <= lastUsed {
+		return 0, false
+	}
+	return counter, true
+}
+
+// match returns the latest counter of the Skew window around at whose code is
+// code.
+func (t *TOTP) match(secret, code string, at time.Time) (uint64, bool) {
+	key, err := decodeTOTPSecret(secret)
+	code = strings.ReplaceAll(code, " ", "")
+	if err != nil || len(code) != t.digits() {
+		return 0, false
+	}
+	counter := t.counter(at)
+	var matched uint64
+	valid := false
+	for i := -t.Skew; i <= t.Skew; i++ {
+		if int64(counter)+int64(i) < 0 {
+			continue
+		}
+		candidate := uint64(int64(counter) + int64(i))
+		expected := t.code(key, candidate)
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			matched, valid = candidate, true
+		}
+	}
+	return matched, valid
+}
+
+// Middleware rejects callers who did not pass the second factor, see Verified.
+// It runs after the authentication middleware.
+func (t *TOTP) Middleware(c *Context) error {
+	verified := t.Verified
+	if verified == nil {
+		verified = principalPassedOTP
+	}
+	if verified(c) {
+		return c.Next()
+	}
+	if t.OnUnverified != nil {
+		return t.OnUnverified(c)
+	}
+	return c.AbortForbidden("Two-factor authentication required")
+}
+
+// digits returns Digits clamped to the lengths supported by authenticator
+// apps, which also keeps the code modulus within uint32.
+func (t *TOTP) digits() int {
+	return min(max(cmp.Or(t.Digits, defaultTOTPDigits), defaultTOTPDigits), maxTOTPDigits)
+}
+
+// period returns Period truncated to whole seconds, as counter divides by it.
+func (t *TOTP) period() time.Duration {
+	if t.Period <= 0 {
+		return defaultTOTPPeriod
+	}
+	return max(t.Period.Truncate(time.Second), time.Second)
+}
+
+func (t *TOTP) counter(at time.Time) uint64 {
+	return uint64(at.Unix() / int64(t.period().Seconds()))
+}
+
+// code computes the HOTP value (RFC 4226) of key for counter.
+func (t *TOTP) code(key []byte, counter uint64) string {
+	mac := hmac.New(sha1.New, key)
+	_ = binary.Write(mac, binary.BigEndian, counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	mod := uint32(1)
+	for range t.digits() {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", t.digits(), value%mod)
+}
+
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidTOTPSecret
+	}
+	return key, nil
+}
+
+// principalPassedOTP reports whether the principal's authentication methods
+// include a one-time password.
+func principalPassedOTP(c *Context) bool {
+	methods := c.Principal().AuthMethods
+	return slices.Contains(methods, "otp") || slices.Contains(methods, "mfa")
+}
+
+// GenerateRecoveryCodes returns n single-use recovery codes, formatted as
+// "xxxxx-xxxxx", letting users sign in without their device. Show them to the
+// user once, store them hashed with HashRecoveryCode, and check them with
+// MatchRecoveryCode.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz023456789"
+	codes := make([]string, n)
+	buf := make([]byte, recoveryCodeLength)
+	for i := range codes {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		var b strings.Builder
+		for j, v := range buf {
+			if j == recoveryCodeLength/2 {
+				b.WriteByte('-')
+			}
+			b.WriteByte(alphabet[v%32])
+		}
+		codes[i] = b.String()
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash of a recovery code to store in place of the
+// code. Recovery codes are random, so a SHA-256 digest is enough to protect them,
+// unlike passwords. Case, spaces and dashes are ignored.
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchRecoveryCode returns the index in hashes of the hash of code, as
+// returned by HashRecoveryCode, or -1. The comparison ignores case, spaces and
+// dashes, and takes the same time whatever the match. Remove the matched hash
+// so that the code cannot be used again.
+func MatchRecoveryCode(code string, hashes []string) int {
+	if normalizeRecoveryCode(code) == "" {
+		return -1
+	}
+	match := -1
+	given := []byte(HashRecoveryCode(code))
+	for i, candidate := range hashes {
+		if subtle.ConstantTimeCompare(given, []byte(candidate)) == 1 && match < 0 {
+			match = i
+		}
+	}
+	return match
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
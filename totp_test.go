@@ -0,0 +1,174 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the base32 form of the RFC 6238 SHA-1 test key.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	totp := &TOTP{Digits: 8}
+	for _, tc := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1234567890, "89005924"},
+		{20000000000, "65353130"},
+	} {
+		code, err := totp.Code(rfc6238Secret, time.Unix(tc.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tc.code, code)
+	}
+
+	_, err := totp.Code("not base32!", time.Now())
+	assert.ErrorIs(t, err, ErrInvalidTOTPSecret)
+}
+
+func TestTOTPVerify(t *testing.T) {
+	totp := &TOTP{Skew: 1}
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code, err := totp.Code(secret, now)
+	require.NoError(t, err)
+	assert.Len(t, code, 6)
+
+	assert.True(t, totp.VerifyAt(secret, code, now))
+	assert.True(t, totp.VerifyAt(secret, code, now.Add(30*time.Second)))
+	assert.False(t, totp.VerifyAt(secret, code, now.Add(90*time.Second)))
+	assert.False(t, totp.VerifyAt(secret, "12345", now))
+	assert.False(t, (&TOTP{}).VerifyAt(secret, code, now.Add(30*time.Second)))
+}
+
+func TestTOTPVerifyCounter(t *testing.T) {
+	totp := &TOTP{Skew: 1}
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	code, err := totp.Code(secret, now)
+	require.NoError(t, err)
+
+	counter, ok := totp.VerifyCounterAt(secret, code, 0, now)
+	require.True(t, ok)
+	assert.Equal(t, uint64(1700000000/30), counter)
+
+	// The same code is rejected once its counter was used, even within the window
+	_, ok = totp.VerifyCounterAt(secret, code, counter, now.Add(30*time.Second))
+	assert.False(t, ok)
+
+	next, err := totp.Code(secret, now.Add(30*time.Second))
+	require.NoError(t, err)
+	nextCounter, ok := totp.VerifyCounterAt(secret, next, counter, now.Add(30*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, counter+1, nextCounter)
+
+	// A code older than the last accepted one is rejected
+	_, ok = totp.VerifyCounterAt(secret, code, nextCounter, now)
+	assert.False(t, ok)
+}
+
+func TestTOTPDigitsAndPeriodBounds(t *testing.T) {
+	secret, err := (&TOTP{}).GenerateSecret()
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	for digits, want := range map[int]int{4: 6, 7: 7, 10: 8} {
+		code, err := (&TOTP{Digits: digits}).Code(secret, now)
+		require.NoError(t, err)
+		assert.Len(t, code, want)
+	}
+
+	totp := &TOTP{Period: 500 * time.Millisecond}
+	code, err := totp.Code(secret, now)
+	require.NoError(t, err)
+	assert.True(t, totp.VerifyAt(secret, code, now))
+	assert.Contains(t, totp.ProvisioningURI("alice", secret), "period=1")
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	totp := &TOTP{Issuer: "Okapi Books"}
+	u, err := url.Parse(totp.ProvisioningURI("alice@example.com", rfc6238Secret))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Okapi Books:alice@example.com", u.Path)
+	assert.Equal(t, rfc6238Secret, u.Query().Get("secret"))
+	assert.Equal(t, "Okapi Books", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(8)
+	require.NoError(t, err)
+	require.Len(t, codes, 8)
+	assert.Regexp(t, `^[a-z0-9]{5}-[a-z0-9]{5}$`, codes[0])
+	assert.NotEqual(t, codes[0], codes[1])
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = HashRecoveryCode(code)
+	}
+	assert.NotContains(t, hashes[3], codes[3])
+	assert.Equal(t, 3, MatchRecoveryCode(" "+strings.ToUpper(codes[3][:5]+codes[3][6:])+" ", hashes))
+	assert.Equal(t, 3, MatchRecoveryCode(codes[3], hashes))
+	assert.Equal(t, -1, MatchRecoveryCode(codes[3], codes))
+	assert.Equal(t, -1, MatchRecoveryCode("aaaaa-aaaaa", hashes))
+	assert.Equal(t, -1, MatchRecoveryCode("", []string{HashRecoveryCode("")}))
+}
+
+func TestTOTPMiddleware(t *testing.T) {
+	auth := &JWTAuth{SigningSecret: jwtTestSecret, Audience: "okapi", Issuer: "okapi-test"}
+	totp := &TOTP{}
+	o := New()
+	o.Get("/admin", helloHandler, UseMiddleware(auth.Middleware, totp.Middleware))
+	ts := NewTestServerWithOkapi(t, o)
+
+	token := func(amr ...string) string {
+		return "Bearer " + signHMACToken(t, jwt.MapClaims{
+			"sub": "alice", "aud": "okapi", "iss": "okapi-test", "amr": amr,
+			"exp": time.Now().Add(time.Minute).Unix(),
+		})
+	}
+	okapitest.GET(t, ts.BaseURL+"/admin").Header("Authorization", token("pwd", "otp")).ExpectStatusOK()
+	okapitest.GET(t, ts.BaseURL+"/admin").Header("Authorization", token("pwd")).ExpectStatus(http.StatusForbidden)
+}
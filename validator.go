@@ -37,6 +37,22 @@ import (
 	"time"
 )
 
+// Validatable is implemented by bound structs with business rules spanning
+// several fields. Bind calls Validate once tag validation passes; returning
+// ValidationErrors makes typed handlers answer with a 422 ValidationErrorResponse.
+//
+// Example:
+//
+//	func (b *Booking) Validate(c *okapi.Context) error {
+//		if !b.End.After(b.Start) {
+//			return okapi.ValidationErrors{{Field: "end", Message: "must be after start"}}
+//		}
+//		return nil
+//	}
+type Validatable interface {
+	Validate(c *Context) error
+}
+
 // Precompiled patterns used by format validators.
 var (
 	semverRegex      = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
@@ -85,11 +101,8 @@ func (c *Context) extractAndSetField(field reflect.Value, sf reflect.StructField
 
 	// Query - supports slices and comma-separated values
 	if key := sf.Tag.Get(tagQuery); key != "" {
-		if field.Kind() == reflect.Slice {
+		if field.Kind() == reflect.Slice && !hasBindFunc(sf) {
 			rawSlice = c.QueryArray(key)
-			if len(rawSlice) == 1 && strings.Contains(rawSlice[0], ",") {
-				rawSlice = strings.Split(rawSlice[0], ",")
-			}
 		} else {
 			raw = c.Query(key)
 		}
@@ -128,7 +141,7 @@ func (c *Context) extractAndSetField(field reflect.Value, sf reflect.StructField
 	// Default values
 	if raw == "" && len(rawSlice) == 0 {
 		if def := sf.Tag.Get(tagDefault); def != "" {
-			if field.Kind() == reflect.Slice {
+			if field.Kind() == reflect.Slice && !hasBindFunc(sf) {
 				rawSlice = strings.Split(def, ",")
 			} else {
 				raw = def
@@ -142,6 +155,10 @@ func (c *Context) extractAndSetField(field reflect.Value, sf reflect.StructField
 			if err := setSliceWithType(field, rawSlice); err != nil {
 				return fmt.Errorf("cannot set field %s: %w", sf.Name, err)
 			}
+		} else if raw != "" && hasBindFunc(sf) {
+			if err := setWithBindFunc(field, raw, sf); err != nil {
+				return err
+			}
 		} else if raw != "" {
 			if err := setWithType(field, raw); err != nil {
 				return fmt.Errorf("cannot set field %s: %w", sf.Name, err)
@@ -165,6 +182,12 @@ var fieldConstraintCheckers = []func(reflect.Value, reflect.StructField) error{
 
 // checkNumericConstraints validates min, max, exclusiveMin, exclusiveMax, and multipleOf.
 func checkNumericConstraints(field reflect.Value, sf reflect.StructField) error {
+	if d, ok := decimalField(field); ok {
+		if d == nil {
+			return nil
+		}
+		return checkDecimalConstraints(*d, sf)
+	}
 	if tag := sf.Tag.Get(tagMin); tag != "" {
 		if err := checkMin(field, tag); err != nil {
 			return err
@@ -276,11 +299,15 @@ func checkCollectionConstraints(field reflect.Value, sf reflect.StructField) err
 // enum, const, multipleOf, format, pattern, and slice/map validations.
 func (c *Context) validateField(field reflect.Value, sf reflect.StructField) error {
 	if sf.Tag.Get(tagRequired) == constTRUE && isEmptyValue(field) {
-		return fmt.Errorf("field %s is required", sf.Name)
+		return &RequiredFieldError{Field: sf.Name}
+	}
+	field, ok := unwrapNull(field)
+	if !ok {
+		return nil
 	}
 	for _, check := range fieldConstraintCheckers {
 		if err := check(field, sf); err != nil {
-			return fmt.Errorf("field %s: %w", sf.Name, err)
+			return &FieldError{Field: sf.Name, Err: err}
 		}
 	}
 	return nil
@@ -295,11 +322,15 @@ func (c *Context) validateStruct(v reflect.Value, parentField reflect.StructFiel
 		sf := t.Field(i)
 
 		if sf.Tag.Get(tagRequired) == constTRUE && isEmptyValue(field) {
-			return fmt.Errorf("field %s.%s is required", parentField.Name, sf.Name)
+			return &RequiredFieldError{Field: parentField.Name + "." + sf.Name}
+		}
+		field, ok := unwrapNull(field)
+		if !ok {
+			continue
 		}
 		for _, check := range fieldConstraintCheckers {
 			if err := check(field, sf); err != nil {
-				return fmt.Errorf("field %s.%s: %w", parentField.Name, sf.Name, err)
+				return &FieldError{Field: parentField.Name + "." + sf.Name, Err: err}
 			}
 		}
 	}
@@ -308,6 +339,17 @@ func (c *Context) validateStruct(v reflect.Value, parentField reflect.StructFiel
 }
 
 func setWithType(field reflect.Value, raw string) error {
+	if n, ok := asNull(field); ok {
+		return n.bindValue(raw)
+	}
+	if field.Type() == decimalType {
+		d, err := ParseDecimal(raw)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(d))
+		return nil
+	}
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(raw)
@@ -369,6 +411,12 @@ func setSliceWithType(field reflect.Value, rawSlice []string) error {
 
 	for i, raw := range rawSlice {
 		elem := slice.Index(i)
+		if elemType == decimalType {
+			if err := setWithType(elem, strings.TrimSpace(raw)); err != nil {
+				return err
+			}
+			continue
+		}
 		switch elemType.Kind() {
 		case reflect.String:
 			elem.SetString(strings.TrimSpace(raw))
@@ -405,7 +453,7 @@ func checkMin(field reflect.Value, minTag string) error {
 			return fmt.Errorf("invalid min value: %s", minTag)
 		}
 		if field.Int() < minValue {
-			return fmt.Errorf("value %d must be >= %d", field.Int(), minValue)
+			return validationErrorf(MsgValueMin, field.Int(), minValue)
 		}
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -414,7 +462,7 @@ func checkMin(field reflect.Value, minTag string) error {
 			return fmt.Errorf("invalid min value: %s", minTag)
 		}
 		if field.Uint() < minValue {
-			return fmt.Errorf("value %d must be >= %d", field.Uint(), minValue)
+			return validationErrorf(MsgValueMin, field.Uint(), minValue)
 		}
 
 	case reflect.Float32, reflect.Float64:
@@ -423,7 +471,7 @@ func checkMin(field reflect.Value, minTag string) error {
 			return fmt.Errorf("invalid min value: %s", minTag)
 		}
 		if field.Float() < minValue {
-			return fmt.Errorf("value %g must be >= %g", field.Float(), minValue)
+			return validationErrorf(MsgValueMin, field.Float(), minValue)
 		}
 
 	case reflect.Slice, reflect.Array, reflect.Map:
@@ -432,7 +480,7 @@ func checkMin(field reflect.Value, minTag string) error {
 			return fmt.Errorf("invalid min length: %s", minTag)
 		}
 		if field.Len() < minValue {
-			return fmt.Errorf("length %d must be >= %d", field.Len(), minValue)
+			return validationErrorf(MsgLengthMin, field.Len(), minValue)
 		}
 	}
 
@@ -447,7 +495,7 @@ func checkMax(field reflect.Value, maxTag string) error {
 			return fmt.Errorf("invalid max value: %s", maxTag)
 		}
 		if field.Int() > maxValue {
-			return fmt.Errorf("value %d must be <= %d", field.Int(), maxValue)
+			return validationErrorf(MsgValueMax, field.Int(), maxValue)
 		}
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -456,7 +504,7 @@ func checkMax(field reflect.Value, maxTag string) error {
 			return fmt.Errorf("invalid max value: %s", maxTag)
 		}
 		if field.Uint() > maxValue {
-			return fmt.Errorf("value %d must be <= %d", field.Uint(), maxValue)
+			return validationErrorf(MsgValueMax, field.Uint(), maxValue)
 		}
 
 	case reflect.Float32, reflect.Float64:
@@ -465,7 +513,7 @@ func checkMax(field reflect.Value, maxTag string) error {
 			return fmt.Errorf("invalid max value: %s", maxTag)
 		}
 		if field.Float() > maxValue {
-			return fmt.Errorf("value %g must be <= %g", field.Float(), maxValue)
+			return validationErrorf(MsgValueMax, field.Float(), maxValue)
 		}
 
 	case reflect.Slice, reflect.Array, reflect.Map:
@@ -474,7 +522,7 @@ func checkMax(field reflect.Value, maxTag string) error {
 			return fmt.Errorf("invalid max length: %s", maxTag)
 		}
 		if field.Len() > maxValue {
-			return fmt.Errorf("length %d must be <= %d", field.Len(), maxValue)
+			return validationErrorf(MsgLengthMax, field.Len(), maxValue)
 		}
 	}
 
@@ -489,7 +537,7 @@ func checkMinLength(field reflect.Value, minTag string) error {
 
 	if field.Kind() == reflect.String {
 		if len(field.String()) < minValue {
-			return fmt.Errorf("string length %d must be at least %d characters", len(field.String()), minValue)
+			return validationErrorf(MsgStringMinLength, len(field.String()), minValue)
 		}
 	}
 	return nil
@@ -503,7 +551,7 @@ func checkMaxLength(field reflect.Value, maxTag string) error {
 
 	if field.Kind() == reflect.String {
 		if len(field.String()) > maxValue {
-			return fmt.Errorf("string length %d must be at most %d characters", len(field.String()), maxValue)
+			return validationErrorf(MsgStringMaxLength, len(field.String()), maxValue)
 		}
 	}
 	return nil
@@ -621,7 +669,7 @@ func checkPatternValue(field reflect.Value, pattern string) error {
 		return fmt.Errorf("regex validation error: %w", err)
 	}
 	if !matched {
-		return fmt.Errorf("value does not match pattern '%s': %s", pattern, value)
+		return validationErrorf(MsgPattern, pattern, value)
 	}
 	return nil
 }
@@ -665,7 +713,7 @@ func checkEnumValue(field reflect.Value, enumTag string) error {
 		}
 	}
 
-	return fmt.Errorf("value '%s' is not one of the allowed values: [%s]", value, strings.Join(allowedValues, ", "))
+	return validationErrorf(MsgEnum, value, strings.Join(allowedValues, ", "))
 }
 
 // checkConst validates that a string field equals a fixed constant value.
@@ -693,7 +741,7 @@ func checkConstValue(field reflect.Value, constTag string) error {
 	}
 
 	if value != constTag {
-		return fmt.Errorf("value '%s' must equal the constant '%s'", value, constTag)
+		return validationErrorf("value '%s' must equal the constant '%s'", value, constTag)
 	}
 	return nil
 }
@@ -707,7 +755,7 @@ func checkExclusiveMin(field reflect.Value, tag string) error {
 			return fmt.Errorf("invalid exclusiveMin value: %s", tag)
 		}
 		if field.Int() <= bound {
-			return fmt.Errorf("value %d must be > %d", field.Int(), bound)
+			return validationErrorf("value %v must be > %v", field.Int(), bound)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		bound, err := strconv.ParseUint(tag, 10, 64)
@@ -715,7 +763,7 @@ func checkExclusiveMin(field reflect.Value, tag string) error {
 			return fmt.Errorf("invalid exclusiveMin value: %s", tag)
 		}
 		if field.Uint() <= bound {
-			return fmt.Errorf("value %d must be > %d", field.Uint(), bound)
+			return validationErrorf("value %v must be > %v", field.Uint(), bound)
 		}
 	case reflect.Float32, reflect.Float64:
 		bound, err := strconv.ParseFloat(tag, 64)
@@ -723,7 +771,7 @@ func checkExclusiveMin(field reflect.Value, tag string) error {
 			return fmt.Errorf("invalid exclusiveMin value: %s", tag)
 		}
 		if field.Float() <= bound {
-			return fmt.Errorf("value %g must be > %g", field.Float(), bound)
+			return validationErrorf("value %v must be > %v", field.Float(), bound)
 		}
 	}
 	return nil
@@ -738,7 +786,7 @@ func checkExclusiveMax(field reflect.Value, tag string) error {
 			return fmt.Errorf("invalid exclusiveMax value: %s", tag)
 		}
 		if field.Int() >= bound {
-			return fmt.Errorf("value %d must be < %d", field.Int(), bound)
+			return validationErrorf("value %v must be < %v", field.Int(), bound)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		bound, err := strconv.ParseUint(tag, 10, 64)
@@ -746,7 +794,7 @@ func checkExclusiveMax(field reflect.Value, tag string) error {
 			return fmt.Errorf("invalid exclusiveMax value: %s", tag)
 		}
 		if field.Uint() >= bound {
-			return fmt.Errorf("value %d must be < %d", field.Uint(), bound)
+			return validationErrorf("value %v must be < %v", field.Uint(), bound)
 		}
 	case reflect.Float32, reflect.Float64:
 		bound, err := strconv.ParseFloat(tag, 64)
@@ -754,7 +802,7 @@ func checkExclusiveMax(field reflect.Value, tag string) error {
 			return fmt.Errorf("invalid exclusiveMax value: %s", tag)
 		}
 		if field.Float() >= bound {
-			return fmt.Errorf("value %g must be < %g", field.Float(), bound)
+			return validationErrorf("value %v must be < %v", field.Float(), bound)
 		}
 	}
 	return nil
@@ -770,7 +818,7 @@ func checkMinProperties(field reflect.Value, tag string) error {
 		return fmt.Errorf("invalid minProperties value: %s", tag)
 	}
 	if field.Len() < minValue {
-		return fmt.Errorf("map has %d properties, must have at least %d", field.Len(), minValue)
+		return validationErrorf("map has %d properties, must have at least %d", field.Len(), minValue)
 	}
 	return nil
 }
@@ -785,7 +833,7 @@ func checkMaxProperties(field reflect.Value, tag string) error {
 		return fmt.Errorf("invalid maxProperties value: %s", tag)
 	}
 	if field.Len() > maxValue {
-		return fmt.Errorf("map has %d properties, must have at most %d", field.Len(), maxValue)
+		return validationErrorf("map has %d properties, must have at most %d", field.Len(), maxValue)
 	}
 	return nil
 }
@@ -796,7 +844,7 @@ func validateEmail(value string) error {
 		return fmt.Errorf("email validation error: %w", err)
 	}
 	if !matched {
-		return fmt.Errorf("invalid email format: %s", value)
+		return validationErrorf("invalid email format: %s", value)
 	}
 	return nil
 }
@@ -805,7 +853,7 @@ func validateDateTime(value string) error {
 	// RFC3339 format: 2006-01-02T15:04:05Z07:00
 	_, err := time.Parse(time.RFC3339, value)
 	if err != nil {
-		return fmt.Errorf("invalid date-time format (expected RFC3339): %s", value)
+		return validationErrorf("invalid date-time format (expected RFC3339): %s", value)
 	}
 	return nil
 }
@@ -814,7 +862,7 @@ func validateDate(value string) error {
 	// ISO 8601 date format: YYYY-MM-DD
 	_, err := time.Parse("2006-01-02", value)
 	if err != nil {
-		return fmt.Errorf("invalid date format (expected YYYY-MM-DD): %s", value)
+		return validationErrorf("invalid date format (expected YYYY-MM-DD): %s", value)
 	}
 	return nil
 }
@@ -823,7 +871,7 @@ func validateDuration(value string) error {
 	// Go duration format: "300ms", "1.5h", "2h45m"
 	_, err := time.ParseDuration(value)
 	if err != nil {
-		return fmt.Errorf("invalid duration format: %s", value)
+		return validationErrorf("invalid duration format: %s", value)
 	}
 	return nil
 }
@@ -831,10 +879,10 @@ func validateDuration(value string) error {
 func validateIPv4(value string) error {
 	ip := net.ParseIP(value)
 	if ip == nil {
-		return fmt.Errorf("invalid IP address: %s", value)
+		return validationErrorf("invalid IP address: %s", value)
 	}
 	if ip.To4() == nil {
-		return fmt.Errorf("not a valid IPv4 address: %s", value)
+		return validationErrorf("not a valid IPv4 address: %s", value)
 	}
 	return nil
 }
@@ -842,10 +890,10 @@ func validateIPv4(value string) error {
 func validateIPv6(value string) error {
 	ip := net.ParseIP(value)
 	if ip == nil {
-		return fmt.Errorf("invalid IP address: %s", value)
+		return validationErrorf("invalid IP address: %s", value)
 	}
 	if ip.To4() != nil {
-		return fmt.Errorf("not a valid IPv6 address: %s", value)
+		return validationErrorf("not a valid IPv6 address: %s", value)
 	}
 	return nil
 }
@@ -857,7 +905,7 @@ func validateUUID(value string) error {
 		return fmt.Errorf("UUID validation error: %w", err)
 	}
 	if !matched {
-		return fmt.Errorf("invalid UUID format: %s", value)
+		return validationErrorf("invalid UUID format: %s", value)
 	}
 	return nil
 }
@@ -868,7 +916,7 @@ func validateRegex(value, pattern string) error {
 		return fmt.Errorf("regex validation error: %w", err)
 	}
 	if !matched {
-		return fmt.Errorf("value does not match pattern '%s': %s", pattern, value)
+		return validationErrorf(MsgPattern, pattern, value)
 	}
 	return nil
 }
@@ -879,7 +927,7 @@ func validateHostname(value string) error {
 		return fmt.Errorf("hostname validation error: %w", err)
 	}
 	if !matched {
-		return fmt.Errorf("invalid hostname format: %s", value)
+		return validationErrorf("invalid hostname format: %s", value)
 	}
 	return nil
 }
@@ -890,7 +938,7 @@ func validateUri(value string) error {
 		return fmt.Errorf("URI validation error: %w", err)
 	}
 	if !matched {
-		return fmt.Errorf("invalid URI format: %s", value)
+		return validationErrorf("invalid URI format: %s", value)
 	}
 	return nil
 }
@@ -903,54 +951,54 @@ func validateTime(value string) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("invalid time format (expected RFC3339 full-time, e.g. 15:04:05Z07:00): %s", value)
+	return validationErrorf("invalid time format (expected RFC3339 full-time, e.g. 15:04:05Z07:00): %s", value)
 }
 
 func validateURL(value string) error {
 	u, err := url.Parse(value)
 	if err != nil {
-		return fmt.Errorf("invalid URL: %s", value)
+		return validationErrorf("invalid URL: %s", value)
 	}
 	if u.Scheme != "http" && u.Scheme != "https" {
-		return fmt.Errorf("invalid URL (must use http or https scheme): %s", value)
+		return validationErrorf("invalid URL (must use http or https scheme): %s", value)
 	}
 	if u.Host == "" {
-		return fmt.Errorf("invalid URL (missing host): %s", value)
+		return validationErrorf("invalid URL (missing host): %s", value)
 	}
 	return nil
 }
 
 func validateURIReference(value string) error {
 	if _, err := url.Parse(value); err != nil {
-		return fmt.Errorf("invalid URI reference: %s", value)
+		return validationErrorf("invalid URI reference: %s", value)
 	}
 	return nil
 }
 
 func validateBase64(value string) error {
 	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
-		return fmt.Errorf("invalid base64 value: %s", value)
+		return validationErrorf("invalid base64 value: %s", value)
 	}
 	return nil
 }
 
 func validateMAC(value string) error {
 	if _, err := net.ParseMAC(value); err != nil {
-		return fmt.Errorf("invalid MAC address: %s", value)
+		return validationErrorf("invalid MAC address: %s", value)
 	}
 	return nil
 }
 
 func validateCIDR(value string) error {
 	if _, _, err := net.ParseCIDR(value); err != nil {
-		return fmt.Errorf("invalid CIDR notation: %s", value)
+		return validationErrorf("invalid CIDR notation: %s", value)
 	}
 	return nil
 }
 
 func validateE164(value string) error {
 	if !e164Regex.MatchString(value) {
-		return fmt.Errorf("invalid phone number (expected E.164 format, e.g. +14155552671): %s", value)
+		return validationErrorf("invalid phone number (expected E.164 format, e.g. +14155552671): %s", value)
 	}
 	return nil
 }
@@ -959,7 +1007,7 @@ func validateE164(value string) error {
 func validateCreditCard(value string) error {
 	cleaned := strings.NewReplacer(" ", "", "-", "").Replace(value)
 	if len(cleaned) < 12 || len(cleaned) > 19 {
-		return fmt.Errorf("invalid credit card number: %s", value)
+		return validationErrorf("invalid credit card number: %s", value)
 	}
 
 	var sum int
@@ -967,7 +1015,7 @@ func validateCreditCard(value string) error {
 	for i := len(cleaned) - 1; i >= 0; i-- {
 		ch := cleaned[i]
 		if ch < '0' || ch > '9' {
-			return fmt.Errorf("invalid credit card number: %s", value)
+			return validationErrorf("invalid credit card number: %s", value)
 		}
 		digit := int(ch - '0')
 		if double {
@@ -980,49 +1028,49 @@ func validateCreditCard(value string) error {
 		double = !double
 	}
 	if sum%10 != 0 {
-		return fmt.Errorf("invalid credit card number (failed Luhn check): %s", value)
+		return validationErrorf("invalid credit card number (failed Luhn check): %s", value)
 	}
 	return nil
 }
 
 func validateSemver(value string) error {
 	if !semverRegex.MatchString(value) {
-		return fmt.Errorf("invalid semantic version: %s", value)
+		return validationErrorf("invalid semantic version: %s", value)
 	}
 	return nil
 }
 
 func validateJSONPointer(value string) error {
 	if !jsonPointerRegex.MatchString(value) {
-		return fmt.Errorf("invalid JSON pointer (RFC 6901): %s", value)
+		return validationErrorf("invalid JSON pointer (RFC 6901): %s", value)
 	}
 	return nil
 }
 
 func validateULID(value string) error {
 	if !ulidRegex.MatchString(value) {
-		return fmt.Errorf("invalid ULID: %s", value)
+		return validationErrorf("invalid ULID: %s", value)
 	}
 	return nil
 }
 
 func validateAlpha(value string) error {
 	if !alphaRegex.MatchString(value) {
-		return fmt.Errorf("value must contain only letters: %s", value)
+		return validationErrorf("value must contain only letters: %s", value)
 	}
 	return nil
 }
 
 func validateAlphanumeric(value string) error {
 	if !alphanumRegex.MatchString(value) {
-		return fmt.Errorf("value must contain only letters and digits: %s", value)
+		return validationErrorf("value must contain only letters and digits: %s", value)
 	}
 	return nil
 }
 
 func validateNumeric(value string) error {
 	if !numericRegex.MatchString(value) {
-		return fmt.Errorf("value must be numeric: %s", value)
+		return validationErrorf("value must be numeric: %s", value)
 	}
 	return nil
 }
@@ -1030,7 +1078,7 @@ func validateNumeric(value string) error {
 func validateASCII(value string) error {
 	for i := 0; i < len(value); i++ {
 		if value[i] > 127 {
-			return fmt.Errorf("value must contain only ASCII characters: %s", value)
+			return validationErrorf("value must contain only ASCII characters: %s", value)
 		}
 	}
 	return nil
@@ -1038,28 +1086,28 @@ func validateASCII(value string) error {
 
 func validateLowercase(value string) error {
 	if value != strings.ToLower(value) {
-		return fmt.Errorf("value must be lowercase: %s", value)
+		return validationErrorf("value must be lowercase: %s", value)
 	}
 	return nil
 }
 
 func validateUppercase(value string) error {
 	if value != strings.ToUpper(value) {
-		return fmt.Errorf("value must be uppercase: %s", value)
+		return validationErrorf("value must be uppercase: %s", value)
 	}
 	return nil
 }
 
 func validateSlug(value string) error {
 	if !slugRegex.MatchString(value) {
-		return fmt.Errorf("value must be a valid slug (lowercase alphanumeric and hyphens): %s", value)
+		return validationErrorf("value must be a valid slug (lowercase alphanumeric and hyphens): %s", value)
 	}
 	return nil
 }
 
 func validateHexColor(value string) error {
 	if !hexColorRegex.MatchString(value) {
-		return fmt.Errorf("invalid hex color (expected #RGB or #RRGGBB): %s", value)
+		return validationErrorf("invalid hex color (expected #RGB or #RRGGBB): %s", value)
 	}
 	return nil
 }
@@ -1071,7 +1119,7 @@ func checkMultipleOf(field reflect.Value, tag string) error {
 			return fmt.Errorf("invalid multipleOf tag: %w", err)
 		}
 		if field.Int()%multipleOf != 0 {
-			return fmt.Errorf("value %d is not a multiple of %d", field.Int(), multipleOf)
+			return validationErrorf("value %v is not a multiple of %v", field.Int(), multipleOf)
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		multipleOf, err := parseUint(tag)
@@ -1079,7 +1127,7 @@ func checkMultipleOf(field reflect.Value, tag string) error {
 			return fmt.Errorf("invalid multipleOf tag: %w", err)
 		}
 		if field.Uint()%multipleOf != 0 {
-			return fmt.Errorf("value %d is not a multiple of %d", field.Uint(), multipleOf)
+			return validationErrorf("value %v is not a multiple of %v", field.Uint(), multipleOf)
 		}
 	case reflect.Float32, reflect.Float64:
 		multipleOf, err := parseFloat(tag)
@@ -1095,7 +1143,7 @@ func checkMultipleOf(field reflect.Value, tag string) error {
 
 		const epsilon = 1e-9
 		if math.Abs(remainder) > epsilon && math.Abs(remainder-multipleOf) > epsilon {
-			return fmt.Errorf("value %f is not a multiple of %f", value, multipleOf)
+			return validationErrorf("value %f is not a multiple of %f", value, multipleOf)
 		}
 	default:
 		return fmt.Errorf("multipleOf validation not supported for type %s", field.Kind())
@@ -1108,7 +1156,7 @@ func checkUniqueItems(field reflect.Value) error {
 		for i := 0; i < field.Len(); i++ {
 			item := field.Index(i).Interface()
 			if seen[item] {
-				return fmt.Errorf("slice contains duplicate item: %v", item)
+				return validationErrorf("slice contains duplicate item: %v", item)
 			}
 			seen[item] = true
 		}
@@ -1125,7 +1173,7 @@ func checkMaxItems(field reflect.Value, tag string) error {
 
 	if field.Kind() == reflect.Slice {
 		if field.Len() > maxItems {
-			return fmt.Errorf("slice length %d must be at most %d items", field.Len(), maxItems)
+			return validationErrorf("slice length %d must be at most %d items", field.Len(), maxItems)
 		}
 	}
 	return nil
@@ -1140,7 +1188,7 @@ func checkMinItems(field reflect.Value, tag string) error {
 
 	if field.Kind() == reflect.Slice {
 		if field.Len() < minItems {
-			return fmt.Errorf("slice length %d must be at least %d items", field.Len(), minItems)
+			return validationErrorf("slice length %d must be at least %d items", field.Len(), minItems)
 		}
 	}
 	return nil
@@ -25,6 +25,7 @@
 package okapi
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -1601,3 +1602,69 @@ func TestTier1ValidationIntegration(t *testing.T) {
 		})
 	}
 }
+
+type bookingInput struct {
+	Start int `json:"start" required:"true"`
+	End   int `json:"end" required:"true"`
+}
+
+func (b *bookingInput) Validate(c *Context) error {
+	if b.End <= b.Start {
+		return ValidationErrors{{Field: "end", Message: "must be after start", Value: b.End}}
+	}
+	return nil
+}
+
+type discountedBook struct {
+	Body struct {
+		Price    int `json:"price" min:"0"`
+		Discount int `json:"discount"`
+	}
+}
+
+func (d *discountedBook) Validate(c *Context) error {
+	if d.Body.Discount > d.Body.Price {
+		return ValidationErrors{{Field: "discount", Message: "exceeds price"}}
+	}
+	return nil
+}
+
+func TestValidatable(t *testing.T) {
+	ts := NewTestServer(t)
+	ts.Post("/bookings", H(func(c *Context, in *bookingInput) error {
+		return c.Created(in)
+	}))
+	ts.Post("/books", HandleIO(func(c *Context, in *discountedBook) (*discountedBook, error) {
+		return in, nil
+	}))
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		want   string
+	}{
+		{"valid", "/bookings", `{"start":1,"end":2}`, http.StatusCreated, `"end":2`},
+		{"hook rejects", "/bookings", `{"start":2,"end":1}`, http.StatusUnprocessableEntity, `"message":"must be after start"`},
+		{"tags checked first", "/bookings", `{"start":2}`, http.StatusBadRequest, `{"field":"end","message":"field End is required"}`},
+		{"body struct valid", "/books", `{"price":10,"discount":2}`, http.StatusOK, `"discount":2`},
+		{"body struct rejects", "/books", `{"price":10,"discount":20}`, http.StatusUnprocessableEntity, `"field":"discount"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(ts.BaseURL+tt.path, "application/json", strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d (%s)", resp.StatusCode, tt.status, body)
+			}
+			if !strings.Contains(string(body), tt.want) {
+				t.Errorf("body %s does not contain %s", body, tt.want)
+			}
+		})
+	}
+}
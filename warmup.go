@@ -0,0 +1,147 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jkaninda/okapi/client"
+)
+
+// WarmupFunc is run once the server is listening, before it reports ready.
+// The client is bound to the server's own address, so warm-ups can prime caches
+// and verify that critical routes respond correctly. Returning an error aborts startup.
+type WarmupFunc func(c *client.Client) error
+
+// warmupTimeout bounds each request issued by the warm-up client.
+const warmupTimeout = 10 * time.Second
+
+// WithWarmup registers a warm-up function executed after the listener is up but
+// before the server is marked ready (see Ready). Warm-ups run in registration order;
+// if one fails, the server is closed and Start returns the error.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithWarmup(func(c *client.Client) error {
+//		resp, err := c.Get("/books").Do()
+//		if err != nil {
+//			return err
+//		}
+//		return resp.Error()
+//	}))
+func WithWarmup(fn WarmupFunc) OptionFunc {
+	return func(o *Okapi) {
+		if fn != nil {
+			o.warmups = append(o.warmups, fn)
+		}
+	}
+}
+
+// WithWarmup registers a warm-up function executed before the server is marked ready.
+func (o *Okapi) WithWarmup(fn WarmupFunc) *Okapi {
+	return o.apply(WithWarmup(fn))
+}
+
+// Ready reports whether the server is listening and all warm-ups succeeded.
+func (o *Okapi) Ready() bool {
+	return o.ready.Load()
+}
+
+// listenAndServe serves server, running the warm-ups once its listener is up.
+// The server is marked ready once it listens and the warm-ups succeeded.
+func (o *Okapi) listenAndServe(server *http.Server, useTLS bool) error {
+	ln, err := o.listen(server, useTLS)
+	if err != nil {
+		return err
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		if useTLS {
+			errCh <- server.ServeTLS(ln, "", "")
+			return
+		}
+		errCh <- server.Serve(ln)
+	}()
+	if err := o.runWarmups(ln.Addr(), useTLS); err != nil {
+		_ = server.Close()
+		<-errCh
+		return err
+	}
+	o.ready.Store(true)
+	return <-errCh
+}
+
+// listen opens the listener of server, bounded by WithMaxConnections, and
+// starts tracking its connections.
+func (o *Okapi) listen(server *http.Server, useTLS bool) (net.Listener, error) {
+	addr := server.Addr
+	if addr == "" {
+		addr = ":http"
+		if useTLS {
+			addr = ":https"
+		}
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	o.trackConnections(server)
+	return o.limitListener(ln), nil
+}
+
+// runWarmups runs the registered warm-ups against the listener at addr.
+func (o *Okapi) runWarmups(addr net.Addr, useTLS bool) error {
+	port := "80"
+	if tcp, ok := addr.(*net.TCPAddr); ok {
+		port = strconv.Itoa(tcp.Port)
+	}
+	scheme := "http"
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if useTLS {
+		scheme = "https"
+		// The warm-up client talks to this very server over loopback,
+		// where the certificate usually doesn't match the host.
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402
+	}
+	c := client.New(scheme+"://"+net.JoinHostPort("127.0.0.1", port),
+		client.WithHTTPClient(&http.Client{Transport: transport}),
+		client.WithTimeout(warmupTimeout),
+	)
+	for i, warmup := range o.warmups {
+		start := time.Now()
+		if err := warmup(c); err != nil {
+			o.logger.Error("[okapi] Warm-up failed", slog.Int("step", i+1), slog.String("error", err.Error()))
+			return fmt.Errorf("warm-up %d failed: %w", i+1, err)
+		}
+		o.logger.Debug("[okapi] Warm-up completed", slog.Int("step", i+1), slog.Duration("duration", time.Since(start)))
+	}
+	return nil
+}
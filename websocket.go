@@ -0,0 +1,562 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WebSocketMessageType is the type of a WebSocket data message.
+type WebSocketMessageType byte
+
+const (
+	// TextMessage is a UTF-8 encoded text message.
+	TextMessage WebSocketMessageType = 1
+	// BinaryMessage is a binary message.
+	BinaryMessage WebSocketMessageType = 2
+)
+
+// WebSocket opcodes (RFC 6455, section 5.2).
+const (
+	wsContinuation = 0x0
+	wsText         = 0x1
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xA
+)
+
+// WebSocket close codes (RFC 6455, section 7.4.1).
+const (
+	wsCloseNormal          = 1000
+	wsCloseGoingAway       = 1001
+	wsCloseProtocolError   = 1002
+	wsCloseNoStatus        = 1005
+	wsCloseInvalidData     = 1007
+	wsClosePolicyViolation = 1008
+	wsCloseTooBig          = 1009
+	wsCloseInternalError   = 1011
+)
+
+const (
+	wsAcceptGUID            = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	defaultWSPingInterval   = 30 * time.Second
+	defaultWSWriteTimeout   = 10 * time.Second
+	defaultWSMaxMessageSize = 1 << 20
+)
+
+// ErrWebSocketClosed is returned when writing to a closed WebSocket.
+var ErrWebSocketClosed = errors.New("okapi: websocket closed")
+
+// WebSocketHandler handles a WebSocket connection. The connection is closed
+// when it returns; an error closes it with status 1011 (internal error).
+type WebSocketHandler func(ws *WebSocketConn) error
+
+// WebSocketOptions configures a WebSocket connection.
+type WebSocketOptions struct {
+	// PingInterval is the interval between two pings keeping the connection
+	// alive. A connection from which nothing, not even a pong, was read for two
+	// intervals is closed. Defaults to 30 seconds; a negative value disables pings.
+	PingInterval time.Duration
+	// WriteTimeout bounds the time to write a message. Defaults to 10 seconds.
+	WriteTimeout time.Duration
+	// MaxMessageSize is the maximum size of a received message in bytes. Larger
+	// messages close the connection with status 1009. Defaults to 1 MiB.
+	MaxMessageSize int64
+	// CheckOrigin accepts or rejects the handshake based on the request, with a
+	// 403 Forbidden. Defaults to accepting requests without an Origin header or
+	// whose Origin matches the Host header.
+	CheckOrigin func(c *Context) bool
+	// Subprotocols are the supported subprotocols, in order of preference. The
+	// first one requested by the client is selected, see WebSocketConn.Subprotocol.
+	Subprotocols []string
+	// Auth re-validates the client's credentials while the connection is open
+	// and closes it, with status 1008, once they expire. See StreamAuth.
+	Auth *StreamAuth
+}
+
+// WebSocketConn is a WebSocket connection. Writes are safe for concurrent use;
+// reads must be made by a single goroutine, usually the handler.
+//
+// A WebSocketConn is a RoomMember, so it can join Rooms to receive broadcasts.
+type WebSocketConn struct {
+	conn        net.Conn
+	reader      *bufio.Reader
+	writer      *bufio.Writer
+	opts        WebSocketOptions
+	subprotocol string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	writeMu   sync.Mutex
+	closeSent bool
+	closeOnce sync.Once
+}
+
+// WebSocketCloseError is returned by ReadMessage once the connection is
+// closed, by the client or because of a protocol violation.
+type WebSocketCloseError struct {
+	// Code is the close status code, e.g. 1000 for a normal closure.
+	Code int
+	// Reason is the close reason sent with the code, if any.
+	Reason string
+}
+
+func (e *WebSocketCloseError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("okapi: websocket closed with status %d", e.Code)
+	}
+	return fmt.Sprintf("okapi: websocket closed with status %d: %s", e.Code, e.Reason)
+}
+
+// WebSocket upgrades the request to a WebSocket connection and runs handler
+// with it, using the default WebSocketOptions. Requests that are not WebSocket
+// handshakes are answered with 426 Upgrade Required.
+//
+// Example:
+//
+//	o.Get("/ws/echo", func(c *okapi.Context) error {
+//		return c.WebSocket(func(ws *okapi.WebSocketConn) error {
+//			return ws.ReadMessages(func(typ okapi.WebSocketMessageType, data []byte) error {
+//				return ws.WriteMessage(typ, data)
+//			})
+//		})
+//	})
+func (c *Context) WebSocket(handler WebSocketHandler) error {
+	return c.WebSocketWithOptions(handler, nil)
+}
+
+// WebSocketWithOptions is WebSocket with custom options.
+func (c *Context) WebSocketWithOptions(handler WebSocketHandler, opts *WebSocketOptions) error {
+	if opts == nil {
+		opts = &WebSocketOptions{}
+	}
+	ws, err := c.upgradeWebSocket(*opts)
+	if ws == nil {
+		return err
+	}
+	if opts.Auth != nil {
+		authCtx, cancel := c.AuthContext(ws.ctx, *opts.Auth)
+		defer cancel()
+		go func() {
+			<-authCtx.Done()
+			if errors.Is(context.Cause(authCtx), ErrAuthExpired) {
+				ws.closeWith(wsClosePolicyViolation, "authentication expired")
+			}
+		}()
+	}
+	if ws.opts.PingInterval > 0 {
+		go ws.keepAlive()
+	}
+	if err := handler(ws); err != nil {
+		c.Logger().Error("[okapi] WebSocket handler failed", "path", c.request.URL.Path, "error", err)
+		ws.closeWith(wsCloseInternalError, "")
+		return nil
+	}
+	_ = ws.Close()
+	return nil
+}
+
+// WebSocket registers a GET route upgrading requests to WebSocket connections
+// handled by handler.
+//
+// Example:
+//
+//	o.WebSocket("/ws/chat", func(ws *okapi.WebSocketConn) error {
+//		return ws.ReadMessages(func(_ okapi.WebSocketMessageType, data []byte) error {
+//			return rooms.Broadcast(ws.Context(), "chat", data)
+//		})
+//	})
+func (o *Okapi) WebSocket(path string, handler WebSocketHandler, opts ...RouteOption) *Route {
+	return o.Get(path, func(c *Context) error { return c.WebSocket(handler) }, opts...)
+}
+
+// WebSocket registers a GET route within the group upgrading requests to
+// WebSocket connections handled by handler.
+func (g *Group) WebSocket(path string, handler WebSocketHandler, opts ...RouteOption) *Route {
+	return g.Get(path, func(c *Context) error { return c.WebSocket(handler) }, opts...)
+}
+
+// upgradeWebSocket performs the opening handshake. It returns a nil connection
+// when the handshake was refused, with the error of the response written.
+func (c *Context) upgradeWebSocket(opts WebSocketOptions) (*WebSocketConn, error) {
+	r := c.request
+	if r.Method != http.MethodGet || !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+		!headerHasToken(r.Header, "Connection", "upgrade") || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		c.SetHeader("Upgrade", "websocket")
+		c.SetHeader("Sec-WebSocket-Version", "13")
+		return nil, c.AbortWithStatus(http.StatusUpgradeRequired, "WebSocket handshake required")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, c.AbortBadRequest("Invalid Sec-WebSocket-Key header")
+	}
+	checkOrigin := opts.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = sameOrigin
+	}
+	if !checkOrigin(c) {
+		return nil, c.AbortForbidden("WebSocket origin not allowed")
+	}
+
+	ws := &WebSocketConn{opts: opts}
+	for _, protocol := range strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",") {
+		if protocol = strings.TrimSpace(protocol); slices.Contains(opts.Subprotocols, protocol) {
+			ws.subprotocol = protocol
+			break
+		}
+	}
+	if ws.opts.PingInterval == 0 {
+		ws.opts.PingInterval = defaultWSPingInterval
+	}
+	if ws.opts.WriteTimeout <= 0 {
+		ws.opts.WriteTimeout = defaultWSWriteTimeout
+	}
+	if ws.opts.MaxMessageSize <= 0 {
+		ws.opts.MaxMessageSize = defaultWSMaxMessageSize
+	}
+
+	conn, rw, err := c.response.Hijack()
+	if err != nil {
+		return nil, c.AbortInternalServerError("WebSocket upgrade not supported", err)
+	}
+	if rw == nil {
+		rw = bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	}
+	// Clear the deadlines the server set for the HTTP request.
+	_ = conn.SetDeadline(time.Time{})
+	ws.conn, ws.reader, ws.writer = conn, rw.Reader, rw.Writer
+	ws.ctx, ws.cancel = context.WithCancel(r.Context())
+
+	sum := sha1.Sum([]byte(key + wsAcceptGUID))
+	var b strings.Builder
+	b.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	b.WriteString("Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n")
+	if ws.subprotocol != "" {
+		b.WriteString("Sec-WebSocket-Protocol: " + ws.subprotocol + "\r\n")
+	}
+	b.WriteString("\r\n")
+	if _, err := ws.writer.WriteString(b.String()); err == nil {
+		err = ws.writer.Flush()
+	}
+	if err != nil {
+		ws.closeConn()
+		c.Logger().Warn("[okapi] WebSocket handshake failed", "path", r.URL.Path, "error", err)
+		return nil, nil
+	}
+	return ws, nil
+}
+
+// sameOrigin accepts requests without an Origin header or whose Origin host
+// matches the Host header.
+func sameOrigin(c *Context) bool {
+	origin := c.request.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, c.request.Host)
+}
+
+// headerHasToken reports whether the comma-separated header contains token.
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Context returns a context canceled once the connection is closed.
+func (ws *WebSocketConn) Context() context.Context {
+	return ws.ctx
+}
+
+// Subprotocol returns the subprotocol selected during the handshake, or "".
+func (ws *WebSocketConn) Subprotocol() string {
+	return ws.subprotocol
+}
+
+// RemoteAddr returns the network address of the client.
+func (ws *WebSocketConn) RemoteAddr() net.Addr {
+	return ws.conn.RemoteAddr()
+}
+
+// ReadMessage reads the next data message, answering pings and reassembling
+// fragmented messages. Once the connection is closed, it returns a
+// *WebSocketCloseError or the network error.
+func (ws *WebSocketConn) ReadMessage() (WebSocketMessageType, []byte, error) {
+	var typ WebSocketMessageType
+	var message []byte
+	for {
+		fin, opcode, payload, err := ws.readFrame(int64(len(message)))
+		if err != nil {
+			return 0, nil, ws.fail(err)
+		}
+		switch opcode {
+		case wsPing:
+			_ = ws.writeFrame(wsPong, payload)
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			return 0, nil, ws.closeReceived(payload)
+		case wsContinuation:
+			if typ == 0 {
+				return 0, nil, ws.fail(&WebSocketCloseError{Code: wsCloseProtocolError, Reason: "unexpected continuation frame"})
+			}
+		case wsText, wsBinary:
+			if typ != 0 {
+				return 0, nil, ws.fail(&WebSocketCloseError{Code: wsCloseProtocolError, Reason: "expected continuation frame"})
+			}
+			typ = WebSocketMessageType(opcode)
+		default:
+			return 0, nil, ws.fail(&WebSocketCloseError{Code: wsCloseProtocolError, Reason: "unknown opcode"})
+		}
+		message = append(message, payload...)
+		if !fin {
+			continue
+		}
+		if typ == TextMessage && !utf8.Valid(message) {
+			return 0, nil, ws.fail(&WebSocketCloseError{Code: wsCloseInvalidData, Reason: "invalid UTF-8"})
+		}
+		return typ, message, nil
+	}
+}
+
+// ReadMessages calls fn with each message until the connection is closed or fn
+// returns an error. It returns nil when the client or the server closed the
+// connection normally.
+func (ws *WebSocketConn) ReadMessages(fn func(typ WebSocketMessageType, data []byte) error) error {
+	for {
+		typ, data, err := ws.ReadMessage()
+		if err != nil {
+			var closeErr *WebSocketCloseError
+			if errors.As(err, &closeErr) {
+				switch closeErr.Code {
+				case wsCloseNormal, wsCloseGoingAway, wsCloseNoStatus:
+					return nil
+				}
+			} else if ws.ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := fn(typ, data); err != nil {
+			return err
+		}
+	}
+}
+
+// WriteMessage sends a message of the given type.
+func (ws *WebSocketConn) WriteMessage(typ WebSocketMessageType, data []byte) error {
+	return ws.writeFrame(byte(typ), data)
+}
+
+// Send sends data as a text message.
+func (ws *WebSocketConn) Send(data []byte) error {
+	return ws.WriteMessage(TextMessage, data)
+}
+
+// SendJSON sends v encoded as JSON in a text message.
+func (ws *WebSocketConn) SendJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return ws.WriteMessage(TextMessage, data)
+}
+
+// Close closes the connection with a normal closure status.
+func (ws *WebSocketConn) Close() error {
+	ws.closeWith(wsCloseNormal, "")
+	return nil
+}
+
+// readFrame reads a frame, whose payload may not make the message, already
+// buffered bytes long, exceed MaxMessageSize.
+func (ws *WebSocketConn) readFrame(buffered int64) (fin bool, opcode byte, payload []byte, err error) {
+	if ws.opts.PingInterval > 0 {
+		_ = ws.conn.SetReadDeadline(time.Now().Add(2 * ws.opts.PingInterval))
+	}
+	var header [8]byte
+	if _, err = io.ReadFull(ws.reader, header[:2]); err != nil {
+		return
+	}
+	fin, opcode = header[0]&0x80 != 0, header[0]&0x0f
+	if header[0]&0x70 != 0 {
+		return fin, opcode, nil, &WebSocketCloseError{Code: wsCloseProtocolError, Reason: "reserved bits set"}
+	}
+	if header[1]&0x80 == 0 {
+		return fin, opcode, nil, &WebSocketCloseError{Code: wsCloseProtocolError, Reason: "unmasked client frame"}
+	}
+	length := int64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		if _, err = io.ReadFull(ws.reader, header[:2]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint16(header[:2]))
+	case 127:
+		if _, err = io.ReadFull(ws.reader, header[:8]); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint64(header[:8])
+		if n&(1<<63) != 0 {
+			// RFC 6455 §5.2: the most significant bit must be 0
+			return fin, opcode, nil, &WebSocketCloseError{Code: wsCloseProtocolError, Reason: "invalid payload length"}
+		}
+		length = int64(n)
+	}
+	if opcode >= wsClose && (length > 125 || !fin) {
+		return fin, opcode, nil, &WebSocketCloseError{Code: wsCloseProtocolError, Reason: "invalid control frame"}
+	}
+	// Compared without adding, so a length close to 2^63 cannot wrap around
+	if opcode < wsClose && length > ws.opts.MaxMessageSize-buffered {
+		return fin, opcode, nil, &WebSocketCloseError{Code: wsCloseTooBig, Reason: "message too big"}
+	}
+	var mask [4]byte
+	if _, err = io.ReadFull(ws.reader, mask[:]); err != nil {
+		return
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(ws.reader, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// writeFrame writes a single, unmasked frame.
+func (ws *WebSocketConn) writeFrame(opcode byte, payload []byte) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	if ws.closeSent {
+		return ErrWebSocketClosed
+	}
+	if opcode == wsClose {
+		ws.closeSent = true
+	}
+	header := make([]byte, 0, 10)
+	header = append(header, 0x80|opcode)
+	switch n := len(payload); {
+	case n <= 125:
+		header = append(header, byte(n))
+	case n <= 0xffff:
+		header = binary.BigEndian.AppendUint16(append(header, 126), uint16(n))
+	default:
+		header = binary.BigEndian.AppendUint64(append(header, 127), uint64(n))
+	}
+	_ = ws.conn.SetWriteDeadline(time.Now().Add(ws.opts.WriteTimeout))
+	if _, err := ws.writer.Write(header); err != nil {
+		return err
+	}
+	if _, err := ws.writer.Write(payload); err != nil {
+		return err
+	}
+	return ws.writer.Flush()
+}
+
+// keepAlive pings the client until the connection is closed.
+func (ws *WebSocketConn) keepAlive() {
+	ticker := time.NewTicker(ws.opts.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ws.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ws.writeFrame(wsPing, nil); err != nil {
+				ws.closeConn()
+				return
+			}
+		}
+	}
+}
+
+// closeReceived answers a close frame from the client and closes the connection.
+func (ws *WebSocketConn) closeReceived(payload []byte) error {
+	closeErr := &WebSocketCloseError{Code: wsCloseNoStatus}
+	if len(payload) >= 2 {
+		closeErr.Code = int(binary.BigEndian.Uint16(payload))
+		closeErr.Reason = string(payload[2:])
+	}
+	reply := payload
+	if len(reply) > 2 {
+		reply = reply[:2]
+	}
+	_ = ws.writeFrame(wsClose, reply)
+	ws.closeConn()
+	return closeErr
+}
+
+// fail closes the connection after a read error, sending the close status of
+// protocol violations.
+func (ws *WebSocketConn) fail(err error) error {
+	var closeErr *WebSocketCloseError
+	if errors.As(err, &closeErr) {
+		ws.closeWith(closeErr.Code, closeErr.Reason)
+		return err
+	}
+	ws.closeConn()
+	return err
+}
+
+// closeWith sends a close frame with code and reason, then closes the connection.
+func (ws *WebSocketConn) closeWith(code int, reason string) {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	_ = ws.writeFrame(wsClose, append(payload, reason...))
+	ws.closeConn()
+}
+
+// closeConn closes the network connection and cancels the connection context.
+func (ws *WebSocketConn) closeConn() {
+	ws.closeOnce.Do(func() {
+		ws.cancel()
+		_ = ws.conn.Close()
+	})
+}
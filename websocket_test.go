@@ -0,0 +1,247 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jkaninda/okapi/okapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wsTestClient is a minimal WebSocket client for the tests.
+type wsTestClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialWebSocket(t *testing.T, baseURL, path string, headers ...string) (*wsTestClient, *http.Response) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(baseURL, "http://"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	request := "GET " + path + " HTTP/1.1\r\nHost: " + strings.TrimPrefix(baseURL, "http://") + "\r\n" +
+		"Upgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Version: 13\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n"
+	for i := 0; i < len(headers); i += 2 {
+		request += headers[i] + ": " + headers[i+1] + "\r\n"
+	}
+	_, err = conn.Write([]byte(request + "\r\n"))
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	return &wsTestClient{conn: conn, reader: reader}, resp
+}
+
+func (w *wsTestClient) write(t *testing.T, opcode byte, payload []byte) {
+	t.Helper()
+	frame := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, 0x80|byte(n))
+	case n <= 0xffff:
+		frame = binary.BigEndian.AppendUint16(append(frame, 0x80|126), uint16(n))
+	default:
+		frame = binary.BigEndian.AppendUint64(append(frame, 0x80|127), uint64(n))
+	}
+	mask := []byte{1, 2, 3, 4}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := w.conn.Write(frame)
+	require.NoError(t, err)
+}
+
+func (w *wsTestClient) read(t *testing.T) (byte, []byte) {
+	t.Helper()
+	header := make([]byte, 2)
+	_, err := io.ReadFull(w.reader, header)
+	require.NoError(t, err)
+	length := int(header[1] & 0x7f)
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		_, err = io.ReadFull(w.reader, ext)
+		length = int(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		_, err = io.ReadFull(w.reader, ext)
+		length = int(binary.BigEndian.Uint64(ext))
+	}
+	require.NoError(t, err)
+	payload := make([]byte, length)
+	_, err = io.ReadFull(w.reader, payload)
+	require.NoError(t, err)
+	return header[0] & 0x0f, payload
+}
+
+func TestWebSocketEcho(t *testing.T) {
+	o := New()
+	o.WebSocket("/echo", func(ws *WebSocketConn) error {
+		return ws.ReadMessages(func(typ WebSocketMessageType, data []byte) error {
+			return ws.WriteMessage(typ, data)
+		})
+	})
+	ts := NewTestServerWithOkapi(t, o)
+
+	client, resp := dialWebSocket(t, ts.BaseURL, "/echo")
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+
+	client.write(t, wsText, []byte("hello"))
+	opcode, payload := client.read(t)
+	assert.Equal(t, byte(wsText), opcode)
+	assert.Equal(t, "hello", string(payload))
+
+	large := []byte(strings.Repeat("x", 70000))
+	client.write(t, wsBinary, large)
+	opcode, payload = client.read(t)
+	assert.Equal(t, byte(wsBinary), opcode)
+	assert.Equal(t, large, payload)
+
+	client.write(t, wsPing, []byte("are you there"))
+	opcode, payload = client.read(t)
+	assert.Equal(t, byte(wsPong), opcode)
+	assert.Equal(t, "are you there", string(payload))
+
+	client.write(t, wsClose, binary.BigEndian.AppendUint16(nil, wsCloseNormal))
+	opcode, payload = client.read(t)
+	assert.Equal(t, byte(wsClose), opcode)
+	assert.Equal(t, uint16(wsCloseNormal), binary.BigEndian.Uint16(payload))
+}
+
+func TestWebSocketLimits(t *testing.T) {
+	o := New()
+	o.Get("/ws", func(c *Context) error {
+		return c.WebSocketWithOptions(func(ws *WebSocketConn) error {
+			return ws.ReadMessages(func(WebSocketMessageType, []byte) error { return nil })
+		}, &WebSocketOptions{MaxMessageSize: 8, PingInterval: 50 * time.Millisecond, Subprotocols: []string{"chat.v2", "chat.v1"}})
+	})
+	ts := NewTestServerWithOkapi(t, o)
+
+	client, resp := dialWebSocket(t, ts.BaseURL, "/ws", "Sec-WebSocket-Protocol", "chat.v1, chat.v2")
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "chat.v1", resp.Header.Get("Sec-WebSocket-Protocol"))
+
+	opcode, _ := client.read(t)
+	assert.Equal(t, byte(wsPing), opcode)
+
+	client.write(t, wsText, []byte("too long message"))
+	opcode, payload := client.read(t)
+	assert.Equal(t, byte(wsClose), opcode)
+	assert.Equal(t, uint16(wsCloseTooBig), binary.BigEndian.Uint16(payload))
+}
+
+func TestWebSocketHugeFrameLength(t *testing.T) {
+	o := New()
+	o.Get("/ws", func(c *Context) error {
+		return c.WebSocketWithOptions(func(ws *WebSocketConn) error {
+			return ws.ReadMessages(func(WebSocketMessageType, []byte) error { return nil })
+		}, &WebSocketOptions{MaxMessageSize: 1024})
+	})
+	ts := NewTestServerWithOkapi(t, o)
+
+	for name, tc := range map[string]struct {
+		length uint64
+		code   uint16
+	}{
+		// buffered + length would wrap around to a negative size
+		"wrapping continuation": {1<<63 - 1, wsCloseTooBig},
+		"top bit set":           {1 << 63, wsCloseProtocolError},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, resp := dialWebSocket(t, ts.BaseURL, "/ws")
+			require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+			// A first fragment, then a continuation declaring a huge length
+			frame := []byte{wsText, 0x80 | 4, 0, 0, 0, 0, 'a', 'b', 'c', 'd'}
+			frame = append(frame, 0x00, 0x80|127)
+			frame = binary.BigEndian.AppendUint64(frame, tc.length)
+			frame = append(frame, 0, 0, 0, 0)
+			_, err := client.conn.Write(frame)
+			require.NoError(t, err)
+
+			opcode, payload := client.read(t)
+			assert.Equal(t, byte(wsClose), opcode)
+			assert.Equal(t, tc.code, binary.BigEndian.Uint16(payload))
+		})
+	}
+}
+
+func TestWebSocketHandshakeRejected(t *testing.T) {
+	o := New()
+	o.WebSocket("/ws", func(ws *WebSocketConn) error { return nil })
+	ts := NewTestServerWithOkapi(t, o)
+
+	okapitest.GET(t, ts.BaseURL+"/ws").
+		ExpectStatus(http.StatusUpgradeRequired).
+		ExpectHeader("Sec-WebSocket-Version", "13")
+
+	_, resp := dialWebSocket(t, ts.BaseURL, "/ws", "Origin", "https://evil.example.com")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestWebSocketRooms(t *testing.T) {
+	rooms := NewRooms()
+	joined := make(chan struct{}, 2)
+	o := New()
+	o.Get("/ws", func(c *Context) error {
+		return c.WebSocket(func(ws *WebSocketConn) error {
+			if err := rooms.Join(c, "chat", ws); err != nil {
+				return err
+			}
+			defer rooms.LeaveAll(ws)
+			joined <- struct{}{}
+			return ws.ReadMessages(func(_ WebSocketMessageType, data []byte) error {
+				return rooms.Broadcast(ws.Context(), "chat", data)
+			})
+		})
+	})
+	ts := NewTestServerWithOkapi(t, o)
+
+	alice, _ := dialWebSocket(t, ts.BaseURL, "/ws")
+	bob, _ := dialWebSocket(t, ts.BaseURL, "/ws")
+	<-joined
+	<-joined
+
+	alice.write(t, wsText, []byte("hi all"))
+	for _, client := range []*wsTestClient{alice, bob} {
+		_, payload := client.read(t)
+		assert.Equal(t, "hi all", string(payload))
+	}
+}
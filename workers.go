@@ -0,0 +1,366 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxJobBackoff caps the exponential delay between job attempts.
+	maxJobBackoff = time.Minute
+	// sourceRestartDelay is the pause before restarting a failed JobSource.
+	sourceRestartDelay = time.Second
+)
+
+type (
+	// Job is a message received from a queue.
+	Job struct {
+		// ID identifies the message, used in logs.
+		ID string
+		// Queue is the name of the consumer processing the job.
+		Queue string
+		// Payload is the raw message body.
+		Payload []byte
+		// Metadata holds broker specific attributes (headers, partition, ...).
+		Metadata map[string]string
+		// Attempt is the current attempt, starting at 1.
+		Attempt int
+	}
+
+	// JobHandler processes a job. Returning an error triggers a retry.
+	JobHandler func(ctx context.Context, job *Job) error
+
+	// JobMiddleware wraps a JobHandler, e.g. for logging, tracing or metrics.
+	JobMiddleware func(next JobHandler) JobHandler
+
+	// JobSource feeds a consumer from a queue. Consume receives messages until
+	// ctx is done and calls handle for each of them; handle returns nil once the
+	// job succeeded, or the last error after every attempt failed and the
+	// dead-letter hook ran, so sources can ack or nack the message accordingly.
+	// A job interrupted by Workers.Stop also returns its error, without running
+	// the dead-letter hook, so that the source can redeliver it.
+	JobSource interface {
+		Consume(ctx context.Context, handle func(*Job) error) error
+	}
+
+	// JobSourceFunc adapts a function to a JobSource, e.g. a NATS or Kafka consumer loop.
+	JobSourceFunc func(ctx context.Context, handle func(*Job) error) error
+
+	// ConsumerOption configures a consumer registered with Workers.Register.
+	ConsumerOption func(*Consumer)
+
+	// Consumer processes the jobs of a single JobSource.
+	Consumer struct {
+		name        string
+		source      JobSource
+		handler     JobHandler
+		middlewares []JobMiddleware
+		attempts    int
+		backoff     time.Duration
+		deadLetter  func(ctx context.Context, job *Job, err error)
+		concurrency int
+	}
+
+	// Workers runs queue consumers alongside the HTTP server: they start with
+	// Start and stop with Stop, sharing the instance's logger. See Okapi.Workers.
+	Workers struct {
+		okapi       *Okapi
+		mu          sync.Mutex
+		consumers   []*Consumer
+		middlewares []JobMiddleware
+		cancel      context.CancelFunc
+		cancelJobs  context.CancelFunc
+		wg          sync.WaitGroup
+	}
+
+	// MemoryQueue is an in-process JobSource, useful for tests and background
+	// work that does not need a broker.
+	MemoryQueue struct {
+		jobs chan *Job
+	}
+)
+
+// Consume calls f(ctx, handle).
+func (f JobSourceFunc) Consume(ctx context.Context, handle func(*Job) error) error {
+	return f(ctx, handle)
+}
+
+// NewMemoryQueue returns an in-memory queue buffering up to size jobs.
+func NewMemoryQueue(size int) *MemoryQueue {
+	return &MemoryQueue{jobs: make(chan *Job, size)}
+}
+
+// Enqueue adds a job to the queue, blocking while the queue is full.
+// []byte and string payloads are kept as is, anything else is JSON encoded.
+func (q *MemoryQueue) Enqueue(ctx context.Context, payload any) error {
+	var data []byte
+	switch p := payload.(type) {
+	case []byte:
+		data = p
+	case string:
+		data = []byte(p)
+	default:
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
+			return fmt.Errorf("encode job: %w", err)
+		}
+	}
+	select {
+	case q.jobs <- &Job{ID: uuid.NewString(), Payload: data}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Consume delivers queued jobs to handle until ctx is done.
+func (q *MemoryQueue) Consume(ctx context.Context, handle func(*Job) error) error {
+	for {
+		select {
+		case job := <-q.jobs:
+			_ = handle(job)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Retry retries failed jobs up to attempts times in total, waiting backoff
+// before the first retry and doubling it after each one, up to a minute.
+// Defaults to a single attempt.
+func Retry(attempts int, backoff time.Duration) ConsumerOption {
+	return func(c *Consumer) {
+		c.attempts = attempts
+		c.backoff = backoff
+	}
+}
+
+// DeadLetter sets the hook called with a job that failed every attempt,
+// typically to move it to a dead-letter queue.
+func DeadLetter(fn func(ctx context.Context, job *Job, err error)) ConsumerOption {
+	return func(c *Consumer) {
+		c.deadLetter = fn
+	}
+}
+
+// Concurrency sets how many jobs the consumer processes in parallel, by
+// running n Consume loops on its source. Defaults to 1.
+func Concurrency(n int) ConsumerOption {
+	return func(c *Consumer) {
+		c.concurrency = n
+	}
+}
+
+// UseJobMiddleware adds middlewares to a single consumer, run after the
+// middlewares registered with Workers.Use.
+func UseJobMiddleware(mw ...JobMiddleware) ConsumerOption {
+	return func(c *Consumer) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// Workers returns the queue consumer runtime of the instance. Registered
+// consumers start with the server and are stopped, waiting for in-flight jobs,
+// by Stop.
+//
+// Example:
+//
+//	queue := okapi.NewMemoryQueue(100)
+//	o.Workers().Register("emails", queue, sendEmail,
+//		okapi.Retry(5, time.Second),
+//		okapi.DeadLetter(func(ctx context.Context, job *okapi.Job, err error) {
+//			slog.Error("email dropped", "job", job.ID, "error", err)
+//		}),
+//	)
+func (o *Okapi) Workers() *Workers {
+	if o.workers == nil {
+		o.workers = &Workers{okapi: o}
+	}
+	return o.workers
+}
+
+// Use registers middlewares applied to the jobs of every consumer.
+func (w *Workers) Use(mw ...JobMiddleware) *Workers {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.middlewares = append(w.middlewares, mw...)
+	return w
+}
+
+// Register adds a consumer processing the jobs of source with h.
+// Consumers registered once the workers are running start on the next Start.
+func (w *Workers) Register(name string, source JobSource, h JobHandler, opts ...ConsumerOption) *Consumer {
+	if source == nil || h == nil {
+		panic(fmt.Sprintf("okapi: consumer %q requires a source and a handler", name))
+	}
+	c := &Consumer{name: name, source: source, handler: h, attempts: 1, concurrency: 1}
+	for _, opt := range opts {
+		opt(c)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.consumers = append(w.consumers, c)
+	return c
+}
+
+// Start runs the registered consumers in the background. It is called by
+// StartServer, and only needs to be called directly when the instance does not
+// serve HTTP. Calling Start on running workers is a no-op.
+func (w *Workers) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cancel != nil || len(w.consumers) == 0 {
+		return
+	}
+	// Consume loops stop with ctx, while in-flight jobs keep jobCtx until
+	// Stop gives up waiting for them.
+	ctx, cancel := context.WithCancel(context.Background())
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	w.cancel, w.cancelJobs = cancel, cancelJobs
+	for _, c := range w.consumers {
+		handler := chainJob(c.handler, append(append([]JobMiddleware(nil), w.middlewares...), c.middlewares...))
+		for i := 0; i < max(c.concurrency, 1); i++ {
+			w.wg.Add(1)
+			go func() {
+				defer w.wg.Done()
+				w.run(ctx, jobCtx, c, handler)
+			}()
+		}
+	}
+}
+
+// Stop stops consuming and waits for in-flight jobs to complete. When ctx is
+// done first, the contexts of the jobs still running are cancelled.
+func (w *Workers) Stop(ctx context.Context) error {
+	w.mu.Lock()
+	cancel, cancelJobs := w.cancel, w.cancelJobs
+	w.cancel, w.cancelJobs = nil, nil
+	w.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	defer cancelJobs()
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for workers: %w", ctx.Err())
+	}
+}
+
+// run consumes the source of c until ctx is done or the source is drained,
+// restarting it on failure. Jobs run with jobCtx.
+func (w *Workers) run(ctx, jobCtx context.Context, c *Consumer, h JobHandler) {
+	logger := w.okapi.logger.With(slog.String("consumer", c.name))
+	for {
+		err := c.source.Consume(ctx, func(job *Job) error {
+			return c.process(ctx, jobCtx, logger, job, h)
+		})
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		logger.Error("job source failed", slog.String("error", err.Error()))
+		select {
+		case <-time.After(sourceRestartDelay):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// process runs h on job with jobCtx, retrying according to the consumer's
+// policy. Once ctx is done, a failed job is not retried, and a job interrupted
+// by the cancellation of jobCtx is not dead-lettered: both return their error
+// so that the source can redeliver them.
+func (c *Consumer) process(ctx, jobCtx context.Context, logger *slog.Logger, job *Job, h JobHandler) error {
+	job.Queue = c.name
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		job.Attempt = attempt
+		err := runJob(jobCtx, job, h)
+		if err == nil {
+			return nil
+		}
+		if jobCtx.Err() != nil {
+			logger.Warn("job interrupted", slog.String("job", job.ID), slog.Int("attempt", attempt), slog.String("error", err.Error()))
+			return err
+		}
+		if attempt >= c.attempts {
+			logger.Error("job failed", slog.String("job", job.ID), slog.Int("attempt", attempt), slog.String("error", err.Error()))
+			if c.deadLetter != nil {
+				c.deadLetter(jobCtx, job, err)
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			logger.Warn("job failed, not retried while stopping", slog.String("job", job.ID), slog.Int("attempt", attempt), slog.String("error", err.Error()))
+			return err
+		}
+		logger.Warn("job failed, retrying", slog.String("job", job.ID), slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff), slog.String("error", err.Error()))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff = min(backoff*2, maxJobBackoff)
+	}
+}
+
+// runJob calls h, turning a panic into an error.
+func runJob(ctx context.Context, job *Job, h JobHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return h(ctx, job)
+}
+
+// chainJob wraps h with mw, the first middleware being the outermost.
+func chainJob(h JobHandler, mw []JobMiddleware) JobHandler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+// Bind decodes the JSON payload of the job into v.
+func (j *Job) Bind(v any) error {
+	return json.Unmarshal(j.Payload, v)
+}
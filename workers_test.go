@@ -0,0 +1,206 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkers(t *testing.T) {
+	o := New()
+	emails := NewMemoryQueue(10)
+	reports := NewMemoryQueue(10)
+
+	var (
+		mu       sync.Mutex
+		order    []string
+		attempts atomic.Int32
+	)
+	sent := make(chan string, 1)
+	dead := make(chan *Job, 1)
+
+	o.Workers().Use(func(next JobHandler) JobHandler {
+		return func(ctx context.Context, job *Job) error {
+			mu.Lock()
+			order = append(order, "global:"+job.Queue)
+			mu.Unlock()
+			return next(ctx, job)
+		}
+	})
+	o.Workers().Register("emails", emails, func(ctx context.Context, job *Job) error {
+		if attempts.Add(1) < 3 {
+			return errors.New("smtp unavailable")
+		}
+		var msg struct {
+			To string `json:"to"`
+		}
+		if err := job.Bind(&msg); err != nil {
+			return err
+		}
+		sent <- msg.To
+		return nil
+	}, Retry(3, time.Millisecond))
+	o.Workers().Register("reports", reports, func(ctx context.Context, job *Job) error {
+		panic("corrupted report")
+	}, Retry(2, time.Millisecond), DeadLetter(func(ctx context.Context, job *Job, err error) {
+		dead <- job
+	}))
+
+	o.Workers().Start()
+	t.Cleanup(func() { _ = o.Workers().Stop(context.Background()) })
+
+	if err := emails.Enqueue(context.Background(), M{"to": "jane@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := reports.Enqueue(context.Background(), "report-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case to := <-sent:
+		if to != "jane@example.com" {
+			t.Errorf("sent to %q", to)
+		}
+		if got := attempts.Load(); got != 3 {
+			t.Errorf("attempts = %d, want 3", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("email job was not processed")
+	}
+
+	select {
+	case job := <-dead:
+		if job.Queue != "reports" || job.Attempt != 2 || string(job.Payload) != "report-1" {
+			t.Errorf("unexpected dead-lettered job %+v", job)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("failed job was not dead-lettered")
+	}
+
+	if err := o.Workers().Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 5 {
+		t.Errorf("global middleware ran %d times, want 5: %v", len(order), order)
+	}
+}
+
+func TestWorkersStopWaitsForJobs(t *testing.T) {
+	o := New()
+	queue := NewMemoryQueue(1)
+	var done atomic.Bool
+	started := make(chan struct{})
+	o.Workers().Register("slow", queue, func(ctx context.Context, job *Job) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		done.Store(true)
+		return nil
+	})
+	o.Workers().Start()
+	_ = queue.Enqueue(context.Background(), "job")
+	<-started
+
+	if err := o.Workers().Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !done.Load() {
+		t.Error("Stop returned before the in-flight job completed")
+	}
+}
+
+func TestWorkersStopDoesNotCancelInFlightJobs(t *testing.T) {
+	o := New()
+	queue := NewMemoryQueue(1)
+	started := make(chan struct{})
+	var cancelled, deadLettered atomic.Bool
+	o.Workers().Register("slow", queue, func(ctx context.Context, job *Job) error {
+		close(started)
+		select {
+		case <-time.After(50 * time.Millisecond):
+			return nil
+		case <-ctx.Done():
+			cancelled.Store(true)
+			return ctx.Err()
+		}
+	}, DeadLetter(func(ctx context.Context, job *Job, err error) { deadLettered.Store(true) }))
+	o.Workers().Start()
+	_ = queue.Enqueue(context.Background(), "job")
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := o.Workers().Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.Load() {
+		t.Error("Stop cancelled the context of an in-flight job")
+	}
+	if deadLettered.Load() {
+		t.Error("completed job was dead-lettered")
+	}
+}
+
+func TestWorkersInterruptedJobIsNotDeadLettered(t *testing.T) {
+	o := New()
+	started := make(chan struct{})
+	handled := make(chan error, 1)
+	var deadLettered atomic.Bool
+	source := JobSourceFunc(func(ctx context.Context, handle func(*Job) error) error {
+		handled <- handle(&Job{ID: "1"})
+		<-ctx.Done()
+		return nil
+	})
+	o.Workers().Register("stuck", source, func(ctx context.Context, job *Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}, Retry(3, time.Millisecond), DeadLetter(func(ctx context.Context, job *Job, err error) { deadLettered.Store(true) }))
+	o.Workers().Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := o.Workers().Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Stop error = %v, want deadline exceeded", err)
+	}
+	select {
+	case err := <-handled:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("handle returned %v, want the interruption error", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("interrupted job did not return")
+	}
+	if deadLettered.Load() {
+		t.Error("interrupted job was dead-lettered")
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentTypeXLSX is the media type of Excel workbooks written by Context.XLSX.
+const ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+const tagXLSX = "xlsx"
+
+// xlsxSheet is a validated worksheet ready to be streamed.
+type xlsxSheet struct {
+	name    string
+	records [][]string
+	rows    reflect.Value
+	fields  []tableColumn
+}
+
+// XLSX writes an Excel workbook as an attachment named filename. Each entry of sheets
+// becomes a worksheet, in name order, and holds a slice of structs (or struct pointers)
+// or a [][]string. Struct columns are named by the `xlsx` tag, then the `csv` tag,
+// then the field name; numbers and booleans are written as typed cells.
+//
+// The workbook is streamed to the client row by row, without buffering it in memory.
+//
+//	o.Get("/reports/sales", func(c *okapi.Context) error {
+//	  return c.XLSX(http.StatusOK, "sales.xlsx", map[string]any{
+//	    "Orders":  orders,
+//	    "Refunds": refunds,
+//	  })
+//	}, okapi.DocFileResponse(http.StatusOK, okapi.ContentTypeXLSX))
+func (c *Context) XLSX(code int, filename string, sheets map[string]any) error {
+	list, err := xlsxSheets(sheets)
+	if err != nil {
+		return c.AbortInternalServerError("Internal Server Error", err)
+	}
+	if filename != "" {
+		if !strings.HasSuffix(strings.ToLower(filename), ".xlsx") {
+			filename += ".xlsx"
+		}
+		c.Attachment(filename)
+	}
+	return c.writeResponse(code, ContentTypeXLSX, func() error {
+		return writeXLSX(c.response, list)
+	})
+}
+
+// xlsxSheets validates sheet names and contents before anything is written.
+func xlsxSheets(sheets map[string]any) ([]xlsxSheet, error) {
+	names := make([]string, 0, len(sheets))
+	for name := range sheets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := make([]xlsxSheet, 0, len(names))
+	for _, name := range names {
+		if name == "" || len(name) > 31 || strings.ContainsAny(name, `[]:*?/\`) {
+			return nil, fmt.Errorf("xlsx: invalid sheet name %q", name)
+		}
+		sheet := xlsxSheet{name: name}
+		switch rows := sheets[name].(type) {
+		case nil:
+		case [][]string:
+			sheet.records = rows
+		default:
+			v := reflect.ValueOf(rows)
+			if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+				return nil, fmt.Errorf("xlsx: sheet %q: unsupported type %T", name, rows)
+			}
+			elemType, _ := tableElemType(v.Type())
+			if elemType.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("xlsx: sheet %q: unsupported element type %s", name, elemType)
+			}
+			sheet.rows = v
+			sheet.fields = tableFields(elemType, tagXLSX, tagCSV)
+		}
+		list = append(list, sheet)
+	}
+	if len(list) == 0 {
+		// A workbook needs at least one worksheet to open.
+		list = append(list, xlsxSheet{name: "Sheet1"})
+	}
+	return list, nil
+}
+
+// writeXLSX streams a minimal SpreadsheetML package holding sheets to w.
+func writeXLSX(w io.Writer, sheets []xlsxSheet) error {
+	zw := zip.NewWriter(w)
+	var types, rels, book strings.Builder
+	for i := range sheets {
+		n := i + 1
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+		fmt.Fprintf(&book, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheets[i].name), n, n)
+	}
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			types.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + book.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() + `</Relationships>`},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, xml.Header+p.body); err != nil {
+			return err
+		}
+	}
+	for i, sheet := range sheets {
+		f, err := zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+		if err != nil {
+			return err
+		}
+		if err := sheet.write(f); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (s xlsxSheet) write(w io.Writer) error {
+	sw := &xlsxSheetWriter{w: w}
+	sw.writeString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	if s.records != nil {
+		for _, record := range s.records {
+			sw.startRow()
+			for _, cell := range record {
+				sw.stringCell(cell)
+			}
+			sw.endRow()
+		}
+	} else if s.rows.IsValid() {
+		sw.startRow()
+		for _, f := range s.fields {
+			sw.stringCell(f.name)
+		}
+		sw.endRow()
+		for i := 0; i < s.rows.Len(); i++ {
+			elem := reflect.Indirect(s.rows.Index(i))
+			sw.startRow()
+			if elem.IsValid() {
+				for _, f := range s.fields {
+					sw.valueCell(elem.FieldByIndex(f.index))
+				}
+			}
+			sw.endRow()
+		}
+	}
+	sw.writeString(`</sheetData></worksheet>`)
+	return sw.err
+}
+
+// xlsxSheetWriter writes worksheet rows, keeping the first write error.
+type xlsxSheetWriter struct {
+	w   io.Writer
+	row int
+	col int
+	err error
+}
+
+func (sw *xlsxSheetWriter) writeString(s string) {
+	if sw.err == nil {
+		_, sw.err = io.WriteString(sw.w, s)
+	}
+}
+
+func (sw *xlsxSheetWriter) startRow() {
+	sw.row++
+	sw.col = 0
+	sw.writeString(`<row r="` + strconv.Itoa(sw.row) + `">`)
+}
+
+func (sw *xlsxSheetWriter) endRow() {
+	sw.writeString(`</row>`)
+}
+
+// ref returns the A1 reference of the next cell in the row.
+func (sw *xlsxSheetWriter) ref() string {
+	sw.col++
+	var name []byte
+	for n := sw.col; n > 0; n = (n - 1) / 26 {
+		name = append([]byte{byte('A' + (n-1)%26)}, name...)
+	}
+	return string(name) + strconv.Itoa(sw.row)
+}
+
+func (sw *xlsxSheetWriter) stringCell(s string) {
+	ref := sw.ref()
+	if s == "" {
+		return
+	}
+	sw.writeString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">` + xmlEscape(s) + `</t></is></c>`)
+}
+
+func (sw *xlsxSheetWriter) valueCell(v reflect.Value) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			sw.ref()
+			return
+		}
+		v = v.Elem()
+	}
+	var num string
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		num = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		num = strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		num = strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.Bool:
+		b := "0"
+		if v.Bool() {
+			b = "1"
+		}
+		sw.writeString(`<c r="` + sw.ref() + `" t="b"><v>` + b + `</v></c>`)
+		return
+	default:
+		sw.stringCell(formatCSVValue(v))
+		return
+	}
+	if _, ok := v.Interface().(fmt.Stringer); ok {
+		// Named numeric types such as enums read better as their string form.
+		sw.stringCell(formatCSVValue(v))
+		return
+	}
+	if num == "NaN" || strings.HasSuffix(num, "Inf") {
+		// Spreadsheets have no numeric NaN or infinity, and a <v> holding one
+		// makes the workbook unreadable.
+		sw.stringCell(num)
+		return
+	}
+	sw.writeString(`<c r="` + sw.ref() + `"><v>` + num + `</v></c>`)
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,132 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xlsxOrder struct {
+	ID       int     `xlsx:"Order ID"`
+	Customer string  `csv:"customer"`
+	Total    float64 `xlsx:"Total"`
+	Paid     bool
+	Note     *string
+	Secret   string `xlsx:"-"`
+}
+
+func readXLSXPart(t *testing.T, data []byte, name string) string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	f, err := zr.Open(name)
+	require.NoError(t, err, name)
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestContext_XLSX(t *testing.T) {
+	ctx, rec := NewTestContext(http.MethodGet, "/reports/orders", nil)
+	err := ctx.XLSX(http.StatusOK, "orders", map[string]any{
+		"Summary": [][]string{{"Total", "2"}},
+		"Orders": []*xlsxOrder{
+			{ID: 1, Customer: "Tom & Jerry", Total: 9.5, Paid: true, Secret: "hidden"},
+			{ID: 2, Customer: "Bob", Total: 12},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ContentTypeXLSX, rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="orders.xlsx"`, rec.Header().Get("Content-Disposition"))
+
+	data := rec.Body.Bytes()
+	workbook := readXLSXPart(t, data, "xl/workbook.xml")
+	assert.Contains(t, workbook, `<sheet name="Orders" sheetId="1" r:id="rId1"/><sheet name="Summary" sheetId="2" r:id="rId2"/>`)
+	readXLSXPart(t, data, "[Content_Types].xml")
+	readXLSXPart(t, data, "xl/_rels/workbook.xml.rels")
+
+	orders := readXLSXPart(t, data, "xl/worksheets/sheet1.xml")
+	assert.Contains(t, orders, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">Order ID</t></is></c>`)
+	assert.Contains(t, orders, `<c r="B1" t="inlineStr"><is><t xml:space="preserve">customer</t></is></c>`)
+	assert.Contains(t, orders, `<c r="A2"><v>1</v></c>`)
+	assert.Contains(t, orders, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">Tom &amp; Jerry</t></is></c>`)
+	assert.Contains(t, orders, `<c r="C2"><v>9.5</v></c><c r="D2" t="b"><v>1</v></c></row>`)
+	assert.NotContains(t, orders, "hidden")
+	assert.Contains(t, readXLSXPart(t, data, "xl/worksheets/sheet2.xml"), `<c r="B1" t="inlineStr"><is><t xml:space="preserve">2</t></is></c>`)
+
+	t.Run("non-finite floats", func(t *testing.T) {
+		ctx, rec := NewTestContext(http.MethodGet, "/reports", nil)
+		require.NoError(t, ctx.XLSX(http.StatusOK, "", map[string]any{"Orders": []xlsxOrder{
+			{ID: 1, Total: math.NaN()},
+			{ID: 2, Total: math.Inf(1)},
+			{ID: 3, Total: math.Inf(-1)},
+		}}))
+		sheet := readXLSXPart(t, rec.Body.Bytes(), "xl/worksheets/sheet1.xml")
+		assert.Contains(t, sheet, `<c r="C2" t="inlineStr"><is><t xml:space="preserve">NaN</t></is></c>`)
+		assert.Contains(t, sheet, `<c r="C3" t="inlineStr"><is><t xml:space="preserve">+Inf</t></is></c>`)
+		assert.Contains(t, sheet, `<c r="C4" t="inlineStr"><is><t xml:space="preserve">-Inf</t></is></c>`)
+		assert.NotContains(t, sheet, "<v>NaN</v>")
+		assert.NotContains(t, sheet, "Inf</v>")
+	})
+
+	t.Run("invalid sheet", func(t *testing.T) {
+		ctx, rec := NewTestContext(http.MethodGet, "/reports", nil)
+		ctx.okapi = New()
+		_ = ctx.XLSX(http.StatusOK, "", map[string]any{"a/b": []xlsxOrder{}})
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("empty workbook", func(t *testing.T) {
+		ctx, rec := NewTestContext(http.MethodGet, "/reports", nil)
+		require.NoError(t, ctx.XLSX(http.StatusOK, "", nil))
+		assert.Empty(t, rec.Header().Get("Content-Disposition"))
+		assert.True(t, strings.Contains(readXLSXPart(t, rec.Body.Bytes(), "xl/workbook.xml"), `name="Sheet1"`))
+	})
+}
+
+func TestFileResponseDocumented(t *testing.T) {
+	o := New()
+	o.Get("/reports", anyHandler, DocFileResponse(http.StatusOK, ContentTypeXLSX))
+	o.Get("/books", anyHandler, Doc().Response(Book{}).FileResponse(http.StatusOK, "text/csv").Build())
+	o.buildOpenAPISpec()
+
+	reports := o.openapiSpec.Paths.Find("/reports").Get.Responses.Status(200).Value
+	require.NotNil(t, reports.Content.Get(ContentTypeXLSX))
+	assert.Equal(t, "binary", reports.Content.Get(ContentTypeXLSX).Schema.Value.Format)
+
+	books := o.openapiSpec.Paths.Find("/books").Get.Responses.Status(200).Value.Content
+	assert.NotNil(t, books.Get(constJSON))
+	assert.NotNil(t, books.Get("text/csv"))
+}
@@ -0,0 +1,214 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/xml"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// XMLOptions configures the XML responses written by Context.XML, for partners
+// expecting strict XML documents. See WithXMLOptions.
+type XMLOptions struct {
+	// Declaration prepends the <?xml version="1.0" encoding="UTF-8"?> declaration.
+	Declaration bool
+	// Indent indents nested elements with the given string, e.g. "  ".
+	Indent string
+	// Namespaces are declared on the root element, keyed by prefix; the empty
+	// prefix declares the default namespace.
+	Namespaces map[string]string
+	// ListRoot names the element wrapping top-level slices, which encoding/xml
+	// otherwise writes as a sequence of elements without a root.
+	ListRoot string
+}
+
+// XMLList encodes a slice as a root element holding one element per item:
+//
+//	return c.XML(http.StatusOK, okapi.XMLList{Name: "books", Item: "book", Items: books})
+//	// <books><book>...</book><book>...</book></books>
+//
+// Attributes are set on items with the standard `xml:"id,attr"` struct tag.
+type XMLList struct {
+	// Name is the root element name. Defaults to "items".
+	Name string
+	// Item is the element name of each item. Defaults to the name encoding/xml
+	// derives from the item's XMLName field or type.
+	Item string
+	// Items is the slice or array to encode.
+	Items any
+}
+
+// WithXMLOptions configures XML responses: declaration, indentation, namespaces
+// and the root of top-level slices. The OpenAPI documentation then lists
+// application/xml next to application/json for request and response bodies.
+//
+// Example:
+//
+//	o := okapi.New(okapi.WithXMLOptions(okapi.XMLOptions{
+//		Declaration: true,
+//		Indent:      "  ",
+//		Namespaces:  map[string]string{"": "urn:partner:books:v1"},
+//		ListRoot:    "books",
+//	}))
+func WithXMLOptions(opts XMLOptions) OptionFunc {
+	return func(o *Okapi) {
+		o.xmlOptions = &opts
+	}
+}
+
+// WithXMLOptions configures XML responses, see the WithXMLOptions option.
+func (o *Okapi) WithXMLOptions(opts XMLOptions) *Okapi {
+	return o.apply(WithXMLOptions(opts))
+}
+
+// MarshalXML implements xml.Marshaler.
+func (l XMLList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: cmp.Or(l.Name, "items")}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	items := reflect.ValueOf(l.Items)
+	for items.Kind() == reflect.Pointer && !items.IsNil() {
+		items = items.Elem()
+	}
+	if items.Kind() == reflect.Slice || items.Kind() == reflect.Array {
+		for i := range items.Len() {
+			if err := l.encodeItem(e, items.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	} else if items.IsValid() {
+		if err := l.encodeItem(e, items.Interface()); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (l XMLList) encodeItem(e *xml.Encoder, item any) error {
+	if l.Item == "" {
+		return e.Encode(item)
+	}
+	return e.EncodeElement(item, xml.StartElement{Name: xml.Name{Local: l.Item}})
+}
+
+// marshal encodes v according to the options.
+func (opts *XMLOptions) marshal(v any) ([]byte, error) {
+	if opts.ListRoot != "" && isXMLList(v) {
+		v = XMLList{Name: opts.ListRoot, Items: v}
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if opts.Indent != "" {
+		data, err = xml.MarshalIndent(v, "", opts.Indent)
+	} else {
+		data, err = xml.Marshal(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	data = declareXMLNamespaces(data, opts.Namespaces)
+	if opts.Declaration {
+		data = append([]byte(xml.Header), data...)
+	}
+	return data, nil
+}
+
+// isXMLList reports whether v is a slice or array encoding/xml writes without a root.
+func isXMLList(v any) bool {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8
+}
+
+// declareXMLNamespaces adds the namespace declarations to the root element of data.
+func declareXMLNamespaces(data []byte, namespaces map[string]string) []byte {
+	if len(namespaces) == 0 || len(data) < 2 || data[0] != '<' {
+		return data
+	}
+	end := bytes.IndexAny(data, " />")
+	if end < 0 {
+		return data
+	}
+	var attrs bytes.Buffer
+	prefixes := make([]string, 0, len(namespaces))
+	for prefix := range namespaces {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.Sort(prefixes)
+	for _, prefix := range prefixes {
+		attrs.WriteString(" xmlns")
+		if prefix != "" {
+			attrs.WriteString(":" + prefix)
+		}
+		attrs.WriteString(`="`)
+		_ = xml.EscapeText(&attrs, []byte(namespaces[prefix]))
+		attrs.WriteByte('"')
+	}
+	return slices.Concat(data[:end], attrs.Bytes(), data[end:])
+}
+
+// xmlNameType is the type of the XMLName field naming a struct's element.
+var xmlNameType = reflect.TypeFor[xml.Name]()
+
+// xmlSchema returns the OpenAPI XML object described by the field's xml tag, or
+// nil when the field is encoded under its JSON name.
+func xmlSchema(field reflect.StructField, jsonName string) *openapi3.XML {
+	tag, ok := field.Tag.Lookup("xml")
+	if !ok || tag == "-" {
+		return nil
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if strings.Contains(name, ">") {
+		return nil
+	}
+	attr := slices.Contains(strings.Split(options, ","), "attr")
+	if field.Type == xmlNameType || attr || (name != "" && name != jsonName) {
+		return &openapi3.XML{Name: name, Attribute: attr}
+	}
+	return nil
+}
+
+// xmlMediaType documents schema as an application/xml body, naming the root of
+// top-level arrays after ListRoot.
+func (opts *XMLOptions) xmlMediaType(schema *openapi3.SchemaRef) *openapi3.MediaType {
+	if opts.ListRoot != "" && schema != nil && schema.Ref == "" && schema.Value != nil &&
+		schema.Value.Type != nil && schema.Value.Type.Is(openapi3.TypeArray) {
+		wrapped := *schema.Value
+		wrapped.XML = &openapi3.XML{Name: opts.ListRoot, Wrapped: true}
+		schema = openapi3.NewSchemaRef("", &wrapped)
+	}
+	return openapi3.NewMediaType().WithSchemaRef(schema)
+}
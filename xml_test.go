@@ -0,0 +1,107 @@
+/*
+ *  MIT License
+ *
+ * Copyright (c) 2025 Jonas Kaninda
+ *
+ *  Permission is hereby granted, free of charge, to any person obtaining a copy
+ *  of this software and associated documentation files (the "Software"), to deal
+ *  in the Software without restriction, including without limitation the rights
+ *  to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *  copies of the Software, and to permit persons to whom the Software is
+ *  furnished to do so, subject to the following conditions:
+ *
+ *  The above copyright notice and this permission notice shall be included in all
+ *  copies or substantial portions of the Software.
+ *
+ *  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *  IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *  FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *  AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *  LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *  SOFTWARE.
+ */
+
+package okapi
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xmlBook struct {
+	XMLName xml.Name `xml:"book" json:"-"`
+	ID      int      `xml:"id,attr" json:"id"`
+	Title   string   `xml:"title" json:"title"`
+}
+
+func TestXMLList(t *testing.T) {
+	books := []xmlBook{{ID: 1, Title: "Go"}, {ID: 2, Title: "XML"}}
+
+	data, err := xml.Marshal(XMLList{Name: "books", Items: books})
+	require.NoError(t, err)
+	assert.Equal(t, `<books><book id="1"><title>Go</title></book><book id="2"><title>XML</title></book></books>`, string(data))
+
+	data, err = xml.Marshal(XMLList{Item: "entry", Items: []int{1, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, `<items><entry>1</entry><entry>2</entry></items>`, string(data))
+}
+
+func TestContext_XMLOptions(t *testing.T) {
+	o := New(WithXMLOptions(XMLOptions{
+		Declaration: true,
+		Indent:      "  ",
+		Namespaces:  map[string]string{"": "urn:books", "x": "urn:ext"},
+		ListRoot:    "books",
+	}))
+	book := xmlBook{ID: 1, Title: "Go"}
+	o.Get("/book", func(c *Context) error { return c.XML(http.StatusOK, book) })
+	o.Get("/books", func(c *Context) error { return c.XML(http.StatusOK, []xmlBook{book}) })
+	ts := NewTestServerWithOkapi(t, o)
+
+	get := func(path string) (*http.Response, string) {
+		t.Helper()
+		resp, err := http.Get(ts.BaseURL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	resp, body := get("/book")
+	assert.Equal(t, constXML, resp.Header.Get("Content-Type"))
+	assert.Equal(t, xml.Header+
+		"<book xmlns=\"urn:books\" xmlns:x=\"urn:ext\" id=\"1\">\n  <title>Go</title>\n</book>", body)
+
+	_, body = get("/books")
+	assert.Equal(t, xml.Header+
+		"<books xmlns=\"urn:books\" xmlns:x=\"urn:ext\">\n  <book id=\"1\">\n    <title>Go</title>\n  </book>\n</books>", body)
+}
+
+func TestOpenAPI_XMLContent(t *testing.T) {
+	o := New(WithXMLOptions(XMLOptions{ListRoot: "books"}))
+	o.Get("/books", anyHandler, DocResponse(200, []xmlBook{}))
+	o.Post("/books", anyHandler, DocRequestBody(xmlBook{}), DocResponse(201, xmlBook{}))
+	o.buildOpenAPISpec()
+
+	list := o.openapiSpec.Paths.Find("/books").Get.Responses.Status(200).Value
+	require.Contains(t, list.Content, constJSON)
+	require.Contains(t, list.Content, constXML)
+	assert.Equal(t, &openapi3.XML{Name: "books", Wrapped: true}, list.Content[constXML].Schema.Value.XML)
+
+	post := o.openapiSpec.Paths.Find("/books").Post
+	require.Contains(t, post.RequestBody.Value.Content, constXML)
+	schema := post.Responses.Status(201).Value.Content[constXML].Schema
+	if schema.Ref != "" {
+		schema = o.openapiSpec.Components.Schemas[schema.Ref[len("#/components/schemas/"):]]
+	}
+	assert.Equal(t, "book", schema.Value.XML.Name)
+	assert.True(t, schema.Value.Properties["id"].Value.XML.Attribute)
+}